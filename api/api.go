@@ -0,0 +1,24 @@
+// Package api holds the OpenAPI specification of the HTTP server. It is served on /openapi.json, the routes
+// of the server are tested against it and pkg/apiclient is generated from it.
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+// JSON is the specification converted to JSON, it is converted once
+var JSON = sync.OnceValues(func() ([]byte, error) {
+	var spec map[string]interface{}
+	if err := yaml.Unmarshal(specYAML, &spec); err != nil {
+		return nil, fmt.Errorf("invalid openapi.yaml: %w", err)
+	}
+	return json.Marshal(spec)
+})
@@ -1,19 +1,29 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
 
 	"go-clean-template/internal/handler/httpserver"
-	"go-clean-template/internal/infras/mongo"
+	"go-clean-template/internal/infras/metrics"
 	"go-clean-template/internal/infras/notification"
 	"go-clean-template/internal/infras/paymentsvc"
+	"go-clean-template/internal/infras/storage"
+	tracinginfra "go-clean-template/internal/infras/tracing"
 	"go-clean-template/internal/usecase"
 	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/errorreport"
+	"go-clean-template/pkg/idgen"
+	"go-clean-template/pkg/lifecycle"
 	"go-clean-template/pkg/logger"
-	"go-clean-template/pkg/sentry"
+	"go-clean-template/pkg/tracing"
 
-	sentrygo "github.com/getsentry/sentry-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
 func main() {
@@ -27,43 +37,132 @@ func main() {
 	if err != nil {
 		applog.Fatal(err)
 	}
+	if err := logger.SetLevel(cfg.LogLevel); err != nil {
+		applog.Fatal(err)
+	}
+	logger.SetDefault(applog)
 
-	err = sentrygo.Init(sentrygo.ClientOptions{
-		Dsn:              cfg.SentryDSN,
-		Environment:      cfg.AppEnv,
-		AttachStacktrace: true,
-	})
+	reporter, err := errorreport.New(errorreport.ParseFromConfig(cfg), applog)
+	if err != nil {
+		applog.Fatalf("cannot init error reporter: %v", err)
+	}
+	// the components are stopped in the reverse order: the server first, the error reporter last
+	manager := lifecycle.New(applog, cfg.ShutdownTimeout)
+	manager.Add(lifecycle.Component{Name: "error reporter", Stop: reporter.Flush})
+
+	tracerProvider, err := tracing.Setup(context.Background(), tracing.ParseFromConfig(cfg))
+	if err != nil {
+		applog.Fatal(err)
+	}
+	storageOpts := []storage.Option{storage.WithLogger(applog)}
+	if tracerProvider != nil {
+		manager.Add(lifecycle.Component{Name: "tracing", Stop: tracerProvider.Shutdown})
+		storageOpts = append(storageOpts, storage.WithTracerProvider(tracerProvider))
+	}
+
+	var (
+		reg        *prometheus.Registry
+		appMetrics *metrics.Metrics
+	)
+	if cfg.Metrics.Enabled {
+		reg = prometheus.NewRegistry()
+		appMetrics = metrics.New(reg)
+		storageOpts = append(storageOpts, storage.WithCommandMetrics(appMetrics))
+	}
+
+	repos, err := storage.New(cfg, storageOpts...)
 	if err != nil {
-		applog.Fatalf("cannot init sentry: %v", err)
+		applog.Fatal(err)
 	}
-	defer sentrygo.Flush(sentry.FlushTime)
+	manager.Add(lifecycle.Component{Name: "storage", Stop: repos.Close})
 
-	// Use Postgres
-	//db, err := postgrestore.NewDB(postgrestore.ParseFromConfig(cfg))
-	//if err != nil {
-	//	applog.Fatal(err)
-	//}
+	serverOpts := []httpserver.Options{
+		httpserver.WithConfig(cfg),
+		httpserver.WithLogger(applog),
+		httpserver.WithErrorReporter(reporter),
+	}
+	if reg != nil {
+		reg.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			metrics.NewBacklogCollector(repos.Transaction, cfg.Metrics.BacklogTimeout),
+		)
+		serverOpts = append(serverOpts, httpserver.WithMetrics(reg))
+	}
+	if tracerProvider != nil {
+		serverOpts = append(serverOpts, httpserver.WithTracerProvider(tracerProvider))
+	}
 
-	// Use MongoDB
-	db, err := mongo.NewDB(mongo.ParseFromConfig(cfg))
+	server, err := httpserver.New(serverOpts...)
 	if err != nil {
 		applog.Fatal(err)
 	}
 
-	server, err := httpserver.New(httpserver.WithConfig(cfg), httpserver.WithLogger(applog))
+	idGenerator, err := idgen.New(cfg.IDStrategy)
 	if err != nil {
 		applog.Fatal(err)
 	}
 
 	//Setup Dependencies
-	//transRepo := postgrestore.NewTransactionRepo(db)
-	transRepo := mongo.NewTransactionRepo(db)
-	paymentSvc := paymentsvc.NewPaymentServiceProvider()
+	psp := paymentsvc.NewPaymentServiceProvider()
+	var (
+		transRepo  usecase.ITransactionRepository  = repos.Transaction
+		paymentSvc usecase.IPaymentServiceProvider = psp
+	)
+	if appMetrics != nil {
+		transRepo = metrics.NewTransactionRepo(transRepo, appMetrics)
+		paymentSvc = metrics.NewPaymentServiceProvider(paymentSvc, appMetrics)
+	}
+	if tracerProvider != nil {
+		paymentSvc = tracinginfra.NewPaymentServiceProvider(paymentSvc, tracerProvider)
+	}
 	transUseCase := usecase.NewTransactionUseCase(transRepo, paymentSvc)
 	transUseCase.SetNotifiers(notification.NewEmailNotifier(), notification.NewAppNotifier())
+	transUseCase.SetApproval(repos.Approval, usecase.ApprovalPolicy{
+		Threshold: cfg.Approval.Threshold,
+		TTL:       cfg.Approval.TTL,
+	})
+	transUseCase.SetBalanceSnapshots(repos.Balance)
+	transUseCase.SetTransactor(repos.Transactor)
+	transUseCase.SetIDGenerator(idGenerator)
+	transUseCase.SetReader(repos.TransactionReader)
+	var transactions usecase.ITransactionUseCase = transUseCase
+	if tracerProvider != nil {
+		transactions = tracinginfra.NewTransactionUseCase(transUseCase, tracerProvider)
+	}
+
+	scheduleUseCase := usecase.NewScheduleUseCase(repos.Schedule, transRepo, transactions)
+	scheduleUseCase.SetReader(repos.ScheduleReader)
+	scheduleUseCase.SetIDGenerator(idGenerator)
+	payoutUseCase := usecase.NewPayoutUseCase(repos.Payout, transRepo, transactions, cfg.Payout.Concurrency)
+	payoutUseCase.SetReader(repos.PayoutReader)
+	payoutUseCase.SetIDGenerator(idGenerator)
+	payoutUseCase.SetNotifiers(notification.NewEmailNotifier(), notification.NewAppNotifier())
+
+	server.TransactionUseCase = transactions
+	server.ScheduleUseCase = scheduleUseCase
+	server.PayoutUseCase = payoutUseCase
+	server.StatementUseCase = usecase.NewStatementUseCase(repos.Statement, transRepo)
+	server.BalanceUseCase = usecase.NewBalanceUseCase(repos.BalanceReader, transRepo)
 
-	server.TransactionUseCase = transUseCase
+	server.Health.Register(cfg.StorageDriver, repos.Health)
+	server.Health.Register("psp", psp)
 
 	addr := fmt.Sprintf(":%d", cfg.Port)
-	applog.Fatal(server.Start(addr))
+	manager.Add(lifecycle.Component{
+		Name: "http server",
+		Run: func(context.Context) error {
+			if err := server.Start(addr); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: server.Shutdown,
+	})
+
+	if err := manager.Run(context.Background()); err != nil {
+		applog.Errorf("shutdown: %v", err)
+		logger.Sync(applog)
+		os.Exit(1)
+	}
 }
@@ -1,15 +1,41 @@
 package main
 
 import (
-	"go-clean-template/infras/postgrestore"
-	"go-clean-template/pkg/config"
-	"go-clean-template/pkg/logger"
+	"context"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
 	"strconv"
 
-	migrate "github.com/rubenv/sql-migrate"
+	"go-clean-template/internal/infras/storage"
+	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/logger"
 )
 
+const usage = `Usage: migrate [flags] <command>
+
+Commands:
+  up [n]       apply the next n pending migrations, all of them when n is omitted (default command)
+  down [n]     revert the last n applied migrations, 1 when n is omitted
+  redo         revert then apply again the last applied migration
+  status       list the migrations and when they were applied
+  new <name>   create an empty sql migration for postgres and sqlite
+
+Flags:
+`
+
+// migrator runs the migrations of one storage driver. A max of 0 means no limit.
+type migrator interface {
+	Up(ctx context.Context, max int) (int, error)
+	Down(ctx context.Context, max int) (int, error)
+	// Plan prints the migrations Up or Down would run, and their statements when the driver has any
+	Plan(ctx context.Context, w io.Writer, up bool, max int) error
+	Status(ctx context.Context, w io.Writer) error
+	Close() error
+}
+
 func main() {
 	applogger, err := logger.NewAppLogger()
 	if err != nil {
@@ -21,31 +47,138 @@ func main() {
 		applogger.Fatalf("cannot load config: %v\n", err)
 	}
 
-	db, err := postgrestore.NewDB(postgrestore.Options{
-		DBName:   cfg.DB.Name,
-		DBUser:   cfg.DB.User,
-		Password: cfg.DB.Pass,
-		Host:     cfg.DB.Host,
-		Port:     strconv.Itoa(cfg.DB.Port),
-		SSLMode:  false,
-	})
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	driver := flag.String("driver", cfg.StorageDriver, "database to migrate: postgres, mongo or sqlite")
+	dryRun := flag.Bool("dry-run", false, "print the migrations up, down and redo would run without running them")
+	dir := flag.String("dir", "migrations", "directory the new command writes sql migrations to")
+	flag.Parse()
+
+	cmd, err := parseCommand(flag.Args())
 	if err != nil {
-		applogger.Fatalf("cannot connecting to db: %v\n", err)
+		flag.Usage()
+		applogger.Fatalf("%v\n", err)
 	}
 
-	migrations := &migrate.FileMigrationSource{
-		Dir: "migrations",
+	if cmd.name == "new" {
+		files, err := newMigration(*driver, *dir, cmd.arg, timeNow())
+		if err != nil {
+			applogger.Fatalf("cannot create migration: %v\n", err)
+		}
+		for _, file := range files {
+			applogger.Infof("created migration %s\n", file)
+		}
+		return
 	}
 
-	sqlDB, err := db.DB()
+	m, err := newMigrator(*driver, cfg)
 	if err != nil {
-		applogger.Fatalf("cannot get sql db: %v\n", err)
+		applogger.Fatalf("cannot connect to %s: %v\n", *driver, err)
 	}
+	defer func() {
+		_ = m.Close()
+	}()
 
-	total, err := migrate.Exec(sqlDB, "postgres", migrations, migrate.Up)
-	if err != nil {
+	if err := run(context.Background(), m, cmd, *dryRun, os.Stdout); err != nil {
 		applogger.Fatalf("cannot execute migration: %v\n", err)
 	}
+}
+
+type command struct {
+	name string
+	max  int
+	arg  string
+}
+
+func parseCommand(args []string) (command, error) {
+	if len(args) == 0 {
+		return command{name: "up"}, nil
+	}
+
+	cmd := command{name: args[0]}
+	switch cmd.name {
+	case "up", "down":
+		if cmd.name == "down" {
+			cmd.max = 1
+		}
+		if len(args) > 2 {
+			return cmd, fmt.Errorf("%s takes at most one argument", cmd.name)
+		}
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return cmd, fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+			cmd.max = n
+		}
+	case "redo", "status":
+		if len(args) > 1 {
+			return cmd, fmt.Errorf("%s takes no argument", cmd.name)
+		}
+	case "new":
+		if len(args) != 2 || args[1] == "" {
+			return cmd, fmt.Errorf("new takes the name of the migration")
+		}
+		cmd.arg = args[1]
+	default:
+		return cmd, fmt.Errorf("unknown command %q", cmd.name)
+	}
+	return cmd, nil
+}
+
+func newMigrator(driver string, cfg *config.Config) (migrator, error) {
+	switch driver {
+	case storage.DriverPostgres:
+		return newPostgresMigrator(cfg)
+	case storage.DriverSQLite:
+		return newSQLiteMigrator(cfg)
+	case storage.DriverMongo:
+		return newMongoMigrator(cfg)
+	default:
+		return nil, fmt.Errorf("unknown driver %q", driver)
+	}
+}
 
-	applogger.Infof("applied %d migrations\n", total)
+func run(ctx context.Context, m migrator, cmd command, dryRun bool, w io.Writer) error {
+	switch cmd.name {
+	case "status":
+		return m.Status(ctx, w)
+	case "up":
+		if dryRun {
+			return m.Plan(ctx, w, true, cmd.max)
+		}
+		n, err := m.Up(ctx, cmd.max)
+		fmt.Fprintf(w, "applied %d migrations\n", n)
+		return err
+	case "down":
+		if dryRun {
+			return m.Plan(ctx, w, false, cmd.max)
+		}
+		n, err := m.Down(ctx, cmd.max)
+		fmt.Fprintf(w, "reverted %d migrations\n", n)
+		return err
+	case "redo":
+		if dryRun {
+			if err := m.Plan(ctx, w, false, 1); err != nil {
+				return err
+			}
+			fmt.Fprintln(w, "-- then applied again")
+			return nil
+		}
+		n, err := m.Down(ctx, 1)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return fmt.Errorf("no applied migration to redo")
+		}
+		if _, err := m.Up(ctx, 1); err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "redid the last migration")
+		return nil
+	}
+	return fmt.Errorf("unknown command %q", cmd.name)
 }
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    command
+		wantErr bool
+	}{
+		{name: "up by default", args: nil, want: command{name: "up"}},
+		{name: "up n", args: []string{"up", "2"}, want: command{name: "up", max: 2}},
+		{name: "down one by default", args: []string{"down"}, want: command{name: "down", max: 1}},
+		{name: "down n", args: []string{"down", "3"}, want: command{name: "down", max: 3}},
+		{name: "new", args: []string{"new", "Add-fees"}, want: command{name: "new", arg: "Add-fees"}},
+		{name: "invalid count", args: []string{"down", "0"}, wantErr: true},
+		{name: "new without name", args: []string{"new"}, wantErr: true},
+		{name: "status with argument", args: []string{"status", "1"}, wantErr: true},
+		{name: "unknown command", args: []string{"reset"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCommand(tt.args)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewMigration(t *testing.T) {
+	now := time.Date(2026, 10, 19, 14, 30, 0, 0, time.UTC)
+
+	t.Run("success: creates postgres and sqlite migrations", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+
+		// Act
+		files, err := newMigration("postgres", dir, "Add-fees", now)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(dir, "20261019143000-Add-fees.sql"),
+			filepath.Join(dir, "sqlite", "20261019143000-Add-fees.sql"),
+		}, files)
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			assert.Equal(t, migrationTemplate, string(content))
+		}
+
+		_, err = newMigration("postgres", dir, "Add-fees", now)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := newMigration("postgres", t.TempDir(), "add fees", now)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("mongo migrations are Go code", func(t *testing.T) {
+		_, err := newMigration("mongo", t.TempDir(), "Add-fees", now)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go-clean-template/internal/infras/mongo"
+	"go-clean-template/pkg/config"
+)
+
+// mongoMigrator runs the Go migrations of internal/infras/mongo
+type mongoMigrator struct {
+	migrator *mongo.Migrator
+	close    func() error
+}
+
+func newMongoMigrator(cfg *config.Config) (*mongoMigrator, error) {
+	db, err := mongo.NewDB(mongo.ParseFromConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+	return &mongoMigrator{
+		migrator: mongo.NewMigrator(db, mongo.Migrations),
+		close: func() error {
+			return db.Client().Disconnect(context.Background())
+		},
+	}, nil
+}
+
+func (m *mongoMigrator) Up(ctx context.Context, max int) (int, error) {
+	return m.migrator.Up(ctx, max)
+}
+
+func (m *mongoMigrator) Down(ctx context.Context, max int) (int, error) {
+	return m.migrator.Down(ctx, max)
+}
+
+// Plan only lists the migration ids: mongo migrations are Go functions, there is no statement to print
+func (m *mongoMigrator) Plan(ctx context.Context, w io.Writer, up bool, max int) error {
+	dir := mongo.MigrateDown
+	if up {
+		dir = mongo.MigrateUp
+	}
+
+	planned, err := m.migrator.Plan(ctx, dir, max)
+	if err != nil {
+		return err
+	}
+	if len(planned) == 0 {
+		fmt.Fprintln(w, "-- no migration to run")
+	}
+	for _, migration := range planned {
+		fmt.Fprintf(w, "-- %s\n", migration.ID)
+	}
+	return nil
+}
+
+func (m *mongoMigrator) Status(ctx context.Context, w io.Writer) error {
+	statuses, err := m.migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(statuses))
+	applied := make(map[string]time.Time, len(statuses))
+	for _, status := range statuses {
+		ids = append(ids, status.ID)
+		if status.AppliedAt != nil {
+			applied[status.ID] = *status.AppliedAt
+		}
+	}
+	printStatus(w, ids, applied)
+	return nil
+}
+
+func (m *mongoMigrator) Close() error {
+	return m.close()
+}
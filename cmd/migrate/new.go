@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"go-clean-template/internal/infras/storage"
+)
+
+const migrationTemplate = `
+-- +migrate Up
+
+-- +migrate Down
+`
+
+var (
+	timeNow = time.Now
+
+	migrationName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// newMigration writes an empty migration named after the current time to dir and its sqlite counterpart to dir/sqlite,
+// since the sqlite migrations mirror the postgres ones. It returns the created files.
+func newMigration(driver string, dir string, name string, now time.Time) ([]string, error) {
+	if driver == storage.DriverMongo {
+		return nil, fmt.Errorf("mongo migrations are Go functions, add a Migration to internal/infras/mongo/migrations.go")
+	}
+	if !migrationName.MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name %q, use letters, digits, - and _", name)
+	}
+
+	filename := fmt.Sprintf("%s-%s.sql", now.UTC().Format("20060102150405"), name)
+	files := []string{filepath.Join(dir, filename), filepath.Join(dir, "sqlite", filename)}
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return nil, err
+		}
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		_, err = f.WriteString(migrationTemplate)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+
+	"go-clean-template/internal/infras/postgrestore"
+	"go-clean-template/internal/infras/sqlitestore"
+	"go-clean-template/migrations"
+	"go-clean-template/pkg/config"
+
+	migrate "github.com/rubenv/sql-migrate"
+	"gorm.io/gorm"
+)
+
+// sqlMigrator runs the embedded sql migrations with sql-migrate
+type sqlMigrator struct {
+	db      *sql.DB
+	dialect string
+	source  migrate.MigrationSource
+}
+
+func newPostgresMigrator(cfg *config.Config) (*sqlMigrator, error) {
+	db, err := postgrestore.NewDB(postgrestore.ParseFromConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+	return newSQLMigrator(db, "postgres", migrations.Postgres)
+}
+
+func newSQLiteMigrator(cfg *config.Config) (*sqlMigrator, error) {
+	db, err := sqlitestore.NewDB(sqlitestore.ParseFromConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+	return newSQLMigrator(db, sqlitestore.Dialect, migrations.SQLite)
+}
+
+func newSQLMigrator(db *gorm.DB, dialect string, source migrate.MigrationSource) (*sqlMigrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return &sqlMigrator{db: sqlDB, dialect: dialect, source: source}, nil
+}
+
+func (m *sqlMigrator) Up(ctx context.Context, max int) (int, error) {
+	return migrate.ExecMaxContext(ctx, m.db, m.dialect, m.source, migrate.Up, max)
+}
+
+func (m *sqlMigrator) Down(ctx context.Context, max int) (int, error) {
+	return migrate.ExecMaxContext(ctx, m.db, m.dialect, m.source, migrate.Down, max)
+}
+
+func (m *sqlMigrator) Plan(_ context.Context, w io.Writer, up bool, max int) error {
+	dir := migrate.Down
+	if up {
+		dir = migrate.Up
+	}
+
+	planned, _, err := migrate.PlanMigration(m.db, m.dialect, m.source, dir, max)
+	if err != nil {
+		return err
+	}
+	if len(planned) == 0 {
+		fmt.Fprintln(w, "-- no migration to run")
+	}
+	for _, migration := range planned {
+		fmt.Fprintf(w, "-- %s\n", migration.Id)
+		for _, query := range migration.Queries {
+			fmt.Fprintln(w, query)
+		}
+	}
+	return nil
+}
+
+func (m *sqlMigrator) Status(_ context.Context, w io.Writer) error {
+	known, err := m.source.FindMigrations()
+	if err != nil {
+		return err
+	}
+	records, err := migrate.GetMigrationRecords(m.db, m.dialect)
+	if err != nil {
+		return err
+	}
+
+	applied := make(map[string]time.Time, len(records))
+	for _, record := range records {
+		applied[record.Id] = record.AppliedAt
+	}
+
+	ids := make([]string, 0, len(known))
+	for _, migration := range known {
+		ids = append(ids, migration.Id)
+	}
+	printStatus(w, ids, applied)
+	return nil
+}
+
+func (m *sqlMigrator) Close() error {
+	return m.db.Close()
+}
+
+// printStatus writes one line per migration, in order, with the time it was applied or pending
+func printStatus(w io.Writer, ids []string, applied map[string]time.Time) {
+	for _, id := range ids {
+		status := "pending"
+		if at, ok := applied[id]; ok {
+			status = at.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%-60s %s\n", id, status)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"go-clean-template/internal/fixture"
+	"go-clean-template/internal/infras/storage"
+	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/logger"
+)
+
+const usage = `Usage: seed [flags] [fixture files...]
+
+Loads the YAML or JSON fixture files, then the random users, into the storage of --driver.
+Records are upserted by id, seeding the same files twice is a no-op.
+
+Flags:
+`
+
+// randomBatchUsers bounds how many random users are generated and written at once
+const randomBatchUsers = 100
+
+func main() {
+	applogger, err := logger.NewAppLogger()
+	if err != nil {
+		log.Fatalf("cannot load config: %v\n", err)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		applogger.Fatalf("cannot load config: %v\n", err)
+	}
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	driver := flag.String("driver", cfg.StorageDriver, "storage to seed: postgres, mongo, sqlite or memory")
+	users := flag.Int("random", 0, "number of random users to generate, each with one wallet and a transaction history")
+	transactions := flag.Int("transactions", 50, "number of transactions of every random user")
+	history := flag.Duration("history", 365*24*time.Hour, "how far back the random transactions go")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed of the random generator, the same seed generates the same users")
+	flag.Parse()
+
+	if flag.NArg() == 0 && *users == 0 {
+		flag.Usage()
+		applogger.Fatalf("nothing to seed, give fixture files or --random\n")
+	}
+
+	cfg.StorageDriver = *driver
+	repos, err := storage.New(cfg, storage.WithLogger(applogger))
+	if err != nil {
+		applogger.Fatalf("cannot init storage: %v\n", err)
+	}
+
+	ctx := context.Background()
+	for _, path := range flag.Args() {
+		set, err := fixture.LoadFile(path)
+		if err != nil {
+			applogger.Fatalf("cannot load fixtures: %v\n", err)
+		}
+		if err := repos.Seeder.Seed(ctx, set); err != nil {
+			applogger.Fatalf("cannot seed %s: %v\n", path, err)
+		}
+		applogger.Infof("seeded %s: %s\n", path, summary(set))
+	}
+
+	if *users > 0 {
+		opts := fixture.RandomOptions{TransactionsPerUser: *transactions, History: *history, Now: time.Now()}
+		if err := seedRandom(ctx, repos.Seeder, rand.New(rand.NewSource(*seed)), *users, opts); err != nil {
+			applogger.Fatalf("cannot seed random users: %v\n", err)
+		}
+		applogger.Infof("seeded %d random users with seed %d\n", *users, *seed)
+	}
+
+	if err := repos.Close(ctx); err != nil {
+		applogger.Errorf("cannot close storage: %v\n", err)
+	}
+}
+
+func seedRandom(ctx context.Context, seeder fixture.Seeder, rng *rand.Rand, users int, opts fixture.RandomOptions) error {
+	for seeded := 0; seeded < users; seeded += opts.Users {
+		opts.Users = min(randomBatchUsers, users-seeded)
+		set, err := fixture.Random(rng, opts)
+		if err != nil {
+			return err
+		}
+		if err := seeder.Seed(ctx, set); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func summary(set *fixture.Set) string {
+	return fmt.Sprintf("%d users, %d wallets, %d linked accounts, %d transactions",
+		len(set.Users), len(set.Wallets), len(set.LinkedAccounts), len(set.Transactions))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"go-clean-template/internal/handler/worker"
+	"go-clean-template/internal/infras/notification"
+	"go-clean-template/internal/infras/paymentsvc"
+	"go-clean-template/internal/infras/storage"
+	tracinginfra "go-clean-template/internal/infras/tracing"
+	"go-clean-template/internal/usecase"
+	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/errorreport"
+	"go-clean-template/pkg/idgen"
+	"go-clean-template/pkg/lifecycle"
+	"go-clean-template/pkg/logger"
+	"go-clean-template/pkg/tracing"
+)
+
+func main() {
+	applog, err := logger.NewAppLogger()
+	if err != nil {
+		log.Fatalf("cannot load config: %v\n", err)
+	}
+	defer logger.Sync(applog)
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		applog.Fatal(err)
+	}
+	if err := logger.SetLevel(cfg.LogLevel); err != nil {
+		applog.Fatal(err)
+	}
+	logger.SetDefault(applog)
+
+	reporter, err := errorreport.New(errorreport.ParseFromConfig(cfg), applog)
+	if err != nil {
+		applog.Fatalf("cannot init error reporter: %v", err)
+	}
+	// the components are stopped in the reverse order: the workers first, the error reporter last
+	manager := lifecycle.New(applog, cfg.ShutdownTimeout)
+	manager.Add(lifecycle.Component{Name: "error reporter", Stop: reporter.Flush})
+
+	tracingOpts := tracing.ParseFromConfig(cfg)
+	tracingOpts.ServiceName += "-worker"
+	tracerProvider, err := tracing.Setup(context.Background(), tracingOpts)
+	if err != nil {
+		applog.Fatal(err)
+	}
+	storageOpts := []storage.Option{storage.WithLogger(applog)}
+	if tracerProvider != nil {
+		manager.Add(lifecycle.Component{Name: "tracing", Stop: tracerProvider.Shutdown})
+		storageOpts = append(storageOpts, storage.WithTracerProvider(tracerProvider))
+	}
+
+	repos, err := storage.New(cfg, storageOpts...)
+	if err != nil {
+		applog.Fatal(err)
+	}
+	manager.Add(lifecycle.Component{Name: "storage", Stop: repos.Close})
+
+	idGenerator, err := idgen.New(cfg.IDStrategy)
+	if err != nil {
+		applog.Fatal(err)
+	}
+
+	//Setup Dependencies
+	transRepo := repos.Transaction
+	var paymentSvc usecase.IPaymentServiceProvider = paymentsvc.NewPaymentServiceProvider()
+	if tracerProvider != nil {
+		paymentSvc = tracinginfra.NewPaymentServiceProvider(paymentSvc, tracerProvider)
+	}
+	transUseCase := usecase.NewTransactionUseCase(transRepo, paymentSvc)
+	transUseCase.SetNotifiers(notification.NewEmailNotifier(), notification.NewAppNotifier())
+	transUseCase.SetApproval(repos.Approval, usecase.ApprovalPolicy{
+		Threshold: cfg.Approval.Threshold,
+		TTL:       cfg.Approval.TTL,
+	})
+	transUseCase.SetBalanceSnapshots(repos.Balance)
+	transUseCase.SetTransactor(repos.Transactor)
+	transUseCase.SetIDGenerator(idGenerator)
+	var transactions usecase.ITransactionUseCase = transUseCase
+	if tracerProvider != nil {
+		transactions = tracinginfra.NewTransactionUseCase(transUseCase, tracerProvider)
+	}
+	scheduleUseCase := usecase.NewScheduleUseCase(repos.Schedule, transRepo, transactions)
+	scheduleUseCase.SetNotifiers(notification.NewEmailNotifier(), notification.NewAppNotifier())
+	scheduleUseCase.SetIDGenerator(idGenerator)
+	balanceUseCase := usecase.NewBalanceUseCase(repos.Balance, transRepo)
+	payoutUseCase := usecase.NewPayoutUseCase(repos.Payout, transRepo, transactions, cfg.Payout.Concurrency)
+	payoutUseCase.SetNotifiers(notification.NewEmailNotifier(), notification.NewAppNotifier())
+	payoutUseCase.SetIDGenerator(idGenerator)
+
+	manager.Add(lifecycle.Component{Name: "scheduler", Run: func(ctx context.Context) error {
+		applog.Infof("scheduler started, interval %s", cfg.Scheduler.Interval)
+		scheduler := worker.NewScheduler(scheduleUseCase, cfg.Scheduler.Interval, applog)
+		scheduler.SetErrorReporter(reporter)
+		scheduler.Run(ctx)
+		return nil
+	}})
+	manager.Add(lifecycle.Component{Name: "balance snapshotter", Run: func(ctx context.Context) error {
+		applog.Infof("balance snapshotter started, interval %s", cfg.BalanceSnapshot.Interval)
+		snapshotter := worker.NewBalanceSnapshotter(balanceUseCase, cfg.BalanceSnapshot.Interval, applog)
+		snapshotter.SetErrorReporter(reporter)
+		snapshotter.Run(ctx)
+		return nil
+	}})
+	manager.Add(lifecycle.Component{Name: "payout resumer", Run: func(ctx context.Context) error {
+		applog.Infof("payout resumer started, interval %s", cfg.Payout.ResumeInterval)
+		resumer := worker.NewPayoutResumer(payoutUseCase, cfg.Payout.ResumeInterval, applog)
+		resumer.SetErrorReporter(reporter)
+		resumer.Run(ctx)
+		return nil
+	}})
+	manager.Add(lifecycle.Component{Name: "approval expirer", Run: func(ctx context.Context) error {
+		applog.Infof("approval expirer started, interval %s", cfg.Approval.SweepInterval)
+		expirer := worker.NewApprovalExpirer(transactions, cfg.Approval.SweepInterval, applog)
+		expirer.SetErrorReporter(reporter)
+		expirer.Run(ctx)
+		return nil
+	}})
+
+	if err := manager.Run(context.Background()); err != nil {
+		applog.Errorf("shutdown: %v", err)
+		logger.Sync(applog)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "PENDING"
+	ApprovalStatusApproved ApprovalStatus = "APPROVED"
+	ApprovalStatusRejected ApprovalStatus = "REJECTED"
+	ApprovalStatusExpired  ApprovalStatus = "EXPIRED"
+)
+
+// ErrApprovalDecided is returned by an update of an approval that is no longer pending, because another
+// decision won the race, or of an approval that does not exist
+var ErrApprovalDecided = errors.New("approval is no longer pending")
+
+// ErrSelfApproval is returned by a decision of the requester of the approval, or of an unknown approver
+var ErrSelfApproval = errors.New("approver must be different from requester")
+
+// Approval is a maker-checker request attached to a transaction that needs a second person to sign off
+type Approval struct {
+	ID            string
+	TransactionID string
+	RequestedBy   string
+	DecidedBy     string
+	Status        ApprovalStatus
+	Reason        string
+	ExpiresAt     time.Time
+	DecidedAt     *time.Time
+}
+
+func NewApproval(id string, transID string, requestedBy string, expiresAt time.Time) *Approval {
+	return &Approval{
+		ID:            id,
+		TransactionID: transID,
+		RequestedBy:   requestedBy,
+		Status:        ApprovalStatusPending,
+		ExpiresAt:     expiresAt,
+	}
+}
+
+func (a *Approval) IsExpired(now time.Time) bool {
+	return a.Status == ApprovalStatusPending && !now.Before(a.ExpiresAt)
+}
+
+func (a *Approval) Approve(approverID string, now time.Time) error {
+	if err := a.checkDecision(approverID, now); err != nil {
+		return err
+	}
+	a.Status = ApprovalStatusApproved
+	a.DecidedBy = approverID
+	a.DecidedAt = &now
+	return nil
+}
+
+func (a *Approval) Reject(approverID string, reason string, now time.Time) error {
+	if err := a.checkDecision(approverID, now); err != nil {
+		return err
+	}
+	a.Status = ApprovalStatusRejected
+	a.DecidedBy = approverID
+	a.Reason = reason
+	a.DecidedAt = &now
+	return nil
+}
+
+func (a *Approval) Expire(now time.Time) error {
+	if !a.IsExpired(now) {
+		return fmt.Errorf("approval %s has not expired", a.ID)
+	}
+	a.Status = ApprovalStatusExpired
+	a.DecidedAt = &now
+	return nil
+}
+
+func (a *Approval) checkDecision(approverID string, now time.Time) error {
+	if a.Status != ApprovalStatusPending {
+		return fmt.Errorf("cant decide approval in status %s", a.Status)
+	}
+	if a.IsExpired(now) {
+		return fmt.Errorf("approval has expired")
+	}
+	if approverID == "" || approverID == a.RequestedBy {
+		return ErrSelfApproval
+	}
+	return nil
+}
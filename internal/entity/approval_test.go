@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestNewApproval(t *testing.T) {
+	expiresAt := time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)
+	want := &Approval{
+		ID:            "ap001",
+		TransactionID: "trans001",
+		RequestedBy:   "u001",
+		Status:        ApprovalStatusPending,
+		ExpiresAt:     expiresAt,
+	}
+
+	if got := NewApproval("ap001", "trans001", "u001", expiresAt); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewApproval() = %v, want %v", got, want)
+	}
+}
+
+func TestApproval_Approve(t *testing.T) {
+	now := time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		approval   *Approval
+		approverID string
+		wantErr    error
+		wantStatus ApprovalStatus
+	}{
+		{
+			name:       "approve success",
+			approval:   NewApproval("ap001", "trans001", "u001", now.Add(time.Hour)),
+			approverID: "u002",
+			wantErr:    nil,
+			wantStatus: ApprovalStatusApproved,
+		},
+		{
+			name:       "approver is requester",
+			approval:   NewApproval("ap001", "trans001", "u001", now.Add(time.Hour)),
+			approverID: "u001",
+			wantErr:    ErrSelfApproval,
+			wantStatus: ApprovalStatusPending,
+		},
+		{
+			name:       "approval has expired",
+			approval:   NewApproval("ap001", "trans001", "u001", now.Add(-time.Hour)),
+			approverID: "u002",
+			wantErr:    fmt.Errorf("approval has expired"),
+			wantStatus: ApprovalStatusPending,
+		},
+		{
+			name: "approval already decided",
+			approval: &Approval{
+				ID:        "ap001",
+				Status:    ApprovalStatusRejected,
+				ExpiresAt: now.Add(time.Hour),
+			},
+			approverID: "u002",
+			wantErr:    fmt.Errorf("cant decide approval in status REJECTED"),
+			wantStatus: ApprovalStatusRejected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.approval.Approve(tt.approverID, now)
+
+			assert.Equal(t, tt.wantErr, err)
+
+			assert.Equal(t, tt.wantStatus, tt.approval.Status)
+		})
+	}
+}
+
+func TestApproval_Reject(t *testing.T) {
+	now := time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)
+	approval := NewApproval("ap001", "trans001", "u001", now.Add(time.Hour))
+
+	err := approval.Reject("u002", "suspicious activity", now)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ApprovalStatusRejected, approval.Status)
+	assert.Equal(t, "u002", approval.DecidedBy)
+	assert.Equal(t, "suspicious activity", approval.Reason)
+	assert.Equal(t, &now, approval.DecidedAt)
+}
+
+func TestApproval_Expire(t *testing.T) {
+	now := time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)
+
+	t.Run("expire success", func(t *testing.T) {
+		approval := NewApproval("ap001", "trans001", "u001", now)
+
+		err := approval.Expire(now)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, ApprovalStatusExpired, approval.Status)
+	})
+
+	t.Run("not expired yet", func(t *testing.T) {
+		approval := NewApproval("ap001", "trans001", "u001", now.Add(time.Minute))
+
+		err := approval.Expire(now)
+
+		assert.Equal(t, fmt.Errorf("approval ap001 has not expired"), err)
+		assert.Equal(t, ApprovalStatusPending, approval.Status)
+	})
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// balanceTolerance absorbs the rounding of summing float amounts in a different order
+const balanceTolerance = 0.000001
+
+// BalanceSnapshot is the balance of a wallet at a daily close,
+// i.e. the sum of its successful transactions created before AsOf.
+type BalanceSnapshot struct {
+	WalletID string
+	AsOf     time.Time
+	Balance  float64
+}
+
+func NewBalanceSnapshot(walletID string, asOf time.Time, balance float64) *BalanceSnapshot {
+	return &BalanceSnapshot{
+		WalletID: walletID,
+		AsOf:     asOf,
+		Balance:  balance,
+	}
+}
+
+// DayClose returns the start of the UTC day of t, which closes the day before
+func DayClose(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// BalanceCheck compares the balance read from a snapshot and the transactions after it
+// with the balance recomputed from every transaction of the wallet.
+type BalanceCheck struct {
+	WalletID     string
+	AsOf         time.Time
+	SnapshotAsOf *time.Time
+	Balance      float64
+	Recomputed   float64
+}
+
+func (c *BalanceCheck) Difference() float64 {
+	return c.Balance - c.Recomputed
+}
+
+func (c *BalanceCheck) IsConsistent() bool {
+	return math.Abs(c.Difference()) < balanceTolerance
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestDayClose(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{
+			name: "utc time",
+			t:    time.Date(2024, 7, 10, 23, 59, 59, 0, time.UTC),
+			want: time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time in another zone is converted to utc first",
+			t:    time.Date(2024, 7, 10, 2, 0, 0, 0, time.FixedZone("ICT", 7*60*60)),
+			want: time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DayClose(tt.t))
+		})
+	}
+}
+
+func TestBalanceCheck_IsConsistent(t *testing.T) {
+	tests := []struct {
+		name  string
+		check BalanceCheck
+		want  bool
+	}{
+		{
+			name:  "same balance",
+			check: BalanceCheck{Balance: 1500, Recomputed: 1500},
+			want:  true,
+		},
+		{
+			name:  "rounding error of float sums",
+			check: BalanceCheck{Balance: 0.1 + 0.2, Recomputed: 0.3},
+			want:  true,
+		},
+		{
+			name:  "snapshot drifted",
+			check: BalanceCheck{Balance: 1500, Recomputed: 1000},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.check.IsConsistent())
+		})
+	}
+}
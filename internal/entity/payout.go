@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go-clean-template/pkg/validation"
+)
+
+// MaxPayoutBatchSize bounds the number of items accepted in one payout batch
+const MaxPayoutBatchSize = 1000
+
+// maxPayoutNoteLength is the length of the note a single withdrawal accepts
+const maxPayoutNoteLength = 255
+
+// PayoutBatchLease is how long a processor keeps a claimed batch before another one may take it over
+const PayoutBatchLease = 10 * time.Minute
+
+type PayoutBatchStatus string
+
+const (
+	PayoutBatchStatusPending    PayoutBatchStatus = "PENDING"
+	PayoutBatchStatusProcessing PayoutBatchStatus = "PROCESSING"
+	PayoutBatchStatusCompleted  PayoutBatchStatus = "COMPLETED"
+)
+
+type PayoutItemStatus string
+
+const (
+	PayoutItemStatusPending          PayoutItemStatus = "PENDING"
+	PayoutItemStatusSucceeded        PayoutItemStatus = "SUCCEEDED"
+	PayoutItemStatusFailed           PayoutItemStatus = "FAILED"
+	PayoutItemStatusAwaitingApproval PayoutItemStatus = "AWAITING_APPROVAL"
+)
+
+// PayoutBatch is a group of withdrawals uploaded at once and processed in the background.
+// ClaimedUntil is the end of the lease of the processor working on it, zero before the first claim.
+type PayoutBatch struct {
+	ID           string
+	CreatedBy    string
+	Status       PayoutBatchStatus
+	ClaimedUntil time.Time
+	Items        []*PayoutItem
+}
+
+// PayoutItem is one withdrawal of a payout batch. Line is its 1-based position in the upload.
+type PayoutItem struct {
+	ID            string
+	BatchID       string
+	Line          int
+	WalletID      string
+	AccountID     string
+	Amount        float64
+	Currency      string
+	Note          string
+	Status        PayoutItemStatus
+	TransactionID string
+	Reason        string
+}
+
+type PayoutProgress struct {
+	Total            int
+	Pending          int
+	Succeeded        int
+	Failed           int
+	AwaitingApproval int
+}
+
+// PayoutItemsError lists every invalid item of a batch, one message per item
+type PayoutItemsError []string
+
+func (e PayoutItemsError) Error() string {
+	return "invalid payout items: " + strings.Join(e, "; ")
+}
+
+// NewPayoutBatch validates every item and numbers them in upload order. All invalid items are
+// reported together in a PayoutItemsError.
+func NewPayoutBatch(id string, createdBy string, items []*PayoutItem) (*PayoutBatch, error) {
+	if id == "" {
+		return nil, fmt.Errorf("invalid payout batch id")
+	}
+	if len(items) == 0 || len(items) > MaxPayoutBatchSize {
+		return nil, fmt.Errorf("payout batch must have between 1 and %d items", MaxPayoutBatchSize)
+	}
+
+	var errs PayoutItemsError
+	for i, item := range items {
+		item.Line = i + 1
+		item.ID = fmt.Sprintf("%s-%d", id, item.Line)
+		item.BatchID = id
+		item.Status = PayoutItemStatusPending
+		if err := item.Validate(); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %s", item.Line, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &PayoutBatch{
+		ID:        id,
+		CreatedBy: createdBy,
+		Status:    PayoutBatchStatusPending,
+		Items:     items,
+	}, nil
+}
+
+func (b *PayoutBatch) Progress() PayoutProgress {
+	p := PayoutProgress{Total: len(b.Items)}
+	for _, item := range b.Items {
+		switch item.Status {
+		case PayoutItemStatusSucceeded:
+			p.Succeeded++
+		case PayoutItemStatusFailed:
+			p.Failed++
+		case PayoutItemStatusAwaitingApproval:
+			p.AwaitingApproval++
+		default:
+			p.Pending++
+		}
+	}
+	return p
+}
+
+// Claimable tells whether a processor may claim the batch at now: it is not completed and its last lease ended
+func (b *PayoutBatch) Claimable(now time.Time) bool {
+	return b.Status != PayoutBatchStatusCompleted && !now.Before(b.ClaimedUntil)
+}
+
+// Validate checks an item with the rules of a single withdrawal, an empty currency is the default one
+func (i *PayoutItem) Validate() error {
+	if i.WalletID == "" {
+		return fmt.Errorf("wallet_id is required")
+	}
+	if i.AccountID == "" {
+		return fmt.Errorf("account_id is required")
+	}
+	if i.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	if i.Currency != "" && !validation.IsCurrency(i.Currency) {
+		return fmt.Errorf("currency must be a supported ISO 4217 currency code")
+	}
+	if !validation.HasCurrencyPrecision(i.Amount, i.Currency) {
+		return fmt.Errorf("amount has more decimals than its currency allows")
+	}
+	if utf8.RuneCountInString(i.Note) > maxPayoutNoteLength {
+		return fmt.Errorf("note must have at most %d characters", maxPayoutNoteLength)
+	}
+	if !validation.IsNote(i.Note) {
+		return fmt.Errorf("note must only contain printable characters and no markup")
+	}
+	return nil
+}
+
+func (i *PayoutItem) ToSucceeded(transID string) error {
+	if i.Status != PayoutItemStatusPending {
+		return fmt.Errorf("cant update payout item status from %s to %s", i.Status, PayoutItemStatusSucceeded)
+	}
+	i.Status = PayoutItemStatusSucceeded
+	i.TransactionID = transID
+	return nil
+}
+
+func (i *PayoutItem) ToAwaitingApproval(transID string) error {
+	if i.Status != PayoutItemStatusPending {
+		return fmt.Errorf("cant update payout item status from %s to %s", i.Status, PayoutItemStatusAwaitingApproval)
+	}
+	i.Status = PayoutItemStatusAwaitingApproval
+	i.TransactionID = transID
+	return nil
+}
+
+func (i *PayoutItem) ToFailed(transID string, reason string) error {
+	if i.Status != PayoutItemStatusPending {
+		return fmt.Errorf("cant update payout item status from %s to %s", i.Status, PayoutItemStatusFailed)
+	}
+	i.Status = PayoutItemStatusFailed
+	i.TransactionID = transID
+	i.Reason = reason
+	return nil
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestNewPayoutBatch(t *testing.T) {
+	t.Run("items are numbered in upload order", func(t *testing.T) {
+		items := []*PayoutItem{
+			{WalletID: "w001", AccountID: "a001", Amount: 1000, Currency: "VND"},
+			{WalletID: "w002", AccountID: "a002", Amount: 2000, Currency: "VND"},
+		}
+
+		got, err := NewPayoutBatch("b001", "u001", items)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, PayoutBatchStatusPending, got.Status)
+		assert.Equal(t, "b001-2", got.Items[1].ID)
+		assert.Equal(t, 2, got.Items[1].Line)
+		assert.Equal(t, PayoutItemStatusPending, got.Items[1].Status)
+	})
+
+	t.Run("every invalid item is reported", func(t *testing.T) {
+		items := []*PayoutItem{
+			{WalletID: "w001", AccountID: "a001", Amount: 1000},
+			{WalletID: "w002", Amount: 2000},
+			{WalletID: "w003", AccountID: "a003", Amount: -1},
+			{WalletID: "w004", AccountID: "a004", Amount: 1000, Currency: "VN"},
+			{WalletID: "w005", AccountID: "a005", Amount: 1000.5, Currency: "VND"},
+			{WalletID: "w006", AccountID: "a006", Amount: 10.25, Currency: "USD", Note: "<b>bonus</b>"},
+			{WalletID: "w007", AccountID: "a007", Amount: 10.25, Note: strings.Repeat("a", 256)},
+		}
+
+		got, err := NewPayoutBatch("b001", "u001", items)
+
+		assert.Equal(t, (*PayoutBatch)(nil), got)
+		assert.Equal(t, PayoutItemsError{
+			"line 2: account_id is required",
+			"line 3: amount must be greater than 0",
+			"line 4: currency must be a supported ISO 4217 currency code",
+			"line 5: amount has more decimals than its currency allows",
+			"line 6: note must only contain printable characters and no markup",
+			"line 7: note must have at most 255 characters",
+		}, err)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		_, err := NewPayoutBatch("b001", "u001", nil)
+
+		assert.Equal(t, fmt.Errorf("payout batch must have between 1 and 1000 items"), err)
+	})
+}
+
+func TestPayoutBatch_Progress(t *testing.T) {
+	b := &PayoutBatch{Items: []*PayoutItem{
+		{Status: PayoutItemStatusPending},
+		{Status: PayoutItemStatusSucceeded},
+		{Status: PayoutItemStatusSucceeded},
+		{Status: PayoutItemStatusFailed},
+		{Status: PayoutItemStatusAwaitingApproval},
+	}}
+
+	assert.Equal(t, PayoutProgress{Total: 5, Pending: 1, Succeeded: 2, Failed: 1, AwaitingApproval: 1}, b.Progress())
+}
+
+func TestPayoutBatch_Claimable(t *testing.T) {
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, true, (&PayoutBatch{Status: PayoutBatchStatusPending}).Claimable(now))
+	assert.Equal(t, true, (&PayoutBatch{Status: PayoutBatchStatusProcessing, ClaimedUntil: now}).Claimable(now))
+	assert.Equal(t, false, (&PayoutBatch{Status: PayoutBatchStatusProcessing, ClaimedUntil: now.Add(time.Second)}).Claimable(now))
+	assert.Equal(t, false, (&PayoutBatch{Status: PayoutBatchStatusCompleted}).Claimable(now))
+}
+
+func TestPayoutItem_ToFailed(t *testing.T) {
+	i := &PayoutItem{Status: PayoutItemStatusSucceeded}
+
+	err := i.ToFailed("t001", "payment failed")
+
+	assert.Equal(t, fmt.Errorf("cant update payout item status from SUCCEEDED to FAILED"), err)
+}
@@ -0,0 +1,210 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go-clean-template/pkg/validation"
+)
+
+// ScheduleRunLease is how long a scheduler keeps a claimed run before another one may take it over
+const ScheduleRunLease = 5 * time.Minute
+
+// ErrScheduleRunClaimed is returned by a claim of an occurrence that another scheduler is still running
+var ErrScheduleRunClaimed = errors.New("schedule run is claimed by another scheduler")
+
+type ScheduleFrequency string
+
+const (
+	ScheduleOnce       ScheduleFrequency = "ONCE"
+	ScheduleWeekly     ScheduleFrequency = "WEEKLY"
+	ScheduleMonthly    ScheduleFrequency = "MONTHLY"
+	ScheduleEndOfMonth ScheduleFrequency = "END_OF_MONTH"
+)
+
+type ScheduleStatus string
+
+const (
+	ScheduleStatusActive    ScheduleStatus = "ACTIVE"
+	ScheduleStatusCompleted ScheduleStatus = "COMPLETED"
+)
+
+// Schedule is a future or recurring transaction which is materialized by the scheduler when it falls due
+type Schedule struct {
+	ID              string
+	WalletID        string
+	AccountID       string
+	Amount          float64
+	Currency        string
+	TransactionKind TransactionKind
+	Note            string
+	Frequency       ScheduleFrequency
+	StartAt         time.Time
+	NextRunAt       time.Time
+	Status          ScheduleStatus
+	// Version is incremented by every update, an update expecting another version is a conflict
+	Version int64
+}
+
+func NewSchedule(id string, walletID string, accountID string, amount float64, currency string, transKind TransactionKind,
+	note string, frequency ScheduleFrequency, startAt time.Time) (*Schedule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id must not be empty")
+	}
+	if transKind != TransactionIn && transKind != TransactionOut {
+		return nil, fmt.Errorf("invalid transaction kind %s", transKind)
+	}
+	if !validation.HasCurrencyPrecision(amount, currency) {
+		return nil, fmt.Errorf("amount has more decimals than its currency allows")
+	}
+
+	s := &Schedule{
+		ID:              id,
+		WalletID:        walletID,
+		AccountID:       accountID,
+		Amount:          amount,
+		Currency:        currency,
+		TransactionKind: transKind,
+		Note:            note,
+		Frequency:       frequency,
+		StartAt:         startAt,
+		Status:          ScheduleStatusActive,
+		Version:         InitialVersion,
+	}
+
+	switch frequency {
+	case ScheduleOnce, ScheduleWeekly, ScheduleMonthly:
+		s.NextRunAt = startAt
+	case ScheduleEndOfMonth:
+		s.NextRunAt = endOfMonth(startAt.Year(), startAt.Month(), startAt)
+	default:
+		return nil, fmt.Errorf("invalid schedule frequency %s", frequency)
+	}
+
+	return s, nil
+}
+
+// Update changes the amount, note and recurrence of the schedule and keeps its status. When the recurrence changes,
+// the next run starts again from startAt but the occurrences before now are skipped, they are not backfilled.
+func (s *Schedule) Update(amount float64, note string, frequency ScheduleFrequency, startAt time.Time, now time.Time) error {
+	updated, err := NewSchedule(s.ID, s.WalletID, s.AccountID, amount, s.Currency, s.TransactionKind, note, frequency, startAt)
+	if err != nil {
+		return err
+	}
+
+	s.Amount = amount
+	s.Note = note
+	if frequency == s.Frequency && startAt.Equal(s.StartAt) {
+		return nil
+	}
+	s.Frequency = frequency
+	s.StartAt = startAt
+	s.NextRunAt = updated.NextRunAt
+	s.SkipMissed(now)
+	return nil
+}
+
+// SkipMissed moves the next run to the first occurrence at or after now, the occurrences before now are not
+// backfilled. A one-off schedule whose occurrence is before now is completed.
+func (s *Schedule) SkipMissed(now time.Time) {
+	for s.Status == ScheduleStatusActive && s.NextRunAt.Before(now) {
+		s.Advance()
+	}
+}
+
+// IsDue reports whether the next occurrence of the schedule should be materialized at now
+func (s *Schedule) IsDue(now time.Time) bool {
+	return s.Status == ScheduleStatusActive && !s.NextRunAt.After(now)
+}
+
+// Advance moves the schedule to the occurrence after NextRunAt. A one-off schedule is completed instead.
+func (s *Schedule) Advance() {
+	next := s.NextRunAt
+	switch s.Frequency {
+	case ScheduleOnce:
+		s.Status = ScheduleStatusCompleted
+		return
+	case ScheduleWeekly:
+		next = next.AddDate(0, 0, 7)
+	case ScheduleMonthly:
+		// keep the day of StartAt, clamped to the length of the month (Jan 31 -> Feb 28 -> Mar 31)
+		year, month, _ := next.Date()
+		year, month = addMonth(year, month)
+		day := s.StartAt.Day()
+		if last := daysIn(year, month); day > last {
+			day = last
+		}
+		next = time.Date(year, month, day, next.Hour(), next.Minute(), next.Second(), next.Nanosecond(), next.Location())
+	case ScheduleEndOfMonth:
+		year, month, _ := next.Date()
+		year, month = addMonth(year, month)
+		next = endOfMonth(year, month, next)
+	}
+	s.NextRunAt = next
+}
+
+type ScheduleRunStatus string
+
+const (
+	ScheduleRunPending      ScheduleRunStatus = "PENDING"
+	ScheduleRunMaterialized ScheduleRunStatus = "MATERIALIZED"
+	ScheduleRunSkipped      ScheduleRunStatus = "SKIPPED"
+	ScheduleRunFailed       ScheduleRunStatus = "FAILED"
+)
+
+// ScheduleRun records one occurrence of a schedule. Its ID is derived from the schedule and the occurrence time,
+// so a store rejects a second run of the same occurrence.
+// ClaimedUntil is the end of the lease of the scheduler running it, zero for a run claimed before the leases.
+type ScheduleRun struct {
+	ID           string
+	ScheduleID   string
+	OccurrenceAt time.Time
+	Status       ScheduleRunStatus
+	Reason       string
+	ClaimedUntil time.Time
+}
+
+func NewScheduleRun(scheduleID string, occurrenceAt time.Time) *ScheduleRun {
+	return &ScheduleRun{
+		ID:           fmt.Sprintf("%s-%d", scheduleID, occurrenceAt.Unix()),
+		ScheduleID:   scheduleID,
+		OccurrenceAt: occurrenceAt,
+		Status:       ScheduleRunPending,
+	}
+}
+
+// Claimable tells whether a scheduler may claim the run again at now: it is still pending and its last lease ended,
+// the scheduler that claimed it stopped before recording its result
+func (r *ScheduleRun) Claimable(now time.Time) bool {
+	return r.Status == ScheduleRunPending && !now.Before(r.ClaimedUntil)
+}
+
+func (r *ScheduleRun) ToMaterialized() {
+	r.Status = ScheduleRunMaterialized
+}
+
+func (r *ScheduleRun) ToSkipped(reason string) {
+	r.Status = ScheduleRunSkipped
+	r.Reason = reason
+}
+
+func (r *ScheduleRun) ToFailed(reason string) {
+	r.Status = ScheduleRunFailed
+	r.Reason = reason
+}
+
+func addMonth(year int, month time.Month) (int, time.Month) {
+	if month == time.December {
+		return year + 1, time.January
+	}
+	return year, month + 1
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func endOfMonth(year int, month time.Month, clock time.Time) time.Time {
+	return time.Date(year, month, daysIn(year, month), clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), clock.Location())
+}
@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestNewSchedule(t *testing.T) {
+	startAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		frequency     ScheduleFrequency
+		kind          TransactionKind
+		wantNextRunAt time.Time
+		wantErr       error
+	}{
+		{
+			name:          "monthly schedule starts at start date",
+			frequency:     ScheduleMonthly,
+			kind:          TransactionOut,
+			wantNextRunAt: startAt,
+		},
+		{
+			name:          "end of month schedule starts at end of start month",
+			frequency:     ScheduleEndOfMonth,
+			kind:          TransactionIn,
+			wantNextRunAt: time.Date(2024, 7, 31, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "invalid frequency",
+			frequency: "DAILY",
+			kind:      TransactionOut,
+			wantErr:   fmt.Errorf("invalid schedule frequency DAILY"),
+		},
+		{
+			name:      "invalid transaction kind",
+			frequency: ScheduleWeekly,
+			kind:      "TRANSFER",
+			wantErr:   fmt.Errorf("invalid transaction kind TRANSFER"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSchedule("s001", "w001", "a001", 1000, "VND", tt.kind, "", tt.frequency, startAt)
+
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantNextRunAt, got.NextRunAt)
+				assert.Equal(t, ScheduleStatusActive, got.Status)
+			}
+		})
+	}
+}
+
+func TestSchedule_Advance(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency ScheduleFrequency
+		startAt   time.Time
+		want      []time.Time
+	}{
+		{
+			name:      "weekly",
+			frequency: ScheduleWeekly,
+			startAt:   time.Date(2024, 12, 25, 9, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "monthly keeps the start day when the month is long enough",
+			frequency: ScheduleMonthly,
+			startAt:   time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 30, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "end of month",
+			frequency: ScheduleEndOfMonth,
+			startAt:   time.Date(2024, 11, 15, 9, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 2, 28, 9, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSchedule("s001", "w001", "a001", 1000, "VND", TransactionOut, "", tt.frequency, tt.startAt)
+			assert.Equal(t, nil, err)
+
+			for _, want := range tt.want {
+				s.Advance()
+				assert.Equal(t, want, s.NextRunAt)
+			}
+		})
+	}
+
+	t.Run("once completes the schedule", func(t *testing.T) {
+		startAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+		s, err := NewSchedule("s001", "w001", "a001", 1000, "VND", TransactionOut, "", ScheduleOnce, startAt)
+		assert.Equal(t, nil, err)
+
+		s.Advance()
+
+		assert.Equal(t, ScheduleStatusCompleted, s.Status)
+		assert.Equal(t, false, s.IsDue(startAt.Add(time.Hour)))
+	})
+}
+
+func TestNewScheduleRun(t *testing.T) {
+	occurrenceAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	got := NewScheduleRun("s001", occurrenceAt)
+
+	assert.Equal(t, &ScheduleRun{
+		ID:           "s001-1720602000",
+		ScheduleID:   "s001",
+		OccurrenceAt: occurrenceAt,
+		Status:       ScheduleRunPending,
+	}, got)
+}
+
+func TestSchedule_Update(t *testing.T) {
+	startAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 8, 20, 12, 0, 0, 0, time.UTC)
+
+	t.Run("same recurrence keeps the next run", func(t *testing.T) {
+		s, _ := NewSchedule("s001", "w001", "a001", 1000, "VND", TransactionOut, "rent", ScheduleMonthly, startAt)
+		s.Advance()
+
+		err := s.Update(2000, "new rent", ScheduleMonthly, startAt, now)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 2000.0, s.Amount)
+		assert.Equal(t, "new rent", s.Note)
+		assert.Equal(t, time.Date(2024, 8, 10, 9, 0, 0, 0, time.UTC), s.NextRunAt)
+	})
+
+	t.Run("a past start does not backfill", func(t *testing.T) {
+		s, _ := NewSchedule("s001", "w001", "a001", 1000, "VND", TransactionOut, "", ScheduleMonthly, startAt)
+
+		err := s.Update(1000, "", ScheduleWeekly, time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC), now)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, ScheduleWeekly, s.Frequency)
+		assert.Equal(t, time.Date(2024, 8, 26, 9, 0, 0, 0, time.UTC), s.NextRunAt)
+		assert.Equal(t, ScheduleStatusActive, s.Status)
+	})
+
+	t.Run("a completed schedule stays completed", func(t *testing.T) {
+		s, _ := NewSchedule("s001", "w001", "a001", 1000, "VND", TransactionIn, "", ScheduleOnce, startAt)
+		s.Advance()
+
+		err := s.Update(1000, "", ScheduleWeekly, now.Add(time.Hour), now)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, ScheduleStatusCompleted, s.Status)
+	})
+
+	t.Run("invalid frequency", func(t *testing.T) {
+		s, _ := NewSchedule("s001", "w001", "a001", 1000, "VND", TransactionIn, "", ScheduleOnce, startAt)
+
+		err := s.Update(1000, "", "DAILY", startAt, now)
+
+		assert.Equal(t, fmt.Errorf("invalid schedule frequency DAILY"), err)
+		assert.Equal(t, ScheduleOnce, s.Frequency)
+	})
+
+	t.Run("amount with more decimals than the currency", func(t *testing.T) {
+		s, _ := NewSchedule("s001", "w001", "a001", 1000, "VND", TransactionOut, "", ScheduleMonthly, startAt)
+
+		err := s.Update(1000.5, "", ScheduleMonthly, startAt, now)
+
+		assert.Equal(t, fmt.Errorf("amount has more decimals than its currency allows"), err)
+		assert.Equal(t, 1000.0, s.Amount)
+	})
+}
+
+func TestScheduleRun_Claimable(t *testing.T) {
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	run := NewScheduleRun("s001", now)
+
+	assert.Equal(t, true, run.Claimable(now))
+	run.ClaimedUntil = now.Add(ScheduleRunLease)
+	assert.Equal(t, false, run.Claimable(now))
+	assert.Equal(t, true, run.Claimable(now.Add(ScheduleRunLease)))
+	run.ToMaterialized()
+	assert.Equal(t, false, run.Claimable(now.Add(ScheduleRunLease)))
+}
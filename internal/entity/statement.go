@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// Statement summarizes the successful transactions of a wallet in [From, To).
+// ClosingBalance is the running balance and equals OpeningBalance until lines are applied.
+type Statement struct {
+	WalletID       string
+	From           time.Time
+	To             time.Time
+	OpeningBalance float64
+	ClosingBalance float64
+}
+
+// StatementLine is a transaction with the wallet balance right after it
+type StatementLine struct {
+	Transaction *Transaction
+	Balance     float64
+}
+
+func NewStatement(walletID string, from time.Time, to time.Time, openingBalance float64) *Statement {
+	return &Statement{
+		WalletID:       walletID,
+		From:           from,
+		To:             to,
+		OpeningBalance: openingBalance,
+		ClosingBalance: openingBalance,
+	}
+}
+
+// Apply adds a transaction to the running balance and returns its statement line
+func (s *Statement) Apply(trans *Transaction) *StatementLine {
+	s.ClosingBalance += trans.SignedAmount()
+	return &StatementLine{
+		Transaction: trans,
+		Balance:     s.ClosingBalance,
+	}
+}
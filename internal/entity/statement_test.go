@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestStatement_Apply(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	s := NewStatement("w001", from, from.AddDate(0, 1, 0), 1000)
+	deposit := NewTransaction("t001", "w001", "a001", 500, "VND", TransactionIn, "", TransactionStatusSuccessful)
+	withdraw := NewTransaction("t002", "w001", "a001", 300, "VND", TransactionOut, "", TransactionStatusSuccessful)
+
+	first := s.Apply(deposit)
+	second := s.Apply(withdraw)
+
+	assert.Equal(t, 1500.0, first.Balance)
+	assert.Equal(t, 1200.0, second.Balance)
+	assert.Equal(t, withdraw, second.Transaction)
+	assert.Equal(t, 1000.0, s.OpeningBalance)
+	assert.Equal(t, 1200.0, s.ClosingBalance)
+}
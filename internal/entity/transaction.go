@@ -1,6 +1,9 @@
 package entity
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type TransactionKind string
 
@@ -12,9 +15,13 @@ const (
 type TransactionStatus string
 
 const (
-	TransactionStatusNew        TransactionStatus = "NEW"
-	TransactionStatusSuccessful TransactionStatus = "SUCCESSFUL"
-	TransactionStatusFailed     TransactionStatus = "FAILED"
+	TransactionStatusNew              TransactionStatus = "NEW"
+	TransactionStatusProcessing       TransactionStatus = "PROCESSING"
+	TransactionStatusSuccessful       TransactionStatus = "SUCCESSFUL"
+	TransactionStatusFailed           TransactionStatus = "FAILED"
+	TransactionStatusAwaitingApproval TransactionStatus = "AWAITING_APPROVAL"
+	TransactionStatusApproved         TransactionStatus = "APPROVED"
+	TransactionStatusRejected         TransactionStatus = "REJECTED"
 )
 
 type Transaction struct {
@@ -26,6 +33,13 @@ type Transaction struct {
 	TransactionKind TransactionKind
 	Note            string
 	Status          TransactionStatus
+	CreatedAt       time.Time
+	// SettledAt is when the transaction became SUCCESSFUL, nil before. The balance snapshots and the statements
+	// are cut on it, so a transaction created before a close and paid after it counts after the close.
+	// A transaction stored SUCCESSFUL from the start, like a seeded one, is settled at its creation.
+	SettledAt *time.Time
+	// Version is incremented by every update, an update expecting another version is a conflict
+	Version int64
 }
 
 func NewTransaction(id string, walletID string, accountID string, amount float64, currency string, transKind TransactionKind, note string, status TransactionStatus) *Transaction {
@@ -38,11 +52,40 @@ func NewTransaction(id string, walletID string, accountID string, amount float64
 		TransactionKind: transKind,
 		Note:            note,
 		Status:          status,
+		Version:         InitialVersion,
+	}
+}
+
+// SignedAmount is the amount the transaction adds to the wallet balance once it is successful
+func (t *Transaction) SignedAmount() float64 {
+	if t.TransactionKind == TransactionOut {
+		return -t.Amount
+	}
+	return t.Amount
+}
+
+// PendingStatuses are the statuses of the transactions that can still be paid or are being paid
+func PendingStatuses() []TransactionStatus {
+	return []TransactionStatus{TransactionStatusNew, TransactionStatusAwaitingApproval, TransactionStatusApproved,
+		TransactionStatusProcessing}
+}
+
+// IsPayable reports whether the transaction can be sent to the payment service provider
+func (t *Transaction) IsPayable() bool {
+	return t.Status == TransactionStatusNew || t.Status == TransactionStatusApproved
+}
+
+// ToProcessing claims a payable transaction before it is sent to the payment service provider
+func (t *Transaction) ToProcessing() error {
+	if !t.IsPayable() {
+		return fmt.Errorf("cant update transaction status from %s to %s", t.Status, TransactionStatusProcessing)
 	}
+	t.Status = TransactionStatusProcessing
+	return nil
 }
 
 func (t *Transaction) ToSuccessful() error {
-	if t.Status != TransactionStatusNew {
+	if t.Status != TransactionStatusProcessing {
 		return fmt.Errorf("cant update transaction status from %s to %s", t.Status, TransactionStatusSuccessful)
 	}
 	t.Status = TransactionStatusSuccessful
@@ -50,9 +93,25 @@ func (t *Transaction) ToSuccessful() error {
 }
 
 func (t *Transaction) ToFailed() error {
-	if t.Status != TransactionStatusNew {
+	if t.Status != TransactionStatusProcessing {
 		return fmt.Errorf("cant update transaction status from %s to %s", t.Status, TransactionStatusFailed)
 	}
 	t.Status = TransactionStatusFailed
 	return nil
 }
+
+func (t *Transaction) ToApproved() error {
+	if t.Status != TransactionStatusAwaitingApproval {
+		return fmt.Errorf("cant update transaction status from %s to %s", t.Status, TransactionStatusApproved)
+	}
+	t.Status = TransactionStatusApproved
+	return nil
+}
+
+func (t *Transaction) ToRejected() error {
+	if t.Status != TransactionStatusAwaitingApproval {
+		return fmt.Errorf("cant update transaction status from %s to %s", t.Status, TransactionStatusRejected)
+	}
+	t.Status = TransactionStatusRejected
+	return nil
+}
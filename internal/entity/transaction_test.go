@@ -42,6 +42,7 @@ func TestNewTransaction(t *testing.T) {
 				TransactionKind: TransactionIn,
 				Note:            "test",
 				Status:          TransactionStatusNew,
+				Version:         InitialVersion,
 			},
 		},
 	}
@@ -53,3 +54,40 @@ func TestNewTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestTransaction_ToProcessing(t *testing.T) {
+	tests := []struct {
+		status  TransactionStatus
+		wantErr bool
+	}{
+		{status: TransactionStatusNew},
+		{status: TransactionStatusApproved},
+		{status: TransactionStatusAwaitingApproval, wantErr: true},
+		{status: TransactionStatusProcessing, wantErr: true},
+		{status: TransactionStatusSuccessful, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			trans := &Transaction{Status: tt.status}
+			err := trans.ToProcessing()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToProcessing() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && trans.Status != TransactionStatusProcessing {
+				t.Errorf("ToProcessing() status = %v, want %v", trans.Status, TransactionStatusProcessing)
+			}
+		})
+	}
+}
+
+func TestTransaction_ToSuccessful(t *testing.T) {
+	trans := &Transaction{Status: TransactionStatusNew}
+	if err := trans.ToSuccessful(); err == nil {
+		t.Errorf("ToSuccessful() of an unclaimed transaction error = nil, want an error")
+	}
+
+	trans.Status = TransactionStatusProcessing
+	if err := trans.ToSuccessful(); err != nil || trans.Status != TransactionStatusSuccessful {
+		t.Errorf("ToSuccessful() error = %v, status = %v", err, trans.Status)
+	}
+}
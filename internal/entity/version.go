@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// InitialVersion is the version of a record that was never updated. Every update increments it.
+const InitialVersion int64 = 1
+
+// ErrVersionConflict matches every VersionConflictError with errors.Is
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrNotFound is returned by an update of a record that does not exist
+var ErrNotFound = errors.New("not found")
+
+// VersionConflictError is returned by an update expecting a version the record no longer has,
+// because another update won the race
+type VersionConflictError struct {
+	Kind     string
+	ID       string
+	Expected int64
+}
+
+func NewVersionConflictError(kind string, id string, expected int64) *VersionConflictError {
+	return &VersionConflictError{Kind: kind, ID: id, Expected: expected}
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %s is not at version %d", e.Kind, e.ID, e.Expected)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
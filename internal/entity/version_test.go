@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestVersionConflictError(t *testing.T) {
+	err := fmt.Errorf("failed to update: %w", NewVersionConflictError("transaction", "t001", 2))
+
+	assert.Equal(t, true, errors.Is(err, ErrVersionConflict))
+	assert.Equal(t, "failed to update: transaction t001 is not at version 2", err.Error())
+
+	var conflict *VersionConflictError
+	assert.Equal(t, true, errors.As(err, &conflict))
+	assert.Equal(t, "t001", conflict.ID)
+}
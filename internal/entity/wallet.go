@@ -6,6 +6,8 @@ type Wallet struct {
 	ID         string
 	UserID     string
 	WalletName string
+	// Version is incremented by every update, an update expecting another version is a conflict
+	Version int64
 }
 
 func NewWallet(id string, userID string, walletName string) (*Wallet, error) {
@@ -16,5 +18,6 @@ func NewWallet(id string, userID string, walletName string) (*Wallet, error) {
 		ID:         id,
 		UserID:     userID,
 		WalletName: walletName,
+		Version:    InitialVersion,
 	}, nil
 }
@@ -30,6 +30,7 @@ func TestNewWallet(t *testing.T) {
 				ID:         "0001",
 				UserID:     "1",
 				WalletName: "quangpn's wallet",
+				Version:    InitialVersion,
 			},
 			wantErr: nil,
 		},
@@ -0,0 +1,203 @@
+// Package fixture loads users, wallets, linked accounts and transactions from declarative YAML or JSON files,
+// or generates them at random, and seeds them into a storage backend.
+package fixture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Set is a group of records seeded together. Records reference each other by id,
+// they may also reference records already in the storage.
+type Set struct {
+	Users          []*entity.User
+	Wallets        []*entity.Wallet
+	LinkedAccounts []*entity.LinkedAccount
+	Transactions   []*entity.Transaction
+}
+
+// Seeder writes a set to a storage. Records are inserted or replaced by id, so seeding the same set twice is a no-op.
+type Seeder interface {
+	Seed(ctx context.Context, set *Set) error
+}
+
+// SeedFunc adapts a function to a Seeder
+type SeedFunc func(ctx context.Context, set *Set) error
+
+func (f SeedFunc) Seed(ctx context.Context, set *Set) error {
+	return f(ctx, set)
+}
+
+// Append adds the records of other to the set
+func (s *Set) Append(other *Set) {
+	s.Users = append(s.Users, other.Users...)
+	s.Wallets = append(s.Wallets, other.Wallets...)
+	s.LinkedAccounts = append(s.LinkedAccounts, other.LinkedAccounts...)
+	s.Transactions = append(s.Transactions, other.Transactions...)
+}
+
+type file struct {
+	Users          []user          `yaml:"users" json:"users"`
+	Wallets        []wallet        `yaml:"wallets" json:"wallets"`
+	LinkedAccounts []linkedAccount `yaml:"linked_accounts" json:"linked_accounts"`
+	Transactions   []transaction   `yaml:"transactions" json:"transactions"`
+}
+
+type user struct {
+	ID             string `yaml:"id" json:"id"`
+	FullName       string `yaml:"full_name" json:"full_name"`
+	Email          string `yaml:"email" json:"email"`
+	PhoneNumber    string `yaml:"phone_number" json:"phone_number"`
+	CurrentAddress string `yaml:"current_address" json:"current_address"`
+}
+
+type wallet struct {
+	ID         string `yaml:"id" json:"id"`
+	UserID     string `yaml:"user_id" json:"user_id"`
+	WalletName string `yaml:"wallet_name" json:"wallet_name"`
+}
+
+type linkedAccount struct {
+	ID          string `yaml:"id" json:"id"`
+	UserID      string `yaml:"user_id" json:"user_id"`
+	AccountName string `yaml:"account_name" json:"account_name"`
+}
+
+type transaction struct {
+	ID              string    `yaml:"id" json:"id"`
+	WalletID        string    `yaml:"wallet_id" json:"wallet_id"`
+	AccountID       string    `yaml:"account_id" json:"account_id"`
+	Amount          float64   `yaml:"amount" json:"amount"`
+	Currency        string    `yaml:"currency" json:"currency"`
+	TransactionKind string    `yaml:"transaction_kind" json:"transaction_kind"`
+	Note            string    `yaml:"note" json:"note"`
+	Status          string    `yaml:"status" json:"status"`
+	CreatedAt       time.Time `yaml:"created_at" json:"created_at"`
+}
+
+// LoadFile reads a fixture file, its format is picked from the extension: .yaml, .yml or .json
+func LoadFile(path string) (*Set, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := Parse(data, filepath.Ext(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return set, nil
+}
+
+// Parse decodes and validates a fixture document. A transaction without status is SUCCESSFUL
+// and one without created_at is created now.
+func Parse(data []byte, ext string) (*Set, error) {
+	var f file
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid yaml: %w", err)
+		}
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&f); err != nil {
+			return nil, fmt.Errorf("invalid json: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported fixture format %q, use .yaml, .yml or .json", ext)
+	}
+	return f.toSet(time.Now())
+}
+
+func (f *file) toSet(now time.Time) (*Set, error) {
+	set := &Set{}
+	for i, u := range f.Users {
+		user, err := entity.NewUser(u.ID, u.FullName, u.Email, u.PhoneNumber, u.CurrentAddress)
+		if err != nil {
+			return nil, fmt.Errorf("users[%d]: %w", i, err)
+		}
+		set.Users = append(set.Users, user)
+	}
+
+	for i, w := range f.Wallets {
+		if w.UserID == "" {
+			return nil, fmt.Errorf("wallets[%d]: user_id is required", i)
+		}
+		wallet, err := entity.NewWallet(w.ID, w.UserID, w.WalletName)
+		if err != nil {
+			return nil, fmt.Errorf("wallets[%d]: %w", i, err)
+		}
+		set.Wallets = append(set.Wallets, wallet)
+	}
+
+	for i, a := range f.LinkedAccounts {
+		if a.ID == "" {
+			return nil, fmt.Errorf("linked_accounts[%d]: id must not be empty", i)
+		}
+		if a.UserID == "" {
+			return nil, fmt.Errorf("linked_accounts[%d]: user_id is required", i)
+		}
+		set.LinkedAccounts = append(set.LinkedAccounts, entity.NewLinkedAccount(a.ID, a.UserID, a.AccountName))
+	}
+
+	for i, t := range f.Transactions {
+		trans, err := t.toTransaction(now)
+		if err != nil {
+			return nil, fmt.Errorf("transactions[%d]: %w", i, err)
+		}
+		set.Transactions = append(set.Transactions, trans)
+	}
+	return set, nil
+}
+
+func (t *transaction) toTransaction(now time.Time) (*entity.Transaction, error) {
+	switch {
+	case t.ID == "":
+		return nil, fmt.Errorf("id must not be empty")
+	case t.WalletID == "":
+		return nil, fmt.Errorf("wallet_id is required")
+	case t.AccountID == "":
+		return nil, fmt.Errorf("account_id is required")
+	case t.Amount <= 0:
+		return nil, fmt.Errorf("amount must be greater than 0")
+	case t.Currency == "":
+		return nil, fmt.Errorf("currency is required")
+	}
+
+	kind := entity.TransactionKind(t.TransactionKind)
+	if kind != entity.TransactionIn && kind != entity.TransactionOut {
+		return nil, fmt.Errorf("invalid transaction kind %s", t.TransactionKind)
+	}
+
+	status := entity.TransactionStatus(t.Status)
+	switch status {
+	case "":
+		status = entity.TransactionStatusSuccessful
+	case entity.TransactionStatusNew, entity.TransactionStatusProcessing, entity.TransactionStatusSuccessful, entity.TransactionStatusFailed,
+		entity.TransactionStatusAwaitingApproval, entity.TransactionStatusApproved, entity.TransactionStatusRejected:
+	default:
+		return nil, fmt.Errorf("invalid transaction status %s", t.Status)
+	}
+
+	trans := entity.NewTransaction(t.ID, t.WalletID, t.AccountID, t.Amount, t.Currency, kind, t.Note, status)
+	trans.CreatedAt = t.CreatedAt
+	if trans.CreatedAt.IsZero() {
+		trans.CreatedAt = now
+	}
+	return trans, nil
+}
@@ -0,0 +1,137 @@
+package fixture
+
+import (
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yamlFixture = `
+users:
+  - id: u_001
+    full_name: Phan Ngoc Quang
+    email: quangpn@tm.teqn.asia
+    phone_number: "0123456789"
+    current_address: HCM
+wallets:
+  - id: w_001
+    user_id: u_001
+    wallet_name: My wallet
+linked_accounts:
+  - id: a_001
+    user_id: u_001
+    account_name: momo
+transactions:
+  - id: t_001
+    wallet_id: w_001
+    account_id: a_001
+    amount: 1000
+    currency: VND
+    transaction_kind: IN
+    note: salary
+    created_at: 2024-07-10T09:00:00Z
+  - id: t_002
+    wallet_id: w_001
+    account_id: a_001
+    amount: 300
+    currency: VND
+    transaction_kind: OUT
+    status: FAILED
+`
+
+const jsonFixture = `{
+  "users": [{"id": "u_001", "full_name": "Phan Ngoc Quang", "email": "quangpn@tm.teqn.asia", "phone_number": "0123456789", "current_address": "HCM"}],
+  "wallets": [{"id": "w_001", "user_id": "u_001", "wallet_name": "My wallet"}],
+  "linked_accounts": [{"id": "a_001", "user_id": "u_001", "account_name": "momo"}],
+  "transactions": [
+    {"id": "t_001", "wallet_id": "w_001", "account_id": "a_001", "amount": 1000, "currency": "VND", "transaction_kind": "IN", "note": "salary", "created_at": "2024-07-10T09:00:00Z"},
+    {"id": "t_002", "wallet_id": "w_001", "account_id": "a_001", "amount": 300, "currency": "VND", "transaction_kind": "OUT", "status": "FAILED"}
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	for ext, data := range map[string]string{".yaml": yamlFixture, ".json": jsonFixture} {
+		t.Run(ext, func(t *testing.T) {
+			// Act
+			before := time.Now()
+			got, err := Parse([]byte(data), ext)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, []*entity.User{{ID: "u_001", FullName: "Phan Ngoc Quang", Email: "quangpn@tm.teqn.asia",
+				PhoneNumber: "0123456789", CurrentAddress: "HCM"}}, got.Users)
+			assert.Equal(t, []*entity.Wallet{{ID: "w_001", UserID: "u_001", WalletName: "My wallet",
+				Version: entity.InitialVersion}}, got.Wallets)
+			assert.Equal(t, []*entity.LinkedAccount{{ID: "a_001", UserID: "u_001", AccountName: "momo"}}, got.LinkedAccounts)
+			require.Len(t, got.Transactions, 2)
+
+			salary := got.Transactions[0]
+			assert.Equal(t, entity.TransactionIn, salary.TransactionKind)
+			assert.Equal(t, entity.TransactionStatusSuccessful, salary.Status)
+			assert.True(t, time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC).Equal(salary.CreatedAt))
+
+			failed := got.Transactions[1]
+			assert.Equal(t, entity.TransactionStatusFailed, failed.Status)
+			assert.False(t, failed.CreatedAt.Before(before))
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		ext     string
+		wantErr string
+	}{
+		{
+			name:    "unsupported format",
+			data:    "",
+			ext:     ".toml",
+			wantErr: `unsupported fixture format ".toml", use .yaml, .yml or .json`,
+		},
+		{
+			name:    "unknown field",
+			data:    "wallets:\n  - id: w_001\n    owner: u_001\n",
+			ext:     ".yml",
+			wantErr: "invalid yaml: yaml: unmarshal errors:\n  line 3: field owner not found in type fixture.wallet",
+		},
+		{
+			name:    "missing user id",
+			data:    `{"users": [{"full_name": "Quang"}]}`,
+			ext:     ".json",
+			wantErr: "users[0]: id must not be empty",
+		},
+		{
+			name:    "invalid transaction kind",
+			data:    `{"transactions": [{"id": "t_001", "wallet_id": "w_001", "account_id": "a_001", "amount": 1, "currency": "VND", "transaction_kind": "TRANSFER"}]}`,
+			ext:     ".json",
+			wantErr: "transactions[0]: invalid transaction kind TRANSFER",
+		},
+		{
+			name:    "negative amount",
+			data:    `{"transactions": [{"id": "t_001", "wallet_id": "w_001", "account_id": "a_001", "amount": -1, "currency": "VND", "transaction_kind": "IN"}]}`,
+			ext:     ".json",
+			wantErr: "transactions[0]: amount must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data), tt.ext)
+
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	got, err := Parse(nil, ".yaml")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Set{}, got)
+}
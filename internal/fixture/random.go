@@ -0,0 +1,139 @@
+package fixture
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// RandomOptions shapes the data Random generates
+type RandomOptions struct {
+	Users int
+	// TransactionsPerUser is the number of transactions of every wallet
+	TransactionsPerUser int
+	// History is how far back before Now the transactions go
+	History time.Duration
+	Now     time.Time
+	// Currency of every transaction, VND when empty
+	Currency string
+}
+
+var (
+	firstNames   = []string{"An", "Binh", "Chi", "Dung", "Giang", "Hoa", "Khanh", "Linh", "Minh", "Nam", "Phuong", "Quang", "Thao", "Tuan", "Vy"}
+	lastNames    = []string{"Nguyen", "Tran", "Le", "Pham", "Hoang", "Phan", "Vu", "Dang", "Bui", "Do"}
+	cities       = []string{"HCM", "Ha Noi", "Da Nang", "Hai Phong", "Can Tho", "Nha Trang", "Hue"}
+	accountNames = []string{"momo", "zalopay", "vietcombank", "techcombank", "vpbank", "acb"}
+	depositNotes = []string{"salary", "top up", "refund", "bonus", "transfer from friend"}
+	withdrawNote = []string{"groceries", "rent", "electricity bill", "coffee", "shopping", "internet bill", "taxi"}
+)
+
+// Random generates users with one wallet, one or two linked accounts and a transaction history each.
+// Transactions are spread over the history, deposits are larger than withdrawals and a withdrawal never
+// takes the successful balance below zero, a few of them fail. The same rng seed gives the same set.
+func Random(rng *rand.Rand, opts RandomOptions) (*Set, error) {
+	if opts.Users < 0 || opts.TransactionsPerUser < 0 {
+		return nil, fmt.Errorf("users and transactions per user must not be negative")
+	}
+	if opts.History <= 0 {
+		return nil, fmt.Errorf("history must be greater than 0")
+	}
+	if opts.Currency == "" {
+		opts.Currency = "VND"
+	}
+
+	g := &generator{rng: rng, opts: opts}
+	set := &Set{}
+	for i := 0; i < opts.Users; i++ {
+		set.Append(g.user())
+	}
+	return set, nil
+}
+
+type generator struct {
+	rng  *rand.Rand
+	opts RandomOptions
+}
+
+func (g *generator) user() *Set {
+	first, last := g.pick(firstNames), g.pick(lastNames)
+	u := &entity.User{
+		ID:             g.id(),
+		FullName:       fmt.Sprintf("%s %s", last, first),
+		Email:          fmt.Sprintf("%s.%s.%d@example.com", strings.ToLower(first), strings.ToLower(last), g.rng.Intn(10000)),
+		PhoneNumber:    fmt.Sprintf("09%08d", g.rng.Intn(100000000)),
+		CurrentAddress: g.pick(cities),
+	}
+	w := &entity.Wallet{ID: g.id(), UserID: u.ID, WalletName: fmt.Sprintf("%s's wallet", first),
+		Version: entity.InitialVersion}
+
+	accounts := []*entity.LinkedAccount{{ID: g.id(), UserID: u.ID, AccountName: g.pick(accountNames)}}
+	if g.rng.Intn(2) == 0 {
+		accounts = append(accounts, &entity.LinkedAccount{ID: g.id(), UserID: u.ID, AccountName: g.pick(accountNames)})
+	}
+
+	return &Set{
+		Users:          []*entity.User{u},
+		Wallets:        []*entity.Wallet{w},
+		LinkedAccounts: accounts,
+		Transactions:   g.history(w, accounts),
+	}
+}
+
+func (g *generator) history(w *entity.Wallet, accounts []*entity.LinkedAccount) []*entity.Transaction {
+	times := make([]time.Time, g.opts.TransactionsPerUser)
+	for i := range times {
+		times[i] = g.opts.Now.Add(-time.Duration(g.rng.Int63n(int64(g.opts.History))))
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	var (
+		balance      float64
+		transactions = make([]*entity.Transaction, 0, len(times))
+	)
+	for _, createdAt := range times {
+		account := accounts[g.rng.Intn(len(accounts))]
+
+		// a third of the transactions are deposits, and every one while the wallet cannot afford a withdrawal
+		kind, amount, note := entity.TransactionIn, g.amount(200_000, 10_000_000), g.pick(depositNotes)
+		if withdraw := g.amount(20_000, 2_000_000); g.rng.Intn(3) != 0 && withdraw <= balance {
+			kind, amount, note = entity.TransactionOut, withdraw, g.pick(withdrawNote)
+		}
+
+		status := entity.TransactionStatusSuccessful
+		if g.rng.Intn(20) == 0 {
+			status = entity.TransactionStatusFailed
+		}
+		if status == entity.TransactionStatusSuccessful {
+			if kind == entity.TransactionIn {
+				balance += amount
+			} else {
+				balance -= amount
+			}
+		}
+
+		trans := entity.NewTransaction(g.id(), w.ID, account.ID, amount, g.opts.Currency, kind, note, status)
+		trans.CreatedAt = createdAt
+		transactions = append(transactions, trans)
+	}
+	return transactions
+}
+
+// amount picks a round amount in [min, max], in thousands like real VND payments
+func (g *generator) amount(min float64, max float64) float64 {
+	return math.Round((min+g.rng.Float64()*(max-min))/1000) * 1000
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rng.Intn(len(values))]
+}
+
+func (g *generator) id() string {
+	return uuid.Must(uuid.NewRandomFromReader(g.rng)).String()
+}
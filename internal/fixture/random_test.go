@@ -0,0 +1,61 @@
+package fixture
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandom(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	opts := RandomOptions{Users: 20, TransactionsPerUser: 50, History: 90 * 24 * time.Hour, Now: now}
+
+	t.Run("realistic histories", func(t *testing.T) {
+		// Act
+		got, err := Random(rand.New(rand.NewSource(1)), opts)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, got.Users, 20)
+		assert.Len(t, got.Wallets, 20)
+		assert.Len(t, got.Transactions, 20*50)
+
+		balances := map[string]float64{}
+		var previous *entity.Transaction
+		for _, trans := range got.Transactions {
+			assert.Equal(t, "VND", trans.Currency)
+			assert.Greater(t, trans.Amount, float64(0))
+			assert.False(t, trans.CreatedAt.After(now))
+			assert.True(t, trans.CreatedAt.After(now.Add(-opts.History)))
+			if previous != nil && previous.WalletID == trans.WalletID {
+				assert.False(t, trans.CreatedAt.Before(previous.CreatedAt))
+			}
+			previous = trans
+
+			if trans.Status == entity.TransactionStatusSuccessful {
+				balances[trans.WalletID] += trans.SignedAmount()
+				assert.GreaterOrEqual(t, balances[trans.WalletID], float64(0), "balance of %s", trans.WalletID)
+			}
+		}
+	})
+
+	t.Run("same seed gives the same set", func(t *testing.T) {
+		first, err1 := Random(rand.New(rand.NewSource(42)), opts)
+		second, err2 := Random(rand.New(rand.NewSource(42)), opts)
+
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("history is required", func(t *testing.T) {
+		_, err := Random(rand.New(rand.NewSource(1)), RandomOptions{Users: 1})
+
+		assert.EqualError(t, err, "history must be greater than 0")
+	})
+}
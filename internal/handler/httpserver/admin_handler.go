@@ -0,0 +1,74 @@
+package httpserver
+
+import (
+	"net/http"
+	"slices"
+
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/constant"
+	"go-clean-template/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) RegisterAdminRoutes(group *echo.Group) {
+	group.Use(s.requireAdmin)
+	group.GET("/log-level", s.GetLogLevel)
+	group.PUT("/log-level", s.SetLogLevel)
+}
+
+// requireAdmin lets through the users of the admin group only, a valid token is not enough to operate the
+// server
+func (s *Server) requireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !inGroup(c, s.Config.AdminGroup) {
+			return s.handleError(c, apperror.ErrNoPermission().WithInfo("the admin routes need the admin group"))
+		}
+		return next(c)
+	}
+}
+
+// requireGroup lets through the users of group or of the admin group only, info explains the refusal
+func (s *Server) requireGroup(group string, info string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !inGroup(c, group) && !inGroup(c, s.Config.AdminGroup) {
+				return s.handleError(c, apperror.ErrNoPermission().WithInfo(info))
+			}
+			return next(c)
+		}
+	}
+}
+
+// inGroup tells whether the authenticated user is in group, nobody is in the empty group
+func inGroup(c echo.Context, group string) bool {
+	groups, _ := c.Get(constant.UserGroupsKey).([]string)
+	return group != "" && slices.Contains(groups, group)
+}
+
+func (s *Server) GetLogLevel(c echo.Context) error {
+	return s.handleSuccess(c, http.StatusOK, model.LogLevelResponse{Level: logger.Level().String()})
+}
+
+// SetLogLevel changes the level of the app loggers until the process restarts, like debug to investigate
+// an incident
+func (s *Server) SetLogLevel(c echo.Context) error {
+	var req model.LogLevelRequest
+
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	previous := logger.Level().String()
+	if err := logger.SetLevel(req.Level); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+	s.log(c.Request().Context()).Warnw("log level changed", "from", previous, "to", req.Level)
+
+	return s.handleSuccess(c, http.StatusOK, model.LogLevelResponse{Level: req.Level})
+}
@@ -0,0 +1,109 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/constant"
+	"go-clean-template/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func setupLogLevel(method string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	r := httptest.NewRequest(method, "/admin/log-level", strings.NewReader(body))
+	r.Header.Set("Content-type", echo.MIMEApplicationJSON)
+	w := httptest.NewRecorder()
+	return newRouter().NewContext(r, w), w
+}
+
+func TestServer_LogLevel(t *testing.T) {
+	cfg := &config.Config{AdminGroup: "admin"}
+	s := Server{Logger: zap.S(), Config: cfg}
+	defer func() {
+		require.NoError(t, logger.SetLevel("info"))
+	}()
+
+	t.Run("200: changes the level", func(t *testing.T) {
+		// Arrange
+		c, resp := setupLogLevel(http.MethodPut, `{"level":"debug"}`)
+
+		// Act
+		err := s.SetLogLevel(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, model.LogLevelResponse{Level: "debug"}, extractSuccessData[model.LogLevelResponse](t, resp.Body))
+		assert.Equal(t, zapcore.DebugLevel, logger.Level().Level())
+	})
+
+	t.Run("200: returns the level", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, logger.SetLevel("warn"))
+		c, resp := setupLogLevel(http.MethodGet, "")
+
+		// Act
+		err := s.GetLogLevel(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, model.LogLevelResponse{Level: "warn"}, extractSuccessData[model.LogLevelResponse](t, resp.Body))
+	})
+
+	t.Run("400: unknown level", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, logger.SetLevel("info"))
+		c, resp := setupLogLevel(http.MethodPut, `{"level":"verbose"}`)
+
+		// Act
+		err := s.SetLogLevel(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, apperror.CODE_INVALID_PARAMS, apperror.Code(extractErrorData(t, resp.Body).ErrCode.(float64)))
+		assert.Equal(t, zapcore.InfoLevel, logger.Level().Level())
+	})
+
+	t.Run("403: the user is not in the admin group", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, logger.SetLevel("info"))
+		c, resp := setupLogLevel(http.MethodPut, `{"level":"debug"}`)
+		c.Set(constant.UserGroupsKey, []string{"operators"})
+
+		// Act
+		err := s.requireAdmin(s.SetLogLevel)(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		assert.Equal(t, apperror.CODE_NO_PERMISSION, apperror.Code(extractErrorData(t, resp.Body).ErrCode.(float64)))
+		assert.Equal(t, zapcore.InfoLevel, logger.Level().Level())
+	})
+
+	t.Run("200: the user is in the admin group", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, logger.SetLevel("info"))
+		c, resp := setupLogLevel(http.MethodPut, `{"level":"warn"}`)
+		c.Set(constant.UserGroupsKey, []string{"operators", "admin"})
+
+		// Act
+		err := s.requireAdmin(s.SetLogLevel)(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, zapcore.WarnLevel, logger.Level().Level())
+	})
+}
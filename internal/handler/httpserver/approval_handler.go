@@ -0,0 +1,82 @@
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/constant"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterApprovalRoutesV1 restricts the decisions to the approver group, the requester is refused by the use case
+func (s *Server) RegisterApprovalRoutesV1(group *echo.Group) {
+	group.Use(s.requireGroup(s.Config.ApproverGroup, "the approvals need the approver group"))
+	group.PUT("/:transID/approve", s.ApproveTransaction)
+	group.PUT("/:transID/reject", s.RejectTransaction)
+}
+
+func (s *Server) ApproveTransaction(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+	)
+
+	transID := c.Param("transID")
+	if transID == "" {
+		return s.handleError(c, apperror.ErrInvalidParams(fmt.Errorf("transID is required")))
+	}
+
+	approverID, ok := c.Get(constant.UserIDKey).(string)
+	if !ok || approverID == "" {
+		return s.handleError(c, apperror.ErrUnauthorized(fmt.Errorf("approver is unknown")))
+	}
+
+	version, err := model.ParseIfMatch(c.Request().Header.Get("If-Match"))
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := s.TransactionUseCase.ApproveTransaction(ctx, transID, approverID, version); err != nil {
+		return s.handleError(c, err)
+	}
+
+	return s.handleSuccess(c, http.StatusOK, "OK")
+}
+
+func (s *Server) RejectTransaction(c echo.Context) error {
+	var (
+		req model.RejectTransactionRequest
+		ctx = c.Request().Context()
+	)
+
+	transID := c.Param("transID")
+	if transID == "" {
+		return s.handleError(c, apperror.ErrInvalidParams(fmt.Errorf("transID is required")))
+	}
+
+	approverID, ok := c.Get(constant.UserIDKey).(string)
+	if !ok || approverID == "" {
+		return s.handleError(c, apperror.ErrUnauthorized(fmt.Errorf("approver is unknown")))
+	}
+
+	version, err := model.ParseIfMatch(c.Request().Header.Get("If-Match"))
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := s.TransactionUseCase.RejectTransaction(ctx, transID, approverID, req.Reason, version); err != nil {
+		return s.handleError(c, err)
+	}
+
+	return s.handleSuccess(c, http.StatusOK, "OK")
+}
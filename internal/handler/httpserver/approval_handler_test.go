@@ -0,0 +1,190 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/constant"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupApproval(t testing.TB, method string, transID string, approverID string, req interface{}) (echo.Context, *httptest.ResponseRecorder) {
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(method, "/v1/approvals/:transID/approve", bytes.NewReader(body))
+	r.Header.Set("Content-type", echo.MIMEApplicationJSON)
+	r.Header.Set("User-agent", "testing")
+	w := httptest.NewRecorder()
+	c := newRouter().NewContext(r, w)
+	c.SetParamNames("transID")
+	c.SetParamValues(transID)
+	if approverID != "" {
+		c.Set(constant.UserIDKey, approverID)
+	}
+
+	return c, w
+}
+
+func TestServer_ApproveTransaction(t *testing.T) {
+	transUCMock := mocks.NewITransactionUseCase(t)
+	s := Server{
+		TransactionUseCase: transUCMock,
+		Logger:             zap.S(),
+	}
+
+	t.Run("200: success", func(t *testing.T) {
+		// Arrange
+		c, resp := setupApproval(t, http.MethodPut, "trans1", "approver1", nil)
+		transUCMock.EXPECT().ApproveTransaction(c.Request().Context(), "trans1", "approver1", int64(0)).Return(nil).Once()
+
+		// Act
+		err := s.ApproveTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		actual := extractSuccessData[string](t, resp.Body)
+		assert.Equal(t, "OK", actual)
+	})
+
+	t.Run("401: approver is unknown", func(t *testing.T) {
+		// Arrange
+		c, resp := setupApproval(t, http.MethodPut, "trans1", "", nil)
+
+		// Act
+		err := s.ApproveTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("403: approver is the requester", func(t *testing.T) {
+		// Arrange
+		c, resp := setupApproval(t, http.MethodPut, "trans1", "approver1", nil)
+		transUCMock.EXPECT().ApproveTransaction(c.Request().Context(), "trans1", "approver1", int64(0)).
+			Return(apperror.ErrNoPermission()).Once()
+
+		// Act
+		err := s.ApproveTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("412: If-Match is not the current version", func(t *testing.T) {
+		// Arrange
+		c, resp := setupApproval(t, http.MethodPut, "trans1", "approver1", nil)
+		c.Request().Header.Set("If-Match", `"2"`)
+		transUCMock.EXPECT().ApproveTransaction(c.Request().Context(), "trans1", "approver1", int64(2)).
+			Return(apperror.New(apperror.VERSION_MISMATCH).WithRaw(fmt.Errorf("transaction trans1 is not at version 2"))).
+			Once()
+
+		// Act
+		err := s.ApproveTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+	})
+
+	t.Run("403: the user is not in the approver group", func(t *testing.T) {
+		// Arrange
+		s := Server{Logger: zap.S(), Config: &config.Config{AdminGroup: "admin", ApproverGroup: "approvers"}}
+		c, resp := setupApproval(t, http.MethodPut, "trans1", "approver1", nil)
+		c.Set(constant.UserGroupsKey, []string{"operations"})
+
+		// Act
+		err := s.requireGroup(s.Config.ApproverGroup, "the approvals need the approver group")(s.ApproveTransaction)(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		assert.Equal(t, apperror.CODE_NO_PERMISSION, apperror.Code(extractErrorData(t, resp.Body).ErrCode.(float64)))
+	})
+
+	t.Run("200: the user is in the approver group", func(t *testing.T) {
+		// Arrange
+		s := Server{TransactionUseCase: transUCMock, Logger: zap.S(),
+			Config: &config.Config{AdminGroup: "admin", ApproverGroup: "approvers"}}
+		c, resp := setupApproval(t, http.MethodPut, "trans1", "approver1", nil)
+		c.Set(constant.UserGroupsKey, []string{"approvers"})
+		transUCMock.EXPECT().ApproveTransaction(c.Request().Context(), "trans1", "approver1", int64(0)).Return(nil).Once()
+
+		// Act
+		err := s.requireGroup(s.Config.ApproverGroup, "the approvals need the approver group")(s.ApproveTransaction)(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+}
+
+func TestServer_RejectTransaction(t *testing.T) {
+	transUCMock := mocks.NewITransactionUseCase(t)
+	s := Server{
+		TransactionUseCase: transUCMock,
+		Logger:             zap.S(),
+	}
+
+	t.Run("200: success", func(t *testing.T) {
+		// Arrange
+		req := model.RejectTransactionRequest{Reason: "suspicious"}
+		c, resp := setupApproval(t, http.MethodPut, "trans1", "approver1", req)
+		transUCMock.EXPECT().RejectTransaction(c.Request().Context(), "trans1", "approver1", req.Reason, int64(0)).
+			Return(nil).Once()
+
+		// Act
+		err := s.RejectTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("400: reason is required", func(t *testing.T) {
+		// Arrange
+		c, resp := setupApproval(t, http.MethodPut, "trans1", "approver1", model.RejectTransactionRequest{})
+
+		// Act
+		err := s.RejectTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		actual := extractErrorData(t, resp.Body)
+		assert.Equal(t, "invalid params", actual.Message)
+	})
+
+	t.Run("500: failed to reject transaction", func(t *testing.T) {
+		// Arrange
+		req := model.RejectTransactionRequest{Reason: "suspicious"}
+		c, resp := setupApproval(t, http.MethodPut, "trans1", "approver1", req)
+		errExpected := fmt.Errorf("unexpected error")
+		transUCMock.EXPECT().RejectTransaction(c.Request().Context(), "trans1", "approver1", req.Reason, int64(0)).
+			Return(errExpected).Once()
+
+		// Act
+		err := s.RejectTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		actual := extractErrorData(t, resp.Body)
+		assert.Equal(t, "internal server error", actual.Message)
+	})
+}
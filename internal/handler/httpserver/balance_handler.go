@@ -0,0 +1,69 @@
+package httpserver
+
+import (
+	"net/http"
+
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) RegisterBalanceRoutesV1(group *echo.Group) {
+	group.GET("/:id/balance", s.GetBalance)
+	group.GET("/:id/balance/consistency", s.CheckBalanceConsistency)
+}
+
+func (s *Server) GetBalance(c echo.Context) error {
+	var (
+		req model.BalanceRequest
+		ctx = c.Request().Context()
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	asOf, err := req.AsOfTime()
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	balance, err := s.BalanceUseCase.GetBalance(ctx, req.WalletID, asOf)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	return s.handleSuccess(c, http.StatusOK, model.ToBalanceResponse(req.WalletID, balance, asOf))
+}
+
+func (s *Server) CheckBalanceConsistency(c echo.Context) error {
+	var (
+		req model.BalanceRequest
+		ctx = c.Request().Context()
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	asOf, err := req.AsOfTime()
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	check, err := s.BalanceUseCase.CheckConsistency(ctx, req.WalletID, asOf)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	return s.handleSuccess(c, http.StatusOK, model.ToBalanceCheckResponse(check))
+}
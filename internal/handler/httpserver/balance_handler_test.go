@@ -0,0 +1,117 @@
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestServer_GetBalance(t *testing.T) {
+	balanceUCMock := mocks.NewIBalanceUseCase(t)
+	s := Server{
+		BalanceUseCase: balanceUCMock,
+		Logger:         zap.S(),
+		Config:         localConfig,
+	}
+
+	t.Run("200: current balance", func(t *testing.T) {
+		// Arrange
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/wallets/"+testWalletID+"/balance", testWalletID, nil)
+		balanceUCMock.EXPECT().GetBalance(c.Request().Context(), testWalletID, time.Time{}).Return(1500, nil).Once()
+
+		// Act
+		err := s.GetBalance(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		actual := extractSuccessData[model.BalanceResponse](t, resp.Body)
+		assert.Equal(t, model.BalanceResponse{WalletID: testWalletID, Balance: 1500}, actual)
+	})
+
+	t.Run("200: balance as of the end of a day", func(t *testing.T) {
+		// Arrange
+		asOf := time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC)
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/wallets/"+testWalletID+"/balance?as_of=2024-07-10", testWalletID, nil)
+		balanceUCMock.EXPECT().GetBalance(c.Request().Context(), testWalletID, asOf).Return(800, nil).Once()
+
+		// Act
+		err := s.GetBalance(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		actual := extractSuccessData[model.BalanceResponse](t, resp.Body)
+		assert.Equal(t, 800.0, actual.Balance)
+		assert.True(t, asOf.Equal(*actual.AsOf))
+	})
+
+	t.Run("400: invalid as_of", func(t *testing.T) {
+		// Arrange
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/wallets/"+testWalletID+"/balance?as_of=yesterday", testWalletID, nil)
+
+		// Act
+		err := s.GetBalance(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("400: wallet not found", func(t *testing.T) {
+		// Arrange
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/wallets/"+testWalletID+"/balance", testWalletID, nil)
+		balanceUCMock.EXPECT().GetBalance(c.Request().Context(), testWalletID, time.Time{}).
+			Return(0, apperror.ErrInvalidParams(fmt.Errorf("wallet not found"))).Once()
+
+		// Act
+		err := s.GetBalance(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		actual := extractErrorData(t, resp.Body)
+		assert.Equal(t, "wallet not found", actual.RawErr)
+	})
+}
+
+func TestServer_CheckBalanceConsistency(t *testing.T) {
+	balanceUCMock := mocks.NewIBalanceUseCase(t)
+	s := Server{
+		BalanceUseCase: balanceUCMock,
+		Logger:         zap.S(),
+	}
+
+	t.Run("200: inconsistent snapshot", func(t *testing.T) {
+		// Arrange
+		asOf := time.Date(2024, 7, 10, 15, 0, 0, 0, time.UTC)
+		snapshotAt := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/wallets/"+testWalletID+"/balance/consistency?as_of=2024-07-10T15:00:00Z", testWalletID, nil)
+		balanceUCMock.EXPECT().CheckConsistency(c.Request().Context(), testWalletID, asOf).Return(&entity.BalanceCheck{
+			WalletID:     testWalletID,
+			AsOf:         asOf,
+			SnapshotAsOf: &snapshotAt,
+			Balance:      1500,
+			Recomputed:   1200,
+		}, nil).Once()
+
+		// Act
+		err := s.CheckBalanceConsistency(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		actual := extractSuccessData[model.BalanceCheckResponse](t, resp.Body)
+		assert.Equal(t, 300.0, actual.Difference)
+		assert.False(t, actual.Consistent)
+	})
+}
@@ -0,0 +1,46 @@
+package httpserver
+
+import (
+	"net/http"
+
+	"go-clean-template/api"
+
+	"github.com/labstack/echo/v4"
+)
+
+// swaggerUI renders /openapi.json, the assets are served by the CDN of swagger-ui-dist
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>go-clean-template API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+    };
+  </script>
+</body>
+</html>`
+
+func (s *Server) RegisterAPIDocs(Router *echo.Group) {
+	Router.GET("/openapi.json", s.OpenAPI)
+	Router.GET("/docs", s.SwaggerUI)
+}
+
+// OpenAPI serves the specification of api/openapi.yaml
+func (s *Server) OpenAPI(c echo.Context) error {
+	spec, err := api.JSON()
+	if err != nil {
+		return s.handleError(c, err)
+	}
+	return c.JSONBlob(http.StatusOK, spec)
+}
+
+func (s *Server) SwaggerUI(c echo.Context) error {
+	return c.HTML(http.StatusOK, swaggerUI)
+}
@@ -0,0 +1,171 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-template/api"
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apiclient"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// undocumentedRoutes are served for the operators and the docs themselves, not for the clients of the API
+var undocumentedRoutes = map[string]bool{
+	"GET /metrics":      true,
+	"GET /openapi.json": true,
+	"GET /docs":         true,
+}
+
+var routeParam = regexp.MustCompile(`:(\w+)`)
+
+func TestServer_OpenAPI(t *testing.T) {
+	t.Run("serves the specification and Swagger UI without authentication", func(t *testing.T) {
+		// Arrange
+		s, err := New()
+		require.NoError(t, err)
+		specResp, docsResp := httptest.NewRecorder(), httptest.NewRecorder()
+
+		// Act
+		s.ServeHTTP(specResp, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		s.ServeHTTP(docsResp, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, specResp.Code)
+		var spec struct {
+			OpenAPI string `json:"openapi"`
+		}
+		require.NoError(t, json.NewDecoder(specResp.Body).Decode(&spec))
+		assert.Equal(t, "3.0.3", spec.OpenAPI)
+		assert.Equal(t, http.StatusOK, docsResp.Code)
+		assert.Contains(t, docsResp.Body.String(), `url: "/openapi.json"`)
+	})
+
+	t.Run("every route is in the specification and every operation is routed", func(t *testing.T) {
+		// Arrange
+		s, err := New(WithMetrics(prometheus.NewRegistry()))
+		require.NoError(t, err)
+		spec, err := api.JSON()
+		require.NoError(t, err)
+
+		// Act
+		routes := routedOperations(s)
+		operations := specOperations(t, spec)
+
+		// Assert
+		assert.ElementsMatch(t, operations, routes,
+			"the routes of Server.Router drifted from api/openapi.yaml, update it and run go generate ./pkg/apiclient")
+	})
+}
+
+func TestServer_APIClient(t *testing.T) {
+	transUCMock := mocks.NewITransactionUseCase(t)
+	s, err := New()
+	require.NoError(t, err)
+	s.TransactionUseCase = transUCMock
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	client, err := apiclient.NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
+
+	t.Run("decodes the success envelope", func(t *testing.T) {
+		// Arrange
+		trans := &entity.Transaction{
+			ID:              "t1",
+			WalletID:        testWalletID,
+			AccountID:       testAccountID,
+			Amount:          1000,
+			Currency:        "USD",
+			TransactionKind: entity.TransactionOut,
+			Status:          entity.TransactionStatusNew,
+			CreatedAt:       time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC),
+			Version:         2,
+		}
+		transUCMock.EXPECT().GetTransaction(mock.Anything, "t1").Return(trans, nil).Once()
+
+		// Act
+		resp, err := client.GetTransactionWithResponse(context.Background(), "t1")
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.NotNil(t, resp.JSON200)
+		data := resp.JSON200.Data
+		require.NotNil(t, data)
+		assert.Equal(t, "t1", *data.Id)
+		assert.Equal(t, testWalletID, *data.WalletId)
+		assert.Equal(t, apiclient.TransactionKind("OUT"), *data.TransactionKind)
+		assert.Equal(t, trans.CreatedAt, data.CreatedAt.UTC())
+		assert.Equal(t, `"2"`, resp.HTTPResponse.Header.Get("ETag"))
+	})
+
+	t.Run("decodes the error envelope", func(t *testing.T) {
+		// Arrange
+		transUCMock.EXPECT().GetTransaction(mock.Anything, "t2").
+			Return(nil, apperror.New(apperror.TXN_NOT_FOUND)).Once()
+
+		// Act
+		resp, err := client.GetTransactionWithResponse(context.Background(), "t2")
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+		require.NotNil(t, resp.JSON404)
+		assert.Equal(t, apiclient.TXNNOTFOUND, *resp.JSON404.Code)
+	})
+}
+
+// routedOperations lists the routes as "GET /api/v1/transactions/{transID}"
+func routedOperations(s *Server) []string {
+	var operations []string
+	for _, r := range s.Router.Routes() {
+		// the groups with middlewares route the unknown paths of their prefix to a not found handler
+		if !isHTTPMethod(r.Method) {
+			continue
+		}
+		operation := r.Method + " " + routeParam.ReplaceAllString(r.Path, "{$1}")
+		if !undocumentedRoutes[operation] {
+			operations = append(operations, operation)
+		}
+	}
+	return operations
+}
+
+func specOperations(t testing.TB, spec []byte) []string {
+	t.Helper()
+
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(spec, &doc))
+
+	var operations []string
+	for path, item := range doc.Paths {
+		for method := range item {
+			if isHTTPMethod(strings.ToUpper(method)) {
+				operations = append(operations, strings.ToUpper(method)+" "+path)
+			}
+		}
+	}
+	return operations
+}
+
+func isHTTPMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
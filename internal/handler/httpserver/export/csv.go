@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+var csvHeader = []string{"date", "transaction_id", "description", "note", "amount", "currency", "balance"}
+
+// csvWriter writes the opening and closing balances as the first and last rows around the transactions
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) WriteHeader(statement *entity.Statement) error {
+	if err := c.w.Write(csvHeader); err != nil {
+		return err
+	}
+	return c.w.Write([]string{statement.From.Format(time.RFC3339), "", "OPENING BALANCE", "", "", "",
+		formatAmount(statement.OpeningBalance)})
+}
+
+func (c *csvWriter) WriteLine(line *entity.StatementLine) error {
+	t := line.Transaction
+	return c.w.Write([]string{lineDate(t).Format(time.RFC3339), t.ID, string(t.TransactionKind), t.Note,
+		formatAmount(t.SignedAmount()), t.Currency, formatAmount(line.Balance)})
+}
+
+func (c *csvWriter) WriteFooter(statement *entity.Statement) error {
+	if err := c.w.Write([]string{statement.To.Format(time.RFC3339), "", "CLOSING BALANCE", "", "", "",
+		formatAmount(statement.ClosingBalance)}); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
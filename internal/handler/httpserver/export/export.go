@@ -0,0 +1,62 @@
+// Package export renders wallet statements in the downloadable formats of the statement API.
+// Writers never buffer more than a page, so statements of any size can be streamed to the client.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatPDF   = "pdf"
+)
+
+// StatementWriter is called once with the opening statement, once per line and once with the closed statement
+type StatementWriter interface {
+	WriteHeader(statement *entity.Statement) error
+	WriteLine(line *entity.StatementLine) error
+	WriteFooter(statement *entity.Statement) error
+}
+
+func NewStatementWriter(format string, w io.Writer) (StatementWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatJSONL:
+		return newJSONLWriter(w), nil
+	case FormatPDF:
+		return newPDFWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported statement format %s", format)
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// lineDate is the date a transaction enters the statement: its settlement, or its creation for the
+// transactions stored before the settlement was recorded
+func lineDate(t *entity.Transaction) time.Time {
+	if t.SettledAt != nil {
+		return *t.SettledAt
+	}
+	return t.CreatedAt
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeStatementForTest(t *testing.T, format string, n int) string {
+	t.Helper()
+
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	statement := entity.NewStatement("w1", from, from.AddDate(0, 1, 0), 1000)
+
+	var buf bytes.Buffer
+	w, err := NewStatementWriter(format, &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteHeader(statement))
+	for i := 0; i < n; i++ {
+		kind := entity.TransactionIn
+		if i%2 == 1 {
+			kind = entity.TransactionOut
+		}
+		trans := entity.NewTransaction(fmt.Sprintf("t%d", i+1), "w1", "a1", 100, "VND", kind, "salary, july",
+			entity.TransactionStatusSuccessful)
+		trans.CreatedAt = from.Add(time.Duration(i+1) * time.Hour)
+		settledAt := trans.CreatedAt.Add(30 * time.Minute)
+		trans.SettledAt = &settledAt
+		require.NoError(t, w.WriteLine(statement.Apply(trans)))
+	}
+	require.NoError(t, w.WriteFooter(statement))
+	return buf.String()
+}
+
+func TestNewStatementWriter(t *testing.T) {
+	_, err := NewStatementWriter("xlsx", &bytes.Buffer{})
+
+	assert.EqualError(t, err, "unsupported statement format xlsx")
+}
+
+func TestCSVWriter(t *testing.T) {
+	got := writeStatementForTest(t, FormatCSV, 2)
+
+	assert.Equal(t, `date,transaction_id,description,note,amount,currency,balance
+2024-07-01T00:00:00Z,,OPENING BALANCE,,,,1000.00
+2024-07-01T01:30:00Z,t1,IN,"salary, july",100.00,VND,1100.00
+2024-07-01T02:30:00Z,t2,OUT,"salary, july",-100.00,VND,1000.00
+2024-08-01T00:00:00Z,,CLOSING BALANCE,,,,1000.00
+`, got)
+}
+
+func TestJSONLWriter(t *testing.T) {
+	got := writeStatementForTest(t, FormatJSONL, 1)
+
+	assert.Equal(t, `{"type":"opening_balance","wallet_id":"w1","at":"2024-07-01T00:00:00Z","balance":1000}
+{"type":"transaction","id":"t1","created_at":"2024-07-01T01:00:00Z","settled_at":"2024-07-01T01:30:00Z","transaction_kind":"IN","note":"salary, july","amount":100,"currency":"VND","balance":1100}
+{"type":"closing_balance","wallet_id":"w1","at":"2024-08-01T00:00:00Z","balance":1100}
+`, got)
+}
+
+func TestPDFWriter(t *testing.T) {
+	t.Run("single page", func(t *testing.T) {
+		got := writeStatementForTest(t, FormatPDF, 2)
+
+		assert.True(t, strings.HasPrefix(got, "%PDF-1.4\n"))
+		assert.True(t, strings.HasSuffix(got, "%%EOF\n"))
+		assert.Contains(t, got, "(Opening balance: 1000.00) Tj")
+		assert.Contains(t, got, "(Closing balance: 1000.00) Tj")
+		assert.Contains(t, got, "/Count 1 >>")
+		assertPDFXref(t, got)
+	})
+
+	t.Run("lines overflow to the next pages", func(t *testing.T) {
+		got := writeStatementForTest(t, FormatPDF, 2*pdfLinesPerPage)
+
+		assert.Contains(t, got, "/Count 3 >>")
+		assertPDFXref(t, got)
+	})
+
+	t.Run("text is escaped", func(t *testing.T) {
+		assert.Equal(t, `a\(b\)\\c ?`, escapePDFText(`a(b)\c đ`))
+	})
+}
+
+// assertPDFXref checks that every xref entry points at the start of its object
+func assertPDFXref(t *testing.T, pdf string) {
+	t.Helper()
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(pdf)
+	require.Len(t, m, 2)
+	xref, err := strconv.Atoi(m[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(pdf[xref:], "xref\n"))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(pdf[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[1])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(pdf[offset:], fmt.Sprintf("%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+const (
+	jsonlTypeOpening     = "opening_balance"
+	jsonlTypeTransaction = "transaction"
+	jsonlTypeClosing     = "closing_balance"
+)
+
+type jsonlBalance struct {
+	Type     string    `json:"type"`
+	WalletID string    `json:"wallet_id"`
+	At       time.Time `json:"at"`
+	Balance  float64   `json:"balance"`
+}
+
+type jsonlTransaction struct {
+	Type            string     `json:"type"`
+	ID              string     `json:"id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	SettledAt       *time.Time `json:"settled_at,omitempty"`
+	TransactionKind string     `json:"transaction_kind"`
+	Note            string     `json:"note"`
+	Amount          float64    `json:"amount"`
+	Currency        string     `json:"currency"`
+	Balance         float64    `json:"balance"`
+}
+
+// jsonlWriter writes one JSON object per line, the first and last ones hold the opening and closing balances
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	return &jsonlWriter{enc: json.NewEncoder(w)}
+}
+
+func (j *jsonlWriter) WriteHeader(statement *entity.Statement) error {
+	return j.enc.Encode(jsonlBalance{
+		Type:     jsonlTypeOpening,
+		WalletID: statement.WalletID,
+		At:       statement.From,
+		Balance:  statement.OpeningBalance,
+	})
+}
+
+func (j *jsonlWriter) WriteLine(line *entity.StatementLine) error {
+	t := line.Transaction
+	return j.enc.Encode(jsonlTransaction{
+		Type:            jsonlTypeTransaction,
+		ID:              t.ID,
+		CreatedAt:       t.CreatedAt,
+		SettledAt:       t.SettledAt,
+		TransactionKind: string(t.TransactionKind),
+		Note:            t.Note,
+		Amount:          t.SignedAmount(),
+		Currency:        t.Currency,
+		Balance:         line.Balance,
+	})
+}
+
+func (j *jsonlWriter) WriteFooter(statement *entity.Statement) error {
+	return j.enc.Encode(jsonlBalance{
+		Type:     jsonlTypeClosing,
+		WalletID: statement.WalletID,
+		At:       statement.To,
+		Balance:  statement.ClosingBalance,
+	})
+}
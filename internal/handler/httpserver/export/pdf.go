@@ -0,0 +1,203 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+// A4 landscape with a monospaced standard font, so columns line up without font metrics
+const (
+	pdfPageWidth    = 842
+	pdfPageHeight   = 595
+	pdfMargin       = 40
+	pdfFontSize     = 9
+	pdfLeading      = 11
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+	pdfNoteWidth    = 40
+)
+
+// Objects 1 to 3 are reserved: the catalog and the page tree are written last since they
+// list every page, the font is shared by all pages.
+const (
+	pdfCatalogObj = 1
+	pdfPagesObj   = 2
+	pdfFontObj    = 3
+)
+
+var pdfColumns = fmt.Sprintf("%-20s %-36s %-4s %-*s %16s %-4s %16s",
+	"Date", "Transaction", "Kind", pdfNoteWidth, "Note", "Amount", "Cur", "Balance")
+
+// pdfWriter streams a text-only PDF. Only the current page and the object offsets are kept in memory;
+// every full page is written out immediately.
+type pdfWriter struct {
+	w       *countingWriter
+	offsets map[int]int64
+	nextObj int
+	pages   []int
+	lines   []string
+}
+
+func newPDFWriter(w io.Writer) *pdfWriter {
+	return &pdfWriter{
+		w:       &countingWriter{w: w},
+		offsets: map[int]int64{},
+		nextObj: pdfFontObj + 1,
+	}
+}
+
+func (p *pdfWriter) WriteHeader(statement *entity.Statement) error {
+	if _, err := io.WriteString(p.w, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"); err != nil {
+		return err
+	}
+	if err := p.writeObject(pdfFontObj,
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>"); err != nil {
+		return err
+	}
+
+	return p.addLines(
+		fmt.Sprintf("Statement of wallet %s", statement.WalletID),
+		fmt.Sprintf("Period: %s - %s", statement.From.Format(time.RFC3339), statement.To.Format(time.RFC3339)),
+		fmt.Sprintf("Opening balance: %s", formatAmount(statement.OpeningBalance)),
+		"",
+		pdfColumns,
+	)
+}
+
+func (p *pdfWriter) WriteLine(line *entity.StatementLine) error {
+	t := line.Transaction
+	return p.addLines(fmt.Sprintf("%-20s %-36s %-4s %-*s %16s %-4s %16s",
+		lineDate(t).Format(time.RFC3339), t.ID, t.TransactionKind, pdfNoteWidth, truncate(t.Note, pdfNoteWidth),
+		formatAmount(t.SignedAmount()), t.Currency, formatAmount(line.Balance)))
+}
+
+func (p *pdfWriter) WriteFooter(statement *entity.Statement) error {
+	if err := p.addLines("", fmt.Sprintf("Closing balance: %s", formatAmount(statement.ClosingBalance))); err != nil {
+		return err
+	}
+	if err := p.flushPage(); err != nil {
+		return err
+	}
+
+	kids := make([]string, 0, len(p.pages))
+	for _, page := range p.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+	}
+	if err := p.writeObject(pdfPagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "), len(p.pages))); err != nil {
+		return err
+	}
+	if err := p.writeObject(pdfCatalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObj)); err != nil {
+		return err
+	}
+	return p.writeTrailer()
+}
+
+func (p *pdfWriter) addLines(lines ...string) error {
+	for _, line := range lines {
+		p.lines = append(p.lines, line)
+		if len(p.lines) == pdfLinesPerPage {
+			if err := p.flushPage(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flushPage writes the content stream and the page object of the buffered lines
+func (p *pdfWriter) flushPage() error {
+	if len(p.lines) == 0 && len(p.pages) > 0 {
+		return nil
+	}
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+	for i, line := range p.lines {
+		if i > 0 {
+			content.WriteString("T*\n")
+		}
+		fmt.Fprintf(&content, "(%s) Tj\n", escapePDFText(line))
+	}
+	content.WriteString("ET")
+	p.lines = p.lines[:0]
+
+	contentObj := p.newObject()
+	if err := p.writeObject(contentObj, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream",
+		content.Len(), content.String())); err != nil {
+		return err
+	}
+
+	pageObj := p.newObject()
+	p.pages = append(p.pages, pageObj)
+	return p.writeObject(pageObj, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObj, pdfPageWidth, pdfPageHeight, pdfFontObj, contentObj))
+}
+
+func (p *pdfWriter) newObject() int {
+	obj := p.nextObj
+	p.nextObj++
+	return obj
+}
+
+func (p *pdfWriter) writeObject(obj int, body string) error {
+	p.offsets[obj] = p.w.n
+	_, err := fmt.Fprintf(p.w, "%d 0 obj\n%s\nendobj\n", obj, body)
+	return err
+}
+
+func (p *pdfWriter) writeTrailer() error {
+	xref := p.w.n
+	size := p.nextObj
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for obj := 1; obj < size; obj++ {
+		fmt.Fprintf(&b, "%010d 00000 n \n", p.offsets[obj])
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, pdfCatalogObj, xref)
+
+	_, err := io.WriteString(p.w, b.String())
+	return err
+}
+
+// escapePDFText escapes a literal string; characters outside printable ASCII are replaced since
+// the standard fonts have no glyphs for them
+func escapePDFText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,36 @@
+package httpserver
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+func (s *Server) RegisterHealthCheck(Router *echo.Group) {
+	// Deprecated: /healthz is kept for the existing probes, use /livez and /readyz
+	Router.GET("/healthz", func(c echo.Context) error {
+		return c.String(http.StatusOK, "OK!!!")
+	})
+	Router.GET("/livez", s.Liveness)
+	Router.GET("/readyz", s.Readiness)
+}
+
+// Liveness reports that the process serves requests, it checks no dependency so a database outage does
+// not restart the server
+func (s *Server) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, LivenessResponse{Status: "ok"})
+}
+
+// Readiness reports whether the server can take traffic: it has started, is not shutting down and its
+// dependencies are up. It answers 503 with the same report otherwise.
+func (s *Server) Readiness(c echo.Context) error {
+	report := s.Health.Report(c.Request().Context())
+	if !report.Ready() {
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
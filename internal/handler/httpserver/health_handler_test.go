@@ -0,0 +1,108 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-clean-template/pkg/health"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Liveness(t *testing.T) {
+	// Arrange
+	s := Server{Router: echo.New(), Health: health.NewRegistry(time.Second, 0)}
+	s.Health.Register("postgres", health.CheckFunc(func(context.Context) error {
+		return errors.New("connection refused")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/livez", nil)
+	rec := httptest.NewRecorder()
+	c := s.Router.NewContext(req, rec)
+
+	// Act
+	err := s.Liveness(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestServer_Readiness(t *testing.T) {
+	up := health.CheckFunc(func(context.Context) error {
+		return nil
+	})
+	down := health.CheckFunc(func(context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	testCases := []struct {
+		name       string
+		started    bool
+		checks     map[string]health.Checker
+		wantCode   int
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{
+			name:       "starting",
+			started:    false,
+			checks:     map[string]health.Checker{"postgres": up},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: health.StatusNotReady,
+			wantChecks: map[string]string{},
+		},
+		{
+			name:       "every check up",
+			started:    true,
+			checks:     map[string]health.Checker{"postgres": up, "psp": up},
+			wantCode:   http.StatusOK,
+			wantStatus: health.StatusReady,
+			wantChecks: map[string]string{"postgres": health.StatusUp, "psp": health.StatusUp},
+		},
+		{
+			name:       "a check down",
+			started:    true,
+			checks:     map[string]health.Checker{"postgres": down, "psp": up},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: health.StatusNotReady,
+			wantChecks: map[string]string{"postgres": health.StatusDown, "psp": health.StatusUp},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := Server{Router: echo.New(), Health: health.NewRegistry(time.Second, 0)}
+			for name, checker := range tc.checks {
+				s.Health.Register(name, checker)
+			}
+			s.Health.SetReady(tc.started)
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+			c := s.Router.NewContext(req, rec)
+
+			// Act
+			err := s.Readiness(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, rec.Code)
+			var report health.Report
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+			assert.Equal(t, tc.wantStatus, report.Status)
+			gotChecks := map[string]string{}
+			for _, check := range report.Checks {
+				gotChecks[check.Name] = check.Status
+			}
+			assert.Equal(t, tc.wantChecks, gotChecks)
+		})
+	}
+}
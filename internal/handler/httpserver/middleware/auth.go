@@ -9,6 +9,8 @@ import (
 	"go-clean-template/pkg/apperror"
 	"go-clean-template/pkg/config"
 	"go-clean-template/pkg/constant"
+	"go-clean-template/pkg/errorreport"
+	"go-clean-template/pkg/logger"
 
 	"github.com/go-jose/go-jose/v3"
 	"github.com/golang-jwt/jwt"
@@ -56,10 +58,11 @@ func (a *Authentication) Skipper(c echo.Context) bool {
 }
 
 type Claims struct {
-	Sub      string `json:"sub"`
-	ClientId string `json:"client_id"`
-	UserName string `json:"username"`
-	TokenUse string `json:"token_use"`
+	Sub      string   `json:"sub"`
+	ClientId string   `json:"client_id"`
+	UserName string   `json:"username"`
+	TokenUse string   `json:"token_use"`
+	Groups   []string `json:"cognito:groups"`
 	jwt.StandardClaims
 }
 
@@ -84,6 +87,9 @@ func (a *Authentication) ValidateAccessToken(token string, c echo.Context) (bool
 	}
 
 	c.Set(constant.UserIDKey, claims.Sub)
+	c.Set(constant.UserGroupsKey, claims.Groups)
+	c.SetRequest(c.Request().WithContext(logger.With(c.Request().Context(), "user_id", claims.Sub)))
+	errorreport.SetUser(c.Request().Context(), claims.Sub)
 	return true, nil
 }
 
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"go-clean-template/pkg/logger"
+	"go-clean-template/pkg/tracing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.uber.org/zap"
+)
+
+type RequestLogger struct {
+	logger  *zap.SugaredLogger
+	skipper middleware.Skipper
+}
+
+// NewRequestLogger logs the requests with l, the requests matched by skipper get the logger but no access log
+func NewRequestLogger(l *zap.SugaredLogger, skipper middleware.Skipper) *RequestLogger {
+	if skipper == nil {
+		skipper = middleware.DefaultSkipper
+	}
+	return &RequestLogger{logger: l, skipper: skipper}
+}
+
+// Middleware stores a logger with the request ID and the trace in the request context, then logs the
+// request once it is served. It must run after the request ID and the tracing middlewares.
+func (r *RequestLogger) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+			fields := append([]interface{}{"request_id", c.Response().Header().Get(echo.HeaderXRequestID)},
+				tracing.LogFields(req.Context())...)
+			c.SetRequest(req.WithContext(logger.WithContext(req.Context(), r.logger.With(fields...))))
+
+			err := next(c)
+			if r.skipper(c) {
+				return err
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			status := responseStatus(c, err)
+			// the authentication and the handlers may have added the user and the wallet
+			l := logger.FromContext(c.Request().Context())
+			fields = []interface{}{
+				"method", req.Method,
+				"route", route,
+				"status", status,
+				"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+				"bytes_out", c.Response().Size,
+				"remote_ip", c.RealIP(),
+			}
+
+			switch {
+			case status >= http.StatusInternalServerError:
+				l.Errorw("request served", fields...)
+			case status >= http.StatusBadRequest:
+				l.Warnw("request served", fields...)
+			default:
+				l.Infow("request served", fields...)
+			}
+			return err
+		}
+	}
+}
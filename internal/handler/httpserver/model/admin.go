@@ -0,0 +1,9 @@
+package model
+
+type LogLevelRequest struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn error"`
+}
+
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
@@ -0,0 +1,5 @@
+package model
+
+type RejectTransactionRequest struct {
+	Reason string `json:"reason" validate:"required,max=500,note"`
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type BalanceRequest struct {
+	WalletID string `param:"id" validate:"required,id"`
+	AsOf     string `query:"as_of"`
+}
+
+// AsOfTime parses as_of as an RFC 3339 time or a date. A date includes the whole day,
+// a missing as_of means now and is returned as the zero time.
+func (r BalanceRequest) AsOfTime() (time.Time, error) {
+	if r.AsOf == "" {
+		return time.Time{}, nil
+	}
+	asOf, isDate, err := parseDateOrTime(r.AsOf)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid as_of: %w", err)
+	}
+	if isDate {
+		asOf = asOf.AddDate(0, 0, 1)
+	}
+	return asOf, nil
+}
+
+type BalanceResponse struct {
+	WalletID string     `json:"wallet_id"`
+	Balance  float64    `json:"balance"`
+	AsOf     *time.Time `json:"as_of,omitempty"`
+}
+
+func ToBalanceResponse(walletID string, balance float64, asOf time.Time) BalanceResponse {
+	return BalanceResponse{
+		WalletID: walletID,
+		Balance:  balance,
+		AsOf:     optionalTime(asOf),
+	}
+}
+
+type BalanceCheckResponse struct {
+	WalletID     string     `json:"wallet_id"`
+	AsOf         *time.Time `json:"as_of,omitempty"`
+	SnapshotAsOf *time.Time `json:"snapshot_as_of"`
+	Balance      float64    `json:"balance"`
+	Recomputed   float64    `json:"recomputed"`
+	Difference   float64    `json:"difference"`
+	Consistent   bool       `json:"consistent"`
+}
+
+func ToBalanceCheckResponse(c *entity.BalanceCheck) BalanceCheckResponse {
+	return BalanceCheckResponse{
+		WalletID:     c.WalletID,
+		AsOf:         optionalTime(c.AsOf),
+		SnapshotAsOf: c.SnapshotAsOf,
+		Balance:      c.Balance,
+		Recomputed:   c.Recomputed,
+		Difference:   c.Difference(),
+		Consistent:   c.IsConsistent(),
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go-clean-template/internal/entity"
+)
+
+// payoutCSVColumns are the columns of a payout CSV upload, currency and note are optional
+var payoutCSVColumns = []string{"wallet_id", "account_id", "amount", "currency", "note"}
+
+type PayoutItemRequest struct {
+	WalletID  string  `json:"wallet_id"`
+	AccountID string  `json:"account_id"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+	Note      string  `json:"note"`
+}
+
+// CreatePayoutBatchRequest only checks the batch size, every item is validated by the use case so that
+// JSON and CSV uploads report invalid items the same way
+type CreatePayoutBatchRequest struct {
+	Items []PayoutItemRequest `json:"items" validate:"required,min=1,max=1000"`
+}
+
+func (r CreatePayoutBatchRequest) ToPayoutItems() []*entity.PayoutItem {
+	items := make([]*entity.PayoutItem, 0, len(r.Items))
+	for _, i := range r.Items {
+		items = append(items, &entity.PayoutItem{
+			WalletID:  i.WalletID,
+			AccountID: i.AccountID,
+			Amount:    i.Amount,
+			Currency:  i.Currency,
+			Note:      i.Note,
+		})
+	}
+	return items
+}
+
+// ParsePayoutCSV reads a payout upload with a header row. Columns are matched by name so their order is free.
+func ParsePayoutCSV(r io.Reader) (CreatePayoutBatchRequest, error) {
+	var req CreatePayoutBatchRequest
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return req, fmt.Errorf("csv is empty")
+		}
+		return req, err
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range payoutCSVColumns[:3] {
+		if _, ok := index[name]; !ok {
+			return req, fmt.Errorf("csv column %s is required", name)
+		}
+	}
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return req, err
+		}
+
+		column := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		amount, err := strconv.ParseFloat(column("amount"), 64)
+		if err != nil {
+			return req, fmt.Errorf("line %d: invalid amount %q", line, column("amount"))
+		}
+		req.Items = append(req.Items, PayoutItemRequest{
+			WalletID:  column("wallet_id"),
+			AccountID: column("account_id"),
+			Amount:    amount,
+			Currency:  column("currency"),
+			Note:      column("note"),
+		})
+	}
+
+	return req, nil
+}
+
+type PayoutProgressResponse struct {
+	Total            int `json:"total"`
+	Pending          int `json:"pending"`
+	Succeeded        int `json:"succeeded"`
+	Failed           int `json:"failed"`
+	AwaitingApproval int `json:"awaiting_approval"`
+}
+
+type PayoutItemResponse struct {
+	ID            string  `json:"id"`
+	Line          int     `json:"line"`
+	WalletID      string  `json:"wallet_id"`
+	AccountID     string  `json:"account_id"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency"`
+	Note          string  `json:"note"`
+	Status        string  `json:"status"`
+	TransactionID string  `json:"transaction_id,omitempty"`
+	Reason        string  `json:"reason,omitempty"`
+}
+
+type PayoutBatchResponse struct {
+	ID        string                 `json:"id"`
+	CreatedBy string                 `json:"created_by"`
+	Status    string                 `json:"status"`
+	Progress  PayoutProgressResponse `json:"progress"`
+	Items     []PayoutItemResponse   `json:"items"`
+}
+
+func ToPayoutBatchResponse(b *entity.PayoutBatch) PayoutBatchResponse {
+	p := b.Progress()
+	resp := PayoutBatchResponse{
+		ID:        b.ID,
+		CreatedBy: b.CreatedBy,
+		Status:    string(b.Status),
+		Progress: PayoutProgressResponse{
+			Total:            p.Total,
+			Pending:          p.Pending,
+			Succeeded:        p.Succeeded,
+			Failed:           p.Failed,
+			AwaitingApproval: p.AwaitingApproval,
+		},
+		Items: make([]PayoutItemResponse, 0, len(b.Items)),
+	}
+	for _, i := range b.Items {
+		resp.Items = append(resp.Items, PayoutItemResponse{
+			ID:            i.ID,
+			Line:          i.Line,
+			WalletID:      i.WalletID,
+			AccountID:     i.AccountID,
+			Amount:        i.Amount,
+			Currency:      i.Currency,
+			Note:          i.Note,
+			Status:        string(i.Status),
+			TransactionID: i.TransactionID,
+			Reason:        i.Reason,
+		})
+	}
+	return resp
+}
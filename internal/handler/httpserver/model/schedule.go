@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type CreateScheduleRequest struct {
+	WalletID        string    `json:"wallet_id" validate:"required,id"`
+	AccountID       string    `json:"account_id" validate:"required,id"`
+	Amount          float64   `json:"amount" validate:"required,gt=0,money=Currency"`
+	Currency        string    `json:"currency" validate:"omitempty,currency"`
+	TransactionKind string    `json:"transaction_kind" validate:"required,oneof=IN OUT"`
+	Note            string    `json:"note" validate:"max=255,note"`
+	Frequency       string    `json:"frequency" validate:"required,oneof=ONCE WEEKLY MONTHLY END_OF_MONTH"`
+	StartAt         time.Time `json:"start_at" validate:"required"`
+}
+
+type UpdateScheduleRequest struct {
+	Amount    float64   `json:"amount" validate:"required,gt=0"`
+	Note      string    `json:"note" validate:"max=255,note"`
+	Frequency string    `json:"frequency" validate:"required,oneof=ONCE WEEKLY MONTHLY END_OF_MONTH"`
+	StartAt   time.Time `json:"start_at" validate:"required"`
+}
+
+type ScheduleResponse struct {
+	ID              string    `json:"id"`
+	WalletID        string    `json:"wallet_id"`
+	AccountID       string    `json:"account_id"`
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	TransactionKind string    `json:"transaction_kind"`
+	Note            string    `json:"note"`
+	Frequency       string    `json:"frequency"`
+	StartAt         time.Time `json:"start_at"`
+	NextRunAt       time.Time `json:"next_run_at"`
+	Status          string    `json:"status"`
+	Version         int64     `json:"version"`
+}
+
+func ToScheduleResponse(s *entity.Schedule) ScheduleResponse {
+	return ScheduleResponse{
+		ID:              s.ID,
+		WalletID:        s.WalletID,
+		AccountID:       s.AccountID,
+		Amount:          s.Amount,
+		Currency:        s.Currency,
+		TransactionKind: string(s.TransactionKind),
+		Note:            s.Note,
+		Frequency:       string(s.Frequency),
+		StartAt:         s.StartAt,
+		NextRunAt:       s.NextRunAt,
+		Status:          string(s.Status),
+		Version:         s.Version,
+	}
+}
+
+func ToScheduleResponses(schedules []*entity.Schedule) []ScheduleResponse {
+	resp := make([]ScheduleResponse, 0, len(schedules))
+	for _, s := range schedules {
+		resp = append(resp, ToScheduleResponse(s))
+	}
+	return resp
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type StatementRequest struct {
+	WalletID string `param:"id" validate:"required,id"`
+	From     string `query:"from" validate:"required"`
+	To       string `query:"to"`
+	Format   string `query:"format" validate:"omitempty,oneof=csv jsonl pdf"`
+}
+
+// Range parses from and to as RFC 3339 times or dates. A date in to includes the whole day,
+// a missing to means now.
+func (r StatementRequest) Range(now time.Time) (time.Time, time.Time, error) {
+	from, _, err := parseDateOrTime(r.From)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %w", err)
+	}
+
+	to := now
+	if r.To != "" {
+		var isDate bool
+		to, isDate, err = parseDateOrTime(r.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %w", err)
+		}
+		if isDate {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+	return from, to, nil
+}
+
+func parseDateOrTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
@@ -1,33 +1,81 @@
 package model
 
 import (
-	"github.com/go-playground/validator/v10"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-clean-template/internal/entity"
 )
 
 type DepositRequest struct {
-	WalletID  string  `json:"wallet_id"`
-	AccountID string  `json:"account_id"`
-	Amount    float64 `json:"amount" validate:"required,gt=0"`
-	Currency  string  `json:"currency"`
-	Note      string  `json:"note"`
+	WalletID  string  `json:"wallet_id" validate:"required,id"`
+	AccountID string  `json:"account_id" validate:"required,id"`
+	Amount    float64 `json:"amount" validate:"required,gt=0,money=Currency"`
+	Currency  string  `json:"currency" validate:"omitempty,currency"`
+	Note      string  `json:"note" validate:"max=255,note"`
 }
 
-func (r DepositRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(r)
-	return err
+type WithdrawRequest struct {
+	WalletID  string  `json:"wallet_id" validate:"required,id"`
+	AccountID string  `json:"account_id" validate:"required,id"`
+	Amount    float64 `json:"amount" validate:"required,gt=0,money=Currency"`
+	Currency  string  `json:"currency" validate:"omitempty,currency"`
+	Note      string  `json:"note" validate:"max=255,note"`
 }
 
-type WithdrawRequest struct {
-	WalletID  string  `json:"wallet_id"`
-	AccountID string  `json:"account_id"`
-	Amount    float64 `json:"amount" validate:"required,gt=0"`
-	Currency  string  `json:"currency"`
-	Note      string  `json:"note"`
+type TransactionResponse struct {
+	ID              string    `json:"id"`
+	WalletID        string    `json:"wallet_id"`
+	AccountID       string    `json:"account_id"`
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	TransactionKind string    `json:"transaction_kind"`
+	Note            string    `json:"note"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+	Version         int64     `json:"version"`
+}
+
+func ToTransactionResponse(t *entity.Transaction) TransactionResponse {
+	return TransactionResponse{
+		ID:              t.ID,
+		WalletID:        t.WalletID,
+		AccountID:       t.AccountID,
+		Amount:          t.Amount,
+		Currency:        t.Currency,
+		TransactionKind: string(t.TransactionKind),
+		Note:            t.Note,
+		Status:          string(t.Status),
+		CreatedAt:       t.CreatedAt,
+		Version:         t.Version,
+	}
+}
+
+// ETag is the strong entity tag of a resource at the given version
+func ETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
 }
 
-func (r WithdrawRequest) Validate() error {
-	v := validator.New()
-	err := v.Struct(r)
-	return err
+// ParseIfMatch reads the version out of an If-Match header written from an ETag.
+// A missing header or * matches any version and is returned as 0. If-Match compares strongly,
+// a weak tag never matches and is rejected.
+func ParseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if strings.HasPrefix(header, "W/") {
+		return 0, fmt.Errorf("invalid If-Match %s: weak tags are not supported", header)
+	}
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, fmt.Errorf("invalid If-Match %s: expected a quoted version", header)
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid If-Match %s: expected a quoted version", header)
+	}
+	return version, nil
 }
@@ -2,7 +2,10 @@ package httpserver
 
 import (
 	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/errorreport"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -21,3 +24,27 @@ func WithLogger(l *zap.SugaredLogger) Options {
 		return nil
 	}
 }
+
+// WithErrorReporter reports the 5xx errors to r
+func WithErrorReporter(r errorreport.ErrorReporter) Options {
+	return func(s *Server) error {
+		s.Reporter = r
+		return nil
+	}
+}
+
+// WithMetrics measures the requests and serves reg on /metrics
+func WithMetrics(reg *prometheus.Registry) Options {
+	return func(s *Server) error {
+		s.Metrics = reg
+		return nil
+	}
+}
+
+// WithTracerProvider traces the requests with tp
+func WithTracerProvider(tp trace.TracerProvider) Options {
+	return func(s *Server) error {
+		s.Tracing = tp
+		return nil
+	}
+}
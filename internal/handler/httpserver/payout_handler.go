@@ -0,0 +1,102 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/constant"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const mimeTextCSV = "text/csv"
+
+// RegisterPayoutRoutesV1 restricts the payout batches to the payout group, they withdraw from any wallet
+func (s *Server) RegisterPayoutRoutesV1(group *echo.Group) {
+	group.Use(s.requireGroup(s.Config.PayoutGroup, "the payout batches need the payout group"))
+	group.POST("", s.CreatePayoutBatch)
+	group.GET("/:id", s.GetPayoutBatch)
+}
+
+// CreatePayoutBatch accepts a JSON body, a text/csv body or a multipart form with a csv "file".
+// The batch is processed in the background and its progress is read from GetPayoutBatch.
+func (s *Server) CreatePayoutBatch(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+	)
+
+	req, err := s.bindPayoutBatch(c)
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	createdBy, _ := c.Get(constant.UserIDKey).(string)
+	batch, err := s.PayoutUseCase.CreatePayoutBatch(ctx, createdBy, req.ToPayoutItems())
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	s.processPayoutBatch(context.WithoutCancel(ctx), batch.ID)
+
+	return s.handleSuccess(c, http.StatusAccepted, model.ToPayoutBatchResponse(batch))
+}
+
+func (s *Server) GetPayoutBatch(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+	)
+
+	batchID := c.Param("id")
+	if batchID == "" {
+		return s.handleError(c, apperror.ErrInvalidParams(fmt.Errorf("id is required")))
+	}
+
+	batch, err := s.PayoutUseCase.GetPayoutBatch(ctx, batchID)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	return s.handleSuccess(c, http.StatusOK, model.ToPayoutBatchResponse(batch))
+}
+
+func (s *Server) bindPayoutBatch(c echo.Context) (model.CreatePayoutBatchRequest, error) {
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	switch {
+	case strings.HasPrefix(contentType, mimeTextCSV):
+		return model.ParsePayoutCSV(c.Request().Body)
+	case strings.HasPrefix(contentType, echo.MIMEMultipartForm):
+		file, err := c.FormFile("file")
+		if err != nil {
+			return model.CreatePayoutBatchRequest{}, err
+		}
+		f, err := file.Open()
+		if err != nil {
+			return model.CreatePayoutBatchRequest{}, err
+		}
+		defer f.Close()
+		return model.ParsePayoutCSV(f)
+	default:
+		var req model.CreatePayoutBatchRequest
+		err := c.Bind(&req)
+		return req, err
+	}
+}
+
+// processPayoutBatch runs the batch outside of the request, errors are only logged since the client
+// has already been answered. Shutdown waits for the batch, the worker resumes it if the server stops anyway.
+func (s *Server) processPayoutBatch(ctx context.Context, batchID string) {
+	s.goBackground(func() {
+		if err := s.PayoutUseCase.ProcessPayoutBatch(ctx, batchID); err != nil {
+			s.log(ctx).Errorw("failed to process payout batch", zap.String("batch_id", batchID), zap.Error(err))
+		}
+	})
+}
@@ -0,0 +1,249 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/constant"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const payoutCSV = `wallet_id,account_id,amount,currency,note
+w1,a1,1000,VND,salary
+w2,a2,2000,VND,
+`
+
+func setupCreatePayoutBatch(t testing.TB, contentType string, body io.Reader) (echo.Context, *httptest.ResponseRecorder) {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/payout-batches", body)
+	r.Header.Set("Content-type", contentType)
+	r.Header.Set("User-agent", "testing")
+	w := httptest.NewRecorder()
+	c := newRouter().NewContext(r, w)
+	c.Set(constant.UserIDKey, "ops1")
+
+	return c, w
+}
+
+func newPayoutBatchForHandlerTest(t testing.TB, items []*entity.PayoutItem) *entity.PayoutBatch {
+	batch, err := entity.NewPayoutBatch("b1", "ops1", items)
+	require.NoError(t, err)
+	return batch
+}
+
+// expectProcessPayoutBatch returns a channel closed once the batch has been handed to the use case
+func expectProcessPayoutBatch(payoutUCMock *mocks.IPayoutUseCase, batchID string) chan struct{} {
+	done := make(chan struct{})
+	payoutUCMock.EXPECT().ProcessPayoutBatch(mock.Anything, batchID).
+		Run(func(context.Context, string) { close(done) }).Return(nil).Once()
+	return done
+}
+
+func waitProcessPayoutBatch(t testing.TB, done chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("payout batch was not processed")
+	}
+}
+
+func TestServer_CreatePayoutBatch(t *testing.T) {
+	payoutUCMock := mocks.NewIPayoutUseCase(t)
+	s := Server{
+		PayoutUseCase: payoutUCMock,
+		Logger:        zap.S(),
+		Config:        localConfig,
+	}
+	csvItems := func() []*entity.PayoutItem {
+		return []*entity.PayoutItem{
+			{WalletID: "w1", AccountID: "a1", Amount: 1000, Currency: "VND", Note: "salary"},
+			{WalletID: "w2", AccountID: "a2", Amount: 2000, Currency: "VND"},
+		}
+	}
+
+	t.Run("202: json", func(t *testing.T) {
+		// Arrange
+		req := model.CreatePayoutBatchRequest{Items: []model.PayoutItemRequest{
+			{WalletID: "w1", AccountID: "a1", Amount: 1000, Currency: "VND", Note: "salary"},
+		}}
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		c, resp := setupCreatePayoutBatch(t, echo.MIMEApplicationJSON, bytes.NewReader(body))
+		batch := newPayoutBatchForHandlerTest(t, req.ToPayoutItems())
+		payoutUCMock.EXPECT().CreatePayoutBatch(c.Request().Context(), "ops1", req.ToPayoutItems()).Return(batch, nil).Once()
+		done := expectProcessPayoutBatch(payoutUCMock, batch.ID)
+
+		// Act
+		err = s.CreatePayoutBatch(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		actual := extractSuccessData[model.PayoutBatchResponse](t, resp.Body)
+		assert.Equal(t, model.ToPayoutBatchResponse(batch), actual)
+		waitProcessPayoutBatch(t, done)
+	})
+
+	t.Run("202: csv", func(t *testing.T) {
+		// Arrange
+		c, resp := setupCreatePayoutBatch(t, "text/csv", strings.NewReader(payoutCSV))
+		batch := newPayoutBatchForHandlerTest(t, csvItems())
+		payoutUCMock.EXPECT().CreatePayoutBatch(c.Request().Context(), "ops1", csvItems()).Return(batch, nil).Once()
+		done := expectProcessPayoutBatch(payoutUCMock, batch.ID)
+
+		// Act
+		err := s.CreatePayoutBatch(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		waitProcessPayoutBatch(t, done)
+	})
+
+	t.Run("202: csv file upload", func(t *testing.T) {
+		// Arrange
+		body := new(bytes.Buffer)
+		form := multipart.NewWriter(body)
+		part, err := form.CreateFormFile("file", "payouts.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(payoutCSV))
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+		c, resp := setupCreatePayoutBatch(t, form.FormDataContentType(), body)
+		batch := newPayoutBatchForHandlerTest(t, csvItems())
+		payoutUCMock.EXPECT().CreatePayoutBatch(c.Request().Context(), "ops1", csvItems()).Return(batch, nil).Once()
+		done := expectProcessPayoutBatch(payoutUCMock, batch.ID)
+
+		// Act
+		err = s.CreatePayoutBatch(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		waitProcessPayoutBatch(t, done)
+	})
+
+	t.Run("400: invalid csv amount", func(t *testing.T) {
+		// Arrange
+		csv := "wallet_id,account_id,amount\nw1,a1,abc\n"
+		c, resp := setupCreatePayoutBatch(t, "text/csv", strings.NewReader(csv))
+
+		// Act
+		err := s.CreatePayoutBatch(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		actual := extractErrorData(t, resp.Body)
+		assert.Equal(t, `line 1: invalid amount "abc"`, actual.RawErr)
+	})
+
+	t.Run("400: empty batch", func(t *testing.T) {
+		// Arrange
+		c, resp := setupCreatePayoutBatch(t, echo.MIMEApplicationJSON, strings.NewReader(`{"items":[]}`))
+
+		// Act
+		err := s.CreatePayoutBatch(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("400: invalid items", func(t *testing.T) {
+		// Arrange
+		c, resp := setupCreatePayoutBatch(t, "text/csv", strings.NewReader(payoutCSV))
+		itemsErr := entity.PayoutItemsError{"line 2: account_id is required"}
+		payoutUCMock.EXPECT().CreatePayoutBatch(c.Request().Context(), "ops1", csvItems()).
+			Return(nil, apperror.ErrInvalidParams(itemsErr).WithInfo([]string(itemsErr))).Once()
+
+		// Act
+		err := s.CreatePayoutBatch(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		actual := extractErrorData(t, resp.Body)
+		assert.Equal(t, []interface{}{"line 2: account_id is required"}, actual.Info)
+	})
+
+	t.Run("403: the user is not in the payout group", func(t *testing.T) {
+		// Arrange
+		s := Server{Logger: zap.S(), Config: &config.Config{AdminGroup: "admin", PayoutGroup: "operations"}}
+		c, resp := setupCreatePayoutBatch(t, "text/csv", strings.NewReader(payoutCSV))
+		c.Set(constant.UserGroupsKey, []string{"support"})
+
+		// Act
+		err := s.requireGroup(s.Config.PayoutGroup, "the payout batches need the payout group")(s.CreatePayoutBatch)(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		assert.Equal(t, apperror.CODE_NO_PERMISSION, apperror.Code(extractErrorData(t, resp.Body).ErrCode.(float64)))
+	})
+
+	t.Run("202: the user is in the admin group", func(t *testing.T) {
+		// Arrange
+		s := Server{PayoutUseCase: payoutUCMock, Logger: zap.S(),
+			Config: &config.Config{AdminGroup: "admin", PayoutGroup: "operations"}}
+		c, resp := setupCreatePayoutBatch(t, "text/csv", strings.NewReader(payoutCSV))
+		c.Set(constant.UserGroupsKey, []string{"admin"})
+		batch := newPayoutBatchForHandlerTest(t, csvItems())
+		payoutUCMock.EXPECT().CreatePayoutBatch(c.Request().Context(), "ops1", csvItems()).Return(batch, nil).Once()
+		done := expectProcessPayoutBatch(payoutUCMock, batch.ID)
+
+		// Act
+		err := s.requireGroup(s.Config.PayoutGroup, "the payout batches need the payout group")(s.CreatePayoutBatch)(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		waitProcessPayoutBatch(t, done)
+	})
+}
+
+func TestServer_GetPayoutBatch(t *testing.T) {
+	payoutUCMock := mocks.NewIPayoutUseCase(t)
+	s := Server{
+		PayoutUseCase: payoutUCMock,
+		Logger:        zap.S(),
+	}
+
+	t.Run("200: success", func(t *testing.T) {
+		// Arrange
+		batch := newPayoutBatchForHandlerTest(t, []*entity.PayoutItem{
+			{WalletID: "w1", AccountID: "a1", Amount: 1000},
+			{WalletID: "w2", AccountID: "a2", Amount: 2000},
+		})
+		require.NoError(t, batch.Items[0].ToSucceeded("t1"))
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/payout-batches/:id", "b1", nil)
+		payoutUCMock.EXPECT().GetPayoutBatch(c.Request().Context(), "b1").Return(batch, nil).Once()
+
+		// Act
+		err := s.GetPayoutBatch(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		actual := extractSuccessData[model.PayoutBatchResponse](t, resp.Body)
+		assert.Equal(t, model.PayoutProgressResponse{Total: 2, Pending: 1, Succeeded: 1}, actual.Progress)
+		assert.Equal(t, "t1", actual.Items[0].TransactionID)
+	})
+}
@@ -0,0 +1,137 @@
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) RegisterScheduleRoutesV1(group *echo.Group) {
+	group.POST("", s.CreateSchedule)
+	group.GET("", s.ListSchedules)
+	group.GET("/:id", s.GetSchedule)
+	group.PUT("/:id", s.UpdateSchedule)
+	group.DELETE("/:id", s.DeleteSchedule)
+}
+
+func (s *Server) CreateSchedule(c echo.Context) error {
+	var (
+		req model.CreateScheduleRequest
+		ctx = c.Request().Context()
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	schedule, err := s.ScheduleUseCase.CreateSchedule(ctx, req.WalletID, req.AccountID, req.Amount, req.Currency,
+		entity.TransactionKind(req.TransactionKind), req.Note, entity.ScheduleFrequency(req.Frequency), req.StartAt)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	c.Response().Header().Set("ETag", model.ETag(schedule.Version))
+	return s.handleSuccess(c, http.StatusCreated, model.ToScheduleResponse(schedule))
+}
+
+func (s *Server) ListSchedules(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+	)
+
+	walletID := c.QueryParam("wallet_id")
+	if walletID == "" {
+		return s.handleError(c, apperror.ErrInvalidParams(fmt.Errorf("wallet_id is required")))
+	}
+
+	schedules, err := s.ScheduleUseCase.ListSchedules(ctx, walletID)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	return s.handleSuccess(c, http.StatusOK, model.ToScheduleResponses(schedules))
+}
+
+func (s *Server) GetSchedule(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+	)
+
+	scheduleID := c.Param("id")
+	if scheduleID == "" {
+		return s.handleError(c, apperror.ErrInvalidParams(fmt.Errorf("id is required")))
+	}
+
+	schedule, err := s.ScheduleUseCase.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	c.Response().Header().Set("ETag", model.ETag(schedule.Version))
+	return s.handleSuccess(c, http.StatusOK, model.ToScheduleResponse(schedule))
+}
+
+func (s *Server) UpdateSchedule(c echo.Context) error {
+	var (
+		req model.UpdateScheduleRequest
+		ctx = c.Request().Context()
+	)
+
+	scheduleID := c.Param("id")
+	if scheduleID == "" {
+		return s.handleError(c, apperror.ErrInvalidParams(fmt.Errorf("id is required")))
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	version, err := model.ParseIfMatch(c.Request().Header.Get("If-Match"))
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	schedule, err := s.ScheduleUseCase.UpdateSchedule(ctx, scheduleID, req.Amount, req.Note,
+		entity.ScheduleFrequency(req.Frequency), req.StartAt, version)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	c.Response().Header().Set("ETag", model.ETag(schedule.Version))
+	return s.handleSuccess(c, http.StatusOK, model.ToScheduleResponse(schedule))
+}
+
+func (s *Server) DeleteSchedule(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+	)
+
+	scheduleID := c.Param("id")
+	if scheduleID == "" {
+		return s.handleError(c, apperror.ErrInvalidParams(fmt.Errorf("id is required")))
+	}
+
+	version, err := model.ParseIfMatch(c.Request().Header.Get("If-Match"))
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := s.ScheduleUseCase.DeleteSchedule(ctx, scheduleID, version); err != nil {
+		return s.handleError(c, err)
+	}
+
+	return s.handleSuccess(c, http.StatusOK, "OK")
+}
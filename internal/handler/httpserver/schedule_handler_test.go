@@ -0,0 +1,274 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupSchedule(t testing.TB, method string, target string, scheduleID string, req interface{}) (echo.Context, *httptest.ResponseRecorder) {
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(method, target, bytes.NewReader(body))
+	r.Header.Set("Content-type", echo.MIMEApplicationJSON)
+	r.Header.Set("User-agent", "testing")
+	w := httptest.NewRecorder()
+	c := newRouter().NewContext(r, w)
+	if scheduleID != "" {
+		c.SetParamNames("id")
+		c.SetParamValues(scheduleID)
+	}
+
+	return c, w
+}
+
+func newScheduleForHandlerTest(t testing.TB) *entity.Schedule {
+	s, err := entity.NewSchedule("s1", testWalletID, testAccountID, 1000, "VND", entity.TransactionOut, "rent",
+		entity.ScheduleMonthly, time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return s
+}
+
+func TestServer_CreateSchedule(t *testing.T) {
+	scheduleUCMock := mocks.NewIScheduleUseCase(t)
+	s := Server{
+		ScheduleUseCase: scheduleUCMock,
+		Logger:          zap.S(),
+	}
+
+	t.Run("201: success", func(t *testing.T) {
+		// Arrange
+		schedule := newScheduleForHandlerTest(t)
+		req := model.CreateScheduleRequest{
+			WalletID:        schedule.WalletID,
+			AccountID:       schedule.AccountID,
+			Amount:          schedule.Amount,
+			Currency:        schedule.Currency,
+			TransactionKind: string(schedule.TransactionKind),
+			Note:            schedule.Note,
+			Frequency:       string(schedule.Frequency),
+			StartAt:         schedule.StartAt,
+		}
+		c, resp := setupSchedule(t, http.MethodPost, "/api/v1/schedules", "", req)
+		scheduleUCMock.EXPECT().CreateSchedule(c.Request().Context(), req.WalletID, req.AccountID, req.Amount, req.Currency,
+			schedule.TransactionKind, req.Note, schedule.Frequency, req.StartAt).Return(schedule, nil).Once()
+
+		// Act
+		err := s.CreateSchedule(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		actual := extractSuccessData[model.ScheduleResponse](t, resp.Body)
+		assert.Equal(t, model.ToScheduleResponse(schedule), actual)
+	})
+
+	t.Run("400: invalid frequency", func(t *testing.T) {
+		// Arrange
+		req := model.CreateScheduleRequest{
+			WalletID:        testWalletID,
+			AccountID:       testAccountID,
+			Amount:          1000,
+			TransactionKind: "OUT",
+			Frequency:       "DAILY",
+			StartAt:         time.Now(),
+		}
+		c, resp := setupSchedule(t, http.MethodPost, "/api/v1/schedules", "", req)
+
+		// Act
+		err := s.CreateSchedule(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		actual := extractErrorData(t, resp.Body)
+		assert.Equal(t, "invalid params", actual.Message)
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"field":   "frequency",
+			"rule":    "oneof",
+			"message": "must be one of ONCE WEEKLY MONTHLY END_OF_MONTH",
+		}}, actual.Info)
+	})
+}
+
+func TestServer_GetSchedule(t *testing.T) {
+	scheduleUCMock := mocks.NewIScheduleUseCase(t)
+	s := Server{
+		ScheduleUseCase: scheduleUCMock,
+		Logger:          zap.S(),
+	}
+
+	t.Run("200: returns the schedule and its version as ETag", func(t *testing.T) {
+		// Arrange
+		schedule := newScheduleForHandlerTest(t)
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/schedules/:id", "s1", nil)
+		scheduleUCMock.EXPECT().GetSchedule(c.Request().Context(), "s1").Return(schedule, nil).Once()
+
+		// Act
+		err := s.GetSchedule(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `"1"`, resp.Header().Get("ETag"))
+		actual := extractSuccessData[model.ScheduleResponse](t, resp.Body)
+		assert.Equal(t, model.ToScheduleResponse(schedule), actual)
+	})
+}
+
+func TestServer_ListSchedules(t *testing.T) {
+	scheduleUCMock := mocks.NewIScheduleUseCase(t)
+	s := Server{
+		ScheduleUseCase: scheduleUCMock,
+		Logger:          zap.S(),
+	}
+
+	t.Run("200: success", func(t *testing.T) {
+		// Arrange
+		schedule := newScheduleForHandlerTest(t)
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/schedules?wallet_id=w1", "", nil)
+		scheduleUCMock.EXPECT().ListSchedules(c.Request().Context(), "w1").
+			Return([]*entity.Schedule{schedule}, nil).Once()
+
+		// Act
+		err := s.ListSchedules(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		actual := extractSuccessData[[]model.ScheduleResponse](t, resp.Body)
+		assert.Equal(t, []model.ScheduleResponse{model.ToScheduleResponse(schedule)}, actual)
+	})
+
+	t.Run("400: wallet_id is required", func(t *testing.T) {
+		// Arrange
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/schedules", "", nil)
+
+		// Act
+		err := s.ListSchedules(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
+func TestServer_UpdateSchedule(t *testing.T) {
+	scheduleUCMock := mocks.NewIScheduleUseCase(t)
+	s := Server{
+		ScheduleUseCase: scheduleUCMock,
+		Logger:          zap.S(),
+		Config:          localConfig,
+	}
+
+	t.Run("200: success", func(t *testing.T) {
+		// Arrange
+		schedule := newScheduleForHandlerTest(t)
+		req := model.UpdateScheduleRequest{
+			Amount:    schedule.Amount,
+			Note:      schedule.Note,
+			Frequency: string(schedule.Frequency),
+			StartAt:   schedule.StartAt,
+		}
+		c, resp := setupSchedule(t, http.MethodPut, "/api/v1/schedules/:id", "s1", req)
+		c.Request().Header.Set("If-Match", `"1"`)
+		updated := *schedule
+		updated.Version++
+		scheduleUCMock.EXPECT().UpdateSchedule(c.Request().Context(), "s1", req.Amount, req.Note,
+			schedule.Frequency, req.StartAt, int64(1)).Return(&updated, nil).Once()
+
+		// Act
+		err := s.UpdateSchedule(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `"2"`, resp.Header().Get("ETag"))
+	})
+
+	t.Run("412: schedule modified since If-Match", func(t *testing.T) {
+		// Arrange
+		req := model.UpdateScheduleRequest{Amount: 1000, Frequency: "WEEKLY", StartAt: time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)}
+		c, resp := setupSchedule(t, http.MethodPut, "/api/v1/schedules/:id", "s1", req)
+		c.Request().Header.Set("If-Match", `"1"`)
+		scheduleUCMock.EXPECT().UpdateSchedule(c.Request().Context(), "s1", req.Amount, req.Note,
+			entity.ScheduleWeekly, req.StartAt, int64(1)).
+			Return(nil, apperror.New(apperror.VERSION_MISMATCH).WithRaw(fmt.Errorf("schedule s1 is not at version 1"))).Once()
+
+		// Act
+		err := s.UpdateSchedule(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+	})
+
+	t.Run("400: If-Match is not a version", func(t *testing.T) {
+		// Arrange
+		req := model.UpdateScheduleRequest{Amount: 1000, Frequency: "WEEKLY", StartAt: time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)}
+		c, resp := setupSchedule(t, http.MethodPut, "/api/v1/schedules/:id", "s1", req)
+		c.Request().Header.Set("If-Match", "abc")
+
+		// Act
+		err := s.UpdateSchedule(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("400: schedule not found", func(t *testing.T) {
+		// Arrange
+		req := model.UpdateScheduleRequest{Amount: 1000, Frequency: "WEEKLY", StartAt: time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)}
+		c, resp := setupSchedule(t, http.MethodPut, "/api/v1/schedules/:id", "s1", req)
+		scheduleUCMock.EXPECT().UpdateSchedule(c.Request().Context(), "s1", req.Amount, req.Note,
+			entity.ScheduleWeekly, req.StartAt, int64(0)).
+			Return(nil, apperror.ErrInvalidParams(fmt.Errorf("schedule not found"))).Once()
+
+		// Act
+		err := s.UpdateSchedule(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		actual := extractErrorData(t, resp.Body)
+		assert.Equal(t, "schedule not found", actual.RawErr)
+	})
+}
+
+func TestServer_DeleteSchedule(t *testing.T) {
+	scheduleUCMock := mocks.NewIScheduleUseCase(t)
+	s := Server{
+		ScheduleUseCase: scheduleUCMock,
+		Logger:          zap.S(),
+	}
+
+	t.Run("200: success", func(t *testing.T) {
+		// Arrange
+		c, resp := setupSchedule(t, http.MethodDelete, "/api/v1/schedules/:id", "s1", nil)
+		c.Request().Header.Set("If-Match", `"2"`)
+		scheduleUCMock.EXPECT().DeleteSchedule(c.Request().Context(), "s1", int64(2)).Return(nil).Once()
+
+		// Act
+		err := s.DeleteSchedule(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+}
@@ -1,19 +1,31 @@
 package httpserver
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	middleware2 "go-clean-template/internal/handler/httpserver/middleware"
 	"go-clean-template/internal/usecase"
 	"go-clean-template/pkg/apperror"
 	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/errorreport"
+	"go-clean-template/pkg/health"
 	"go-clean-template/pkg/logger"
-	"go-clean-template/pkg/sentry"
+	"go-clean-template/pkg/validation"
 
 	sentryecho "github.com/getsentry/sentry-go/echo"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -23,13 +35,30 @@ type Server struct {
 	Logger *zap.SugaredLogger
 
 	TransactionUseCase usecase.ITransactionUseCase
+	ScheduleUseCase    usecase.IScheduleUseCase
+	PayoutUseCase      usecase.IPayoutUseCase
+	StatementUseCase   usecase.IStatementUseCase
+	BalanceUseCase     usecase.IBalanceUseCase
+
+	// Health holds the dependency checks of /readyz, it is ready once the server listens
+	Health *health.Registry
+	// Metrics is served on /metrics, the requests are not measured without it
+	Metrics *prometheus.Registry
+	// Reporter receives the 5xx errors with the user, the transaction and the steps of the request
+	Reporter errorreport.ErrorReporter
+	// Tracing records a span per request, continuing the W3C trace context of the caller. Nil traces nothing.
+	Tracing trace.TracerProvider
+
+	// background tracks the work started by requests and outliving them, like payout batches
+	background sync.WaitGroup
 }
 
 func New(options ...Options) (*Server, error) {
 	s := Server{
-		Router: echo.New(),
-		Config: config.Empty,
-		Logger: logger.NOOPLogger,
+		Router:   echo.New(),
+		Config:   config.Empty,
+		Logger:   logger.NOOPLogger,
+		Reporter: errorreport.Noop{},
 	}
 
 	for _, fn := range options {
@@ -38,27 +67,54 @@ func New(options ...Options) (*Server, error) {
 		}
 	}
 
+	s.Router.Validator = validation.Default
+	s.Health = health.NewRegistry(s.Config.Readiness.CheckTimeout, s.Config.Readiness.CacheTTL)
 	s.RegisterGlobalMiddlewares()
 
 	apiV1 := s.Router.Group("/api/v1")
 
 	s.RegisterHealthCheck(s.Router.Group(""))
+	s.RegisterMetrics(s.Router.Group(""))
+	s.RegisterAPIDocs(s.Router.Group(""))
 	s.RegisterTransactionRoutesV1(apiV1.Group("/transactions"))
+	s.RegisterApprovalRoutesV1(apiV1.Group("/approvals"))
+	s.RegisterScheduleRoutesV1(apiV1.Group("/schedules"))
+	s.RegisterPayoutRoutesV1(apiV1.Group("/payout-batches"))
+	wallets := apiV1.Group("/wallets", walletLogFields)
+	s.RegisterStatementRoutesV1(wallets)
+	s.RegisterBalanceRoutesV1(wallets)
+	s.RegisterAdminRoutes(s.Router.Group("/admin"))
 
 	return &s, nil
 }
 
 func (s *Server) RegisterGlobalMiddlewares() {
+	// outside Recover, so the panics are traced and measured as 500
+	if s.Tracing != nil {
+		s.Router.Use(otelecho.Middleware(s.Config.Tracing.ServiceName,
+			otelecho.WithTracerProvider(s.Tracing),
+			otelecho.WithSkipper(isProbe)))
+	}
+	if s.Metrics != nil {
+		s.Router.Use(middleware2.NewMetrics(s.Metrics).Middleware())
+	}
 	s.Router.Use(middleware.Recover())
 	s.Router.HideBanner = false
 	s.Router.HidePort = false
 	s.Router.Use(middleware.Secure())
 	s.Router.Use(middleware.RequestID())
+	s.Router.Use(reportScope)
+	s.Router.Use(middleware2.NewRequestLogger(s.Logger, isProbe).Middleware())
 	s.Router.Use(middleware.Gzip())
 	s.Router.Use(sentryecho.New(sentryecho.Options{Repanic: true}))
 
 	skipPath := []string{
 		"/healthz",
+		"/livez",
+		"/readyz",
+		"/metrics",
+		"/openapi.json",
+		"/docs",
 		"/api/v1/transactions",
 	}
 
@@ -75,50 +131,227 @@ func (s *Server) RegisterGlobalMiddlewares() {
 	}
 }
 
+// isProbe matches the probes and the scrapes, they would drown the traces and the access logs of the real
+// requests
+func isProbe(c echo.Context) bool {
+	switch c.Path() {
+	case "/healthz", "/livez", "/readyz", "/metrics":
+		return true
+	}
+	return false
+}
+
+// Start listens on addr and serves until Shutdown, the server is ready once it listens
 func (s *Server) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.Router.Listener = ln
+	s.Health.SetReady(true)
 	return s.Router.Start(addr)
 }
 
+// Shutdown reports not ready and keeps serving for the readiness shutdown delay, then stops accepting
+// connections and waits for the in-flight requests and the background work they started, like payout
+// batches, until ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.Health.SetReady(false)
+	if delay := s.Config.Readiness.ShutdownDelay; delay > 0 {
+		s.Logger.Infof("not ready, closing the listener in %s", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	if err := s.Router.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.background.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background work still running: %w", ctx.Err())
+	}
+}
+
+// goBackground runs fn outside of the request, Shutdown waits for it
+func (s *Server) goBackground(fn func()) {
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		fn()
+	}()
+}
+
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.Router.ServeHTTP(w, r)
 }
 
-func (s *Server) RegisterHealthCheck(Router *echo.Group) {
-	Router.GET("/healthz", func(c echo.Context) error {
-		return c.String(http.StatusOK, "OK!!!")
-	})
+func (s *Server) RegisterMetrics(Router *echo.Group) {
+	if s.Metrics == nil {
+		return
+	}
+	Router.GET("/metrics", echo.WrapHandler(promhttp.HandlerFor(s.Metrics, promhttp.HandlerOpts{})))
+}
+
+// log returns the request logger of ctx, carrying the request ID, the trace, the user and the wallet, or
+// the server logger outside of a request
+func (s *Server) log(ctx context.Context) *zap.SugaredLogger {
+	if l, ok := logger.Lookup(ctx); ok {
+		return l
+	}
+	return s.Logger
+}
+
+// withLogFields adds the key value pairs to the logs of the rest of the request
+func withLogFields(c echo.Context, keysAndValues ...interface{}) context.Context {
+	ctx := logger.With(c.Request().Context(), keysAndValues...)
+	c.SetRequest(c.Request().WithContext(ctx))
+	return ctx
+}
+
+// reportScope gives every request its own error report scope, tagged with the request ID
+func reportScope(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := errorreport.NewScope(c.Request().Context())
+		errorreport.SetTag(ctx, "request_id", c.Response().Header().Get(echo.HeaderXRequestID))
+		c.SetRequest(c.Request().WithContext(ctx))
+		return next(c)
+	}
+}
+
+// walletLogFields adds the wallet of the /wallets/:id routes to the logs
+func walletLogFields(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if id := c.Param("id"); id != "" {
+			withLogFields(c, "wallet_id", id)
+		}
+		return next(c)
+	}
+}
+
+// reportError sends err with the scope of the request, the servers built without reporter drop it
+func (s *Server) reportError(c echo.Context, err error) {
+	if s.Reporter != nil {
+		s.Reporter.CaptureError(c.Request().Context(), err)
+	}
 }
 
+// handleError answers the error with its catalog message in the language of Accept-Language, as problem
+// details when the client accepts them. The raw errors are only shown on APP_ENV local or dev.
 func (s *Server) handleError(c echo.Context, err error) error {
-	s.Logger.Errorw(
-		err.Error(),
-		zap.String("request_id", s.requestID(c)),
-	)
-
-	if e, ok := apperror.ErrorAs(err); ok {
-		if e.HTTPCode >= http.StatusInternalServerError {
-			sentry.WithContext(c).Error(err)
+	s.log(c.Request().Context()).Errorw("request failed", zap.Error(err))
+
+	e, ok := apperror.ErrorAs(err)
+	if !ok {
+		e = &apperror.Error{HTTPCode: http.StatusInternalServerError, Message: "internal server error"}
+		if s.exposeRawErrors() {
+			e.Message = err.Error()
 		}
+	}
+	if e.HTTPCode >= http.StatusInternalServerError {
+		s.reportError(c, err)
+	}
+
+	var rawErr string
+	if e.Raw != nil && s.exposeRawErrors() {
+		rawErr = e.Raw.Error()
+	}
+	// the errors outside of the catalog are in English
+	lang := apperror.LanguageEnglish
+	if e.DomainCode != "" {
+		lang = apperror.MatchLanguage(c.Request().Header.Get("Accept-Language"))
+	}
+	message := e.LocalizedMessage(lang)
+	c.Response().Header().Set("Content-Language", lang)
 
-		return c.JSON(e.HTTPCode, Errs{
-			ErrCode: e.Code,
-			Message: e.Message,
-			RawErr:  e.Raw.Error(),
-			Info:    e.Info,
-		})
-	} else {
-		sentry.WithContext(c).Error(err)
-		return c.JSON(http.StatusInternalServerError, Errs{
-			Message: err.Error(),
-		})
+	if acceptsProblem(c) {
+		return s.handleProblem(c, e, message, rawErr)
 	}
+	return c.JSON(e.HTTPCode, Errs{
+		ErrCode: errCode(e),
+		Code:    e.DomainCode,
+		Message: message,
+		RawErr:  rawErr,
+		Info:    e.Info,
+	})
+}
+
+func (s *Server) handleProblem(c echo.Context, e *apperror.Error, message string, rawErr string) error {
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(e.HTTPCode),
+		Status:   e.HTTPCode,
+		Detail:   message,
+		Instance: c.Request().URL.Path,
+		Code:     e.DomainCode,
+		ErrCode:  errCode(e),
+		RawErr:   rawErr,
+		Info:     e.Info,
+	}
+	if e.DomainCode != "" {
+		problem.Type = ProblemTypePrefix + string(e.DomainCode)
+	}
+
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	return c.Blob(e.HTTPCode, MIMEProblemJSON, body)
+}
+
+// exposeRawErrors is only true on APP_ENV local or dev, the raw errors can carry database or PSP details
+func (s *Server) exposeRawErrors() bool {
+	return s.Config != nil && s.Config.IsDevelopment()
+}
+
+// errCode is the numeric code of e, none for the errors outside of apperror
+func errCode(e *apperror.Error) interface{} {
+	if e.Code == apperror.CODE_UNSPECIFIED {
+		return nil
+	}
+	return e.Code
+}
+
+// acceptsProblem reports whether the client asked for problem details
+func acceptsProblem(c echo.Context) bool {
+	return strings.Contains(c.Request().Header.Get(echo.HeaderAccept), MIMEProblemJSON)
 }
 
 type Errs struct {
-	ErrCode interface{} `json:"err_code,omitempty"`
-	Message string      `json:"message,omitempty"`
-	RawErr  string      `json:"raw_err,omitempty"`
-	Info    interface{} `json:"info,omitempty"`
+	ErrCode interface{}         `json:"err_code,omitempty"`
+	Code    apperror.DomainCode `json:"code,omitempty"`
+	Message string              `json:"message,omitempty"`
+	RawErr  string              `json:"raw_err,omitempty"`
+	Info    interface{}         `json:"info,omitempty"`
+}
+
+const (
+	// MIMEProblemJSON is the media type of the RFC 7807 problem details
+	MIMEProblemJSON = "application/problem+json"
+	// ProblemTypePrefix prefixes the domain code in the type of the problem details
+	ProblemTypePrefix = "urn:go-clean-template:error:"
+)
+
+// Problem is an error as RFC 7807 problem details, extended with the codes of Errs
+type Problem struct {
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Status   int                 `json:"status"`
+	Detail   string              `json:"detail,omitempty"`
+	Instance string              `json:"instance,omitempty"`
+	Code     apperror.DomainCode `json:"code,omitempty"`
+	ErrCode  interface{}         `json:"err_code,omitempty"`
+	RawErr   string              `json:"raw_err,omitempty"`
+	Info     interface{}         `json:"info,omitempty"`
 }
 
 type Success struct {
@@ -134,7 +367,3 @@ func (s *Server) handleSuccess(c echo.Context, httpCode int, data interface{}) e
 		Data:    data,
 	})
 }
-
-func (s *Server) requestID(c echo.Context) string {
-	return c.Response().Header().Get(echo.HeaderXRequestID)
-}
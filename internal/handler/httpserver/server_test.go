@@ -0,0 +1,469 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/errorreport"
+	"go-clean-template/pkg/health"
+	"go-clean-template/pkg/logger"
+	"go-clean-template/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// the ids of the requests must pass the id rule
+const (
+	testWalletID  = "0190a6f4-5e8b-7c3d-9f21-6b4e8a2c1d05"
+	testAccountID = "0190a6f4-6a1c-7e42-8b53-2d7f9c3e4a18"
+)
+
+// startSlowServer serves /slow, whose requests block until release is closed
+func startSlowServer(t *testing.T, cfg *config.Config, entered chan<- struct{}, release <-chan struct{}) (*Server, string) {
+	t.Helper()
+
+	s := &Server{Router: echo.New(), Config: cfg, Logger: zap.S(), Health: health.NewRegistry(time.Second, 0)}
+	s.RegisterHealthCheck(s.Router.Group(""))
+	s.Router.HideBanner, s.Router.HidePort = true, true
+	s.Router.GET("/slow", func(c echo.Context) error {
+		entered <- struct{}{}
+		<-release
+		return c.String(http.StatusOK, "done")
+	})
+	go func() {
+		_ = s.Start("127.0.0.1:0")
+	}()
+
+	require.Eventually(t, func() bool {
+		return s.Router.ListenerAddr() != nil
+	}, time.Second, 5*time.Millisecond)
+	return s, "http://" + s.Router.ListenerAddr().String()
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	t.Run("in-flight requests complete", func(t *testing.T) {
+		// Arrange
+		entered, release := make(chan struct{}, 1), make(chan struct{})
+		s, url := startSlowServer(t, config.Empty, entered, release)
+
+		type result struct {
+			code int
+			body string
+			err  error
+		}
+		responses := make(chan result, 1)
+		go func() {
+			resp, err := http.Get(url + "/slow")
+			if err != nil {
+				responses <- result{err: err}
+				return
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			responses <- result{code: resp.StatusCode, body: string(body), err: err}
+		}()
+		<-entered
+
+		// Act
+		shutdown := make(chan error, 1)
+		go func() {
+			shutdown <- s.Shutdown(context.Background())
+		}()
+
+		// Assert
+		require.Eventually(t, func() bool {
+			_, err := http.Get(url + "/slow")
+			return err != nil
+		}, time.Second, 5*time.Millisecond, "new requests are refused")
+		select {
+		case err := <-shutdown:
+			t.Fatalf("shutdown returned before the request completed: %v", err)
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		close(release)
+		got := <-responses
+		require.NoError(t, got.err)
+		assert.Equal(t, http.StatusOK, got.code)
+		assert.Equal(t, "done", got.body)
+		assert.NoError(t, <-shutdown)
+	})
+
+	t.Run("deadline exceeded by an in-flight request", func(t *testing.T) {
+		// Arrange
+		entered, release := make(chan struct{}, 1), make(chan struct{})
+		defer close(release)
+		s, url := startSlowServer(t, config.Empty, entered, release)
+		go func() {
+			resp, err := http.Get(url + "/slow")
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		<-entered
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		// Act
+		err := s.Shutdown(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("payout batches started by requests complete", func(t *testing.T) {
+		// Arrange
+		payoutUCMock := mocks.NewIPayoutUseCase(t)
+		s := &Server{
+			Router:        echo.New(),
+			Config:        config.Empty,
+			Logger:        zap.S(),
+			Health:        health.NewRegistry(time.Second, 0),
+			PayoutUseCase: payoutUCMock,
+		}
+		started, release := make(chan struct{}), make(chan struct{})
+		payoutUCMock.EXPECT().ProcessPayoutBatch(mock.Anything, "batch1").RunAndReturn(
+			func(context.Context, string) error {
+				close(started)
+				<-release
+				return nil
+			}).Once()
+		s.processPayoutBatch(context.Background(), "batch1")
+		<-started
+
+		// Act
+		shutdown := make(chan error, 1)
+		go func() {
+			shutdown <- s.Shutdown(context.Background())
+		}()
+
+		// Assert
+		select {
+		case err := <-shutdown:
+			t.Fatalf("shutdown returned before the batch completed: %v", err)
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(release)
+		assert.NoError(t, <-shutdown)
+	})
+
+	t.Run("not ready during the shutdown delay, then the listener closes", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{}
+		cfg.Readiness.ShutdownDelay = 100 * time.Millisecond
+		s, url := startSlowServer(t, cfg, make(chan struct{}), nil)
+		resp, err := http.Get(url + "/readyz")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// Act
+		shutdown := make(chan error, 1)
+		go func() {
+			shutdown <- s.Shutdown(context.Background())
+		}()
+
+		// Assert
+		require.Eventually(t, func() bool {
+			resp, err := http.Get(url + "/readyz")
+			if err != nil {
+				return false
+			}
+			resp.Body.Close()
+			return resp.StatusCode == http.StatusServiceUnavailable
+		}, time.Second, 5*time.Millisecond, "still serving, not ready")
+		assert.NoError(t, <-shutdown)
+		_, err = http.Get(url + "/readyz")
+		assert.Error(t, err, "the listener is closed")
+	})
+}
+
+func TestServer_Metrics(t *testing.T) {
+	// Arrange
+	reg := prometheus.NewRegistry()
+	s, err := New(WithMetrics(reg))
+	require.NoError(t, err)
+	s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/livez", nil))
+	s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/path", nil))
+	rec := httptest.NewRecorder()
+
+	// Act
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `http_requests_total{method="GET",route="/livez",status="200"} 1`)
+	// the authentication rejects the unknown paths before the router answers 404
+	assert.Contains(t, rec.Body.String(), `http_requests_total{method="GET",route="unmatched",status="400"} 1`)
+}
+
+func TestServer_Tracing(t *testing.T) {
+	// Arrange
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	exporter := tracetest.NewInMemoryExporter()
+	core, logs := observer.New(zap.ErrorLevel)
+	transUCMock := mocks.NewITransactionUseCase(t)
+	s, err := New(WithLogger(zap.New(core).Sugar()),
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))))
+	require.NoError(t, err)
+	s.TransactionUseCase = transUCMock
+	transUCMock.EXPECT().GetTransaction(mock.Anything, "t1").RunAndReturn(
+		func(ctx context.Context, _ string) (*entity.Transaction, error) {
+			assert.True(t, trace.SpanContextFromContext(ctx).IsValid(), "the use case gets the request span")
+			return nil, errors.New("connection refused")
+		}).Once()
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/transactions/t1", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	// Act
+	s.ServeHTTP(httptest.NewRecorder(), req)
+	s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	// Assert
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1, "the probes are not traced")
+	assert.Equal(t, "/api/v1/transactions/:transID", spans[0].Name)
+	assert.Equal(t, traceID, spans[0].SpanContext.TraceID().String(), "the trace of the caller continues")
+	require.Equal(t, 2, logs.Len(), "the error and the access log of the 500")
+	for _, entry := range logs.All() {
+		assert.Equal(t, traceID, entry.ContextMap()["trace_id"])
+	}
+}
+
+func TestServer_RequestLogging(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zap.InfoLevel)
+	transUCMock := mocks.NewITransactionUseCase(t)
+	s, err := New(WithLogger(zap.New(core).Sugar()))
+	require.NoError(t, err)
+	s.TransactionUseCase = transUCMock
+	transUCMock.EXPECT().Deposit(mock.Anything, testWalletID, testAccountID, float64(1000), "USD", "").RunAndReturn(
+		func(ctx context.Context, _, _ string, _ float64, _, _ string) error {
+			logger.FromContext(ctx).Infow("depositing")
+			return apperror.ErrInvalidParams(errors.New("wallet is closed"))
+		}).Once()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/deposit",
+		strings.NewReader(`{"wallet_id":"`+testWalletID+`","account_id":"`+testAccountID+`","amount":1000,"currency":"USD"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	// Act
+	s.ServeHTTP(rec, req)
+	s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	// Assert
+	requestID := rec.Header().Get(echo.HeaderXRequestID)
+	require.NotEmpty(t, requestID)
+	entries := logs.All()
+	require.Len(t, entries, 3, "the use case log, the error and the access log, the probes are not logged")
+	for _, entry := range entries {
+		assert.Equal(t, requestID, entry.ContextMap()["request_id"])
+		assert.Equal(t, testWalletID, entry.ContextMap()["wallet_id"])
+	}
+	access := entries[2]
+	assert.Equal(t, zap.WarnLevel, access.Level)
+	assert.Equal(t, "/api/v1/transactions/deposit", access.ContextMap()["route"])
+	assert.EqualValues(t, http.StatusBadRequest, access.ContextMap()["status"])
+}
+
+func TestServer_ErrorReporting(t *testing.T) {
+	// Arrange
+	reporter := errorreport.NewMemory()
+	transUCMock := mocks.NewITransactionUseCase(t)
+	s, err := New(WithErrorReporter(reporter))
+	require.NoError(t, err)
+	s.TransactionUseCase = transUCMock
+	transUCMock.EXPECT().GetTransaction(mock.Anything, "t1").RunAndReturn(
+		func(ctx context.Context, transID string) (*entity.Transaction, error) {
+			errorreport.SetTransaction(ctx, transID)
+			return nil, apperror.ErrGet(errors.New("connection refused"), "failed to get transaction by id")
+		}).Once()
+	transUCMock.EXPECT().GetTransaction(mock.Anything, "t2").
+		Return(nil, apperror.ErrInvalidParams(errors.New("transaction not found"))).Once()
+	rec := httptest.NewRecorder()
+
+	// Act
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/transactions/t1", nil))
+	s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/transactions/t2", nil))
+
+	// Assert
+	events := reporter.Events()
+	require.Len(t, events, 1, "the 4xx are not reported")
+	assert.Equal(t, map[string]string{
+		"request_id":     rec.Header().Get(echo.HeaderXRequestID),
+		"transaction_id": "t1",
+	}, events[0].Tags)
+}
+
+func TestServer_handleError_RedactsTheLoggedError(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zap.ErrorLevel)
+	s := Server{Logger: zap.New(logger.NewRedactingCore(core)).Sugar()}
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/transactions/t1", nil),
+		httptest.NewRecorder())
+	err := apperror.ErrThirdParty(errors.New("psp rejected jane.doe@example.com with Bearer abc.def"),
+		"failed to call psp")
+
+	// Act
+	require.NoError(t, s.handleError(c, err))
+
+	// Assert
+	require.Equal(t, 1, logs.Len())
+	logged := logs.All()[0].ContextMap()["error"]
+	assert.NotContains(t, logged, "jane.doe@example.com")
+	assert.NotContains(t, logged, "abc.def")
+	assert.Contains(t, logged, logger.Redacted)
+}
+
+// newRouter is a router with the validator of the server, the handlers validate the requests through it
+func newRouter() *echo.Echo {
+	router := echo.New()
+	router.Validator = validation.Default
+	return router
+}
+
+// localConfig shows the raw errors in the responses
+var localConfig = &config.Config{AppEnv: "local"}
+
+func TestServer_handleError(t *testing.T) {
+	production := &config.Config{AppEnv: "production"}
+	dbErr := apperror.ErrGet(errors.New("pq: relation \"wallets\" does not exist"), "failed to get wallet by id")
+
+	testCases := []struct {
+		name            string
+		cfg             *config.Config
+		headers         map[string]string
+		err             error
+		wantCode        int
+		wantContentType string
+		wantLanguage    string
+		wantBody        string
+	}{
+		{
+			name:            "a catalog error in the language of the client",
+			cfg:             config.Empty,
+			headers:         map[string]string{"Accept-Language": "vi-VN,vi;q=0.9,en;q=0.8"},
+			err:             apperror.New(apperror.WALLET_NOT_FOUND),
+			wantCode:        http.StatusNotFound,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantLanguage:    apperror.LanguageVietnamese,
+			wantBody:        `{"err_code":100004,"code":"WALLET_NOT_FOUND","message":"không tìm thấy ví"}`,
+		},
+		{
+			name:            "problem details",
+			cfg:             config.Empty,
+			headers:         map[string]string{"Accept": MIMEProblemJSON + ", application/json"},
+			err:             apperror.New(apperror.INSUFFICIENT_FUNDS),
+			wantCode:        http.StatusBadRequest,
+			wantContentType: MIMEProblemJSON,
+			wantLanguage:    apperror.LanguageEnglish,
+			wantBody: `{"type":"urn:go-clean-template:error:INSUFFICIENT_FUNDS","title":"Bad Request","status":400,
+				"detail":"insufficient balance","instance":"/api/v1/transactions/withdraw","code":"INSUFFICIENT_FUNDS",
+				"err_code":100003}`,
+		},
+		{
+			name:            "the raw error on APP_ENV local",
+			cfg:             localConfig,
+			err:             dbErr,
+			wantCode:        http.StatusInternalServerError,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantLanguage:    apperror.LanguageEnglish,
+			wantBody: `{"err_code":100005,"message":"failed to get wallet by id",
+				"raw_err":"pq: relation \"wallets\" does not exist"}`,
+		},
+		{
+			name:            "no raw error without APP_ENV",
+			cfg:             config.Empty,
+			err:             dbErr,
+			wantCode:        http.StatusInternalServerError,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantLanguage:    apperror.LanguageEnglish,
+			wantBody:        `{"err_code":100005,"message":"failed to get wallet by id"}`,
+		},
+		{
+			name:            "no raw error on an unknown APP_ENV",
+			cfg:             &config.Config{AppEnv: "staging"},
+			err:             errors.New("dial tcp 10.0.0.5:5432: connection refused"),
+			wantCode:        http.StatusInternalServerError,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantLanguage:    apperror.LanguageEnglish,
+			wantBody:        `{"message":"internal server error"}`,
+		},
+		{
+			name:            "no raw error in production",
+			cfg:             production,
+			headers:         map[string]string{"Accept": MIMEProblemJSON},
+			err:             dbErr,
+			wantCode:        http.StatusInternalServerError,
+			wantContentType: MIMEProblemJSON,
+			wantLanguage:    apperror.LanguageEnglish,
+			wantBody: `{"type":"about:blank","title":"Internal Server Error","status":500,
+				"detail":"failed to get wallet by id","instance":"/api/v1/transactions/withdraw","err_code":100005}`,
+		},
+		{
+			name:            "a version conflict in the language of the client",
+			cfg:             production,
+			headers:         map[string]string{"Accept-Language": "vi"},
+			err:             apperror.New(apperror.VERSION_CONFLICT).WithRaw(errors.New("transaction t1 is not at version 2")),
+			wantCode:        http.StatusConflict,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantLanguage:    apperror.LanguageVietnamese,
+			wantBody: `{"err_code":100011,"code":"VERSION_CONFLICT",
+				"message":"dữ liệu đã bị thay đổi, vui lòng tải lại và thử lại"}`,
+		},
+		{
+			name:            "an unexpected error in production",
+			cfg:             production,
+			err:             errors.New("dial tcp 10.0.0.5:5432: connection refused"),
+			wantCode:        http.StatusInternalServerError,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantLanguage:    apperror.LanguageEnglish,
+			wantBody:        `{"message":"internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := Server{Config: tc.cfg, Logger: zap.S()}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/withdraw", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			// Act
+			err := s.handleError(c, tc.err)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, rec.Code)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), tc.wantContentType)
+			assert.Equal(t, tc.wantLanguage, rec.Header().Get("Content-Language"))
+			assert.JSONEq(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/handler/httpserver/export"
+	"go-clean-template/internal/handler/httpserver/model"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+func (s *Server) RegisterStatementRoutesV1(group *echo.Group) {
+	group.GET("/:id/statement", s.GetStatement)
+}
+
+// GetStatement streams the statement of a wallet as a file download. Errors found before the first byte
+// is sent are answered as usual; later ones can only be logged and cut the download short.
+func (s *Server) GetStatement(c echo.Context) error {
+	var (
+		req model.StatementRequest
+		ctx = c.Request().Context()
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	from, to, err := req.Range(time.Now().UTC())
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	format := req.Format
+	if format == "" {
+		format = export.FormatCSV
+	}
+
+	statement, err := s.StatementUseCase.OpenStatement(ctx, req.WalletID, from, to)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	resp := c.Response()
+	w, err := export.NewStatementWriter(format, resp)
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	resp.Header().Set(echo.HeaderContentType, export.ContentType(format))
+	resp.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="statement-%s-%s-%s.%s"`,
+		statement.WalletID, from.Format("20060102"), to.Format("20060102"), format))
+	resp.WriteHeader(http.StatusOK)
+
+	if err := s.writeStatement(c, w, statement); err != nil {
+		s.log(c.Request().Context()).Errorw("failed to write statement", zap.Error(err))
+	}
+	return nil
+}
+
+func (s *Server) writeStatement(c echo.Context, w export.StatementWriter, statement *entity.Statement) error {
+	if err := w.WriteHeader(statement); err != nil {
+		return err
+	}
+	if err := s.StatementUseCase.StreamStatement(c.Request().Context(), statement, w.WriteLine); err != nil {
+		return err
+	}
+	if err := w.WriteFooter(statement); err != nil {
+		return err
+	}
+	c.Response().Flush()
+	return nil
+}
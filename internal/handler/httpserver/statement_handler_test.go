@@ -0,0 +1,96 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestServer_GetStatement(t *testing.T) {
+	statementUCMock := mocks.NewIStatementUseCase(t)
+	s := Server{
+		StatementUseCase: statementUCMock,
+		Logger:           zap.S(),
+		Config:           localConfig,
+	}
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("200: success csv", func(t *testing.T) {
+		// Arrange
+		statement := entity.NewStatement(testWalletID, from, to, 1000)
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/wallets/"+testWalletID+"/statement?from=2024-07-01&to=2024-07-31", testWalletID, nil)
+		statementUCMock.EXPECT().OpenStatement(c.Request().Context(), testWalletID, from, to).Return(statement, nil).Once()
+		statementUCMock.EXPECT().StreamStatement(c.Request().Context(), statement, mock.Anything).
+			RunAndReturn(func(_ context.Context, statement *entity.Statement, fn func(*entity.StatementLine) error) error {
+				return fn(statement.Apply(&entity.Transaction{
+					ID:              "t1",
+					Amount:          500,
+					Currency:        "VND",
+					TransactionKind: entity.TransactionIn,
+					CreatedAt:       from.Add(time.Hour),
+				}))
+			}).Once()
+
+		// Act
+		err := s.GetStatement(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="statement-`+testWalletID+`-20240701-20240801.csv"`, resp.Header().Get("Content-Disposition"))
+		assert.Contains(t, resp.Body.String(), "t1")
+		assert.Contains(t, resp.Body.String(), "CLOSING BALANCE")
+	})
+
+	t.Run("400: invalid format", func(t *testing.T) {
+		// Arrange
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/wallets/"+testWalletID+"/statement?from=2024-07-01&format=xlsx", testWalletID, nil)
+
+		// Act
+		err := s.GetStatement(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("400: from is required", func(t *testing.T) {
+		// Arrange
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/wallets/"+testWalletID+"/statement", testWalletID, nil)
+
+		// Act
+		err := s.GetStatement(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("400: wallet not found", func(t *testing.T) {
+		// Arrange
+		c, resp := setupSchedule(t, http.MethodGet, "/api/v1/wallets/"+testWalletID+"/statement?from=2024-07-01T00:00:00Z&to=2024-08-01T00:00:00Z", testWalletID, nil)
+		statementUCMock.EXPECT().OpenStatement(c.Request().Context(), testWalletID, from, to).
+			Return(nil, apperror.ErrInvalidParams(fmt.Errorf("wallet not found"))).Once()
+
+		// Act
+		err := s.GetStatement(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		actual := extractErrorData(t, resp.Body)
+		assert.Equal(t, "wallet not found", actual.RawErr)
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"go-clean-template/internal/handler/httpserver/model"
 	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/constant"
 
 	"github.com/labstack/echo/v4"
 )
@@ -14,6 +15,7 @@ func (s *Server) RegisterTransactionRoutesV1(group *echo.Group) {
 	group.POST("/deposit", s.Deposit)
 	group.POST("/withdraw", s.Withdraw)
 	group.PUT("/pay/:transID", s.PayTransaction)
+	group.GET("/:transID", s.GetTransaction)
 }
 
 func (s *Server) Deposit(c echo.Context) error {
@@ -25,8 +27,9 @@ func (s *Server) Deposit(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return s.handleError(c, apperror.ErrInvalidParams(err))
 	}
+	ctx = withLogFields(c, "wallet_id", req.WalletID)
 
-	if err := req.Validate(); err != nil {
+	if err := c.Validate(&req); err != nil {
 		return s.handleError(c, apperror.ErrInvalidParams(err))
 	}
 
@@ -47,12 +50,15 @@ func (s *Server) Withdraw(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return s.handleError(c, apperror.ErrInvalidParams(err))
 	}
+	ctx = withLogFields(c, "wallet_id", req.WalletID)
 
-	if err := req.Validate(); err != nil {
+	if err := c.Validate(&req); err != nil {
 		return s.handleError(c, apperror.ErrInvalidParams(err))
 	}
 
-	if err := s.TransactionUseCase.Withdraw(ctx, req.WalletID, req.AccountID, req.Amount, req.Currency, req.Note);
+	// the approver of a large withdrawal has to be someone else than its requester
+	requestedBy, _ := c.Get(constant.UserIDKey).(string)
+	if _, err := s.TransactionUseCase.Withdraw(ctx, requestedBy, req.WalletID, req.AccountID, req.Amount, req.Currency, req.Note);
 		err != nil {
 		return s.handleError(c, err)
 	}
@@ -70,9 +76,33 @@ func (s *Server) PayTransaction(c echo.Context) error {
 		return s.handleError(c, apperror.ErrInvalidParams(fmt.Errorf("transID is required")))
 	}
 
-	if err := s.TransactionUseCase.PayTransaction(ctx, transID); err != nil {
+	version, err := model.ParseIfMatch(c.Request().Header.Get("If-Match"))
+	if err != nil {
+		return s.handleError(c, apperror.ErrInvalidParams(err))
+	}
+
+	if err := s.TransactionUseCase.PayTransaction(ctx, transID, version); err != nil {
 		return s.handleError(c, err)
 	}
 
 	return s.handleSuccess(c, http.StatusOK, "OK")
 }
+
+func (s *Server) GetTransaction(c echo.Context) error {
+	var (
+		ctx = c.Request().Context()
+	)
+
+	transID := c.Param("transID")
+	if transID == "" {
+		return s.handleError(c, apperror.ErrInvalidParams(fmt.Errorf("transID is required")))
+	}
+
+	trans, err := s.TransactionUseCase.GetTransaction(ctx, transID)
+	if err != nil {
+		return s.handleError(c, err)
+	}
+
+	c.Response().Header().Set("ETag", model.ETag(trans.Version))
+	return s.handleSuccess(c, http.StatusOK, model.ToTransactionResponse(trans))
+}
@@ -8,12 +8,16 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"go-clean-template/internal/entity"
 	"go-clean-template/internal/handler/httpserver/model"
 	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/constant"
 	"go-clean-template/pkg/testutil"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -27,7 +31,7 @@ func setupDeposit(t testing.TB, req interface{}) (echo.Context, *httptest.Respon
 	r.Header.Set("User-agent", "testing")
 	w := httptest.NewRecorder()
 
-	return echo.New().NewContext(r, w), w
+	return newRouter().NewContext(r, w), w
 }
 
 func setupWithdraw(t testing.TB, req interface{}) (echo.Context, *httptest.ResponseRecorder) {
@@ -39,7 +43,7 @@ func setupWithdraw(t testing.TB, req interface{}) (echo.Context, *httptest.Respo
 	r.Header.Set("User-agent", "testing")
 	w := httptest.NewRecorder()
 
-	return echo.New().NewContext(r, w), w
+	return newRouter().NewContext(r, w), w
 }
 
 func setupPayTransaction(t testing.TB, transID string) (echo.Context, *httptest.ResponseRecorder) {
@@ -48,7 +52,7 @@ func setupPayTransaction(t testing.TB, transID string) (echo.Context, *httptest.
 	r.Header.Set("User-agent", "testing")
 	w := httptest.NewRecorder()
 
-	c := echo.New().NewContext(r, w)
+	c := newRouter().NewContext(r, w)
 	c.SetParamNames("transID")
 	c.SetParamValues(transID)
 
@@ -57,7 +61,7 @@ func setupPayTransaction(t testing.TB, transID string) (echo.Context, *httptest.
 
 func TestServer_Deposit(t *testing.T) {
 	db := testutil.CreateConnection(t, "test1", "user", "123456")
-	testutil.MigrateTestDatabase(t, db, "../../migrations")
+	testutil.MigrateTestDatabase(t, db)
 
 	transUCMock := mocks.NewITransactionUseCase(t)
 	s := Server{
@@ -68,14 +72,14 @@ func TestServer_Deposit(t *testing.T) {
 	t.Run("200: success", func(t *testing.T) {
 		// Arrange
 		req := model.DepositRequest{
-			WalletID:  "wallet1",
-			AccountID: "account1",
+			WalletID:  testWalletID,
+			AccountID: testAccountID,
 			Amount:    1000,
 			Currency:  "USD",
 			Note:      "deposit",
 		}
 		c, resp := setupDeposit(t, req)
-		transUCMock.EXPECT().Deposit(c.Request().Context(), req.WalletID, req.AccountID, req.Amount, req.Currency,
+		transUCMock.EXPECT().Deposit(mock.Anything, req.WalletID, req.AccountID, req.Amount, req.Currency,
 			req.Note).Return(nil).Once()
 
 		// Act
@@ -143,7 +147,7 @@ func TestServer_Deposit(t *testing.T) {
 			Note:      "deposit",
 		}
 		c, resp := setupDeposit(t, req)
-		transUCMock.EXPECT().Deposit(c.Request().Context(), req.WalletID, req.AccountID, req.Amount, req.Currency,
+		transUCMock.EXPECT().Deposit(mock.Anything, req.WalletID, req.AccountID, req.Amount, req.Currency,
 			req.Note).Return(fmt.Errorf("unexpected error")).Once()
 
 		// Act
@@ -160,7 +164,7 @@ func TestServer_Deposit(t *testing.T) {
 
 func TestServer_Withdraw(t *testing.T) {
 	db := testutil.CreateConnection(t, "test1", "user", "123456")
-	testutil.MigrateTestDatabase(t, db, "../../migrations")
+	testutil.MigrateTestDatabase(t, db)
 
 	transUCMock := mocks.NewITransactionUseCase(t)
 	s := Server{
@@ -171,15 +175,16 @@ func TestServer_Withdraw(t *testing.T) {
 	t.Run("200: success", func(t *testing.T) {
 		// Arrange
 		req := model.WithdrawRequest{
-			WalletID:  "wallet1",
-			AccountID: "account1",
+			WalletID:  testWalletID,
+			AccountID: testAccountID,
 			Amount:    1000,
 			Currency:  "USD",
 			Note:      "deposit",
 		}
 		c, resp := setupWithdraw(t, req)
-		transUCMock.EXPECT().Withdraw(c.Request().Context(), req.WalletID, req.AccountID, req.Amount, req.Currency,
-			req.Note).Return(nil).Once()
+		c.Set(constant.UserIDKey, "u_001")
+		transUCMock.EXPECT().Withdraw(mock.Anything, "u_001", req.WalletID, req.AccountID, req.Amount, req.Currency,
+			req.Note).Return(&entity.Transaction{ID: "trans1", Status: entity.TransactionStatusNew}, nil).Once()
 
 		// Act
 		err := s.Withdraw(c)
@@ -246,8 +251,8 @@ func TestServer_Withdraw(t *testing.T) {
 			Note:      "deposit",
 		}
 		c, resp := setupWithdraw(t, req)
-		transUCMock.EXPECT().Withdraw(c.Request().Context(), req.WalletID, req.AccountID, req.Amount, req.Currency,
-			req.Note).Return(fmt.Errorf("unexpected error")).Once()
+		transUCMock.EXPECT().Withdraw(mock.Anything, "", req.WalletID, req.AccountID, req.Amount, req.Currency,
+			req.Note).Return(nil, fmt.Errorf("unexpected error")).Once()
 
 		// Act
 		err := s.Withdraw(c)
@@ -263,7 +268,7 @@ func TestServer_Withdraw(t *testing.T) {
 
 func TestServer_PayTransaction(t *testing.T) {
 	db := testutil.CreateConnection(t, "test1", "user", "123")
-	testutil.MigrateTestDatabase(t, db, "../../migrations")
+	testutil.MigrateTestDatabase(t, db)
 
 	transUCMock := mocks.NewITransactionUseCase(t)
 	s := Server{
@@ -276,7 +281,7 @@ func TestServer_PayTransaction(t *testing.T) {
 		transID := "trans1"
 		c, resp := setupPayTransaction(t, transID)
 
-		transUCMock.EXPECT().PayTransaction(c.Request().Context(), transID).Return(nil).Once()
+		transUCMock.EXPECT().PayTransaction(c.Request().Context(), transID, int64(0)).Return(nil).Once()
 
 		// Act
 		err := s.PayTransaction(c)
@@ -310,7 +315,7 @@ func TestServer_PayTransaction(t *testing.T) {
 		c, resp := setupPayTransaction(t, transID)
 		errExpected := fmt.Errorf("unexpected error")
 
-		transUCMock.EXPECT().PayTransaction(c.Request().Context(), transID).Return(errExpected).Once()
+		transUCMock.EXPECT().PayTransaction(c.Request().Context(), transID, int64(0)).Return(errExpected).Once()
 
 		// Act
 		err := s.PayTransaction(c)
@@ -321,4 +326,120 @@ func TestServer_PayTransaction(t *testing.T) {
 		actual := extractErrorData(t, resp.Body)
 		assert.Equal(t, errExpected.Error(), actual.Message)
 	})
+
+	t.Run("200: If-Match passes the expected version", func(t *testing.T) {
+		// Arrange
+		transID := "trans1"
+		c, resp := setupPayTransaction(t, transID)
+		c.Request().Header.Set("If-Match", `"3"`)
+
+		transUCMock.EXPECT().PayTransaction(c.Request().Context(), transID, int64(3)).Return(nil).Once()
+
+		// Act
+		err := s.PayTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("400: If-Match is not a version", func(t *testing.T) {
+		// Arrange
+		c, resp := setupPayTransaction(t, "trans1")
+		c.Request().Header.Set("If-Match", "abc")
+
+		// Act
+		err := s.PayTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("400: If-Match is a weak tag", func(t *testing.T) {
+		// Arrange
+		c, resp := setupPayTransaction(t, "trans1")
+		c.Request().Header.Set("If-Match", `W/"1"`)
+
+		// Act
+		err := s.PayTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("412: transaction was modified", func(t *testing.T) {
+		// Arrange
+		transID := "trans1"
+		c, resp := setupPayTransaction(t, transID)
+		c.Request().Header.Set("If-Match", `"1"`)
+
+		transUCMock.EXPECT().PayTransaction(c.Request().Context(), transID, int64(1)).
+			Return(apperror.New(apperror.VERSION_MISMATCH).WithRaw(fmt.Errorf("transaction trans1 is not at version 1"))).
+			Once()
+
+		// Act
+		err := s.PayTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+		actual := extractErrorData(t, resp.Body)
+		assert.Equal(t, apperror.CODE_CONFLICT, apperror.Code(actual.ErrCode.(float64)))
+	})
+}
+
+func setupGetTransaction(transID string) (echo.Context, *httptest.ResponseRecorder) {
+	r := httptest.NewRequest(http.MethodGet, "/v1/transactions/:transID", nil)
+	r.Header.Set("User-agent", "testing")
+	w := httptest.NewRecorder()
+
+	c := newRouter().NewContext(r, w)
+	c.SetParamNames("transID")
+	c.SetParamValues(transID)
+
+	return c, w
+}
+
+func TestServer_GetTransaction(t *testing.T) {
+	transUCMock := mocks.NewITransactionUseCase(t)
+	s := Server{
+		TransactionUseCase: transUCMock,
+		Logger:             zap.S(),
+	}
+
+	t.Run("200: returns the transaction and its version as ETag", func(t *testing.T) {
+		// Arrange
+		trans := entity.NewTransaction("trans1", "w_001", "a_001", 1000, "VND", entity.TransactionOut, "",
+			entity.TransactionStatusAwaitingApproval)
+		trans.Version = 2
+		c, resp := setupGetTransaction(trans.ID)
+		transUCMock.EXPECT().GetTransaction(c.Request().Context(), trans.ID).Return(trans, nil).Once()
+
+		// Act
+		err := s.GetTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, `"2"`, resp.Header().Get("ETag"))
+		actual := extractSuccessData[model.TransactionResponse](t, resp.Body)
+		assert.Equal(t, model.ToTransactionResponse(trans), actual)
+	})
+
+	t.Run("400: transaction not found", func(t *testing.T) {
+		// Arrange
+		c, resp := setupGetTransaction("trans2")
+		transUCMock.EXPECT().GetTransaction(c.Request().Context(), "trans2").
+			Return(nil, apperror.ErrInvalidParams(fmt.Errorf("transaction not found"))).Once()
+
+		// Act
+		err := s.GetTransaction(c)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Empty(t, resp.Header().Get("ETag"))
+	})
 }
@@ -1,44 +1,29 @@
 package httpserver
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"go-clean-template/internal/entity"
-	"go-clean-template/internal/handler/httpserver/model"
 	"go-clean-template/internal/infras/notification"
 	"go-clean-template/internal/infras/paymentsvc"
 	"go-clean-template/internal/infras/postgrestore"
 	"go-clean-template/internal/infras/postgrestore/schema"
 	"go-clean-template/internal/usecase"
+	"go-clean-template/pkg/apiclient"
 	"go-clean-template/pkg/config"
 	"go-clean-template/pkg/logger"
 	"go-clean-template/pkg/testutil"
 
 	"github.com/google/uuid"
-	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
-func setupTestDepositAPI(t testing.TB, req model.DepositRequest) (*http.Request, *httptest.ResponseRecorder) {
-	t.Helper()
-
-	body, err := json.Marshal(req)
-	assert.NoError(t, err)
-
-	r := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/deposit", bytes.NewReader(body))
-	r.Header.Set("Content-type", echo.MIMEApplicationJSON)
-	r.Header.Set("User-agent", "testing")
-	w := httptest.NewRecorder()
-
-	return r, w
-}
-
-func newTransactionServerForTest(t testing.TB, db *gorm.DB) Server {
+func newTransactionServerForTest(t testing.TB, db *gorm.DB) *Server {
 	t.Helper()
 
 	transRepo := postgrestore.NewTransactionRepo(db)
@@ -46,7 +31,7 @@ func newTransactionServerForTest(t testing.TB, db *gorm.DB) Server {
 	transUseCase := usecase.NewTransactionUseCase(transRepo, paymentSvc)
 	transUseCase.SetNotifiers(notification.NewEmailNotifier(), notification.NewAppNotifier())
 
-	router := echo.New()
+	router := newRouter()
 
 	applog, err := logger.NewAppLogger()
 	assert.NoError(t, err)
@@ -61,7 +46,7 @@ func newTransactionServerForTest(t testing.TB, db *gorm.DB) Server {
 		Config:             cfg,
 	}
 	s.RegisterTransactionRoutesV1(router.Group("/api/v1/transactions"))
-	return s
+	return &s
 }
 
 func initDataForDeposit(t testing.TB, db *gorm.DB) (*schema.WalletSchema, *schema.LinkedAccountSchema) {
@@ -98,28 +83,33 @@ func initDataForDeposit(t testing.TB, db *gorm.DB) (*schema.WalletSchema, *schem
 func TestDepositAPI(t *testing.T) {
 	dbName, dbUser, dbPass := "server", "server", "123456"
 	db := testutil.CreateConnection(t, dbName, dbUser, dbPass)
-	testutil.MigrateTestDatabase(t, db, "../../migrations")
+	testutil.MigrateTestDatabase(t, db)
 	s := newTransactionServerForTest(t, db)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	client, err := apiclient.NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
 
 	t.Run("deposit successfully", func(t *testing.T) {
 		// Arrange
 		wallet, account := initDataForDeposit(t, db)
 
-		req := model.DepositRequest{
-			WalletID:  wallet.ID,
-			AccountID: account.ID,
+		currency := apiclient.USD
+		note := "Deposit 100000 VND"
+		req := apiclient.DepositRequest{
+			WalletId:  wallet.ID,
+			AccountId: account.ID,
 			Amount:    100000,
-			Currency:  "USD",
-			Note:      "Deposit 100000 VND",
+			Currency:  &currency,
+			Note:      &note,
 		}
 
-		request, resp := setupTestDepositAPI(t, req)
-
 		// Act
-		s.ServeHTTP(resp, request)
+		resp, err := client.DepositWithResponse(context.Background(), req)
 
 		// Assert
-		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
 		var trans *schema.TransactionSchema
 		assert.NoError(t, db.Table(postgrestore.TransactionsTable).
 			Where("wallet_id = ? AND account_id = ?", wallet.ID, account.ID).
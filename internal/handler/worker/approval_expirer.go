@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/usecase"
+	"go-clean-template/pkg/errorreport"
+
+	"go.uber.org/zap"
+)
+
+// ApprovalExpirer periodically rejects the withdrawals whose approval is past its deadline, which releases the
+// amounts they reserved on their wallets.
+type ApprovalExpirer struct {
+	useCase  usecase.ITransactionUseCase
+	interval time.Duration
+	logger   *zap.SugaredLogger
+	reporter errorreport.ErrorReporter
+	now      func() time.Time
+}
+
+func NewApprovalExpirer(useCase usecase.ITransactionUseCase, interval time.Duration, logger *zap.SugaredLogger) *ApprovalExpirer {
+	return &ApprovalExpirer{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+		reporter: errorreport.Noop{},
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// SetErrorReporter reports the failed ticks to reporter, they are only logged otherwise
+func (e *ApprovalExpirer) SetErrorReporter(reporter errorreport.ErrorReporter) {
+	e.reporter = reporter
+}
+
+// Run ticks until ctx is cancelled. An approval is expired at most one interval after its deadline, an
+// approver deciding it before that is told it expired.
+func (e *ApprovalExpirer) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.Tick(e.newScope(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *ApprovalExpirer) Tick(ctx context.Context) {
+	expired, err := e.useCase.ExpireApprovals(ctx, e.now())
+	if err != nil {
+		e.logger.Errorw("failed to expire approvals", zap.Error(err))
+		e.reporter.CaptureError(ctx, err)
+	}
+	if expired > 0 {
+		e.logger.Infow("expired approvals", zap.Int("count", expired))
+	}
+}
+
+// newScope gives every tick its own error report scope
+func (e *ApprovalExpirer) newScope(ctx context.Context) context.Context {
+	ctx = errorreport.NewScope(ctx)
+	errorreport.SetTag(ctx, "job", "approval_expirer")
+	return ctx
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/errorreport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestApprovalExpirer_Tick(t *testing.T) {
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("expire approvals at now", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		transUCMock := mocks.NewITransactionUseCase(t)
+		e := NewApprovalExpirer(transUCMock, time.Minute, zap.S())
+		e.now = func() time.Time { return now }
+		transUCMock.EXPECT().ExpireApprovals(ctx, now).Return(2, nil).Once()
+
+		//Act
+		e.Tick(ctx)
+	})
+
+	t.Run("error is reported and does not stop the expirer", func(t *testing.T) {
+		//Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		transUCMock := mocks.NewITransactionUseCase(t)
+		e := NewApprovalExpirer(transUCMock, time.Millisecond, zap.S())
+		e.now = func() time.Time { return now }
+		reporter := errorreport.NewMemory()
+		e.SetErrorReporter(reporter)
+		transUCMock.EXPECT().ExpireApprovals(mock.Anything, now).Return(1, fmt.Errorf("unexpected error")).Once()
+		transUCMock.EXPECT().ExpireApprovals(mock.Anything, now).
+			Run(func(context.Context, time.Time) { cancel() }).Return(0, nil).Once()
+
+		//Act
+		e.Run(ctx)
+
+		//Assert
+		events := reporter.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "unexpected error", events[0].Message)
+		assert.Equal(t, map[string]string{"job": "approval_expirer"}, events[0].Tags)
+	})
+}
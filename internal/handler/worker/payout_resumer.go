@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/usecase"
+	"go-clean-template/pkg/errorreport"
+
+	"go.uber.org/zap"
+)
+
+// PayoutResumer periodically processes the payout batches left unfinished by a stopped server.
+type PayoutResumer struct {
+	useCase  usecase.IPayoutUseCase
+	interval time.Duration
+	logger   *zap.SugaredLogger
+	reporter errorreport.ErrorReporter
+	now      func() time.Time
+}
+
+func NewPayoutResumer(useCase usecase.IPayoutUseCase, interval time.Duration, logger *zap.SugaredLogger) *PayoutResumer {
+	return &PayoutResumer{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+		reporter: errorreport.Noop{},
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// SetErrorReporter reports the failed ticks to reporter, they are only logged otherwise
+func (r *PayoutResumer) SetErrorReporter(reporter errorreport.ErrorReporter) {
+	r.reporter = reporter
+}
+
+// Run ticks until ctx is cancelled. A batch is only resumed once the lease of its last processor has ended,
+// so the interval adds at most one tick to that delay.
+func (r *PayoutResumer) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.Tick(r.newScope(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *PayoutResumer) Tick(ctx context.Context) {
+	resumed, err := r.useCase.ResumePayoutBatches(ctx, r.now())
+	if err != nil {
+		r.logger.Errorw("failed to resume payout batches", zap.Error(err))
+		r.reporter.CaptureError(ctx, err)
+	}
+	if resumed > 0 {
+		r.logger.Infow("resumed payout batches", zap.Int("count", resumed))
+	}
+}
+
+// newScope gives every tick its own error report scope
+func (r *PayoutResumer) newScope(ctx context.Context) context.Context {
+	ctx = errorreport.NewScope(ctx)
+	errorreport.SetTag(ctx, "job", "payout_resumer")
+	return ctx
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/errorreport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPayoutResumer_Tick(t *testing.T) {
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("resume payout batches at now", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		payoutUCMock := mocks.NewIPayoutUseCase(t)
+		r := NewPayoutResumer(payoutUCMock, time.Minute, zap.S())
+		r.now = func() time.Time { return now }
+		payoutUCMock.EXPECT().ResumePayoutBatches(ctx, now).Return(2, nil).Once()
+
+		//Act
+		r.Tick(ctx)
+	})
+
+	t.Run("error is reported and does not stop the resumer", func(t *testing.T) {
+		//Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		payoutUCMock := mocks.NewIPayoutUseCase(t)
+		r := NewPayoutResumer(payoutUCMock, time.Millisecond, zap.S())
+		r.now = func() time.Time { return now }
+		reporter := errorreport.NewMemory()
+		r.SetErrorReporter(reporter)
+		payoutUCMock.EXPECT().ResumePayoutBatches(mock.Anything, now).Return(1, fmt.Errorf("unexpected error")).Once()
+		payoutUCMock.EXPECT().ResumePayoutBatches(mock.Anything, now).
+			Run(func(context.Context, time.Time) { cancel() }).Return(0, nil).Once()
+
+		//Act
+		r.Run(ctx)
+
+		//Assert
+		events := reporter.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "unexpected error", events[0].Message)
+		assert.Equal(t, map[string]string{"job": "payout_resumer"}, events[0].Tags)
+	})
+}
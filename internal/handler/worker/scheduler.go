@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/usecase"
+	"go-clean-template/pkg/errorreport"
+
+	"go.uber.org/zap"
+)
+
+// Scheduler periodically materializes the due occurrences of scheduled transactions.
+type Scheduler struct {
+	useCase  usecase.IScheduleUseCase
+	interval time.Duration
+	logger   *zap.SugaredLogger
+	reporter errorreport.ErrorReporter
+	now      func() time.Time
+}
+
+func NewScheduler(useCase usecase.IScheduleUseCase, interval time.Duration, logger *zap.SugaredLogger) *Scheduler {
+	return &Scheduler{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+		reporter: errorreport.Noop{},
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// SetErrorReporter reports the failed ticks to reporter, they are only logged otherwise
+func (s *Scheduler) SetErrorReporter(reporter errorreport.ErrorReporter) {
+	s.reporter = reporter
+}
+
+// Run ticks until ctx is cancelled. The first tick happens immediately so missed runs are caught up on startup.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Tick(s.newScope(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) Tick(ctx context.Context) {
+	materialized, err := s.useCase.RunDueSchedules(ctx, s.now())
+	if err != nil {
+		s.logger.Errorw("failed to run due schedules", zap.Error(err))
+		s.reporter.CaptureError(ctx, err)
+	}
+	if materialized > 0 {
+		s.logger.Infow("materialized scheduled transactions", zap.Int("count", materialized))
+	}
+}
+
+// newScope gives every tick its own error report scope
+func (s *Scheduler) newScope(ctx context.Context) context.Context {
+	ctx = errorreport.NewScope(ctx)
+	errorreport.SetTag(ctx, "job", "scheduler")
+	return ctx
+}
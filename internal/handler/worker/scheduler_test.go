@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/errorreport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestScheduler_Tick(t *testing.T) {
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("run due schedules at now", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		scheduleUCMock := mocks.NewIScheduleUseCase(t)
+		s := NewScheduler(scheduleUCMock, time.Minute, zap.S())
+		s.now = func() time.Time { return now }
+		scheduleUCMock.EXPECT().RunDueSchedules(ctx, now).Return(2, nil).Once()
+
+		//Act
+		s.Tick(ctx)
+	})
+
+	t.Run("error is reported and does not stop the scheduler", func(t *testing.T) {
+		//Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		scheduleUCMock := mocks.NewIScheduleUseCase(t)
+		s := NewScheduler(scheduleUCMock, time.Millisecond, zap.S())
+		s.now = func() time.Time { return now }
+		reporter := errorreport.NewMemory()
+		s.SetErrorReporter(reporter)
+		scheduleUCMock.EXPECT().RunDueSchedules(mock.Anything, now).Return(0, fmt.Errorf("unexpected error")).Once()
+		scheduleUCMock.EXPECT().RunDueSchedules(mock.Anything, now).
+			Run(func(context.Context, time.Time) { cancel() }).Return(0, nil).Once()
+
+		//Act
+		s.Run(ctx)
+
+		//Assert
+		events := reporter.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "unexpected error", events[0].Message)
+		assert.Equal(t, map[string]string{"job": "scheduler"}, events[0].Tags)
+	})
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/usecase"
+	"go-clean-template/pkg/errorreport"
+
+	"go.uber.org/zap"
+)
+
+// BalanceSnapshotter periodically snapshots the wallet balances at the last daily close.
+type BalanceSnapshotter struct {
+	useCase  usecase.IBalanceUseCase
+	interval time.Duration
+	logger   *zap.SugaredLogger
+	reporter errorreport.ErrorReporter
+	now      func() time.Time
+}
+
+func NewBalanceSnapshotter(useCase usecase.IBalanceUseCase, interval time.Duration, logger *zap.SugaredLogger) *BalanceSnapshotter {
+	return &BalanceSnapshotter{
+		useCase:  useCase,
+		interval: interval,
+		logger:   logger,
+		reporter: errorreport.Noop{},
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// SetErrorReporter reports the failed ticks to reporter, they are only logged otherwise
+func (s *BalanceSnapshotter) SetErrorReporter(reporter errorreport.ErrorReporter) {
+	s.reporter = reporter
+}
+
+// Run ticks until ctx is cancelled. Building the snapshots of a day twice is harmless,
+// so the interval only bounds how late after midnight a day is closed.
+func (s *BalanceSnapshotter) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Tick(s.newScope(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *BalanceSnapshotter) Tick(ctx context.Context) {
+	built, err := s.useCase.BuildSnapshots(ctx, s.now())
+	if err != nil {
+		s.logger.Errorw("failed to build balance snapshots", zap.Error(err))
+		s.reporter.CaptureError(ctx, err)
+	}
+	if built > 0 {
+		s.logger.Infow("built balance snapshots", zap.Int("count", built))
+	}
+}
+
+// newScope gives every tick its own error report scope
+func (s *BalanceSnapshotter) newScope(ctx context.Context) context.Context {
+	ctx = errorreport.NewScope(ctx)
+	errorreport.SetTag(ctx, "job", "balance_snapshotter")
+	return ctx
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/errorreport"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBalanceSnapshotter_Tick(t *testing.T) {
+	now := time.Date(2024, 7, 11, 0, 30, 0, 0, time.UTC)
+
+	t.Run("build snapshots at now", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		balanceUCMock := mocks.NewIBalanceUseCase(t)
+		s := NewBalanceSnapshotter(balanceUCMock, time.Hour, zap.S())
+		s.now = func() time.Time { return now }
+		balanceUCMock.EXPECT().BuildSnapshots(ctx, now).Return(3, nil).Once()
+
+		//Act
+		s.Tick(ctx)
+	})
+
+	t.Run("error is reported and does not stop the snapshotter", func(t *testing.T) {
+		//Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		balanceUCMock := mocks.NewIBalanceUseCase(t)
+		s := NewBalanceSnapshotter(balanceUCMock, time.Millisecond, zap.S())
+		s.now = func() time.Time { return now }
+		reporter := errorreport.NewMemory()
+		s.SetErrorReporter(reporter)
+		balanceUCMock.EXPECT().BuildSnapshots(mock.Anything, now).Return(1, fmt.Errorf("unexpected error")).Once()
+		balanceUCMock.EXPECT().BuildSnapshots(mock.Anything, now).
+			Run(func(context.Context, time.Time) { cancel() }).Return(0, nil).Once()
+
+		//Act
+		s.Run(ctx)
+
+		//Assert
+		events := reporter.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "unexpected error", events[0].Message)
+		assert.Equal(t, map[string]string{"job": "balance_snapshotter"}, events[0].Tags)
+	})
+}
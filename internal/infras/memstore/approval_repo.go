@@ -0,0 +1,93 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type ApprovalRepo struct {
+	db *DB
+}
+
+func NewApprovalRepo(db *DB) *ApprovalRepo {
+	return &ApprovalRepo{db: db}
+}
+
+func (r *ApprovalRepo) SaveApproval(ctx context.Context, approval *entity.Approval) error {
+	return r.db.write(ctx, func(d *data) error {
+		if _, ok := d.approvals[approval.ID]; ok {
+			return fmt.Errorf("approval %s: %w", approval.ID, ErrDuplicatedKey)
+		}
+		for _, a := range d.approvals {
+			if a.TransactionID == approval.TransactionID {
+				return fmt.Errorf("approval of transaction %s: %w", approval.TransactionID, ErrDuplicatedKey)
+			}
+		}
+		d.approvals[approval.ID] = cloneApproval(*approval)
+		return nil
+	})
+}
+
+func (r *ApprovalRepo) GetApprovalByTransactionID(ctx context.Context, transID string) (*entity.Approval, error) {
+	var approval *entity.Approval
+	r.db.read(ctx, func(d *data) {
+		for _, a := range d.approvals {
+			if a.TransactionID == transID {
+				a = cloneApproval(a)
+				approval = &a
+				return
+			}
+		}
+	})
+	return approval, nil
+}
+
+func (r *ApprovalRepo) UpdateApproval(ctx context.Context, approval *entity.Approval) error {
+	return r.db.write(ctx, func(d *data) error {
+		a, ok := d.approvals[approval.ID]
+		if !ok || a.Status != entity.ApprovalStatusPending {
+			return entity.ErrApprovalDecided
+		}
+		a.DecidedBy = approval.DecidedBy
+		a.Status = approval.Status
+		a.Reason = approval.Reason
+		a.DecidedAt = approval.DecidedAt
+		d.approvals[approval.ID] = cloneApproval(a)
+		return nil
+	})
+}
+
+func (r *ApprovalRepo) ListExpiredApprovals(ctx context.Context, now time.Time, limit int) ([]*entity.Approval, error) {
+	approvals := []*entity.Approval{}
+	r.db.read(ctx, func(d *data) {
+		for _, a := range d.approvals {
+			if a.IsExpired(now) {
+				a = cloneApproval(a)
+				approvals = append(approvals, &a)
+			}
+		}
+	})
+
+	sort.Slice(approvals, func(i, j int) bool {
+		if !approvals[i].ExpiresAt.Equal(approvals[j].ExpiresAt) {
+			return approvals[i].ExpiresAt.Before(approvals[j].ExpiresAt)
+		}
+		return approvals[i].ID < approvals[j].ID
+	})
+	if len(approvals) > limit {
+		approvals = approvals[:limit]
+	}
+	return approvals, nil
+}
+
+func cloneApproval(a entity.Approval) entity.Approval {
+	if a.DecidedAt != nil {
+		decidedAt := *a.DecidedAt
+		a.DecidedAt = &decidedAt
+	}
+	return a
+}
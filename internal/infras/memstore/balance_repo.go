@@ -0,0 +1,61 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type BalanceRepo struct {
+	db *DB
+}
+
+func NewBalanceRepo(db *DB) *BalanceRepo {
+	return &BalanceRepo{db: db}
+}
+
+func (r *BalanceRepo) GetLatestSnapshot(ctx context.Context, walletID string, asOf time.Time) (*entity.BalanceSnapshot, error) {
+	var latest *entity.BalanceSnapshot
+	r.db.read(ctx, func(d *data) {
+		for _, snapshot := range d.balanceSnapshots {
+			if snapshot.WalletID != walletID || snapshot.AsOf.After(asOf) {
+				continue
+			}
+			if latest == nil || snapshot.AsOf.After(latest.AsOf) {
+				snapshot := snapshot
+				latest = &snapshot
+			}
+		}
+	})
+	return latest, nil
+}
+
+func (r *BalanceRepo) SaveSnapshot(ctx context.Context, snapshot *entity.BalanceSnapshot) error {
+	return r.db.write(ctx, func(d *data) error {
+		d.balanceSnapshots[snapshotKey{walletID: snapshot.WalletID, asOf: snapshot.AsOf.UnixNano()}] = *snapshot
+		return nil
+	})
+}
+
+func (r *BalanceRepo) SumTransactions(ctx context.Context, walletID string, from time.Time, to time.Time) (float64, error) {
+	return r.db.sumTransactions(ctx, walletID, from, to), nil
+}
+
+func (r *BalanceRepo) ListWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	ids := []string{}
+	r.db.read(ctx, func(d *data) {
+		for id := range d.wallets {
+			if id > afterID {
+				ids = append(ids, id)
+			}
+		}
+	})
+
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
@@ -0,0 +1,164 @@
+// Package memstore implements the repositories in memory, for tests and the demo mode that runs without a database.
+//
+// Every repository call is atomic: it runs under the lock of the DB and checks its constraints before writing,
+// so it applies all of its writes or none, like a statement or transaction of the real stores.
+// WithinTransaction groups calls the same way, the calls outside of it wait for it to end.
+// Records are copied on the way in and out, callers never share state with the DB.
+package memstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+// ErrDuplicatedKey is returned when an insert would violate a primary or unique key
+var ErrDuplicatedKey = errors.New("duplicated key")
+
+type DB struct {
+	// tx is held by the running transaction, the calls outside of it share it
+	tx   sync.RWMutex
+	mu   sync.RWMutex
+	data *data
+}
+
+// txKey is the context key marking the calls of the transaction of a DB
+type txKey struct{}
+
+type data struct {
+	wallets          map[string]entity.Wallet
+	linkedAccounts   map[string]entity.LinkedAccount
+	transactions     map[string]entity.Transaction
+	approvals        map[string]entity.Approval
+	schedules        map[string]entity.Schedule
+	scheduleIDs      []string // insertion order of schedules
+	scheduleRuns     map[string]entity.ScheduleRun
+	payoutBatches    map[string]entity.PayoutBatch
+	payoutItems      map[string]entity.PayoutItem
+	balanceSnapshots map[snapshotKey]entity.BalanceSnapshot
+}
+
+type snapshotKey struct {
+	walletID string
+	asOf     int64
+}
+
+func newData() *data {
+	return &data{
+		wallets:          map[string]entity.Wallet{},
+		linkedAccounts:   map[string]entity.LinkedAccount{},
+		transactions:     map[string]entity.Transaction{},
+		approvals:        map[string]entity.Approval{},
+		schedules:        map[string]entity.Schedule{},
+		scheduleRuns:     map[string]entity.ScheduleRun{},
+		payoutBatches:    map[string]entity.PayoutBatch{},
+		payoutItems:      map[string]entity.PayoutItem{},
+		balanceSnapshots: map[snapshotKey]entity.BalanceSnapshot{},
+	}
+}
+
+func NewDB() *DB {
+	return &DB{data: newData()}
+}
+
+// SeedWallets insert or replace wallets
+func (db *DB) SeedWallets(wallets ...*entity.Wallet) {
+	_ = db.write(context.Background(), func(d *data) error {
+		for _, w := range wallets {
+			d.wallets[w.ID] = *w
+		}
+		return nil
+	})
+}
+
+// SeedLinkedAccounts insert or replace linked accounts
+func (db *DB) SeedLinkedAccounts(accounts ...*entity.LinkedAccount) {
+	_ = db.write(context.Background(), func(d *data) error {
+		for _, a := range accounts {
+			d.linkedAccounts[a.ID] = *a
+		}
+		return nil
+	})
+}
+
+// SeedTransactions insert or replace transactions. A zero CreatedAt is set to now.
+func (db *DB) SeedTransactions(transactions ...*entity.Transaction) {
+	_ = db.write(context.Background(), func(d *data) error {
+		for _, trans := range transactions {
+			d.transactions[trans.ID] = withTimes(*trans)
+		}
+		return nil
+	})
+}
+
+// WithinTransaction runs fn alone on the DB and undoes its writes when it returns an error.
+// The content of the DB is copied before fn, a transaction costs as much as a snapshot.
+func (db *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if db.inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	db.tx.Lock()
+	defer db.tx.Unlock()
+
+	db.mu.RLock()
+	before := db.data.snapshot()
+	db.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, db)); err != nil {
+		d, restoreErr := before.toData()
+		if restoreErr != nil {
+			return errors.Join(err, restoreErr)
+		}
+		db.mu.Lock()
+		db.data = d
+		db.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (db *DB) inTransaction(ctx context.Context) bool {
+	return ctx.Value(txKey{}) == db
+}
+
+func (db *DB) read(ctx context.Context, fn func(d *data)) {
+	if !db.inTransaction(ctx) {
+		db.tx.RLock()
+		defer db.tx.RUnlock()
+	}
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	fn(db.data)
+}
+
+func (db *DB) write(ctx context.Context, fn func(d *data) error) error {
+	if !db.inTransaction(ctx) {
+		db.tx.RLock()
+		defer db.tx.RUnlock()
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return fn(db.data)
+}
+
+// withTimes sets a zero CreatedAt to now and settles a transaction stored SUCCESSFUL at its creation
+func withTimes(trans entity.Transaction) entity.Transaction {
+	if trans.CreatedAt.IsZero() {
+		trans.CreatedAt = time.Now()
+	}
+	switch {
+	case trans.SettledAt != nil:
+		settledAt := *trans.SettledAt
+		trans.SettledAt = &settledAt
+	case trans.Status == entity.TransactionStatusSuccessful:
+		settledAt := trans.CreatedAt
+		trans.SettledAt = &settledAt
+	}
+	return trans
+}
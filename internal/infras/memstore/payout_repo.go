@@ -0,0 +1,124 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type PayoutRepo struct {
+	db *DB
+}
+
+func NewPayoutRepo(db *DB) *PayoutRepo {
+	return &PayoutRepo{db: db}
+}
+
+func (r *PayoutRepo) SavePayoutBatch(ctx context.Context, batch *entity.PayoutBatch) error {
+	return r.db.write(ctx, func(d *data) error {
+		if _, ok := d.payoutBatches[batch.ID]; ok {
+			return fmt.Errorf("payout batch %s: %w", batch.ID, ErrDuplicatedKey)
+		}
+		seen := make(map[string]bool, len(batch.Items))
+		for _, item := range batch.Items {
+			if _, ok := d.payoutItems[item.ID]; ok || seen[item.ID] {
+				return fmt.Errorf("payout item %s: %w", item.ID, ErrDuplicatedKey)
+			}
+			seen[item.ID] = true
+		}
+
+		d.payoutBatches[batch.ID] = entity.PayoutBatch{ID: batch.ID, CreatedBy: batch.CreatedBy, Status: batch.Status,
+			ClaimedUntil: batch.ClaimedUntil}
+		for _, item := range batch.Items {
+			d.payoutItems[item.ID] = *item
+		}
+		return nil
+	})
+}
+
+func (r *PayoutRepo) GetPayoutBatchByID(ctx context.Context, batchID string) (*entity.PayoutBatch, error) {
+	var batch *entity.PayoutBatch
+	r.db.read(ctx, func(d *data) {
+		b, ok := d.payoutBatches[batchID]
+		if !ok {
+			return
+		}
+		b.Items = []*entity.PayoutItem{}
+		for _, item := range d.payoutItems {
+			if item.BatchID == batchID {
+				item := item
+				b.Items = append(b.Items, &item)
+			}
+		}
+		batch = &b
+	})
+	if batch == nil {
+		return nil, nil
+	}
+
+	sort.Slice(batch.Items, func(i, j int) bool {
+		return batch.Items[i].Line < batch.Items[j].Line
+	})
+	return batch, nil
+}
+
+func (r *PayoutRepo) ClaimPayoutBatch(ctx context.Context, batchID string, now time.Time, until time.Time) (bool, error) {
+	var claimed bool
+	err := r.db.write(ctx, func(d *data) error {
+		batch, ok := d.payoutBatches[batchID]
+		if !ok || !batch.Claimable(now) {
+			return nil
+		}
+		batch.Status = entity.PayoutBatchStatusProcessing
+		batch.ClaimedUntil = until
+		d.payoutBatches[batchID] = batch
+		claimed = true
+		return nil
+	})
+	return claimed, err
+}
+
+// ListClaimablePayoutBatchIDs lists the batches by id, the store does not keep their creation time
+func (r *PayoutRepo) ListClaimablePayoutBatchIDs(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	ids := []string{}
+	r.db.read(ctx, func(d *data) {
+		for _, batch := range d.payoutBatches {
+			if batch.Claimable(now) {
+				ids = append(ids, batch.ID)
+			}
+		}
+	})
+
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (r *PayoutRepo) UpdatePayoutBatchStatus(ctx context.Context, batchID string, status entity.PayoutBatchStatus) error {
+	return r.db.write(ctx, func(d *data) error {
+		if batch, ok := d.payoutBatches[batchID]; ok {
+			batch.Status = status
+			d.payoutBatches[batchID] = batch
+		}
+		return nil
+	})
+}
+
+func (r *PayoutRepo) UpdatePayoutItem(ctx context.Context, item *entity.PayoutItem) error {
+	return r.db.write(ctx, func(d *data) error {
+		current, ok := d.payoutItems[item.ID]
+		if !ok {
+			return nil
+		}
+		current.Status = item.Status
+		current.TransactionID = item.TransactionID
+		current.Reason = item.Reason
+		d.payoutItems[item.ID] = current
+		return nil
+	})
+}
@@ -0,0 +1,90 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayoutRepo_SavePayoutBatch(t *testing.T) {
+	t.Run("duplicated item saves nothing", func(t *testing.T) {
+		//Arrange
+		repo := NewPayoutRepo(NewDB())
+		ctx := context.Background()
+		assert.NoError(t, repo.SavePayoutBatch(ctx, &entity.PayoutBatch{
+			ID: "b001", Items: []*entity.PayoutItem{{ID: "i001", BatchID: "b001", Line: 1}},
+		}))
+
+		//Act
+		err := repo.SavePayoutBatch(ctx, &entity.PayoutBatch{
+			ID: "b002", Items: []*entity.PayoutItem{{ID: "i002", BatchID: "b002", Line: 1}, {ID: "i001", BatchID: "b002", Line: 2}},
+		})
+
+		//Assert
+		assert.True(t, errors.Is(err, ErrDuplicatedKey))
+		got, err := repo.GetPayoutBatchByID(ctx, "b002")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestPayoutRepo_UpdatePayoutItem(t *testing.T) {
+	t.Run("success: returned batch is a copy", func(t *testing.T) {
+		//Arrange
+		repo := NewPayoutRepo(NewDB())
+		ctx := context.Background()
+		assert.NoError(t, repo.SavePayoutBatch(ctx, &entity.PayoutBatch{
+			ID: "b001", Status: entity.PayoutBatchStatusPending,
+			Items: []*entity.PayoutItem{{ID: "i001", BatchID: "b001", Line: 1, Status: entity.PayoutItemStatusPending}},
+		}))
+		batch, err := repo.GetPayoutBatchByID(ctx, "b001")
+		assert.NoError(t, err)
+		item := batch.Items[0]
+		item.Status = entity.PayoutItemStatusSucceeded
+		item.TransactionID = "t001"
+
+		//Act
+		before, _ := repo.GetPayoutBatchByID(ctx, "b001")
+		err = repo.UpdatePayoutItem(ctx, item)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PayoutItemStatusPending, before.Items[0].Status)
+		after, err := repo.GetPayoutBatchByID(ctx, "b001")
+		assert.NoError(t, err)
+		assert.Equal(t, item, after.Items[0])
+	})
+}
+
+func TestPayoutRepo_ClaimPayoutBatch(t *testing.T) {
+	t.Run("claimed batch is only listed and claimed again after its lease", func(t *testing.T) {
+		//Arrange
+		repo := NewPayoutRepo(NewDB())
+		ctx := context.Background()
+		now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+		for _, id := range []string{"b002", "b001"} {
+			assert.NoError(t, repo.SavePayoutBatch(ctx, &entity.PayoutBatch{ID: id, Status: entity.PayoutBatchStatusPending}))
+		}
+
+		//Act
+		claimed, err := repo.ClaimPayoutBatch(ctx, "b001", now, now.Add(time.Minute))
+		claimable, errList := repo.ListClaimablePayoutBatchIDs(ctx, now, 10)
+		claimedAgain, errAgain := repo.ClaimPayoutBatch(ctx, "b001", now, now.Add(time.Minute))
+		afterLease, errAfterLease := repo.ListClaimablePayoutBatchIDs(ctx, now.Add(time.Minute), 10)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+		assert.NoError(t, errList)
+		assert.Equal(t, []string{"b002"}, claimable)
+		assert.NoError(t, errAgain)
+		assert.False(t, claimedAgain)
+		assert.NoError(t, errAfterLease)
+		assert.Equal(t, []string{"b001", "b002"}, afterLease)
+	})
+}
@@ -0,0 +1,150 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type ScheduleRepo struct {
+	db *DB
+}
+
+func NewScheduleRepo(db *DB) *ScheduleRepo {
+	return &ScheduleRepo{db: db}
+}
+
+func (r *ScheduleRepo) SaveSchedule(ctx context.Context, s *entity.Schedule) error {
+	return r.db.write(ctx, func(d *data) error {
+		if _, ok := d.schedules[s.ID]; ok {
+			return fmt.Errorf("schedule %s: %w", s.ID, ErrDuplicatedKey)
+		}
+		d.schedules[s.ID] = *s
+		d.scheduleIDs = append(d.scheduleIDs, s.ID)
+		return nil
+	})
+}
+
+func (r *ScheduleRepo) GetScheduleByID(ctx context.Context, scheduleID string) (*entity.Schedule, error) {
+	var schedule *entity.Schedule
+	r.db.read(ctx, func(d *data) {
+		if s, ok := d.schedules[scheduleID]; ok {
+			schedule = &s
+		}
+	})
+	return schedule, nil
+}
+
+func (r *ScheduleRepo) ListSchedulesByWalletID(ctx context.Context, walletID string) ([]*entity.Schedule, error) {
+	schedules := []*entity.Schedule{}
+	r.db.read(ctx, func(d *data) {
+		for _, id := range d.scheduleIDs {
+			if s := d.schedules[id]; s.WalletID == walletID {
+				schedules = append(schedules, &s)
+			}
+		}
+	})
+	return schedules, nil
+}
+
+func (r *ScheduleRepo) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*entity.Schedule, error) {
+	schedules := []*entity.Schedule{}
+	r.db.read(ctx, func(d *data) {
+		for _, id := range d.scheduleIDs {
+			if s := d.schedules[id]; s.Status == entity.ScheduleStatusActive && !s.NextRunAt.After(now) {
+				schedules = append(schedules, &s)
+			}
+		}
+	})
+
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].NextRunAt.Before(schedules[j].NextRunAt)
+	})
+	if len(schedules) > limit {
+		schedules = schedules[:limit]
+	}
+	return schedules, nil
+}
+
+func (r *ScheduleRepo) UpdateSchedule(ctx context.Context, s *entity.Schedule) error {
+	return r.db.write(ctx, func(d *data) error {
+		current, ok := d.schedules[s.ID]
+		if !ok {
+			return fmt.Errorf("schedule %s: %w", s.ID, entity.ErrNotFound)
+		}
+		if current.Version != s.Version {
+			return entity.NewVersionConflictError("schedule", s.ID, s.Version)
+		}
+		current.Amount = s.Amount
+		current.Note = s.Note
+		current.Frequency = s.Frequency
+		current.StartAt = s.StartAt
+		current.NextRunAt = s.NextRunAt
+		current.Status = s.Status
+		current.Version++
+		d.schedules[s.ID] = current
+		return nil
+	})
+}
+
+func (r *ScheduleRepo) DeleteSchedule(ctx context.Context, scheduleID string, version int64) error {
+	return r.db.write(ctx, func(d *data) error {
+		current, ok := d.schedules[scheduleID]
+		if !ok {
+			return fmt.Errorf("schedule %s: %w", scheduleID, entity.ErrNotFound)
+		}
+		if current.Version != version {
+			return entity.NewVersionConflictError("schedule", scheduleID, version)
+		}
+		delete(d.schedules, scheduleID)
+		for i, id := range d.scheduleIDs {
+			if id == scheduleID {
+				d.scheduleIDs = append(d.scheduleIDs[:i], d.scheduleIDs[i+1:]...)
+				break
+			}
+		}
+		for id, run := range d.scheduleRuns {
+			if run.ScheduleID == scheduleID {
+				delete(d.scheduleRuns, id)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ScheduleRepo) ClaimScheduleRun(ctx context.Context, run *entity.ScheduleRun, now time.Time) (bool, error) {
+	claimed := false
+	err := r.db.write(ctx, func(d *data) error {
+		current, ok := d.scheduleRuns[run.ID]
+		switch {
+		case !ok:
+			d.scheduleRuns[run.ID] = *run
+		case current.Claimable(now):
+			current.ClaimedUntil = run.ClaimedUntil
+			d.scheduleRuns[run.ID] = current
+		case current.Status == entity.ScheduleRunPending:
+			return entity.ErrScheduleRunClaimed
+		default:
+			return nil
+		}
+		claimed = true
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *ScheduleRepo) UpdateScheduleRun(ctx context.Context, run *entity.ScheduleRun) error {
+	return r.db.write(ctx, func(d *data) error {
+		current, ok := d.scheduleRuns[run.ID]
+		if !ok {
+			return nil
+		}
+		current.Status = run.Status
+		current.Reason = run.Reason
+		d.scheduleRuns[run.ID] = current
+		return nil
+	})
+}
@@ -0,0 +1,159 @@
+package memstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newScheduleForTest(t testing.TB, id string, startAt time.Time) *entity.Schedule {
+	t.Helper()
+
+	s, err := entity.NewSchedule(id, "w001", "a001", 1000, "VND", entity.TransactionOut, "rent", entity.ScheduleWeekly, startAt)
+	assert.NoError(t, err)
+	return s
+}
+
+func TestScheduleRepo_ListDueSchedules(t *testing.T) {
+	t.Run("success: active due schedules, oldest first", func(t *testing.T) {
+		//Arrange
+		now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+		repo := NewScheduleRepo(NewDB())
+		ctx := context.Background()
+		due := newScheduleForTest(t, "s001", now.Add(-time.Hour))
+		older := newScheduleForTest(t, "s002", now.Add(-2*time.Hour))
+		notDue := newScheduleForTest(t, "s003", now.Add(time.Hour))
+		completed := newScheduleForTest(t, "s004", now.Add(-time.Hour))
+		completed.Status = entity.ScheduleStatusCompleted
+		for _, s := range []*entity.Schedule{due, older, notDue, completed} {
+			assert.NoError(t, repo.SaveSchedule(ctx, s))
+		}
+
+		//Act
+		got, err := repo.ListDueSchedules(ctx, now, 10)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.Schedule{older, due}, got)
+	})
+}
+
+func TestScheduleRepo_UpdateSchedule(t *testing.T) {
+	t.Run("expected version updates and increments it", func(t *testing.T) {
+		//Arrange
+		repo := NewScheduleRepo(NewDB())
+		ctx := context.Background()
+		s := newScheduleForTest(t, "s001", time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+		assert.NoError(t, repo.SaveSchedule(ctx, s))
+		s.Advance()
+
+		//Act
+		err := repo.UpdateSchedule(ctx, s)
+
+		//Assert
+		assert.NoError(t, err)
+		got, err := repo.GetScheduleByID(ctx, s.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, s.NextRunAt, got.NextRunAt)
+		assert.Equal(t, s.Version+1, got.Version)
+	})
+
+	t.Run("stale version is a conflict and unknown schedule is not found", func(t *testing.T) {
+		//Arrange
+		repo := NewScheduleRepo(NewDB())
+		ctx := context.Background()
+		s := newScheduleForTest(t, "s001", time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+		assert.NoError(t, repo.SaveSchedule(ctx, s))
+		assert.NoError(t, repo.UpdateSchedule(ctx, s))
+
+		//Act
+		errUpdate := repo.UpdateSchedule(ctx, s)
+		errDelete := repo.DeleteSchedule(ctx, s.ID, s.Version)
+		errUnknown := repo.DeleteSchedule(ctx, "s002", entity.InitialVersion)
+
+		//Assert
+		assert.ErrorIs(t, errUpdate, entity.ErrVersionConflict)
+		assert.ErrorIs(t, errDelete, entity.ErrVersionConflict)
+		assert.ErrorIs(t, errUnknown, entity.ErrNotFound)
+	})
+}
+
+func TestScheduleRepo_ClaimScheduleRun(t *testing.T) {
+	t.Run("occurrence can only be claimed once", func(t *testing.T) {
+		//Arrange
+		repo := NewScheduleRepo(NewDB())
+		ctx := context.Background()
+		s := newScheduleForTest(t, "s001", time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+		assert.NoError(t, repo.SaveSchedule(ctx, s))
+		now := s.NextRunAt
+		run := newClaimedRun(s, now)
+
+		//Act
+		first, err1 := repo.ClaimScheduleRun(ctx, run, now)
+		second, err2 := repo.ClaimScheduleRun(ctx, newClaimedRun(s, now), now)
+
+		//Assert
+		assert.NoError(t, err1)
+		assert.True(t, first)
+		assert.ErrorIs(t, err2, entity.ErrScheduleRunClaimed)
+		assert.False(t, second)
+
+		run.ToMaterialized()
+		assert.NoError(t, repo.UpdateScheduleRun(ctx, run))
+		later := now.Add(entity.ScheduleRunLease)
+		third, err3 := repo.ClaimScheduleRun(ctx, newClaimedRun(s, later), later)
+		assert.NoError(t, err3)
+		assert.False(t, third)
+	})
+
+	t.Run("a pending run is claimed again once its lease ended", func(t *testing.T) {
+		//Arrange
+		repo := NewScheduleRepo(NewDB())
+		ctx := context.Background()
+		s := newScheduleForTest(t, "s001", time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+		assert.NoError(t, repo.SaveSchedule(ctx, s))
+		now := s.NextRunAt
+		_, err := repo.ClaimScheduleRun(ctx, newClaimedRun(s, now), now)
+		assert.NoError(t, err)
+		later := now.Add(entity.ScheduleRunLease)
+
+		//Act
+		got, err := repo.ClaimScheduleRun(ctx, newClaimedRun(s, later), later)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.True(t, got)
+	})
+
+	t.Run("delete schedule deletes its runs", func(t *testing.T) {
+		//Arrange
+		repo := NewScheduleRepo(NewDB())
+		ctx := context.Background()
+		s := newScheduleForTest(t, "s001", time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+		assert.NoError(t, repo.SaveSchedule(ctx, s))
+		_, err := repo.ClaimScheduleRun(ctx, newClaimedRun(s, s.NextRunAt), s.NextRunAt)
+		assert.NoError(t, err)
+
+		//Act
+		assert.NoError(t, repo.DeleteSchedule(ctx, s.ID, s.Version))
+
+		//Assert
+		got, err := repo.GetScheduleByID(ctx, s.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+		claimed, err := repo.ClaimScheduleRun(ctx, newClaimedRun(s, s.NextRunAt), s.NextRunAt)
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+	})
+}
+
+// newClaimedRun is the run of the next occurrence of s, claimed by a scheduler at now
+func newClaimedRun(s *entity.Schedule, now time.Time) *entity.ScheduleRun {
+	run := entity.NewScheduleRun(s.ID, s.NextRunAt)
+	run.ClaimedUntil = now.Add(entity.ScheduleRunLease)
+	return run
+}
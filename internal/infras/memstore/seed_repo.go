@@ -0,0 +1,32 @@
+package memstore
+
+import (
+	"context"
+
+	"go-clean-template/internal/fixture"
+)
+
+type SeedRepo struct {
+	db *DB
+}
+
+func NewSeedRepo(db *DB) *SeedRepo {
+	return &SeedRepo{db: db}
+}
+
+// Seed inserts or replaces the wallets, linked accounts and transactions of the set at once.
+// Users are not stored in memory, wallets and accounts only carry their user id.
+func (r *SeedRepo) Seed(ctx context.Context, set *fixture.Set) error {
+	return r.db.write(ctx, func(d *data) error {
+		for _, w := range set.Wallets {
+			d.wallets[w.ID] = *w
+		}
+		for _, a := range set.LinkedAccounts {
+			d.linkedAccounts[a.ID] = *a
+		}
+		for _, trans := range set.Transactions {
+			d.transactions[trans.ID] = withTimes(*trans)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,185 @@
+package memstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"go-clean-template/internal/entity"
+)
+
+// Snapshot is the JSON document written by Save and read by Load. Records are sorted by id so that
+// snapshots of the same data are identical, and the fields use the names of the entities.
+type Snapshot struct {
+	Wallets          []entity.Wallet          `json:"wallets"`
+	LinkedAccounts   []entity.LinkedAccount   `json:"linked_accounts"`
+	Transactions     []entity.Transaction     `json:"transactions"`
+	Approvals        []entity.Approval        `json:"approvals"`
+	Schedules        []entity.Schedule        `json:"schedules"`
+	ScheduleRuns     []entity.ScheduleRun     `json:"schedule_runs"`
+	PayoutBatches    []entity.PayoutBatch     `json:"payout_batches"`
+	BalanceSnapshots []entity.BalanceSnapshot `json:"balance_snapshots"`
+}
+
+// Snapshot copies the whole content of the DB
+func (db *DB) Snapshot() *Snapshot {
+	var snapshot *Snapshot
+	db.read(context.Background(), func(d *data) {
+		snapshot = d.snapshot()
+	})
+	return snapshot
+}
+
+func (d *data) snapshot() *Snapshot {
+	snapshot := &Snapshot{}
+	snapshot.Wallets = sortedValues(d.wallets, func(w entity.Wallet) string { return w.ID })
+	snapshot.LinkedAccounts = sortedValues(d.linkedAccounts, func(a entity.LinkedAccount) string { return a.ID })
+	snapshot.Transactions = sortedValues(d.transactions, func(t entity.Transaction) string { return t.ID })
+	snapshot.Approvals = sortedValues(d.approvals, func(a entity.Approval) string { return a.ID })
+	snapshot.ScheduleRuns = sortedValues(d.scheduleRuns, func(r entity.ScheduleRun) string { return r.ID })
+
+	// schedules keep their insertion order, it is the order they are listed in
+	snapshot.Schedules = make([]entity.Schedule, 0, len(d.scheduleIDs))
+	for _, id := range d.scheduleIDs {
+		snapshot.Schedules = append(snapshot.Schedules, d.schedules[id])
+	}
+
+	snapshot.PayoutBatches = sortedValues(d.payoutBatches, func(b entity.PayoutBatch) string { return b.ID })
+	for i := range snapshot.PayoutBatches {
+		batch := &snapshot.PayoutBatches[i]
+		batch.Items = []*entity.PayoutItem{}
+		for _, item := range d.payoutItems {
+			if item.BatchID == batch.ID {
+				item := item
+				batch.Items = append(batch.Items, &item)
+			}
+		}
+		sort.Slice(batch.Items, func(i, j int) bool { return batch.Items[i].Line < batch.Items[j].Line })
+	}
+
+	snapshot.BalanceSnapshots = make([]entity.BalanceSnapshot, 0, len(d.balanceSnapshots))
+	for _, s := range d.balanceSnapshots {
+		snapshot.BalanceSnapshots = append(snapshot.BalanceSnapshots, s)
+	}
+	sort.Slice(snapshot.BalanceSnapshots, func(i, j int) bool {
+		a, b := snapshot.BalanceSnapshots[i], snapshot.BalanceSnapshots[j]
+		if a.WalletID != b.WalletID {
+			return a.WalletID < b.WalletID
+		}
+		return a.AsOf.Before(b.AsOf)
+	})
+	return snapshot
+}
+
+// Restore replaces the whole content of the DB with a snapshot. The DB is left untouched if the snapshot has duplicated keys.
+func (db *DB) Restore(snapshot *Snapshot) error {
+	d, err := snapshot.toData()
+	if err != nil {
+		return err
+	}
+
+	return db.write(context.Background(), func(current *data) error {
+		*current = *d
+		return nil
+	})
+}
+
+// SaveFile writes a snapshot of the DB to a JSON file
+func (db *DB) SaveFile(path string) error {
+	content, err := json.MarshalIndent(db.Snapshot(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write then rename, a crash never leaves a truncated snapshot behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadFile restores the DB from a JSON file written by SaveFile
+func (db *DB) LoadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return fmt.Errorf("failed to decode snapshot %s: %w", path, err)
+	}
+	return db.Restore(&snapshot)
+}
+
+func (s *Snapshot) toData() (*data, error) {
+	d := newData()
+	for _, w := range s.Wallets {
+		if err := insert(d.wallets, w.ID, w, "wallet"); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range s.LinkedAccounts {
+		if err := insert(d.linkedAccounts, a.ID, a, "linked account"); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range s.Transactions {
+		if err := insert(d.transactions, t.ID, withTimes(t), "transaction"); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range s.Approvals {
+		if err := insert(d.approvals, a.ID, cloneApproval(a), "approval"); err != nil {
+			return nil, err
+		}
+	}
+	for _, schedule := range s.Schedules {
+		if err := insert(d.schedules, schedule.ID, schedule, "schedule"); err != nil {
+			return nil, err
+		}
+		d.scheduleIDs = append(d.scheduleIDs, schedule.ID)
+	}
+	for _, r := range s.ScheduleRuns {
+		if err := insert(d.scheduleRuns, r.ID, r, "schedule run"); err != nil {
+			return nil, err
+		}
+	}
+	for _, b := range s.PayoutBatches {
+		for _, item := range b.Items {
+			item := *item
+			item.BatchID = b.ID
+			if err := insert(d.payoutItems, item.ID, item, "payout item"); err != nil {
+				return nil, err
+			}
+		}
+		b.Items = nil
+		if err := insert(d.payoutBatches, b.ID, b, "payout batch"); err != nil {
+			return nil, err
+		}
+	}
+	for _, snapshot := range s.BalanceSnapshots {
+		d.balanceSnapshots[snapshotKey{walletID: snapshot.WalletID, asOf: snapshot.AsOf.UnixNano()}] = snapshot
+	}
+	return d, nil
+}
+
+func insert[T any](m map[string]T, id string, value T, kind string) error {
+	if _, ok := m[id]; ok {
+		return fmt.Errorf("%s %s: %w", kind, id, ErrDuplicatedKey)
+	}
+	m[id] = value
+	return nil
+}
+
+func sortedValues[T any](m map[string]T, id func(T) string) []T {
+	values := make([]T, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return id(values[i]) < id(values[j]) })
+	return values
+}
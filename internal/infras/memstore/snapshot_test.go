@@ -0,0 +1,95 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seedForSnapshot(t *testing.T, db *DB) {
+	t.Helper()
+	ctx := context.Background()
+	createdAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	db.SeedWallets(&entity.Wallet{ID: "w001", UserID: "u001", WalletName: "My wallet"})
+	db.SeedLinkedAccounts(&entity.LinkedAccount{ID: "a001", UserID: "u001", AccountName: "momo"})
+	trans := entity.NewTransaction("t001", "w001", "a001", 1000, "VND", entity.TransactionIn, "", entity.TransactionStatusSuccessful)
+	trans.CreatedAt = createdAt
+	db.SeedTransactions(trans)
+
+	decidedAt := createdAt.Add(time.Hour)
+	assert.NoError(t, NewApprovalRepo(db).SaveApproval(ctx, &entity.Approval{
+		ID: "ap001", TransactionID: "t001", RequestedBy: "u001", DecidedBy: "u002",
+		Status: entity.ApprovalStatusApproved, ExpiresAt: createdAt.Add(24 * time.Hour), DecidedAt: &decidedAt,
+	}))
+
+	schedule, err := entity.NewSchedule("s001", "w001", "a001", 100, "VND", entity.TransactionOut, "rent", entity.ScheduleWeekly, createdAt)
+	assert.NoError(t, err)
+	assert.NoError(t, NewScheduleRepo(db).SaveSchedule(ctx, schedule))
+	_, err = NewScheduleRepo(db).ClaimScheduleRun(ctx, entity.NewScheduleRun("s001", createdAt), createdAt)
+	assert.NoError(t, err)
+
+	assert.NoError(t, NewPayoutRepo(db).SavePayoutBatch(ctx, &entity.PayoutBatch{
+		ID: "b001", CreatedBy: "u001", Status: entity.PayoutBatchStatusPending,
+		Items: []*entity.PayoutItem{
+			{ID: "i002", BatchID: "b001", Line: 2, WalletID: "w001", AccountID: "a001", Amount: 20, Status: entity.PayoutItemStatusPending},
+			{ID: "i001", BatchID: "b001", Line: 1, WalletID: "w001", AccountID: "a001", Amount: 10, Status: entity.PayoutItemStatusPending},
+		},
+	}))
+
+	assert.NoError(t, NewBalanceRepo(db).SaveSnapshot(ctx, entity.NewBalanceSnapshot("w001", entity.DayClose(createdAt), 1000)))
+}
+
+func TestDB_SaveFileAndLoadFile(t *testing.T) {
+	t.Run("success: round trip", func(t *testing.T) {
+		//Arrange
+		db := NewDB()
+		seedForSnapshot(t, db)
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+
+		//Act
+		assert.NoError(t, db.SaveFile(path))
+		restored := NewDB()
+		err := restored.LoadFile(path)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, db.Snapshot(), restored.Snapshot())
+
+		batch, err := NewPayoutRepo(restored).GetPayoutBatchByID(context.Background(), "b001")
+		assert.NoError(t, err)
+		assert.Len(t, batch.Items, 2)
+		assert.Equal(t, "i001", batch.Items[0].ID)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		//Act
+		err := NewDB().LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+
+		//Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestDB_Restore(t *testing.T) {
+	t.Run("duplicated key leaves the db untouched", func(t *testing.T) {
+		//Arrange
+		db := NewDB()
+		seedForSnapshot(t, db)
+		want := db.Snapshot()
+		snapshot := &Snapshot{Wallets: []entity.Wallet{{ID: "w002"}, {ID: "w002"}}}
+
+		//Act
+		err := db.Restore(snapshot)
+
+		//Assert
+		assert.True(t, errors.Is(err, ErrDuplicatedKey))
+		assert.Equal(t, want, db.Snapshot())
+	})
+}
@@ -0,0 +1,72 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type StatementRepo struct {
+	db *DB
+}
+
+func NewStatementRepo(db *DB) *StatementRepo {
+	return &StatementRepo{db: db}
+}
+
+func (r *StatementRepo) GetBalanceBefore(ctx context.Context, walletID string, before time.Time) (float64, error) {
+	return r.db.sumTransactions(ctx, walletID, time.Time{}, before), nil
+}
+
+// StreamTransactions copies the matching transactions before calling fn, so fn never runs under the lock
+func (r *StatementRepo) StreamTransactions(ctx context.Context, walletID string, from time.Time, to time.Time,
+	fn func(trans *entity.Transaction) error) error {
+	transactions := r.db.successfulTransactions(ctx, walletID, from, to)
+	for i := range transactions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(&transactions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// successfulTransactions list the successful transactions of a wallet settled in [from, to) ordered by settled_at and id.
+// A zero from or to leaves that side unbounded.
+func (db *DB) successfulTransactions(ctx context.Context, walletID string, from time.Time, to time.Time) []entity.Transaction {
+	var transactions []entity.Transaction
+	db.read(ctx, func(d *data) {
+		for _, trans := range d.transactions {
+			if trans.WalletID != walletID || trans.Status != entity.TransactionStatusSuccessful {
+				continue
+			}
+			if !from.IsZero() && trans.SettledAt.Before(from) {
+				continue
+			}
+			if !to.IsZero() && !trans.SettledAt.Before(to) {
+				continue
+			}
+			transactions = append(transactions, trans)
+		}
+	})
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if !transactions[i].SettledAt.Equal(*transactions[j].SettledAt) {
+			return transactions[i].SettledAt.Before(*transactions[j].SettledAt)
+		}
+		return transactions[i].ID < transactions[j].ID
+	})
+	return transactions
+}
+
+func (db *DB) sumTransactions(ctx context.Context, walletID string, from time.Time, to time.Time) float64 {
+	var balance float64
+	for _, trans := range db.successfulTransactions(ctx, walletID, from, to) {
+		balance += trans.SignedAmount()
+	}
+	return balance
+}
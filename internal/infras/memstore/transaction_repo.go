@@ -0,0 +1,125 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type TransactionRepo struct {
+	db *DB
+}
+
+func NewTransactionRepo(db *DB) *TransactionRepo {
+	return &TransactionRepo{db: db}
+}
+
+func (r *TransactionRepo) GetWalletByID(ctx context.Context, walletID string) (*entity.Wallet, error) {
+	var wallet *entity.Wallet
+	r.db.read(ctx, func(d *data) {
+		if w, ok := d.wallets[walletID]; ok {
+			wallet = &w
+		}
+	})
+	return wallet, nil
+}
+
+func (r *TransactionRepo) SaveTransaction(ctx context.Context, trans *entity.Transaction) error {
+	return r.db.write(ctx, func(d *data) error {
+		if _, ok := d.transactions[trans.ID]; ok {
+			return fmt.Errorf("transaction %s: %w", trans.ID, ErrDuplicatedKey)
+		}
+		d.transactions[trans.ID] = withTimes(*trans)
+		return nil
+	})
+}
+
+func (r *TransactionRepo) GetLinkedAccountByID(ctx context.Context, accountID string) (*entity.LinkedAccount, error) {
+	var account *entity.LinkedAccount
+	r.db.read(ctx, func(d *data) {
+		if a, ok := d.linkedAccounts[accountID]; ok {
+			account = &a
+		}
+	})
+	return account, nil
+}
+
+func (r *TransactionRepo) GetBalanceByWalletID(ctx context.Context, walletID string) (float64, error) {
+	var balance float64
+	r.db.read(ctx, func(d *data) {
+		for _, trans := range d.transactions {
+			if trans.WalletID == walletID && trans.Status == entity.TransactionStatusSuccessful {
+				balance += trans.SignedAmount()
+			}
+		}
+	})
+	return balance, nil
+}
+
+func (r *TransactionRepo) GetTransactionByID(ctx context.Context, transID string) (*entity.Transaction, error) {
+	var trans *entity.Transaction
+	r.db.read(ctx, func(d *data) {
+		if t, ok := d.transactions[transID]; ok {
+			trans = &t
+		}
+	})
+	return trans, nil
+}
+
+func (r *TransactionRepo) UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus,
+	version int64) error {
+	return r.db.write(ctx, func(d *data) error {
+		trans, ok := d.transactions[transID]
+		if !ok {
+			return fmt.Errorf("transaction %s: %w", transID, entity.ErrNotFound)
+		}
+		if trans.Version != version {
+			return entity.NewVersionConflictError("transaction", transID, version)
+		}
+		trans.Status = status
+		trans.Version++
+		if status == entity.TransactionStatusSuccessful {
+			now := time.Now()
+			trans.SettledAt = &now
+		}
+		d.transactions[transID] = trans
+		return nil
+	})
+}
+
+func (r *TransactionRepo) CountTransactionsByStatus(ctx context.Context,
+	statuses []entity.TransactionStatus) (map[entity.TransactionStatus]int64, error) {
+	counts := make(map[entity.TransactionStatus]int64, len(statuses))
+	for _, status := range statuses {
+		counts[status] = 0
+	}
+	r.db.read(ctx, func(d *data) {
+		for _, trans := range d.transactions {
+			if _, ok := counts[trans.Status]; ok {
+				counts[trans.Status]++
+			}
+		}
+	})
+	return counts, nil
+}
+
+// LockWallet is GetWalletByID: a transaction of the DB already runs alone
+func (r *TransactionRepo) LockWallet(ctx context.Context, walletID string) (*entity.Wallet, error) {
+	return r.GetWalletByID(ctx, walletID)
+}
+
+func (r *TransactionRepo) GetPendingWithdrawals(ctx context.Context, walletID string) (float64, error) {
+	var pending float64
+	r.db.read(ctx, func(d *data) {
+		for _, trans := range d.transactions {
+			if trans.WalletID == walletID && trans.TransactionKind == entity.TransactionOut &&
+				slices.Contains(entity.PendingStatuses(), trans.Status) {
+				pending += trans.Amount
+			}
+		}
+	})
+	return pending, nil
+}
@@ -0,0 +1,27 @@
+package memstore
+
+import (
+	"testing"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/repotest"
+)
+
+type contractFixture struct {
+	db *DB
+}
+
+func (f contractFixture) CreateWallet(_ testing.TB, wallet *entity.Wallet) {
+	f.db.SeedWallets(wallet)
+}
+
+func (f contractFixture) CreateLinkedAccount(_ testing.TB, account *entity.LinkedAccount) {
+	f.db.SeedLinkedAccounts(account)
+}
+
+func TestTransactionRepo_Contract(t *testing.T) {
+	repotest.RunTransactionRepositoryContract(t, func(t *testing.T) repotest.TransactionBackend {
+		db := NewDB()
+		return repotest.TransactionBackend{Repo: NewTransactionRepo(db), Fixture: contractFixture{db: db}, Transactor: db}
+	})
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/usecase"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BacklogStatuses are the statuses of the transactions waiting for a payment or a decision
+var BacklogStatuses = []entity.TransactionStatus{
+	entity.TransactionStatusNew,
+	entity.TransactionStatusProcessing,
+	entity.TransactionStatusAwaitingApproval,
+	entity.TransactionStatusApproved,
+}
+
+var backlogDesc = prometheus.NewDesc(
+	"transactions_backlog",
+	"Transactions waiting for a payment or an approval by status.",
+	[]string{"status"}, nil,
+)
+
+// BacklogCollector counts the backlog when it is scraped, the query has timeout to answer
+type BacklogCollector struct {
+	repo    usecase.ITransactionRepository
+	timeout time.Duration
+}
+
+func NewBacklogCollector(repo usecase.ITransactionRepository, timeout time.Duration) *BacklogCollector {
+	return &BacklogCollector{repo: repo, timeout: timeout}
+}
+
+func (c *BacklogCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- backlogDesc
+}
+
+func (c *BacklogCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	counts, err := c.repo.CountTransactionsByStatus(ctx, BacklogStatuses)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(backlogDesc, err)
+		return
+	}
+	for _, status := range BacklogStatuses {
+		ch <- prometheus.MustNewConstMetric(backlogDesc, prometheus.GaugeValue, float64(counts[status]), string(status))
+	}
+}
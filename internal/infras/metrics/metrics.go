@@ -0,0 +1,64 @@
+// Package metrics decorates the use case dependencies to record Prometheus metrics, so the use cases stay
+// free of instrumentation
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// Metrics holds the collectors shared by the decorators, create it once per registry
+type Metrics struct {
+	pspDuration        *prometheus.HistogramVec
+	queryDuration      *prometheus.HistogramVec
+	transactions       *prometheus.CounterVec
+	transactionUpdates *prometheus.CounterVec
+	mongoCommands      *prometheus.HistogramVec
+}
+
+func New(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		pspDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "psp_request_duration_seconds",
+			Help:    "Duration of the payment service provider calls by operation and result.",
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"operation", "result"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "repository_query_duration_seconds",
+			Help:    "Duration of the repository calls by repository, method and result.",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"repository", "method", "result"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "transactions_created_total",
+			Help: "Transactions created by kind, initial status and currency.",
+		}, []string{"kind", "status", "currency"}),
+		transactionUpdates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "transaction_status_updates_total",
+			Help: "Transaction status changes by new status.",
+		}, []string{"status"}),
+		mongoCommands: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mongo_command_duration_seconds",
+			Help:    "Duration of the commands sent to Mongo by command name and result.",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"command", "result"}),
+	}
+	reg.MustRegister(m.pspDuration, m.queryDuration, m.transactions, m.transactionUpdates, m.mongoCommands)
+	return m
+}
+
+func result(err error) string {
+	if err != nil {
+		return resultError
+	}
+	return resultSuccess
+}
+
+func (m *Metrics) observeQuery(repository, method string, start time.Time, err error) {
+	m.queryDuration.WithLabelValues(repository, method, result(err)).Observe(time.Since(start).Seconds())
+}
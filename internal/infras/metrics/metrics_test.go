@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/usecase/mocks"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentServiceProvider(t *testing.T) {
+	// Arrange
+	m := New(prometheus.NewRegistry())
+	pspMock := mocks.NewIPaymentServiceProvider(t)
+	pspMock.EXPECT().Deposit(mock.Anything, 100.0, "VND", "note").Return(nil).Once()
+	pspMock.EXPECT().Withdraw(mock.Anything, 50.0, "VND", "note").Return(errors.New("timeout")).Once()
+	psp := NewPaymentServiceProvider(pspMock, m)
+
+	// Act
+	depositErr := psp.Deposit(context.Background(), 100, "VND", "note")
+	withdrawErr := psp.Withdraw(context.Background(), 50, "VND", "note")
+
+	// Assert
+	assert.NoError(t, depositErr)
+	assert.EqualError(t, withdrawErr, "timeout")
+	assert.Equal(t, uint64(1), histogramCount(t, m.pspDuration, "deposit", resultSuccess))
+	assert.Equal(t, uint64(1), histogramCount(t, m.pspDuration, "withdraw", resultError))
+}
+
+func TestTransactionRepo(t *testing.T) {
+	t.Run("saved transactions are counted by kind, status and currency", func(t *testing.T) {
+		// Arrange
+		m := New(prometheus.NewRegistry())
+		repoMock := mocks.NewITransactionRepository(t)
+		trans := &entity.Transaction{ID: "t1", TransactionKind: entity.TransactionOut, Status: entity.TransactionStatusNew,
+			Currency: "VND"}
+		repoMock.EXPECT().SaveTransaction(mock.Anything, trans).Return(nil).Once()
+		repoMock.EXPECT().SaveTransaction(mock.Anything, trans).Return(errors.New("duplicated")).Once()
+		repo := NewTransactionRepo(repoMock, m)
+
+		// Act
+		firstErr := repo.SaveTransaction(context.Background(), trans)
+		secondErr := repo.SaveTransaction(context.Background(), trans)
+
+		// Assert
+		assert.NoError(t, firstErr)
+		assert.Error(t, secondErr)
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.transactions.WithLabelValues("OUT", "NEW", "VND")))
+		assert.Equal(t, uint64(1), histogramCount(t, m.queryDuration, transactionRepository, "SaveTransaction", resultSuccess))
+		assert.Equal(t, uint64(1), histogramCount(t, m.queryDuration, transactionRepository, "SaveTransaction", resultError))
+	})
+
+	t.Run("status updates are counted, conflicts are not", func(t *testing.T) {
+		// Arrange
+		m := New(prometheus.NewRegistry())
+		repoMock := mocks.NewITransactionRepository(t)
+		repoMock.EXPECT().UpdateTransactionStatus(mock.Anything, "t1", entity.TransactionStatusSuccessful, int64(1)).
+			Return(nil).Once()
+		repoMock.EXPECT().UpdateTransactionStatus(mock.Anything, "t1", entity.TransactionStatusSuccessful, int64(1)).
+			Return(entity.NewVersionConflictError("transaction", "t1", 1)).Once()
+		repo := NewTransactionRepo(repoMock, m)
+
+		// Act
+		_ = repo.UpdateTransactionStatus(context.Background(), "t1", entity.TransactionStatusSuccessful, 1)
+		err := repo.UpdateTransactionStatus(context.Background(), "t1", entity.TransactionStatusSuccessful, 1)
+
+		// Assert
+		assert.ErrorIs(t, err, entity.ErrVersionConflict)
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.transactionUpdates.WithLabelValues("SUCCESSFUL")))
+	})
+
+	t.Run("reads are timed by method", func(t *testing.T) {
+		// Arrange
+		m := New(prometheus.NewRegistry())
+		repoMock := mocks.NewITransactionRepository(t)
+		repoMock.EXPECT().GetBalanceByWalletID(mock.Anything, "w1").Return(100, nil).Once()
+		repo := NewTransactionRepo(repoMock, m)
+
+		// Act
+		balance, err := repo.GetBalanceByWalletID(context.Background(), "w1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 100.0, balance)
+		assert.Equal(t, uint64(1), histogramCount(t, m.queryDuration, transactionRepository, "GetBalanceByWalletID", resultSuccess))
+	})
+}
+
+func TestBacklogCollector(t *testing.T) {
+	t.Run("gauge by status", func(t *testing.T) {
+		// Arrange
+		repoMock := mocks.NewITransactionRepository(t)
+		repoMock.EXPECT().CountTransactionsByStatus(mock.Anything, BacklogStatuses).
+			Return(map[entity.TransactionStatus]int64{
+				entity.TransactionStatusNew:              3,
+				entity.TransactionStatusProcessing:       2,
+				entity.TransactionStatusAwaitingApproval: 1,
+				entity.TransactionStatusApproved:         0,
+			}, nil).Once()
+
+		// Act
+		err := testutil.CollectAndCompare(NewBacklogCollector(repoMock, time.Second), strings.NewReader(`
+# HELP transactions_backlog Transactions waiting for a payment or an approval by status.
+# TYPE transactions_backlog gauge
+transactions_backlog{status="APPROVED"} 0
+transactions_backlog{status="AWAITING_APPROVAL"} 1
+transactions_backlog{status="NEW"} 3
+transactions_backlog{status="PROCESSING"} 2
+`))
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("a failed count fails the scrape", func(t *testing.T) {
+		// Arrange
+		repoMock := mocks.NewITransactionRepository(t)
+		repoMock.EXPECT().CountTransactionsByStatus(mock.Anything, BacklogStatuses).
+			Return(nil, errors.New("connection refused")).Once()
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(NewBacklogCollector(repoMock, time.Second))
+
+		// Act
+		_, err := reg.Gather()
+
+		// Assert
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
+
+func TestMetrics_ObserveCommand(t *testing.T) {
+	// Arrange
+	m := New(prometheus.NewRegistry())
+
+	// Act
+	m.ObserveCommand("find", 2*time.Millisecond, false)
+	m.ObserveCommand("insert", time.Millisecond, true)
+
+	// Assert
+	assert.Equal(t, uint64(1), histogramCount(t, m.mongoCommands, "find", resultSuccess))
+	assert.Equal(t, uint64(1), histogramCount(t, m.mongoCommands, "insert", resultError))
+}
+
+func histogramCount(t *testing.T, vec *prometheus.HistogramVec, labels ...string) uint64 {
+	t.Helper()
+
+	var metric dto.Metric
+	require.NoError(t, vec.WithLabelValues(labels...).(prometheus.Histogram).Write(&metric))
+	return metric.GetHistogram().GetSampleCount()
+}
@@ -0,0 +1,12 @@
+package metrics
+
+import "time"
+
+// ObserveCommand implements mongo.CommandMetrics, storage.WithCommandMetrics passes it to the driver
+func (m *Metrics) ObserveCommand(name string, duration time.Duration, failed bool) {
+	res := resultSuccess
+	if failed {
+		res = resultError
+	}
+	m.mongoCommands.WithLabelValues(name, res).Observe(duration.Seconds())
+}
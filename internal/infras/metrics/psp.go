@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/usecase"
+)
+
+// PaymentServiceProvider records the latency and the errors of the PSP calls
+type PaymentServiceProvider struct {
+	next    usecase.IPaymentServiceProvider
+	metrics *Metrics
+}
+
+func NewPaymentServiceProvider(next usecase.IPaymentServiceProvider, m *Metrics) *PaymentServiceProvider {
+	return &PaymentServiceProvider{next: next, metrics: m}
+}
+
+func (p *PaymentServiceProvider) Deposit(ctx context.Context, amount float64, currency string, note string) error {
+	start := time.Now()
+	err := p.next.Deposit(ctx, amount, currency, note)
+	p.observe("deposit", start, err)
+	return err
+}
+
+func (p *PaymentServiceProvider) Withdraw(ctx context.Context, amount float64, currency string, note string) error {
+	start := time.Now()
+	err := p.next.Withdraw(ctx, amount, currency, note)
+	p.observe("withdraw", start, err)
+	return err
+}
+
+func (p *PaymentServiceProvider) observe(operation string, start time.Time, err error) {
+	p.metrics.pspDuration.WithLabelValues(operation, result(err)).Observe(time.Since(start).Seconds())
+}
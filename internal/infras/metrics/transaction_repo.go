@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/usecase"
+)
+
+const transactionRepository = "transaction"
+
+// TransactionRepo records the latency of every call and counts the saved transactions and status changes
+type TransactionRepo struct {
+	next    usecase.ITransactionRepository
+	metrics *Metrics
+}
+
+func NewTransactionRepo(next usecase.ITransactionRepository, m *Metrics) *TransactionRepo {
+	return &TransactionRepo{next: next, metrics: m}
+}
+
+func (r *TransactionRepo) GetWalletByID(ctx context.Context, walletID string) (*entity.Wallet, error) {
+	start := time.Now()
+	wallet, err := r.next.GetWalletByID(ctx, walletID)
+	r.metrics.observeQuery(transactionRepository, "GetWalletByID", start, err)
+	return wallet, err
+}
+
+func (r *TransactionRepo) LockWallet(ctx context.Context, walletID string) (*entity.Wallet, error) {
+	start := time.Now()
+	wallet, err := r.next.LockWallet(ctx, walletID)
+	r.metrics.observeQuery(transactionRepository, "LockWallet", start, err)
+	return wallet, err
+}
+
+func (r *TransactionRepo) SaveTransaction(ctx context.Context, trans *entity.Transaction) error {
+	start := time.Now()
+	err := r.next.SaveTransaction(ctx, trans)
+	r.metrics.observeQuery(transactionRepository, "SaveTransaction", start, err)
+	if err == nil {
+		r.metrics.transactions.WithLabelValues(string(trans.TransactionKind), string(trans.Status), trans.Currency).Inc()
+	}
+	return err
+}
+
+func (r *TransactionRepo) GetLinkedAccountByID(ctx context.Context, accountID string) (*entity.LinkedAccount, error) {
+	start := time.Now()
+	account, err := r.next.GetLinkedAccountByID(ctx, accountID)
+	r.metrics.observeQuery(transactionRepository, "GetLinkedAccountByID", start, err)
+	return account, err
+}
+
+func (r *TransactionRepo) GetBalanceByWalletID(ctx context.Context, walletID string) (float64, error) {
+	start := time.Now()
+	balance, err := r.next.GetBalanceByWalletID(ctx, walletID)
+	r.metrics.observeQuery(transactionRepository, "GetBalanceByWalletID", start, err)
+	return balance, err
+}
+
+func (r *TransactionRepo) GetPendingWithdrawals(ctx context.Context, walletID string) (float64, error) {
+	start := time.Now()
+	pending, err := r.next.GetPendingWithdrawals(ctx, walletID)
+	r.metrics.observeQuery(transactionRepository, "GetPendingWithdrawals", start, err)
+	return pending, err
+}
+
+func (r *TransactionRepo) GetTransactionByID(ctx context.Context, transID string) (*entity.Transaction, error) {
+	start := time.Now()
+	trans, err := r.next.GetTransactionByID(ctx, transID)
+	r.metrics.observeQuery(transactionRepository, "GetTransactionByID", start, err)
+	return trans, err
+}
+
+func (r *TransactionRepo) UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus,
+	version int64) error {
+	start := time.Now()
+	err := r.next.UpdateTransactionStatus(ctx, transID, status, version)
+	r.metrics.observeQuery(transactionRepository, "UpdateTransactionStatus", start, err)
+	if err == nil {
+		r.metrics.transactionUpdates.WithLabelValues(string(status)).Inc()
+	}
+	return err
+}
+
+func (r *TransactionRepo) CountTransactionsByStatus(ctx context.Context,
+	statuses []entity.TransactionStatus) (map[entity.TransactionStatus]int64, error) {
+	start := time.Now()
+	counts, err := r.next.CountTransactionsByStatus(ctx, statuses)
+	r.metrics.observeQuery(transactionRepository, "CountTransactionsByStatus", start, err)
+	return counts, err
+}
@@ -0,0 +1,91 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	schema2 "go-clean-template/internal/infras/mongo/schema"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const ApprovalCollection = "transaction_approvals"
+
+type ApprovalRepo struct {
+	db *mongo.Database
+}
+
+func NewApprovalRepo(db *mongo.Database) *ApprovalRepo {
+	return &ApprovalRepo{db: db}
+}
+
+func (r *ApprovalRepo) SaveApproval(ctx context.Context, approval *entity.Approval) error {
+	approvalSchema := schema2.ToApprovalSchema(approval)
+	approvalSchema.CreatedAt = time.Now()
+	approvalSchema.UpdatedAt = approvalSchema.CreatedAt
+
+	_, err := r.db.Collection(ApprovalCollection).InsertOne(ctx, approvalSchema)
+
+	return err
+}
+
+func (r *ApprovalRepo) GetApprovalByTransactionID(ctx context.Context, transID string) (*entity.Approval, error) {
+	var approvalSchema schema2.ApprovalSchema
+	if err := r.db.Collection(ApprovalCollection).FindOne(ctx, bson.M{"transaction_id": transID}).
+		Decode(&approvalSchema); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return approvalSchema.ToApproval(), nil
+}
+
+func (r *ApprovalRepo) UpdateApproval(ctx context.Context, approval *entity.Approval) error {
+	update := bson.M{"$set": bson.M{
+		"decided_by": approval.DecidedBy,
+		"status":     string(approval.Status),
+		"reason":     approval.Reason,
+		"decided_at": approval.DecidedAt,
+		"updated_at": time.Now(),
+	}}
+	filter := bson.M{"_id": approval.ID, "status": string(entity.ApprovalStatusPending)}
+	result, err := r.db.Collection(ApprovalCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount != 1 {
+		return entity.ErrApprovalDecided
+	}
+	return nil
+}
+
+func (r *ApprovalRepo) ListExpiredApprovals(ctx context.Context, now time.Time, limit int) ([]*entity.Approval, error) {
+	filter := bson.M{
+		"status":     string(entity.ApprovalStatusPending),
+		"expires_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetSort(bson.M{"expires_at": 1}).SetLimit(int64(limit))
+	cursor, err := r.db.Collection(ApprovalCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	approvals := make([]*entity.Approval, 0)
+	for cursor.Next(ctx) {
+		var approvalSchema schema2.ApprovalSchema
+		if err := cursor.Decode(&approvalSchema); err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, approvalSchema.ToApproval())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return approvals, nil
+}
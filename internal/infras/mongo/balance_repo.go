@@ -0,0 +1,131 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	schema2 "go-clean-template/internal/infras/mongo/schema"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const BalanceSnapshotCollection = "balance_snapshots"
+
+type BalanceRepo struct {
+	db *mongo.Database
+}
+
+func NewBalanceRepo(db *mongo.Database) *BalanceRepo {
+	return &BalanceRepo{db: db}
+}
+
+func (r *BalanceRepo) GetLatestSnapshot(ctx context.Context, walletID string, asOf time.Time) (*entity.BalanceSnapshot, error) {
+	var snapshotSchema schema2.BalanceSnapshotSchema
+	filter := bson.M{
+		"wallet_id": walletID,
+		"as_of":     bson.M{"$lte": asOf},
+	}
+	opts := options.FindOne().SetSort(bson.M{"as_of": -1})
+	if err := r.db.Collection(BalanceSnapshotCollection).FindOne(ctx, filter, opts).Decode(&snapshotSchema); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return snapshotSchema.ToBalanceSnapshot(), nil
+}
+
+func (r *BalanceRepo) SaveSnapshot(ctx context.Context, snapshot *entity.BalanceSnapshot) error {
+	now := time.Now()
+	filter := bson.M{
+		"wallet_id": snapshot.WalletID,
+		"as_of":     snapshot.AsOf,
+	}
+	update := bson.M{
+		"$set":         bson.M{"balance": snapshot.Balance, "updated_at": now},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+	_, err := r.db.Collection(BalanceSnapshotCollection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *BalanceRepo) SumTransactions(ctx context.Context, walletID string, from time.Time, to time.Time) (float64, error) {
+	settledAt := bson.M{}
+	if !from.IsZero() {
+		settledAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		settledAt["$lt"] = to
+	}
+
+	match := bson.M{
+		"wallet_id": walletID,
+		"status":    string(entity.TransactionStatusSuccessful),
+	}
+	if len(settledAt) > 0 {
+		match["settled_at"] = settledAt
+	}
+	return sumTransactions(ctx, r.db, match)
+}
+
+func (r *BalanceRepo) ListWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	filter := bson.M{}
+	if afterID != "" {
+		filter["_id"] = bson.M{"$gt": afterID}
+	}
+	opts := options.Find().SetSort(bson.M{"_id": 1}).SetLimit(int64(limit)).SetProjection(bson.M{"_id": 1})
+
+	cursor, err := r.db.Collection(WalletCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var walletIDs []string
+	for cursor.Next(ctx) {
+		var walletSchema schema2.WalletSchema
+		if err := cursor.Decode(&walletSchema); err != nil {
+			return nil, err
+		}
+		walletIDs = append(walletIDs, walletSchema.ID)
+	}
+	return walletIDs, cursor.Err()
+}
+
+// sumTransactions sums the signed amounts of the transactions matching the filter
+func sumTransactions(ctx context.Context, db *mongo.Database, match bson.M) (float64, error) {
+	var result struct {
+		Balance float64 `bson:"balance"`
+	}
+
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: match}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id": nil,
+			"balance": bson.M{"$sum": bson.M{"$cond": bson.M{
+				"if":   bson.M{"$eq": bson.A{"$transaction_kind", string(entity.TransactionIn)}},
+				"then": "$amount",
+				"else": bson.M{"$multiply": bson.A{-1, "$amount"}},
+			}}},
+		}}},
+	}
+
+	cursor, err := db.Collection(TransactionsCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return 0, err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, err
+	}
+	return result.Balance, nil
+}
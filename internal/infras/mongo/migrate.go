@@ -0,0 +1,155 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigrationCollection records the applied migrations, one document per migration id
+const MigrationCollection = "schema_migrations"
+
+// Migration is a versioned change of the database. Ids sort in the order the migrations are applied,
+// they follow the timestamp names of the sql migrations. Up and Down must be idempotent:
+// a migration interrupted before it is recorded runs again.
+type Migration struct {
+	ID   string
+	Up   func(ctx context.Context, db *mongo.Database) error
+	Down func(ctx context.Context, db *mongo.Database) error
+}
+
+type MigrationRecord struct {
+	ID        string    `bson:"_id"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// MigrationStatus is a known migration, with the time it was applied or nil if it is pending
+type MigrationStatus struct {
+	ID        string
+	AppliedAt *time.Time
+}
+
+type MigrationDirection int
+
+const (
+	MigrateUp MigrationDirection = iota
+	MigrateDown
+)
+
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+func NewMigrator(db *mongo.Database, migrations []Migration) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return &Migrator{db: db, migrations: sorted}
+}
+
+// Plan lists the migrations Up or Down would run, in the order they would run.
+// A max of 0 means all pending migrations up, or all applied migrations down.
+func (m *Migrator) Plan(ctx context.Context, dir MigrationDirection, max int) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var planned []Migration
+	if dir == MigrateUp {
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.ID]; !ok {
+				planned = append(planned, migration)
+			}
+		}
+	} else {
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := applied[m.migrations[i].ID]; ok {
+				planned = append(planned, m.migrations[i])
+			}
+		}
+	}
+
+	if max > 0 && max < len(planned) {
+		planned = planned[:max]
+	}
+	return planned, nil
+}
+
+// Up applies up to max pending migrations in order, 0 applies them all. It returns how many were applied.
+func (m *Migrator) Up(ctx context.Context, max int) (int, error) {
+	planned, err := m.Plan(ctx, MigrateUp, max)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, migration := range planned {
+		if err := migration.Up(ctx, m.db); err != nil {
+			return i, fmt.Errorf("failed to apply migration %s: %w", migration.ID, err)
+		}
+		record := MigrationRecord{ID: migration.ID, AppliedAt: time.Now()}
+		if _, err := m.db.Collection(MigrationCollection).InsertOne(ctx, record); err != nil {
+			return i, fmt.Errorf("failed to record migration %s: %w", migration.ID, err)
+		}
+	}
+	return len(planned), nil
+}
+
+// Down reverts up to max applied migrations, latest first, 0 reverts them all. It returns how many were reverted.
+func (m *Migrator) Down(ctx context.Context, max int) (int, error) {
+	planned, err := m.Plan(ctx, MigrateDown, max)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, migration := range planned {
+		if err := migration.Down(ctx, m.db); err != nil {
+			return i, fmt.Errorf("failed to revert migration %s: %w", migration.ID, err)
+		}
+		if _, err := m.db.Collection(MigrationCollection).DeleteOne(ctx, bson.M{"_id": migration.ID}); err != nil {
+			return i, fmt.Errorf("failed to unrecord migration %s: %w", migration.ID, err)
+		}
+	}
+	return len(planned), nil
+}
+
+// Status lists every known migration in order with the time it was applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{ID: migration.ID}
+		if record, ok := applied[migration.ID]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[string]MigrationRecord, error) {
+	cursor, err := m.db.Collection(MigrationCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var records []MigrationRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]MigrationRecord, len(records))
+	for _, record := range records {
+		applied[record.ID] = record
+	}
+	return applied, nil
+}
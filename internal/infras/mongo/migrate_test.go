@@ -0,0 +1,104 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	schema2 "go-clean-template/internal/infras/mongo/schema"
+	"go-clean-template/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestMigrations(t *testing.T) {
+	seen := map[string]bool{}
+	for i, migration := range Migrations {
+		assert.False(t, seen[migration.ID], "duplicated migration %s", migration.ID)
+		seen[migration.ID] = true
+		assert.NotNil(t, migration.Up, migration.ID)
+		assert.NotNil(t, migration.Down, migration.ID)
+		if i > 0 {
+			assert.Less(t, Migrations[i-1].ID, migration.ID, "migrations must be listed in order")
+		}
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	db := testutil.CreateMongoDatabase(t, "test1")
+	ctx := context.Background()
+	migrator := NewMigrator(db, Migrations)
+
+	t.Run("up applies pending migrations once", func(t *testing.T) {
+		//Act
+		first, err1 := migrator.Up(ctx, 0)
+		second, err2 := migrator.Up(ctx, 0)
+
+		//Assert
+		assert.NoError(t, err1)
+		assert.Equal(t, len(Migrations), first)
+		assert.NoError(t, err2)
+		assert.Equal(t, 0, second)
+		statuses, err := migrator.Status(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, statuses, len(Migrations))
+		for _, status := range statuses {
+			assert.NotNil(t, status.AppliedAt, status.ID)
+		}
+	})
+
+	t.Run("validator rejects invalid transaction", func(t *testing.T) {
+		//Arrange
+		trans := schema2.ToTransactionSchema(entity.NewTransaction("t_001", "w_001", "a_001", 1000, "VND",
+			entity.TransactionIn, "", "UNKNOWN"))
+		trans.CreatedAt = time.Now()
+
+		//Act
+		_, err := db.Collection(TransactionsCollection).InsertOne(ctx, trans)
+
+		//Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("unique index rejects a second approval of a transaction", func(t *testing.T) {
+		//Arrange
+		expiresAt := time.Now().Add(time.Hour)
+		first := schema2.ToApprovalSchema(entity.NewApproval("ap_001", "t_001", "u_001", expiresAt))
+		second := schema2.ToApprovalSchema(entity.NewApproval("ap_002", "t_001", "u_001", expiresAt))
+		_, err := db.Collection(ApprovalCollection).InsertOne(ctx, first)
+		require.NoError(t, err)
+
+		//Act
+		_, err = db.Collection(ApprovalCollection).InsertOne(ctx, second)
+
+		//Assert
+		assert.True(t, mongo.IsDuplicateKeyError(err))
+	})
+
+	t.Run("down reverts the latest migration", func(t *testing.T) {
+		//Act
+		reverted, err := migrator.Down(ctx, 1)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reverted)
+		cursor, err := db.Collection(ApprovalCollection).Indexes().List(ctx)
+		assert.NoError(t, err)
+		var indexes []bson.M
+		assert.NoError(t, cursor.All(ctx, &indexes))
+		assert.Len(t, indexes, 1, "only the _id index is left")
+
+		planned, err := migrator.Plan(ctx, MigrateUp, 0)
+		assert.NoError(t, err)
+		assert.Len(t, planned, 1)
+		assert.Equal(t, Migrations[len(Migrations)-1].ID, planned[0].ID)
+
+		applied, err := migrator.Up(ctx, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, applied)
+	})
+}
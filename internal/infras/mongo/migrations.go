@@ -0,0 +1,332 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/mongo/schema"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migrations are the migrations of the mongo database, applied by cmd/migrate --driver=mongo
+var Migrations = []Migration{
+	{
+		ID:   "20261019130000-Add-collection-validators",
+		Up:   addValidators,
+		Down: removeValidators,
+	},
+	{
+		ID:   "20261019130100-Add-indexes",
+		Up:   addIndexes,
+		Down: dropIndexes,
+	},
+	{
+		ID:   "20261019140000-Add-versions",
+		Up:   addVersions,
+		Down: removeVersions,
+	},
+	{
+		ID:   "20261019150000-Add-payout-batch-claims",
+		Up:   addPayoutBatchClaims,
+		Down: removePayoutBatchClaims,
+	},
+	{
+		ID:   "20261019160000-Add-settled-at",
+		Up:   addSettledAt,
+		Down: removeSettledAt,
+	},
+	{
+		ID:   "20261019170000-Add-schedule-run-claims",
+		Up:   addScheduleRunClaims,
+		Down: removeScheduleRunClaims,
+	},
+	{
+		ID:   "20261019180000-Add-schedule-versions",
+		Up:   addScheduleVersions,
+		Down: removeScheduleVersions,
+	},
+	{
+		ID:   "20261019190000-Add-approval-expiry-index",
+		Up:   addApprovalExpiryIndex,
+		Down: removeApprovalExpiryIndex,
+	},
+	{
+		ID:   "20261019200000-Add-processing-status",
+		Up:   addProcessingStatus,
+		Down: removeProcessingStatus,
+	},
+}
+
+// versioned are the collections updated with optimistic concurrency
+var versioned = []string{WalletCollection, TransactionsCollection}
+
+var validators = map[string]func() bson.M{
+	WalletCollection:          schema.WalletValidator,
+	LinkedAccountCollection:   schema.LinkedAccountValidator,
+	TransactionsCollection:    schema.TransactionValidator,
+	ApprovalCollection:        schema.ApprovalValidator,
+	ScheduleCollection:        schema.ScheduleValidator,
+	ScheduleRunCollection:     schema.ScheduleRunValidator,
+	PayoutBatchCollection:     schema.PayoutBatchValidator,
+	PayoutItemCollection:      schema.PayoutItemValidator,
+	BalanceSnapshotCollection: schema.BalanceSnapshotValidator,
+}
+
+// indexes follow the queries of the repositories. The unique ones are the idempotency keys:
+// one approval per transaction, one run per schedule occurrence, one item per batch line and one snapshot per wallet and day.
+var indexes = map[string][]mongo.IndexModel{
+	TransactionsCollection: {
+		{Keys: bson.D{{Key: "wallet_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("idx_trans_wallet_status_created_at")},
+	},
+	ApprovalCollection: {
+		{Keys: bson.D{{Key: "transaction_id", Value: 1}},
+			Options: options.Index().SetName("idx_approval_transaction_id").SetUnique(true)},
+	},
+	ScheduleCollection: {
+		{Keys: bson.D{{Key: "wallet_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("idx_schedule_wallet_created_at")},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_run_at", Value: 1}},
+			Options: options.Index().SetName("idx_schedule_due")},
+	},
+	ScheduleRunCollection: {
+		{Keys: bson.D{{Key: "schedule_id", Value: 1}, {Key: "occurrence_at", Value: 1}},
+			Options: options.Index().SetName("idx_schedule_run_occurrence").SetUnique(true)},
+	},
+	PayoutItemCollection: {
+		{Keys: bson.D{{Key: "batch_id", Value: 1}, {Key: "line", Value: 1}},
+			Options: options.Index().SetName("idx_payout_item_line").SetUnique(true)},
+	},
+	BalanceSnapshotCollection: {
+		{Keys: bson.D{{Key: "wallet_id", Value: 1}, {Key: "as_of", Value: -1}},
+			Options: options.Index().SetName("idx_balance_snapshot_wallet_as_of").SetUnique(true)},
+	},
+}
+
+// addValidators creates the missing collections and sets their validators.
+// Documents written before the validators are only checked when they are updated.
+func addValidators(ctx context.Context, db *mongo.Database) error {
+	for collection, validator := range validators {
+		if err := setValidator(ctx, db, collection, validator()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setValidator(ctx context.Context, db *mongo.Database, collection string, validator bson.M) error {
+	if err := ensureCollection(ctx, db, collection); err != nil {
+		return err
+	}
+	return db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}).Err()
+}
+
+// removeValidators keeps the collections and their documents, only the validation is removed
+func removeValidators(ctx context.Context, db *mongo.Database) error {
+	for collection := range validators {
+		if err := ensureCollection(ctx, db, collection); err != nil {
+			return err
+		}
+		if err := db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: collection},
+			{Key: "validator", Value: bson.M{}},
+			{Key: "validationLevel", Value: "off"},
+		}).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addVersions starts the existing wallets and transactions at the initial version and refreshes their validators,
+// which check the type of the version
+func addVersions(ctx context.Context, db *mongo.Database) error {
+	for _, collection := range versioned {
+		if err := startVersions(ctx, db, collection); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeVersions(ctx context.Context, db *mongo.Database) error {
+	for _, collection := range versioned {
+		if err := dropVersions(ctx, db, collection); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addScheduleVersions does for the schedules what addVersions did for the wallets and transactions
+func addScheduleVersions(ctx context.Context, db *mongo.Database) error {
+	return startVersions(ctx, db, ScheduleCollection)
+}
+
+func removeScheduleVersions(ctx context.Context, db *mongo.Database) error {
+	return dropVersions(ctx, db, ScheduleCollection)
+}
+
+func startVersions(ctx context.Context, db *mongo.Database, collection string) error {
+	if err := setValidator(ctx, db, collection, validators[collection]()); err != nil {
+		return err
+	}
+	_, err := db.Collection(collection).UpdateMany(ctx,
+		bson.M{"version": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"version": entity.InitialVersion}})
+	return err
+}
+
+func dropVersions(ctx context.Context, db *mongo.Database, collection string) error {
+	_, err := db.Collection(collection).UpdateMany(ctx,
+		bson.M{"version": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"version": ""}})
+	return err
+}
+
+// payoutBatchClaimIndex serves ListClaimablePayoutBatchIDs
+var payoutBatchClaimIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: "status", Value: 1}, {Key: "claimed_until", Value: 1}},
+	Options: options.Index().SetName("idx_payout_batch_claimable"),
+}
+
+// addPayoutBatchClaims refreshes the validator of the batches, which checks the type of claimed_until
+func addPayoutBatchClaims(ctx context.Context, db *mongo.Database) error {
+	if err := setValidator(ctx, db, PayoutBatchCollection, validators[PayoutBatchCollection]()); err != nil {
+		return err
+	}
+	_, err := db.Collection(PayoutBatchCollection).Indexes().CreateOne(ctx, payoutBatchClaimIndex)
+	return err
+}
+
+func removePayoutBatchClaims(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(PayoutBatchCollection).Indexes().DropOne(ctx, *payoutBatchClaimIndex.Options.Name)
+	if err != nil && !isNamespaceOrIndexNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// settledAtIndex serves the balance snapshots and the statements, cut on the settlement time
+var settledAtIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: "wallet_id", Value: 1}, {Key: "status", Value: 1}, {Key: "settled_at", Value: 1}},
+	Options: options.Index().SetName("idx_trans_wallet_status_settled_at"),
+}
+
+// addSettledAt refreshes the validator of the transactions and settles the successful ones at their creation,
+// which is where the existing snapshots were cut
+func addSettledAt(ctx context.Context, db *mongo.Database) error {
+	if err := setValidator(ctx, db, TransactionsCollection, validators[TransactionsCollection]()); err != nil {
+		return err
+	}
+	if _, err := db.Collection(TransactionsCollection).UpdateMany(ctx,
+		bson.M{"status": string(entity.TransactionStatusSuccessful), "settled_at": bson.M{"$exists": false}},
+		bson.A{bson.M{"$set": bson.M{"settled_at": "$created_at"}}}); err != nil {
+		return err
+	}
+	_, err := db.Collection(TransactionsCollection).Indexes().CreateOne(ctx, settledAtIndex)
+	return err
+}
+
+func removeSettledAt(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(TransactionsCollection).Indexes().DropOne(ctx, *settledAtIndex.Options.Name)
+	if err != nil && !isNamespaceOrIndexNotFound(err) {
+		return err
+	}
+	_, err = db.Collection(TransactionsCollection).UpdateMany(ctx,
+		bson.M{"settled_at": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"settled_at": ""}})
+	return err
+}
+
+// addScheduleRunClaims refreshes the validator of the schedule runs, which checks the type of claimed_until
+func addScheduleRunClaims(ctx context.Context, db *mongo.Database) error {
+	return setValidator(ctx, db, ScheduleRunCollection, validators[ScheduleRunCollection]())
+}
+
+func removeScheduleRunClaims(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(ScheduleRunCollection).UpdateMany(ctx,
+		bson.M{"claimed_until": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"claimed_until": ""}})
+	return err
+}
+
+// approvalExpiryIndex serves ListExpiredApprovals
+var approvalExpiryIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}},
+	Options: options.Index().SetName("idx_approval_expired"),
+}
+
+func addApprovalExpiryIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(ApprovalCollection).Indexes().CreateOne(ctx, approvalExpiryIndex)
+	return err
+}
+
+func removeApprovalExpiryIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(ApprovalCollection).Indexes().DropOne(ctx, *approvalExpiryIndex.Options.Name)
+	if err != nil && !isNamespaceOrIndexNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// addProcessingStatus refreshes the validator of the transactions, which accepts the PROCESSING status
+func addProcessingStatus(ctx context.Context, db *mongo.Database) error {
+	return setValidator(ctx, db, TransactionsCollection, validators[TransactionsCollection]())
+}
+
+// removeProcessingStatus keeps the validator and the transactions left PROCESSING, their outcome is only known once
+// they are reconciled with the payment service provider
+func removeProcessingStatus(context.Context, *mongo.Database) error {
+	return nil
+}
+
+func addIndexes(ctx context.Context, db *mongo.Database) error {
+	for collection, models := range indexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropIndexes(ctx context.Context, db *mongo.Database) error {
+	for collection, models := range indexes {
+		for _, model := range models {
+			_, err := db.Collection(collection).Indexes().DropOne(ctx, *model.Options.Name)
+			if err != nil && !isNamespaceOrIndexNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func ensureCollection(ctx context.Context, db *mongo.Database, collection string) error {
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": collection})
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		return nil
+	}
+	return db.CreateCollection(ctx, collection)
+}
+
+// isNamespaceOrIndexNotFound reports whether the index or its collection is already gone
+func isNamespaceOrIndexNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) {
+		return false
+	}
+	return cmdErr.Code == 26 || cmdErr.Code == 27
+}
@@ -2,63 +2,224 @@ package mongo
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"time"
 
 	"go-clean-template/pkg/config"
 
-	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// connectTimeout bounds the first connection and ping when Options has no ConnectTimeout
+const connectTimeout = 10 * time.Second
+
 type Options struct {
+	// URI is a full connection string, Host and Port are only used without it. The other options
+	// override the ones of the URI when they are set.
+	URI    string
 	DBName string
 	Host   string
 	Port   string
 	User   string
 	Pass   string
+
+	AuthSource string
+	ReplicaSet string
+	// ReadPreference is primary, primaryPreferred, secondary, secondaryPreferred or nearest
+	ReadPreference string
+	// WriteConcern is majority, a number of nodes or a tag set name
+	WriteConcern string
+	TLS          TLSOptions
+
+	MaxPoolSize            uint64
+	MinPoolSize            uint64
+	MaxConnIdleTime        time.Duration
+	ConnectTimeout         time.Duration
+	ServerSelectionTimeout time.Duration
+
+	Log LogOptions
+	// Metrics records every command, nil records nothing
+	Metrics CommandMetrics
+	// Tracing records a span per command, without the command itself. Nil traces nothing.
+	Tracing trace.TracerProvider
+}
+
+type TLSOptions struct {
+	Enabled bool
+	// CAFile verifies the server, the system pool is used without it
+	CAFile string
+	// CertKeyFile is a PEM file holding the client certificate and its key
+	CertKeyFile string
 }
 
 func ParseFromConfig(c *config.Config) Options {
 	return Options{
-		DBName: c.MongoDB.DBName,
-		Host:   c.MongoDB.Host,
-		Port:   strconv.Itoa(c.MongoDB.Port),
-		User:   c.MongoDB.User,
-		Pass:   c.MongoDB.Pass,
+		URI:            c.MongoDB.URI,
+		DBName:         c.MongoDB.DBName,
+		Host:           c.MongoDB.Host,
+		Port:           strconv.Itoa(c.MongoDB.Port),
+		User:           c.MongoDB.User,
+		Pass:           c.MongoDB.Pass,
+		AuthSource:     c.MongoDB.AuthSource,
+		ReplicaSet:     c.MongoDB.ReplicaSet,
+		ReadPreference: c.MongoDB.ReadPreference,
+		WriteConcern:   c.MongoDB.WriteConcern,
+		TLS: TLSOptions{
+			Enabled:     c.MongoDB.TLS,
+			CAFile:      c.MongoDB.TLSCAFile,
+			CertKeyFile: c.MongoDB.TLSCertKeyFile,
+		},
+		MaxPoolSize:            c.MongoDB.MaxPoolSize,
+		MinPoolSize:            c.MongoDB.MinPoolSize,
+		MaxConnIdleTime:        c.MongoDB.MaxConnIdleTime,
+		ConnectTimeout:         c.MongoDB.ConnectTimeout,
+		ServerSelectionTimeout: c.MongoDB.ServerSelectionTimeout,
+		Log: LogOptions{
+			Level:         c.MongoDB.LogLevel,
+			SlowThreshold: c.MongoDB.SlowThreshold,
+		},
 	}
 }
 
+// NewDB connects and pings the server. The caller disconnects db.Client() when it is done.
 func NewDB(opt Options) (*mongo.Database, error) {
-	uri := fmt.Sprintf("mongodb://%v:%v", opt.Host, opt.Port)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	monitor := &event.CommandMonitor{
-		Started: func(_ context.Context, e *event.CommandStartedEvent) {
-			fmt.Println(e.Command)
-		},
-		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
-			fmt.Println(e.Reply)
-		},
-		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
-			fmt.Println(e.Failure)
-		},
+	clientOpts, err := opt.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := opt.ConnectTimeout
+	if timeout <= 0 {
+		timeout = connectTimeout
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetAuth(options.Credential{
-		Username: opt.User,
-		Password: opt.Pass,
-	}).SetMonitor(monitor))
+	client, err := mongo.Connect(ctx, clientOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.Ping(ctx, nil)
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, errors.Join(err, client.Disconnect(context.Background()))
+	}
+
+	return client.Database(opt.DBName), nil
+}
+
+func (o Options) clientOptions() (*options.ClientOptions, error) {
+	uri := o.URI
+	if uri == "" {
+		uri = fmt.Sprintf("mongodb://%v:%v", o.Host, o.Port)
+	}
+	opts := options.Client().ApplyURI(uri)
+
+	if o.User != "" {
+		opts.SetAuth(options.Credential{Username: o.User, Password: o.Pass, AuthSource: o.AuthSource})
+	} else if o.AuthSource != "" && opts.Auth != nil {
+		opts.Auth.AuthSource = o.AuthSource
+	}
+	if o.ReplicaSet != "" {
+		opts.SetReplicaSet(o.ReplicaSet)
+	}
+	if o.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(o.ReadPreference)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read preference: %w", err)
+		}
+		rp, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read preference: %w", err)
+		}
+		opts.SetReadPreference(rp)
+	}
+	if o.WriteConcern != "" {
+		opts.SetWriteConcern(parseWriteConcern(o.WriteConcern))
+	}
+	if o.TLS.Enabled || o.TLS.CAFile != "" || o.TLS.CertKeyFile != "" {
+		tlsConfig, err := o.TLS.config()
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	if o.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(o.MaxPoolSize)
+	}
+	if o.MinPoolSize > 0 {
+		opts.SetMinPoolSize(o.MinPoolSize)
+	}
+	if o.MaxConnIdleTime > 0 {
+		opts.SetMaxConnIdleTime(o.MaxConnIdleTime)
+	}
+	if o.ConnectTimeout > 0 {
+		opts.SetConnectTimeout(o.ConnectTimeout)
+	}
+	if o.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(o.ServerSelectionTimeout)
+	}
+
+	monitor, err := newCommandMonitor(o.Log, o.Metrics)
 	if err != nil {
 		return nil, err
 	}
+	if o.Tracing != nil {
+		monitor = chainMonitors(otelmongo.NewMonitor(otelmongo.WithTracerProvider(o.Tracing)), monitor)
+	}
+	opts.SetMonitor(monitor)
 
-	return client.Database(opt.DBName), nil
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func parseWriteConcern(w string) *writeconcern.WriteConcern {
+	if w == "majority" {
+		return writeconcern.Majority()
+	}
+	if n, err := strconv.Atoi(w); err == nil {
+		return &writeconcern.WriteConcern{W: n}
+	}
+	return writeconcern.Custom(w)
+}
+
+func (o TLSOptions) config() (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS CA file: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate in the TLS CA file %s", o.CAFile)
+		}
+	}
+
+	if o.CertKeyFile != "" {
+		pem, err := os.ReadFile(o.CertKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS certificate key file: %w", err)
+		}
+		cert, err := tls.X509KeyPair(pem, pem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS certificate key file: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
 }
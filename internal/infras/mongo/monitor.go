@@ -0,0 +1,238 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/event"
+	"go.uber.org/zap"
+)
+
+// maxLoggedCommand truncates the redacted commands, a bulk write lists every document
+const maxLoggedCommand = 1024
+
+// redactedValue replaces the values of the logged commands
+const redactedValue = "?"
+
+// LogOptions bridges the command monitoring to zap
+type LogOptions struct {
+	// Logger is the zap logger, the commands are not logged when nil
+	Logger *zap.SugaredLogger
+	// Level is silent, error, warn or info. Errors are failed commands, warnings slow ones and
+	// info every command.
+	Level         string
+	SlowThreshold time.Duration
+}
+
+// CommandMetrics records the commands sent to the server
+type CommandMetrics interface {
+	ObserveCommand(name string, duration time.Duration, failed bool)
+}
+
+const (
+	levelSilent = iota
+	levelError
+	levelWarn
+	levelInfo
+)
+
+var logLevels = map[string]int{
+	"silent": levelSilent,
+	"error":  levelError,
+	"warn":   levelWarn,
+	"info":   levelInfo,
+}
+
+// commandMonitor logs the commands with their values redacted. The driver reports the command when it
+// starts and its outcome when it ends, the redacted command is kept in between under the request id.
+type commandMonitor struct {
+	log           *zap.SugaredLogger
+	level         int
+	slowThreshold time.Duration
+	metrics       CommandMetrics
+	started       sync.Map
+}
+
+func newCommandMonitor(opt LogOptions, metrics CommandMetrics) (*event.CommandMonitor, error) {
+	level := levelWarn
+	if opt.Level != "" {
+		var ok bool
+		if level, ok = logLevels[opt.Level]; !ok {
+			return nil, fmt.Errorf("unknown log level %q, must be silent, error, warn or info", opt.Level)
+		}
+	}
+	if opt.Logger == nil {
+		level = levelSilent
+	}
+
+	m := &commandMonitor{log: opt.Logger, level: level, slowThreshold: opt.SlowThreshold, metrics: metrics}
+	return &event.CommandMonitor{
+		Started:   m.commandStarted,
+		Succeeded: m.commandSucceeded,
+		Failed:    m.commandFailed,
+	}, nil
+}
+
+func (m *commandMonitor) commandStarted(_ context.Context, e *event.CommandStartedEvent) {
+	if m.level > levelSilent {
+		m.started.Store(e.RequestID, redact(e.Command))
+	}
+}
+
+func (m *commandMonitor) commandSucceeded(_ context.Context, e *event.CommandSucceededEvent) {
+	m.observe(e.CommandFinishedEvent, false)
+
+	command := m.finished(e.RequestID)
+	switch {
+	case m.slowThreshold > 0 && e.Duration > m.slowThreshold && m.level >= levelWarn:
+		m.log.Warnw("slow mongo command", append(m.fields(e.CommandFinishedEvent, command),
+			zap.Duration("threshold", m.slowThreshold))...)
+	case m.level >= levelInfo:
+		m.log.Infow("mongo command", m.fields(e.CommandFinishedEvent, command)...)
+	}
+}
+
+func (m *commandMonitor) commandFailed(_ context.Context, e *event.CommandFailedEvent) {
+	m.observe(e.CommandFinishedEvent, true)
+
+	command := m.finished(e.RequestID)
+	if m.level >= levelError {
+		m.log.Errorw("mongo command failed", append(m.fields(e.CommandFinishedEvent, command),
+			zap.String("error", e.Failure))...)
+	}
+}
+
+func (m *commandMonitor) observe(e event.CommandFinishedEvent, failed bool) {
+	if m.metrics != nil {
+		m.metrics.ObserveCommand(e.CommandName, e.Duration, failed)
+	}
+}
+
+func (m *commandMonitor) finished(requestID int64) string {
+	command, ok := m.started.LoadAndDelete(requestID)
+	if !ok {
+		return ""
+	}
+	return command.(string)
+}
+
+func (m *commandMonitor) fields(e event.CommandFinishedEvent, command string) []interface{} {
+	return []interface{}{
+		zap.String("command_name", e.CommandName),
+		zap.String("database", e.DatabaseName),
+		zap.Int64("request_id", e.RequestID),
+		zap.Duration("elapsed", e.Duration),
+		zap.String("command", command),
+	}
+}
+
+// safeKeys are the command options logged with their value, they hold no data
+var safeKeys = map[string]bool{
+	"$db":         true,
+	"ordered":     true,
+	"limit":       true,
+	"skip":        true,
+	"batchSize":   true,
+	"singleBatch": true,
+	"upsert":      true,
+	"multi":       true,
+	"maxTimeMS":   true,
+}
+
+// noiseKeys are the session and cluster fields the driver adds to every command
+var noiseKeys = map[string]bool{
+	"lsid":            true,
+	"$clusterTime":    true,
+	"txnNumber":       true,
+	"$readPreference": true,
+}
+
+// redact keeps the shape of a command: its name, the collection it targets, the field names and the
+// options in safeKeys. Every other value is replaced, filters and documents hold personal data.
+func redact(command bson.Raw) string {
+	elements, err := command.Elements()
+	if err != nil {
+		return ""
+	}
+
+	redacted := make(bson.D, 0, len(elements))
+	for i, element := range elements {
+		key, value := element.Key(), element.Value()
+		switch {
+		case noiseKeys[key]:
+			continue
+		// the first element is the command name, its value the collection
+		case i == 0 || safeKeys[key]:
+			redacted = append(redacted, bson.E{Key: key, Value: value})
+		default:
+			redacted = append(redacted, bson.E{Key: key, Value: redactValue(value)})
+		}
+	}
+
+	out, err := bson.MarshalExtJSON(redacted, false, false)
+	if err != nil {
+		return ""
+	}
+	if len(out) > maxLoggedCommand {
+		return string(out[:maxLoggedCommand]) + "..."
+	}
+	return string(out)
+}
+
+func redactValue(value bson.RawValue) interface{} {
+	switch value.Type {
+	case bsontype.EmbeddedDocument:
+		elements, err := value.Document().Elements()
+		if err != nil {
+			return redactedValue
+		}
+		doc := make(bson.D, 0, len(elements))
+		for _, element := range elements {
+			doc = append(doc, bson.E{Key: element.Key(), Value: redactValue(element.Value())})
+		}
+		return doc
+	case bsontype.Array:
+		values, err := value.Array().Values()
+		if err != nil {
+			return redactedValue
+		}
+		array := make(bson.A, 0, len(values))
+		for _, v := range values {
+			array = append(array, redactValue(v))
+		}
+		return array
+	default:
+		return redactedValue
+	}
+}
+
+// chainMonitors calls the monitors in order, the driver takes a single one
+func chainMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,172 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/infras/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type commandObservation struct {
+	name     string
+	duration time.Duration
+	failed   bool
+}
+
+type metricsRecorder []commandObservation
+
+func (r *metricsRecorder) ObserveCommand(name string, duration time.Duration, failed bool) {
+	*r = append(*r, commandObservation{name: name, duration: duration, failed: failed})
+}
+
+func newObservedMonitor(t *testing.T, opt LogOptions) (*event.CommandMonitor, *observer.ObservedLogs, *metricsRecorder) {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	opt.Logger = zap.New(core).Sugar()
+	metrics := &metricsRecorder{}
+	monitor, err := newCommandMonitor(opt, metrics)
+	require.NoError(t, err)
+	return monitor, logs, metrics
+}
+
+func insertCommand(t *testing.T) bson.Raw {
+	t.Helper()
+
+	command, err := bson.Marshal(bson.D{
+		{Key: "insert", Value: "transactions"},
+		{Key: "documents", Value: bson.A{bson.D{{Key: "_id", Value: "t_001"}, {Key: "note", Value: "rent for Alice"}}}},
+		{Key: "ordered", Value: true},
+		{Key: "lsid", Value: bson.D{{Key: "id", Value: "session"}}},
+		{Key: "$db", Value: "wallet"},
+	})
+	require.NoError(t, err)
+	return command
+}
+
+func TestRedact(t *testing.T) {
+	// Act
+	actual := redact(insertCommand(t))
+
+	// Assert
+	assert.Equal(t, `{"insert":"transactions","documents":[{"_id":"?","note":"?"}],"ordered":true,"$db":"wallet"}`, actual)
+}
+
+func TestCommandMonitor(t *testing.T) {
+	finished := func(requestID int64, duration time.Duration) event.CommandFinishedEvent {
+		return event.CommandFinishedEvent{CommandName: "insert", DatabaseName: "wallet", RequestID: requestID,
+			Duration: duration}
+	}
+
+	t.Run("slow command is a warning with the redacted command", func(t *testing.T) {
+		// Arrange
+		monitor, logs, metrics := newObservedMonitor(t, LogOptions{Level: "warn", SlowThreshold: 100 * time.Millisecond})
+
+		// Act
+		monitor.Started(context.Background(), &event.CommandStartedEvent{Command: insertCommand(t), RequestID: 1})
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished(1, time.Second)})
+		monitor.Started(context.Background(), &event.CommandStartedEvent{Command: insertCommand(t), RequestID: 2})
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished(2, time.Millisecond)})
+
+		// Assert
+		require.Equal(t, 1, logs.Len())
+		entry := logs.All()[0]
+		assert.Equal(t, zapcore.WarnLevel, entry.Level)
+		assert.Equal(t, "slow mongo command", entry.Message)
+		assert.NotContains(t, entry.ContextMap()["command"], "Alice")
+		assert.Contains(t, entry.ContextMap()["command"], "transactions")
+		assert.Equal(t, metricsRecorder{{name: "insert", duration: time.Second}, {name: "insert", duration: time.Millisecond}},
+			*metrics)
+	})
+
+	t.Run("failed command is an error", func(t *testing.T) {
+		// Arrange
+		monitor, logs, metrics := newObservedMonitor(t, LogOptions{Level: "error"})
+
+		// Act
+		monitor.Started(context.Background(), &event.CommandStartedEvent{Command: insertCommand(t), RequestID: 1})
+		monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: finished(1, time.Millisecond),
+			Failure: "duplicate key"})
+
+		// Assert
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, zapcore.ErrorLevel, logs.All()[0].Level)
+		assert.Equal(t, "duplicate key", logs.All()[0].ContextMap()["error"])
+		assert.Equal(t, metricsRecorder{{name: "insert", duration: time.Millisecond, failed: true}}, *metrics)
+	})
+
+	t.Run("info logs every command", func(t *testing.T) {
+		// Arrange
+		monitor, logs, _ := newObservedMonitor(t, LogOptions{Level: "info"})
+
+		// Act
+		monitor.Started(context.Background(), &event.CommandStartedEvent{Command: insertCommand(t), RequestID: 1})
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished(1, time.Millisecond)})
+
+		// Assert
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, zapcore.InfoLevel, logs.All()[0].Level)
+	})
+
+	t.Run("no zap logger still records the metrics", func(t *testing.T) {
+		// Arrange
+		metrics := &metricsRecorder{}
+		monitor, err := newCommandMonitor(LogOptions{Level: "info"}, metrics)
+		require.NoError(t, err)
+
+		// Act
+		monitor.Started(context.Background(), &event.CommandStartedEvent{Command: insertCommand(t), RequestID: 1})
+		monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: finished(1, time.Millisecond)})
+
+		// Assert
+		assert.Len(t, *metrics, 1)
+	})
+}
+
+func TestCommandMonitor_PrometheusMetrics(t *testing.T) {
+	// Arrange
+	reg := prometheus.NewRegistry()
+	monitor, err := newCommandMonitor(LogOptions{}, metrics.New(reg))
+	require.NoError(t, err)
+
+	// Act
+	monitor.Started(context.Background(), &event.CommandStartedEvent{Command: insertCommand(t), RequestID: 1})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		CommandName: "insert", DatabaseName: "wallet", RequestID: 1, Duration: time.Millisecond}})
+
+	// Assert
+	count, err := testutil.GatherAndCount(reg, "mongo_command_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestChainMonitors(t *testing.T) {
+	// Arrange
+	var calls []string
+	record := func(name string) *event.CommandMonitor {
+		return &event.CommandMonitor{
+			Started: func(context.Context, *event.CommandStartedEvent) { calls = append(calls, name+" started") },
+			Failed:  func(context.Context, *event.CommandFailedEvent) { calls = append(calls, name+" failed") },
+		}
+	}
+	monitor := chainMonitors(record("tracing"), record("log"))
+
+	// Act
+	monitor.Started(context.Background(), &event.CommandStartedEvent{})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{})
+	monitor.Failed(context.Background(), &event.CommandFailedEvent{})
+
+	// Assert
+	assert.Equal(t, []string{"tracing started", "log started", "tracing failed", "log failed"}, calls)
+}
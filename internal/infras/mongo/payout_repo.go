@@ -0,0 +1,136 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	schema2 "go-clean-template/internal/infras/mongo/schema"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	PayoutBatchCollection = "payout_batches"
+	PayoutItemCollection  = "payout_items"
+)
+
+type PayoutRepo struct {
+	db *mongo.Database
+}
+
+func NewPayoutRepo(db *mongo.Database) *PayoutRepo {
+	return &PayoutRepo{db: db}
+}
+
+func (r *PayoutRepo) SavePayoutBatch(ctx context.Context, batch *entity.PayoutBatch) error {
+	now := time.Now()
+	batchSchema := schema2.ToPayoutBatchSchema(batch)
+	batchSchema.CreatedAt = now
+	batchSchema.UpdatedAt = now
+
+	items := make([]interface{}, 0, len(batch.Items))
+	for _, item := range batch.Items {
+		itemSchema := schema2.ToPayoutItemSchema(item)
+		itemSchema.CreatedAt = now
+		itemSchema.UpdatedAt = now
+		items = append(items, itemSchema)
+	}
+
+	// items are inserted first so a batch is never visible without its items
+	if _, err := r.db.Collection(PayoutItemCollection).InsertMany(ctx, items); err != nil {
+		return err
+	}
+	_, err := r.db.Collection(PayoutBatchCollection).InsertOne(ctx, batchSchema)
+	return err
+}
+
+func (r *PayoutRepo) GetPayoutBatchByID(ctx context.Context, batchID string) (*entity.PayoutBatch, error) {
+	var batchSchema schema2.PayoutBatchSchema
+	if err := r.db.Collection(PayoutBatchCollection).FindOne(ctx, bson.M{"_id": batchID}).
+		Decode(&batchSchema); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.M{"line": 1})
+	cursor, err := r.db.Collection(PayoutItemCollection).Find(ctx, bson.M{"batch_id": batchID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var itemSchemas []schema2.PayoutItemSchema
+	if err := cursor.All(ctx, &itemSchemas); err != nil {
+		return nil, err
+	}
+	return batchSchema.ToPayoutBatch(itemSchemas), nil
+}
+
+func (r *PayoutRepo) ClaimPayoutBatch(ctx context.Context, batchID string, now time.Time, until time.Time) (bool, error) {
+	filter := claimableFilter(now)
+	filter["_id"] = batchID
+	update := bson.M{"$set": bson.M{
+		"status":        string(entity.PayoutBatchStatusProcessing),
+		"claimed_until": until,
+		"updated_at":    time.Now(),
+	}}
+	res, err := r.db.Collection(PayoutBatchCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount == 1, nil
+}
+
+func (r *PayoutRepo) ListClaimablePayoutBatchIDs(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": 1}).SetLimit(int64(limit)).SetProjection(bson.M{"_id": 1})
+	cursor, err := r.db.Collection(PayoutBatchCollection).Find(ctx, claimableFilter(now), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var batchSchemas []schema2.PayoutBatchSchema
+	if err := cursor.All(ctx, &batchSchemas); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(batchSchemas))
+	for _, batchSchema := range batchSchemas {
+		ids = append(ids, batchSchema.ID)
+	}
+	return ids, nil
+}
+
+// claimableFilter matches the batches that are entity.PayoutBatch.Claimable at now, claimed_until is missing
+// before the first claim
+func claimableFilter(now time.Time) bson.M {
+	return bson.M{
+		"status": bson.M{"$ne": string(entity.PayoutBatchStatusCompleted)},
+		"$or": bson.A{
+			bson.M{"claimed_until": bson.M{"$exists": false}},
+			bson.M{"claimed_until": bson.M{"$lte": now}},
+		},
+	}
+}
+
+func (r *PayoutRepo) UpdatePayoutBatchStatus(ctx context.Context, batchID string, status entity.PayoutBatchStatus) error {
+	update := bson.M{"$set": bson.M{
+		"status":     string(status),
+		"updated_at": time.Now(),
+	}}
+	_, err := r.db.Collection(PayoutBatchCollection).UpdateByID(ctx, batchID, update)
+	return err
+}
+
+func (r *PayoutRepo) UpdatePayoutItem(ctx context.Context, item *entity.PayoutItem) error {
+	update := bson.M{"$set": bson.M{
+		"status":         string(item.Status),
+		"transaction_id": item.TransactionID,
+		"reason":         item.Reason,
+		"updated_at":     time.Now(),
+	}}
+	_, err := r.db.Collection(PayoutItemCollection).UpdateByID(ctx, item.ID, update)
+	return err
+}
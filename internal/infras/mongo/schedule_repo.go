@@ -0,0 +1,172 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	schema2 "go-clean-template/internal/infras/mongo/schema"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	ScheduleCollection    = "schedules"
+	ScheduleRunCollection = "schedule_runs"
+)
+
+type ScheduleRepo struct {
+	db *mongo.Database
+}
+
+func NewScheduleRepo(db *mongo.Database) *ScheduleRepo {
+	return &ScheduleRepo{db: db}
+}
+
+func (r *ScheduleRepo) SaveSchedule(ctx context.Context, s *entity.Schedule) error {
+	scheduleSchema := schema2.ToScheduleSchema(s)
+	scheduleSchema.CreatedAt = time.Now()
+	scheduleSchema.UpdatedAt = scheduleSchema.CreatedAt
+
+	_, err := r.db.Collection(ScheduleCollection).InsertOne(ctx, scheduleSchema)
+
+	return err
+}
+
+func (r *ScheduleRepo) GetScheduleByID(ctx context.Context, scheduleID string) (*entity.Schedule, error) {
+	var scheduleSchema schema2.ScheduleSchema
+	if err := r.db.Collection(ScheduleCollection).FindOne(ctx, bson.M{"_id": scheduleID}).
+		Decode(&scheduleSchema); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return scheduleSchema.ToSchedule(), nil
+}
+
+func (r *ScheduleRepo) ListSchedulesByWalletID(ctx context.Context, walletID string) ([]*entity.Schedule, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+	return r.findSchedules(ctx, bson.M{"wallet_id": walletID}, opts)
+}
+
+func (r *ScheduleRepo) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*entity.Schedule, error) {
+	filter := bson.M{
+		"status":      string(entity.ScheduleStatusActive),
+		"next_run_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetSort(bson.M{"next_run_at": 1}).SetLimit(int64(limit))
+	return r.findSchedules(ctx, filter, opts)
+}
+
+func (r *ScheduleRepo) UpdateSchedule(ctx context.Context, s *entity.Schedule) error {
+	filter := bson.M{"_id": s.ID, "version": s.Version}
+	update := bson.M{
+		"$set": bson.M{
+			"amount":      s.Amount,
+			"note":        s.Note,
+			"frequency":   string(s.Frequency),
+			"start_at":    s.StartAt,
+			"next_run_at": s.NextRunAt,
+			"status":      string(s.Status),
+			"updated_at":  time.Now(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	result, err := r.db.Collection(ScheduleCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return missedVersion(ctx, r.db.Collection(ScheduleCollection), "schedule", s.ID, s.Version)
+	}
+	return nil
+}
+
+func (r *ScheduleRepo) DeleteSchedule(ctx context.Context, scheduleID string, version int64) error {
+	result, err := r.db.Collection(ScheduleCollection).DeleteOne(ctx, bson.M{"_id": scheduleID, "version": version})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return missedVersion(ctx, r.db.Collection(ScheduleCollection), "schedule", scheduleID, version)
+	}
+	_, err = r.db.Collection(ScheduleRunCollection).DeleteMany(ctx, bson.M{"schedule_id": scheduleID})
+	return err
+}
+
+func (r *ScheduleRepo) ClaimScheduleRun(ctx context.Context, run *entity.ScheduleRun, now time.Time) (bool, error) {
+	runSchema := schema2.ToScheduleRunSchema(run)
+	runSchema.CreatedAt = time.Now()
+	runSchema.UpdatedAt = runSchema.CreatedAt
+
+	// the run id is derived from the occurrence, so a second claim hits the _id unique index
+	_, err := r.db.Collection(ScheduleRunCollection).InsertOne(ctx, runSchema)
+	if err == nil {
+		return true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return false, err
+	}
+
+	// the occurrence has a run, take it over if its scheduler stopped before recording the result
+	filter := bson.M{
+		"_id":    run.ID,
+		"status": string(entity.ScheduleRunPending),
+		"$or": bson.A{
+			bson.M{"claimed_until": bson.M{"$exists": false}},
+			bson.M{"claimed_until": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"claimed_until": run.ClaimedUntil, "updated_at": time.Now()}}
+	res, err := r.db.Collection(ScheduleRunCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	if res.MatchedCount == 1 {
+		return true, nil
+	}
+
+	var current schema2.ScheduleRunSchema
+	if err := r.db.Collection(ScheduleRunCollection).FindOne(ctx, bson.M{"_id": run.ID}).Decode(&current); err != nil {
+		return false, err
+	}
+	if current.Status == string(entity.ScheduleRunPending) {
+		return false, entity.ErrScheduleRunClaimed
+	}
+	return false, nil
+}
+
+func (r *ScheduleRepo) UpdateScheduleRun(ctx context.Context, run *entity.ScheduleRun) error {
+	update := bson.M{"$set": bson.M{
+		"status":     string(run.Status),
+		"reason":     run.Reason,
+		"updated_at": time.Now(),
+	}}
+	_, err := r.db.Collection(ScheduleRunCollection).UpdateByID(ctx, run.ID, update)
+	return err
+}
+
+func (r *ScheduleRepo) findSchedules(ctx context.Context, filter interface{}, opts *options.FindOptions) ([]*entity.Schedule, error) {
+	cursor, err := r.db.Collection(ScheduleCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	schedules := make([]*entity.Schedule, 0)
+	for cursor.Next(ctx) {
+		var scheduleSchema schema2.ScheduleSchema
+		if err := cursor.Decode(&scheduleSchema); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, scheduleSchema.ToSchedule())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
@@ -4,21 +4,27 @@ import (
 	"time"
 
 	"go-clean-template/internal/entity"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type LinkedAccountSchema struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	UserID      string             `bson:"user_id,omitempty"`
-	AccountName string             `bson:"account_name,omitempty"`
-	CreatedAt   time.Time          `bson:"created_at,omitempty"`
-	UpdatedAt   time.Time          `bson:"updated_at,omitempty"`
+	ID          string    `bson:"_id,omitempty"`
+	UserID      string    `bson:"user_id,omitempty"`
+	AccountName string    `bson:"account_name,omitempty"`
+	CreatedAt   time.Time `bson:"created_at,omitempty"`
+	UpdatedAt   time.Time `bson:"updated_at,omitempty"`
+}
+
+func ToLinkedAccountSchema(a *entity.LinkedAccount) *LinkedAccountSchema {
+	return &LinkedAccountSchema{
+		ID:          a.ID,
+		UserID:      a.UserID,
+		AccountName: a.AccountName,
+	}
 }
 
 func (a *LinkedAccountSchema) ToLinkedAccount() *entity.LinkedAccount {
 	return &entity.LinkedAccount{
-		ID:          a.ID.String(),
+		ID:          a.ID,
 		UserID:      a.UserID,
 		AccountName: a.AccountName,
 	}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type ApprovalSchema struct {
+	ID            string     `bson:"_id,omitempty"`
+	TransactionID string     `bson:"transaction_id,omitempty"`
+	RequestedBy   string     `bson:"requested_by,omitempty"`
+	DecidedBy     string     `bson:"decided_by,omitempty"`
+	Status        string     `bson:"status,omitempty"`
+	Reason        string     `bson:"reason,omitempty"`
+	ExpiresAt     time.Time  `bson:"expires_at,omitempty"`
+	DecidedAt     *time.Time `bson:"decided_at,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at,omitempty"`
+	UpdatedAt     time.Time  `bson:"updated_at,omitempty"`
+}
+
+func ToApprovalSchema(approval *entity.Approval) *ApprovalSchema {
+	return &ApprovalSchema{
+		ID:            approval.ID,
+		TransactionID: approval.TransactionID,
+		RequestedBy:   approval.RequestedBy,
+		DecidedBy:     approval.DecidedBy,
+		Status:        string(approval.Status),
+		Reason:        approval.Reason,
+		ExpiresAt:     approval.ExpiresAt,
+		DecidedAt:     approval.DecidedAt,
+	}
+}
+
+func (a *ApprovalSchema) ToApproval() *entity.Approval {
+	return &entity.Approval{
+		ID:            a.ID,
+		TransactionID: a.TransactionID,
+		RequestedBy:   a.RequestedBy,
+		DecidedBy:     a.DecidedBy,
+		Status:        entity.ApprovalStatus(a.Status),
+		Reason:        a.Reason,
+		ExpiresAt:     a.ExpiresAt,
+		DecidedAt:     a.DecidedAt,
+	}
+}
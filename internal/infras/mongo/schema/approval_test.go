@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+func TestApprovalSchema_ToApproval(t *testing.T) {
+	expiresAt := time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)
+	decidedAt := time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		schema *ApprovalSchema
+		want   *entity.Approval
+	}{
+		{
+			name: "test to approval",
+			schema: &ApprovalSchema{
+				ID:            "ap_001",
+				TransactionID: "t_001",
+				RequestedBy:   "u_001",
+				DecidedBy:     "u_002",
+				Status:        "APPROVED",
+				ExpiresAt:     expiresAt,
+				DecidedAt:     &decidedAt,
+			},
+			want: &entity.Approval{
+				ID:            "ap_001",
+				TransactionID: "t_001",
+				RequestedBy:   "u_001",
+				DecidedBy:     "u_002",
+				Status:        entity.ApprovalStatusApproved,
+				ExpiresAt:     expiresAt,
+				DecidedAt:     &decidedAt,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schema.ToApproval(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToApproval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToApprovalSchema(t *testing.T) {
+	expiresAt := time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		approval *entity.Approval
+		want     *ApprovalSchema
+	}{
+		{
+			name:     "To ApprovalSchema",
+			approval: entity.NewApproval("ap_001", "t_001", "u_001", expiresAt),
+			want: &ApprovalSchema{
+				ID:            "ap_001",
+				TransactionID: "t_001",
+				RequestedBy:   "u_001",
+				Status:        "PENDING",
+				ExpiresAt:     expiresAt,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToApprovalSchema(tt.approval); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToApprovalSchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type BalanceSnapshotSchema struct {
+	WalletID  string    `bson:"wallet_id,omitempty"`
+	AsOf      time.Time `bson:"as_of,omitempty"`
+	Balance   float64   `bson:"balance"`
+	CreatedAt time.Time `bson:"created_at,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at,omitempty"`
+}
+
+func ToBalanceSnapshotSchema(s *entity.BalanceSnapshot) *BalanceSnapshotSchema {
+	return &BalanceSnapshotSchema{
+		WalletID: s.WalletID,
+		AsOf:     s.AsOf,
+		Balance:  s.Balance,
+	}
+}
+
+func (s *BalanceSnapshotSchema) ToBalanceSnapshot() *entity.BalanceSnapshot {
+	return &entity.BalanceSnapshot{
+		WalletID: s.WalletID,
+		AsOf:     s.AsOf,
+		Balance:  s.Balance,
+	}
+}
@@ -0,0 +1,27 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+func TestBalanceSnapshotSchema_RoundTrip(t *testing.T) {
+	asOf := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+	snapshot := entity.NewBalanceSnapshot("w_001", asOf, 1500)
+	want := &BalanceSnapshotSchema{
+		WalletID: "w_001",
+		AsOf:     asOf,
+		Balance:  1500,
+	}
+
+	got := ToBalanceSnapshotSchema(snapshot)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToBalanceSnapshotSchema() = %v, want %v", got, want)
+	}
+	if back := got.ToBalanceSnapshot(); !reflect.DeepEqual(back, snapshot) {
+		t.Errorf("ToBalanceSnapshot() = %v, want %v", back, snapshot)
+	}
+}
@@ -0,0 +1,88 @@
+package schema
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type PayoutBatchSchema struct {
+	ID           string    `bson:"_id,omitempty"`
+	CreatedBy    string    `bson:"created_by,omitempty"`
+	Status       string    `bson:"status,omitempty"`
+	ClaimedUntil time.Time `bson:"claimed_until,omitempty"`
+	CreatedAt    time.Time `bson:"created_at,omitempty"`
+	UpdatedAt    time.Time `bson:"updated_at,omitempty"`
+}
+
+func ToPayoutBatchSchema(b *entity.PayoutBatch) *PayoutBatchSchema {
+	return &PayoutBatchSchema{
+		ID:           b.ID,
+		CreatedBy:    b.CreatedBy,
+		Status:       string(b.Status),
+		ClaimedUntil: b.ClaimedUntil,
+	}
+}
+
+// ToPayoutBatch converts the batch row and its item rows to an entity
+func (b *PayoutBatchSchema) ToPayoutBatch(items []PayoutItemSchema) *entity.PayoutBatch {
+	batch := &entity.PayoutBatch{
+		ID:           b.ID,
+		CreatedBy:    b.CreatedBy,
+		Status:       entity.PayoutBatchStatus(b.Status),
+		ClaimedUntil: b.ClaimedUntil,
+		Items:        make([]*entity.PayoutItem, 0, len(items)),
+	}
+	for i := range items {
+		batch.Items = append(batch.Items, items[i].ToPayoutItem())
+	}
+	return batch
+}
+
+type PayoutItemSchema struct {
+	ID            string    `bson:"_id,omitempty"`
+	BatchID       string    `bson:"batch_id,omitempty"`
+	Line          int       `bson:"line,omitempty"`
+	WalletID      string    `bson:"wallet_id,omitempty"`
+	AccountID     string    `bson:"account_id,omitempty"`
+	Amount        float64   `bson:"amount,omitempty"`
+	Currency      string    `bson:"currency,omitempty"`
+	Note          string    `bson:"note,omitempty"`
+	Status        string    `bson:"status,omitempty"`
+	TransactionID string    `bson:"transaction_id,omitempty"`
+	Reason        string    `bson:"reason,omitempty"`
+	CreatedAt     time.Time `bson:"created_at,omitempty"`
+	UpdatedAt     time.Time `bson:"updated_at,omitempty"`
+}
+
+func ToPayoutItemSchema(i *entity.PayoutItem) *PayoutItemSchema {
+	return &PayoutItemSchema{
+		ID:            i.ID,
+		BatchID:       i.BatchID,
+		Line:          i.Line,
+		WalletID:      i.WalletID,
+		AccountID:     i.AccountID,
+		Amount:        i.Amount,
+		Currency:      i.Currency,
+		Note:          i.Note,
+		Status:        string(i.Status),
+		TransactionID: i.TransactionID,
+		Reason:        i.Reason,
+	}
+}
+
+func (i *PayoutItemSchema) ToPayoutItem() *entity.PayoutItem {
+	return &entity.PayoutItem{
+		ID:            i.ID,
+		BatchID:       i.BatchID,
+		Line:          i.Line,
+		WalletID:      i.WalletID,
+		AccountID:     i.AccountID,
+		Amount:        i.Amount,
+		Currency:      i.Currency,
+		Note:          i.Note,
+		Status:        entity.PayoutItemStatus(i.Status),
+		TransactionID: i.TransactionID,
+		Reason:        i.Reason,
+	}
+}
@@ -0,0 +1,91 @@
+package schema
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type ScheduleSchema struct {
+	ID              string    `bson:"_id,omitempty"`
+	WalletID        string    `bson:"wallet_id,omitempty"`
+	AccountID       string    `bson:"account_id,omitempty"`
+	Amount          float64   `bson:"amount,omitempty"`
+	Currency        string    `bson:"currency,omitempty"`
+	TransactionKind string    `bson:"transaction_kind,omitempty"`
+	Note            string    `bson:"note,omitempty"`
+	Frequency       string    `bson:"frequency,omitempty"`
+	StartAt         time.Time `bson:"start_at,omitempty"`
+	NextRunAt       time.Time `bson:"next_run_at,omitempty"`
+	Status          string    `bson:"status,omitempty"`
+	CreatedAt       time.Time `bson:"created_at,omitempty"`
+	UpdatedAt       time.Time `bson:"updated_at,omitempty"`
+	Version         int64     `bson:"version"`
+}
+
+func ToScheduleSchema(s *entity.Schedule) *ScheduleSchema {
+	return &ScheduleSchema{
+		ID:              s.ID,
+		WalletID:        s.WalletID,
+		AccountID:       s.AccountID,
+		Amount:          s.Amount,
+		Currency:        s.Currency,
+		TransactionKind: string(s.TransactionKind),
+		Note:            s.Note,
+		Frequency:       string(s.Frequency),
+		StartAt:         s.StartAt,
+		NextRunAt:       s.NextRunAt,
+		Status:          string(s.Status),
+		Version:         s.Version,
+	}
+}
+
+func (s *ScheduleSchema) ToSchedule() *entity.Schedule {
+	return &entity.Schedule{
+		ID:              s.ID,
+		WalletID:        s.WalletID,
+		AccountID:       s.AccountID,
+		Amount:          s.Amount,
+		Currency:        s.Currency,
+		TransactionKind: entity.TransactionKind(s.TransactionKind),
+		Note:            s.Note,
+		Frequency:       entity.ScheduleFrequency(s.Frequency),
+		StartAt:         s.StartAt,
+		NextRunAt:       s.NextRunAt,
+		Status:          entity.ScheduleStatus(s.Status),
+		Version:         s.Version,
+	}
+}
+
+type ScheduleRunSchema struct {
+	ID           string    `bson:"_id,omitempty"`
+	ScheduleID   string    `bson:"schedule_id,omitempty"`
+	OccurrenceAt time.Time `bson:"occurrence_at,omitempty"`
+	Status       string    `bson:"status,omitempty"`
+	Reason       string    `bson:"reason,omitempty"`
+	ClaimedUntil time.Time `bson:"claimed_until,omitempty"`
+	CreatedAt    time.Time `bson:"created_at,omitempty"`
+	UpdatedAt    time.Time `bson:"updated_at,omitempty"`
+}
+
+func ToScheduleRunSchema(r *entity.ScheduleRun) *ScheduleRunSchema {
+	return &ScheduleRunSchema{
+		ID:           r.ID,
+		ScheduleID:   r.ScheduleID,
+		OccurrenceAt: r.OccurrenceAt,
+		Status:       string(r.Status),
+		Reason:       r.Reason,
+		ClaimedUntil: r.ClaimedUntil,
+	}
+}
+
+func (r *ScheduleRunSchema) ToScheduleRun() *entity.ScheduleRun {
+	return &entity.ScheduleRun{
+		ID:           r.ID,
+		ScheduleID:   r.ScheduleID,
+		OccurrenceAt: r.OccurrenceAt,
+		Status:       entity.ScheduleRunStatus(r.Status),
+		Reason:       r.Reason,
+		ClaimedUntil: r.ClaimedUntil,
+	}
+}
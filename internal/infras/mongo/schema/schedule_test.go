@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+func TestScheduleSchema_RoundTrip(t *testing.T) {
+	startAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	schedule, err := entity.NewSchedule("s_001", "w_001", "a_001", 100, "USD", entity.TransactionOut, "rent",
+		entity.ScheduleMonthly, startAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ScheduleSchema{
+		ID:              "s_001",
+		WalletID:        "w_001",
+		AccountID:       "a_001",
+		Amount:          100,
+		Currency:        "USD",
+		TransactionKind: "OUT",
+		Note:            "rent",
+		Frequency:       "MONTHLY",
+		StartAt:         startAt,
+		NextRunAt:       startAt,
+		Status:          "ACTIVE",
+		Version:         1,
+	}
+
+	got := ToScheduleSchema(schedule)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToScheduleSchema() = %v, want %v", got, want)
+	}
+	if back := got.ToSchedule(); !reflect.DeepEqual(back, schedule) {
+		t.Errorf("ToSchedule() = %v, want %v", back, schedule)
+	}
+}
+
+func TestScheduleRunSchema_RoundTrip(t *testing.T) {
+	run := entity.NewScheduleRun("s_001", time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+	run.ToSkipped("insufficient balance")
+	want := &ScheduleRunSchema{
+		ID:           run.ID,
+		ScheduleID:   "s_001",
+		OccurrenceAt: run.OccurrenceAt,
+		Status:       "SKIPPED",
+		Reason:       "insufficient balance",
+	}
+
+	got := ToScheduleRunSchema(run)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToScheduleRunSchema() = %v, want %v", got, want)
+	}
+	if back := got.ToScheduleRun(); !reflect.DeepEqual(back, run) {
+		t.Errorf("ToScheduleRun() = %v, want %v", back, run)
+	}
+}
@@ -4,27 +4,26 @@ import (
 	"time"
 
 	"go-clean-template/internal/entity"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type TransactionSchema struct {
-	ID              primitive.ObjectID `bson:"_id,omitempty"`
-	WalletID        string             `bson:"wallet_id,omitempty"`
-	AccountID       string             `bson:"account_id,omitempty"`
-	Amount          float64            `bson:"amount,omitempty"`
-	Currency        string             `bson:"currency,omitempty"`
-	TransactionKind string             `bson:"transaction_kind,omitempty"`
-	Status          string             `bson:"status,omitempty"`
-	Note            string             `bson:"note,omitempty"`
-	CreatedAt       time.Time          `bson:"created_at,omitempty"`
-	UpdatedAt       time.Time          `bson:"updated_at,omitempty"`
+	ID              string     `bson:"_id,omitempty"`
+	WalletID        string     `bson:"wallet_id,omitempty"`
+	AccountID       string     `bson:"account_id,omitempty"`
+	Amount          float64    `bson:"amount,omitempty"`
+	Currency        string     `bson:"currency,omitempty"`
+	TransactionKind string     `bson:"transaction_kind,omitempty"`
+	Status          string     `bson:"status,omitempty"`
+	Note            string     `bson:"note,omitempty"`
+	CreatedAt       time.Time  `bson:"created_at,omitempty"`
+	SettledAt       *time.Time `bson:"settled_at,omitempty"`
+	UpdatedAt       time.Time  `bson:"updated_at,omitempty"`
+	Version         int64      `bson:"version"`
 }
 
 func ToTransactionSchema(trans *entity.Transaction) *TransactionSchema {
-	objID, _ := primitive.ObjectIDFromHex(trans.ID)
 	return &TransactionSchema{
-		ID:              objID,
+		ID:              trans.ID,
 		WalletID:        trans.WalletID,
 		AccountID:       trans.AccountID,
 		Amount:          trans.Amount,
@@ -32,12 +31,15 @@ func ToTransactionSchema(trans *entity.Transaction) *TransactionSchema {
 		TransactionKind: string(trans.TransactionKind),
 		Status:          string(trans.Status),
 		Note:            trans.Note,
+		CreatedAt:       trans.CreatedAt,
+		SettledAt:       trans.SettledAt,
+		Version:         trans.Version,
 	}
 }
 
 func (trans *TransactionSchema) ToTransaction() *entity.Transaction {
 	return &entity.Transaction{
-		ID:              trans.ID.String(),
+		ID:              trans.ID,
 		WalletID:        trans.WalletID,
 		AccountID:       trans.AccountID,
 		Amount:          trans.Amount,
@@ -45,5 +47,8 @@ func (trans *TransactionSchema) ToTransaction() *entity.Transaction {
 		TransactionKind: entity.TransactionKind(trans.TransactionKind),
 		Status:          entity.TransactionStatus(trans.Status),
 		Note:            trans.Note,
+		CreatedAt:       trans.CreatedAt,
+		SettledAt:       trans.SettledAt,
+		Version:         trans.Version,
 	}
 }
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"go-clean-template/internal/entity"
+	"go-clean-template/pkg/idgen"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestTransactionSchema_ToTransaction(t *testing.T) {
@@ -119,3 +122,41 @@ func TestToTransactionSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionSchema_BSONRoundTrip(t *testing.T) {
+	for _, strategy := range []string{idgen.StrategyUUIDv7, idgen.StrategyULID, idgen.StrategyObjectID} {
+		t.Run(strategy, func(t *testing.T) {
+			generator, err := idgen.New(strategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := &entity.Transaction{
+				ID:              generator.NewID(),
+				WalletID:        generator.NewID(),
+				AccountID:       generator.NewID(),
+				Amount:          100,
+				Currency:        "USD",
+				TransactionKind: entity.TransactionIn,
+				Status:          entity.TransactionStatusNew,
+				Note:            "deposit 100",
+				CreatedAt:       time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC),
+			}
+
+			doc, err := bson.Marshal(ToTransactionSchema(want))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id := bson.Raw(doc).Lookup("_id").StringValue(); id != want.ID {
+				t.Errorf("_id = %v, want %v", id, want.ID)
+			}
+
+			var transSchema TransactionSchema
+			if err := bson.Unmarshal(doc, &transSchema); err != nil {
+				t.Fatal(err)
+			}
+			if got := transSchema.ToTransaction(); !reflect.DeepEqual(got, want) {
+				t.Errorf("ToTransaction() = %v, want %v", got, want)
+			}
+		})
+	}
+}
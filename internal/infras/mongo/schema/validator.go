@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"go-clean-template/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// The validators below are the $jsonSchema of the collections. They require the fields the repositories
+// filter on and check the types and enums of the others. Fields tagged omitempty are never required.
+
+var (
+	stringType = bson.M{"bsonType": "string"}
+	dateType   = bson.M{"bsonType": "date"}
+	numberType = bson.M{"bsonType": bson.A{"double", "int", "long", "decimal"}}
+	intType    = bson.M{"bsonType": bson.A{"int", "long"}}
+)
+
+func enumOf[T ~string](values ...T) bson.M {
+	enum := make(bson.A, 0, len(values))
+	for _, v := range values {
+		enum = append(enum, string(v))
+	}
+	return bson.M{"bsonType": "string", "enum": enum}
+}
+
+func jsonSchema(required bson.A, properties bson.M) bson.M {
+	properties["created_at"] = dateType
+	properties["updated_at"] = dateType
+	return bson.M{"$jsonSchema": bson.M{
+		"bsonType":   "object",
+		"required":   required,
+		"properties": properties,
+	}}
+}
+
+func WalletValidator() bson.M {
+	return jsonSchema(bson.A{"user_id"}, bson.M{
+		"_id":         stringType,
+		"user_id":     stringType,
+		"wallet_name": stringType,
+		"version":     intType,
+	})
+}
+
+func LinkedAccountValidator() bson.M {
+	return jsonSchema(bson.A{"user_id"}, bson.M{
+		"_id":          stringType,
+		"user_id":      stringType,
+		"account_name": stringType,
+	})
+}
+
+func TransactionValidator() bson.M {
+	return jsonSchema(bson.A{"wallet_id", "transaction_kind", "status", "created_at"}, bson.M{
+		"_id":              stringType,
+		"wallet_id":        stringType,
+		"account_id":       stringType,
+		"amount":           numberType,
+		"currency":         stringType,
+		"transaction_kind": enumOf(entity.TransactionIn, entity.TransactionOut),
+		"status": enumOf(entity.TransactionStatusNew, entity.TransactionStatusProcessing, entity.TransactionStatusSuccessful,
+			entity.TransactionStatusFailed, entity.TransactionStatusAwaitingApproval, entity.TransactionStatusApproved, entity.TransactionStatusRejected),
+		"note":       stringType,
+		"settled_at": dateType,
+		"version":    intType,
+	})
+}
+
+func ApprovalValidator() bson.M {
+	return jsonSchema(bson.A{"transaction_id", "status", "expires_at"}, bson.M{
+		"_id":            stringType,
+		"transaction_id": stringType,
+		"requested_by":   stringType,
+		"decided_by":     stringType,
+		"status": enumOf(entity.ApprovalStatusPending, entity.ApprovalStatusApproved, entity.ApprovalStatusRejected,
+			entity.ApprovalStatusExpired),
+		"reason":     stringType,
+		"expires_at": dateType,
+		"decided_at": dateType,
+	})
+}
+
+func ScheduleValidator() bson.M {
+	return jsonSchema(bson.A{"wallet_id", "transaction_kind", "frequency", "next_run_at", "status"}, bson.M{
+		"_id":              stringType,
+		"wallet_id":        stringType,
+		"account_id":       stringType,
+		"amount":           numberType,
+		"currency":         stringType,
+		"transaction_kind": enumOf(entity.TransactionIn, entity.TransactionOut),
+		"note":             stringType,
+		"frequency": enumOf(entity.ScheduleOnce, entity.ScheduleWeekly, entity.ScheduleMonthly,
+			entity.ScheduleEndOfMonth),
+		"start_at":    dateType,
+		"next_run_at": dateType,
+		"status":      enumOf(entity.ScheduleStatusActive, entity.ScheduleStatusCompleted),
+		"version":     intType,
+	})
+}
+
+func ScheduleRunValidator() bson.M {
+	return jsonSchema(bson.A{"schedule_id", "occurrence_at", "status"}, bson.M{
+		"_id":           stringType,
+		"schedule_id":   stringType,
+		"occurrence_at": dateType,
+		"status": enumOf(entity.ScheduleRunPending, entity.ScheduleRunMaterialized, entity.ScheduleRunSkipped,
+			entity.ScheduleRunFailed),
+		"reason":        stringType,
+		"claimed_until": dateType,
+	})
+}
+
+func PayoutBatchValidator() bson.M {
+	return jsonSchema(bson.A{"status"}, bson.M{
+		"_id":        stringType,
+		"created_by": stringType,
+		"status": enumOf(entity.PayoutBatchStatusPending, entity.PayoutBatchStatusProcessing,
+			entity.PayoutBatchStatusCompleted),
+		"claimed_until": dateType,
+	})
+}
+
+func PayoutItemValidator() bson.M {
+	return jsonSchema(bson.A{"batch_id", "status"}, bson.M{
+		"_id":        stringType,
+		"batch_id":   stringType,
+		"line":       bson.M{"bsonType": bson.A{"int", "long"}},
+		"wallet_id":  stringType,
+		"account_id": stringType,
+		"amount":     numberType,
+		"currency":   stringType,
+		"note":       stringType,
+		"status": enumOf(entity.PayoutItemStatusPending, entity.PayoutItemStatusSucceeded, entity.PayoutItemStatusFailed,
+			entity.PayoutItemStatusAwaitingApproval),
+		"transaction_id": stringType,
+		"reason":         stringType,
+	})
+}
+
+func BalanceSnapshotValidator() bson.M {
+	return jsonSchema(bson.A{"wallet_id", "as_of", "balance"}, bson.M{
+		"wallet_id": stringType,
+		"as_of":     dateType,
+		"balance":   numberType,
+	})
+}
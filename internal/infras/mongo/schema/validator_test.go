@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestValidators(t *testing.T) {
+	validators := map[string]func() bson.M{
+		"wallet":           WalletValidator,
+		"linked account":   LinkedAccountValidator,
+		"transaction":      TransactionValidator,
+		"approval":         ApprovalValidator,
+		"schedule":         ScheduleValidator,
+		"schedule run":     ScheduleRunValidator,
+		"payout batch":     PayoutBatchValidator,
+		"payout item":      PayoutItemValidator,
+		"balance snapshot": BalanceSnapshotValidator,
+	}
+	for name, validator := range validators {
+		t.Run(name+" requires only described fields", func(t *testing.T) {
+			s := validator()["$jsonSchema"].(bson.M)
+			properties := s["properties"].(bson.M)
+
+			for _, field := range s["required"].(bson.A) {
+				assert.Contains(t, properties, field)
+			}
+			assert.Contains(t, properties, "created_at")
+			assert.Contains(t, properties, "updated_at")
+		})
+	}
+}
+
+func TestTransactionValidator(t *testing.T) {
+	properties := TransactionValidator()["$jsonSchema"].(bson.M)["properties"].(bson.M)
+
+	assert.Equal(t, bson.A{"IN", "OUT"}, properties["transaction_kind"].(bson.M)["enum"])
+	assert.Contains(t, properties["status"].(bson.M)["enum"], "AWAITING_APPROVAL")
+}
@@ -4,22 +4,31 @@ import (
 	"time"
 
 	"go-clean-template/internal/entity"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type WalletSchema struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty"`
-	UserID     string             `bson:"user_id,omitempty"`
-	WalletName string             `bson:"wallet_name,omitempty"`
-	CreatedAt  time.Time          `bson:"created_at,omitempty"`
-	UpdatedAt  time.Time          `bson:"updated_at,omitempty"`
+	ID         string    `bson:"_id,omitempty"`
+	UserID     string    `bson:"user_id,omitempty"`
+	WalletName string    `bson:"wallet_name,omitempty"`
+	CreatedAt  time.Time `bson:"created_at,omitempty"`
+	UpdatedAt  time.Time `bson:"updated_at,omitempty"`
+	Version    int64     `bson:"version"`
+}
+
+func ToWalletSchema(w *entity.Wallet) *WalletSchema {
+	return &WalletSchema{
+		ID:         w.ID,
+		UserID:     w.UserID,
+		WalletName: w.WalletName,
+		Version:    w.Version,
+	}
 }
 
 func (w *WalletSchema) ToWallet() *entity.Wallet {
 	return &entity.Wallet{
-		ID:         w.ID.String(),
+		ID:         w.ID,
 		UserID:     w.UserID,
 		WalletName: w.WalletName,
+		Version:    w.Version,
 	}
 }
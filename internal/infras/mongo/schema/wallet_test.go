@@ -50,3 +50,11 @@ func TestWalletSchema_ToWallet(t *testing.T) {
 		})
 	}
 }
+
+func TestToWalletSchema(t *testing.T) {
+	wallet := &entity.Wallet{ID: "1", UserID: "1", WalletName: "My wallet"}
+
+	if got := ToWalletSchema(wallet).ToWallet(); !reflect.DeepEqual(got, wallet) {
+		t.Errorf("ToWalletSchema() = %v, want %v", got, wallet)
+	}
+}
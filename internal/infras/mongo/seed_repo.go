@@ -0,0 +1,62 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/fixture"
+	schema2 "go-clean-template/internal/infras/mongo/schema"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type SeedRepo struct {
+	db *mongo.Database
+}
+
+func NewSeedRepo(db *mongo.Database) *SeedRepo {
+	return &SeedRepo{db: db}
+}
+
+// Seed replaces or inserts the wallets, linked accounts and transactions of the set by id.
+// Users are not stored in mongo, wallets and accounts only carry their user id.
+func (r *SeedRepo) Seed(ctx context.Context, set *fixture.Set) error {
+	now := time.Now()
+
+	wallets := make([]mongo.WriteModel, 0, len(set.Wallets))
+	for _, w := range set.Wallets {
+		walletSchema := schema2.ToWalletSchema(w)
+		walletSchema.CreatedAt, walletSchema.UpdatedAt = now, now
+		wallets = append(wallets, replaceByID(walletSchema.ID, walletSchema))
+	}
+	accounts := make([]mongo.WriteModel, 0, len(set.LinkedAccounts))
+	for _, a := range set.LinkedAccounts {
+		accountSchema := schema2.ToLinkedAccountSchema(a)
+		accountSchema.CreatedAt, accountSchema.UpdatedAt = now, now
+		accounts = append(accounts, replaceByID(accountSchema.ID, accountSchema))
+	}
+	transactions := make([]mongo.WriteModel, 0, len(set.Transactions))
+	for _, trans := range set.Transactions {
+		transSchema := newTransactionSchema(trans, now)
+		transactions = append(transactions, replaceByID(transSchema.ID, transSchema))
+	}
+
+	for collection, models := range map[string][]mongo.WriteModel{
+		WalletCollection:        wallets,
+		LinkedAccountCollection: accounts,
+		TransactionsCollection:  transactions,
+	} {
+		if len(models) == 0 {
+			continue
+		}
+		if _, err := r.db.Collection(collection).BulkWrite(ctx, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func replaceByID(id string, document interface{}) mongo.WriteModel {
+	return mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": id}).SetReplacement(document).SetUpsert(true)
+}
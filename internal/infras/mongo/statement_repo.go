@@ -0,0 +1,56 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	schema2 "go-clean-template/internal/infras/mongo/schema"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type StatementRepo struct {
+	db *mongo.Database
+}
+
+func NewStatementRepo(db *mongo.Database) *StatementRepo {
+	return &StatementRepo{db: db}
+}
+
+func (r *StatementRepo) GetBalanceBefore(ctx context.Context, walletID string, before time.Time) (float64, error) {
+	return sumTransactions(ctx, r.db, bson.M{
+		"wallet_id":  walletID,
+		"status":     string(entity.TransactionStatusSuccessful),
+		"settled_at": bson.M{"$lt": before},
+	})
+}
+
+func (r *StatementRepo) StreamTransactions(ctx context.Context, walletID string, from time.Time, to time.Time,
+	fn func(trans *entity.Transaction) error) error {
+	filter := bson.M{
+		"wallet_id":  walletID,
+		"status":     string(entity.TransactionStatusSuccessful),
+		"settled_at": bson.M{"$gte": from, "$lt": to},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "settled_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.db.Collection(TransactionsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var transSchema schema2.TransactionSchema
+		if err := cursor.Decode(&transSchema); err != nil {
+			return err
+		}
+		if err := fn(transSchema.ToTransaction()); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -2,13 +2,15 @@ package mongo
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"go-clean-template/internal/entity"
 	schema2 "go-clean-template/internal/infras/mongo/schema"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
@@ -31,12 +33,7 @@ func (r *TransactionRepo) GetWalletByID(ctx context.Context, walletID string) (*
 		walletSchema schema2.WalletSchema
 	)
 
-	walletIDObj, err := primitive.ObjectIDFromHex(walletID)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := r.db.Collection(WalletCollection).FindOne(ctx, schema2.WalletSchema{ID: walletIDObj}).Decode(&walletSchema); err != nil {
+	if err := r.db.Collection(WalletCollection).FindOne(ctx, bson.M{"_id": walletID}).Decode(&walletSchema); err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
 		}
@@ -48,20 +45,33 @@ func (r *TransactionRepo) GetWalletByID(ctx context.Context, walletID string) (*
 }
 
 func (r *TransactionRepo) SaveTransaction(ctx context.Context, trans *entity.Transaction) error {
-	transSchema := schema2.ToTransactionSchema(trans)
+	transSchema := newTransactionSchema(trans, time.Now())
 
 	_, err := r.db.Collection(TransactionsCollection).InsertOne(ctx, transSchema)
 
 	return err
 }
 
+// newTransactionSchema sets a zero CreatedAt to now and settles a transaction stored SUCCESSFUL at its creation
+func newTransactionSchema(trans *entity.Transaction, now time.Time) *schema2.TransactionSchema {
+	transSchema := schema2.ToTransactionSchema(trans)
+	if transSchema.CreatedAt.IsZero() {
+		transSchema.CreatedAt = now
+	}
+	if transSchema.SettledAt == nil && trans.Status == entity.TransactionStatusSuccessful {
+		settledAt := transSchema.CreatedAt
+		transSchema.SettledAt = &settledAt
+	}
+	transSchema.UpdatedAt = transSchema.CreatedAt
+	return transSchema
+}
+
 func (r *TransactionRepo) GetLinkedAccountByID(ctx context.Context, accountID string) (*entity.LinkedAccount, error) {
 	var (
 		account       *entity.LinkedAccount
 		accountSchema schema2.LinkedAccountSchema
 	)
-	objectId, _ := primitive.ObjectIDFromHex(accountID)
-	if err := r.db.Collection(LinkedAccountCollection).FindOne(ctx, schema2.LinkedAccountSchema{ID: objectId}).
+	if err := r.db.Collection(LinkedAccountCollection).FindOne(ctx, bson.M{"_id": accountID}).
 		Decode(&accountSchema); err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
@@ -73,73 +83,121 @@ func (r *TransactionRepo) GetLinkedAccountByID(ctx context.Context, accountID st
 }
 
 func (r *TransactionRepo) GetBalanceByWalletID(ctx context.Context, walletID string) (float64, error) {
-	var result struct {
-		Balance float64 `bson:"balance"`
+	return sumTransactions(ctx, r.db, bson.M{
+		"wallet_id": walletID,
+		"status":    string(entity.TransactionStatusSuccessful),
+	})
+}
+
+func (r *TransactionRepo) GetTransactionByID(ctx context.Context, transID string) (*entity.Transaction, error) {
+	var transSchema schema2.TransactionSchema
+
+	if err := r.db.Collection(TransactionsCollection).FindOne(ctx, bson.M{"_id": transID}).
+		Decode(&transSchema); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
 	}
+	return transSchema.ToTransaction(), nil
+}
 
-	pipeline := mongo.Pipeline{
-		{{"$match", bson.D{{"wallet_id", walletID}, {"status", entity.TransactionStatusSuccessful}}}},
-		{
-			{"$group", bson.D{
-				{"_id", nil},
-				{"balance", bson.D{
-					{"$sum", bson.D{
-						{"$cond", bson.D{
-							{"if", bson.D{{"$eq", bson.A{"$transaction_kind", entity.TransactionIn}}}},
-							{"then", "$amount"},
-							{"else", bson.D{{"$multiply", bson.A{-1, "$amount"}}}},
-						}},
-					}},
-				}},
-			}},
-		},
+func (r *TransactionRepo) UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus,
+	version int64) error {
+	now := time.Now()
+	set := bson.M{"status": string(status), "updated_at": now}
+	if status == entity.TransactionStatusSuccessful {
+		set["settled_at"] = now
+	}
+	filter := bson.M{"_id": transID, "version": version}
+	update := bson.M{
+		"$set": set,
+		"$inc": bson.M{"version": 1},
+	}
+	result, err := r.db.Collection(TransactionsCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return missedVersion(ctx, r.db.Collection(TransactionsCollection), "transaction", transID, version)
 	}
+	return nil
+}
 
-	cursor, err := r.db.Collection(TransactionsCollection).Aggregate(ctx, pipeline)
+// missedVersion tells why a write expecting a version matched no document: it does not exist or it is at another version
+func missedVersion(ctx context.Context, collection *mongo.Collection, kind string, id string, version int64) error {
+	count, err := collection.CountDocuments(ctx, bson.M{"_id": id})
 	if err != nil {
-		return 0, err
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%s %s: %w", kind, id, entity.ErrNotFound)
+	}
+	return entity.NewVersionConflictError(kind, id, version)
+}
+
+func (r *TransactionRepo) CountTransactionsByStatus(ctx context.Context,
+	statuses []entity.TransactionStatus) (map[entity.TransactionStatus]int64, error) {
+	values := make(bson.A, 0, len(statuses))
+	counts := make(map[entity.TransactionStatus]int64, len(statuses))
+	for _, status := range statuses {
+		values = append(values, string(status))
+		counts[status] = 0
 	}
 
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"status": bson.M{"$in": values}}}},
+		bson.D{{Key: "$group", Value: bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}}},
+	}
+	cursor, err := r.db.Collection(TransactionsCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
 	defer cursor.Close(ctx)
 
-	// Iterate over the cursor to get the result
-	if cursor.Next(ctx) {
-		if err := cursor.Decode(&result); err != nil {
-			return 0, err
+	for cursor.Next(ctx) {
+		var row struct {
+			Status string `bson:"_id"`
+			Count  int64  `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
 		}
+		counts[entity.TransactionStatus(row.Status)] = row.Count
 	}
-
-	// Handle errors in the aggregation cursor
 	if err := cursor.Err(); err != nil {
-		return 0, err
+		return nil, err
 	}
-
-	return result.Balance, nil
+	return counts, nil
 }
 
-func (r *TransactionRepo) GetTransactionByID(ctx context.Context, transID string) (*entity.Transaction, error) {
-	var transSchema schema2.TransactionSchema
-
-	transIdObj, err := primitive.ObjectIDFromHex(transID)
-	if err != nil {
-		return nil, err
-	}
-	if err := r.db.Collection(TransactionsCollection).FindOne(ctx, schema2.TransactionSchema{ID: transIdObj}).
-		Decode(&transSchema); err != nil {
+// LockWallet writes the wallet: a concurrent transaction writing it too fails with a write conflict,
+// which WithTransaction retries. The version is bumped like any other write of the wallet, so an update
+// versioned against the wallet read before the lock fails with a version conflict.
+func (r *TransactionRepo) LockWallet(ctx context.Context, walletID string) (*entity.Wallet, error) {
+	var walletSchema schema2.WalletSchema
+	update := bson.M{"$set": bson.M{"updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := r.db.Collection(WalletCollection).FindOneAndUpdate(ctx, bson.M{"_id": walletID}, update, opts).
+		Decode(&walletSchema); err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return transSchema.ToTransaction(), nil
+	return walletSchema.ToWallet(), nil
 }
 
-func (r *TransactionRepo) UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus) error {
-	update := bson.D{{"$set", bson.D{{"status", string(status)}}}}
-	transIDObj, err := primitive.ObjectIDFromHex(transID)
-	if err != nil {
-		return err
+func (r *TransactionRepo) GetPendingWithdrawals(ctx context.Context, walletID string) (float64, error) {
+	statuses := bson.A{}
+	for _, status := range entity.PendingStatuses() {
+		statuses = append(statuses, string(status))
 	}
-	_, err = r.db.Collection(TransactionsCollection).UpdateByID(ctx, transIDObj, update)
-	return err
+	balance, err := sumTransactions(ctx, r.db, bson.M{
+		"wallet_id":        walletID,
+		"transaction_kind": string(entity.TransactionOut),
+		"status":           bson.M{"$in": statuses},
+	})
+	// the withdrawals are summed negative
+	return -balance, err
 }
@@ -0,0 +1,47 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go-clean-template/internal/entity"
+	schema2 "go-clean-template/internal/infras/mongo/schema"
+	"go-clean-template/internal/infras/repotest"
+	"go-clean-template/pkg/testutil"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type contractFixture struct {
+	db *mongo.Database
+}
+
+func (f contractFixture) CreateWallet(t testing.TB, wallet *entity.Wallet) {
+	_, err := f.db.Collection(WalletCollection).InsertOne(context.Background(), &schema2.WalletSchema{
+		ID:         wallet.ID,
+		UserID:     wallet.UserID,
+		WalletName: wallet.WalletName,
+	})
+	require.NoError(t, err)
+}
+
+func (f contractFixture) CreateLinkedAccount(t testing.TB, account *entity.LinkedAccount) {
+	_, err := f.db.Collection(LinkedAccountCollection).InsertOne(context.Background(), &schema2.LinkedAccountSchema{
+		ID:          account.ID,
+		UserID:      account.UserID,
+		AccountName: account.AccountName,
+	})
+	require.NoError(t, err)
+}
+
+func TestTransactionRepo_Contract(t *testing.T) {
+	repotest.RunTransactionRepositoryContract(t, func(t *testing.T) repotest.TransactionBackend {
+		db := testutil.CreateMongoDatabase(t, "test1")
+		return repotest.TransactionBackend{
+			Repo:       NewTransactionRepo(db),
+			Fixture:    contractFixture{db: db},
+			Transactor: NewTransactor(db),
+		}
+	})
+}
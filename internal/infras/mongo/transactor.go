@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Transactor opens the transactions the repositories of the package join through the session of the context.
+// Transactions need a replica set, a standalone server rejects them.
+type Transactor struct {
+	db *mongo.Database
+}
+
+func NewTransactor(db *mongo.Database) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTransaction commits the writes of fn, or aborts them when fn returns an error. The driver retries fn
+// on the transient transaction errors, it must not have other side effects. A call within fn joins the
+// transaction of its ctx.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := t.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
@@ -23,3 +23,9 @@ func (b *PaymentServiceProvider) Withdraw(ctx context.Context, amount float64, c
 	fmt.Printf("Withdraw %.2f %s successfully\n", amount, currency)
 	return nil
 }
+
+// Ping checks the PSP is reachable, for the readiness probe
+func (b *PaymentServiceProvider) Ping(ctx context.Context) error {
+	//call psp api health endpoint
+	return nil
+}
@@ -0,0 +1,71 @@
+package postgrestore
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/postgrestore/schema"
+
+	"gorm.io/gorm"
+)
+
+const ApprovalTable = "transaction_approvals"
+
+type ApprovalRepo struct {
+	db *gorm.DB
+}
+
+func NewApprovalRepo(db *gorm.DB) *ApprovalRepo {
+	return &ApprovalRepo{db: db}
+}
+
+func (r *ApprovalRepo) SaveApproval(ctx context.Context, approval *entity.Approval) error {
+	approvalSchema := schema.ToApprovalSchema(approval)
+	approvalSchema.ExpiresAt = utc(approvalSchema.ExpiresAt)
+	approvalSchema.DecidedAt = utcPtr(approvalSchema.DecidedAt)
+	return conn(ctx, r.db).Table(ApprovalTable).Create(approvalSchema).Error
+}
+
+func (r *ApprovalRepo) GetApprovalByTransactionID(ctx context.Context, transID string) (*entity.Approval, error) {
+	var approvalSchema schema.ApprovalSchema
+	if err := conn(ctx, r.db).Table(ApprovalTable).Where("transaction_id = ?", transID).Take(&approvalSchema).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return approvalSchema.ToApproval(), nil
+}
+
+func (r *ApprovalRepo) UpdateApproval(ctx context.Context, approval *entity.Approval) error {
+	result := conn(ctx, r.db).Table(ApprovalTable).
+		Where("id = ? AND status = ?", approval.ID, entity.ApprovalStatusPending).
+		Updates(map[string]interface{}{
+			"decided_by": approval.DecidedBy,
+			"status":     string(approval.Status),
+			"reason":     approval.Reason,
+			"decided_at": utcPtr(approval.DecidedAt),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return entity.ErrApprovalDecided
+	}
+	return nil
+}
+
+func (r *ApprovalRepo) ListExpiredApprovals(ctx context.Context, now time.Time, limit int) ([]*entity.Approval, error) {
+	var approvalSchemas []schema.ApprovalSchema
+	if err := conn(ctx, r.db).Table(ApprovalTable).
+		Where("status = ? AND expires_at <= ?", entity.ApprovalStatusPending, utc(now)).
+		Order("expires_at").Limit(limit).Find(&approvalSchemas).Error; err != nil {
+		return nil, err
+	}
+	approvals := make([]*entity.Approval, 0, len(approvalSchemas))
+	for _, approvalSchema := range approvalSchemas {
+		approvals = append(approvals, approvalSchema.ToApproval())
+	}
+	return approvals, nil
+}
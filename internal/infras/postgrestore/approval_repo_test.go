@@ -0,0 +1,209 @@
+package postgrestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/postgrestore/schema"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initTransactionForApproval(t testing.TB, db *gorm.DB, transID string) {
+	t.Helper()
+
+	userId := uuid.New().String()
+	query := `INSERT INTO users (id,full_name, email, phone_number,current_address)
+		VALUES (?, 'Phan Ngoc Quang', 'quangpn@tm.teqn.asia', '0123456789', 'HCM')`
+	assert.NoError(t, db.Exec(query, userId).Error)
+
+	wallet := &schema.WalletSchema{
+		ID:         uuid.New().String(),
+		UserID:     userId,
+		WalletName: "My wallet",
+	}
+	assert.NoError(t, db.Table(WalletTable).Create(wallet).Error)
+
+	account := &schema.LinkedAccountSchema{
+		ID:          uuid.New().String(),
+		UserID:      userId,
+		AccountName: "momo",
+	}
+	assert.NoError(t, db.Table(LinkedAccountTable).Create(account).Error)
+
+	trans := entity.NewTransaction(transID, wallet.ID, account.ID, 60000000, "VND",
+		entity.TransactionOut, "", entity.TransactionStatusAwaitingApproval)
+	assert.NoError(t, db.Table(TransactionsTable).Create(schema.ToTransactionSchema(trans)).Error)
+}
+
+func TestApprovalRepo_SaveApproval(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewApprovalRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: save approval", func(t *testing.T) {
+			//Arrange
+			transID := "t_001"
+			initTransactionForApproval(t, db, transID)
+			want := entity.NewApproval("ap_001", transID, "u_001", time.Now().Add(time.Hour).UTC().Truncate(time.Second))
+
+			//Act
+			err := repo.SaveApproval(ctx, want)
+
+			//Assert
+			assert.NoError(t, err)
+			var got schema.ApprovalSchema
+			assert.NoError(t, repo.db.Table(ApprovalTable).Where("id = ?", want.ID).Take(&got).Error)
+			assertApproval(t, want, got.ToApproval())
+		})
+	})
+}
+
+func TestApprovalRepo_GetApprovalByTransactionID(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewApprovalRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: get approval by transaction id", func(t *testing.T) {
+			//Arrange
+			transID := "t_001"
+			initTransactionForApproval(t, db, transID)
+			want := entity.NewApproval("ap_001", transID, "u_001", time.Now().Add(time.Hour).UTC().Truncate(time.Second))
+			assert.NoError(t, repo.db.Table(ApprovalTable).Create(schema.ToApprovalSchema(want)).Error)
+
+			//Act
+			got, err := repo.GetApprovalByTransactionID(ctx, transID)
+
+			//Assert
+			assert.NoError(t, err)
+			assertApproval(t, want, got)
+		})
+
+		t.Run("record not found", func(t *testing.T) {
+			//Act
+			got, err := repo.GetApprovalByTransactionID(ctx, "t_002")
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Nil(t, got)
+		})
+	})
+}
+
+func TestApprovalRepo_UpdateApproval(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewApprovalRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: update approval", func(t *testing.T) {
+			//Arrange
+			transID := "t_001"
+			initTransactionForApproval(t, db, transID)
+			approval := entity.NewApproval("ap_001", transID, "u_001", time.Now().Add(time.Hour).UTC().Truncate(time.Second))
+			assert.NoError(t, repo.db.Table(ApprovalTable).Create(schema.ToApprovalSchema(approval)).Error)
+			assert.NoError(t, approval.Reject("u_002", "suspicious", time.Now().UTC().Truncate(time.Second)))
+
+			//Act
+			err := repo.UpdateApproval(ctx, approval)
+
+			//Assert
+			assert.NoError(t, err)
+			got, err := repo.GetApprovalByTransactionID(ctx, transID)
+			assert.NoError(t, err)
+			assertApproval(t, approval, got)
+		})
+
+		t.Run("bad case: approval already decided", func(t *testing.T) {
+			//Arrange
+			transID := "t_002"
+			initTransactionForApproval(t, db, transID)
+			approval := entity.NewApproval("ap_002", transID, "u_001", time.Now().Add(time.Hour).UTC().Truncate(time.Second))
+			assert.NoError(t, repo.db.Table(ApprovalTable).Create(schema.ToApprovalSchema(approval)).Error)
+			approved, rejected := *approval, *approval
+			assert.NoError(t, approved.Approve("u_002", time.Now().UTC().Truncate(time.Second)))
+			assert.NoError(t, rejected.Reject("u_003", "suspicious", time.Now().UTC().Truncate(time.Second)))
+			assert.NoError(t, repo.UpdateApproval(ctx, &approved))
+
+			//Act
+			err := repo.UpdateApproval(ctx, &rejected)
+
+			//Assert
+			assert.ErrorIs(t, err, entity.ErrApprovalDecided)
+			got, err := repo.GetApprovalByTransactionID(ctx, transID)
+			assert.NoError(t, err)
+			assertApproval(t, &approved, got)
+		})
+
+		t.Run("bad case: approval not found", func(t *testing.T) {
+			//Arrange
+			approval := entity.NewApproval("ap_missing", "t_missing", "u_001", time.Now().Add(time.Hour))
+			assert.NoError(t, approval.Approve("u_002", time.Now()))
+
+			//Act
+			err := repo.UpdateApproval(ctx, approval)
+
+			//Assert
+			assert.ErrorIs(t, err, entity.ErrApprovalDecided)
+		})
+	})
+}
+
+func TestApprovalRepo_ListExpiredApprovals(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewApprovalRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: list pending approvals past their deadline, oldest first", func(t *testing.T) {
+			//Arrange
+			now := time.Now().UTC().Truncate(time.Second)
+			approvals := []*entity.Approval{
+				entity.NewApproval("ap_001", "t_001", "u_001", now.Add(-time.Minute)),
+				entity.NewApproval("ap_002", "t_002", "u_001", now.Add(-time.Hour)),
+				entity.NewApproval("ap_003", "t_003", "u_001", now.Add(time.Hour)),
+				entity.NewApproval("ap_004", "t_004", "u_001", now.Add(-2*time.Hour)),
+				entity.NewApproval("ap_005", "t_005", "u_001", now),
+			}
+			assert.NoError(t, approvals[3].Reject("u_002", "suspicious", now.Add(-3*time.Hour)))
+			for _, approval := range approvals {
+				initTransactionForApproval(t, db, approval.TransactionID)
+				assert.NoError(t, repo.db.Table(ApprovalTable).Create(schema.ToApprovalSchema(approval)).Error)
+			}
+
+			//Act
+			got, err := repo.ListExpiredApprovals(ctx, now, 10)
+
+			//Assert
+			assert.NoError(t, err)
+			if assert.Len(t, got, 3) {
+				assertApproval(t, approvals[1], got[0])
+				assertApproval(t, approvals[0], got[1])
+				assertApproval(t, approvals[4], got[2])
+			}
+
+			//Act
+			got, err = repo.ListExpiredApprovals(ctx, now, 1)
+
+			//Assert
+			assert.NoError(t, err)
+			if assert.Len(t, got, 1) {
+				assertApproval(t, approvals[1], got[0])
+			}
+		})
+	})
+}
+
+func assertApproval(t testing.TB, want *entity.Approval, got *entity.Approval) {
+	t.Helper()
+
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.TransactionID, got.TransactionID)
+	assert.Equal(t, want.RequestedBy, got.RequestedBy)
+	assert.Equal(t, want.DecidedBy, got.DecidedBy)
+	assert.Equal(t, want.Status, got.Status)
+	assert.Equal(t, want.Reason, got.Reason)
+	assert.True(t, want.ExpiresAt.Equal(got.ExpiresAt))
+}
@@ -0,0 +1,71 @@
+package postgrestore
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/postgrestore/schema"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const BalanceSnapshotTable = "balance_snapshots"
+
+type BalanceRepo struct {
+	db *gorm.DB
+}
+
+func NewBalanceRepo(db *gorm.DB) *BalanceRepo {
+	return &BalanceRepo{db: db}
+}
+
+func (r *BalanceRepo) GetLatestSnapshot(ctx context.Context, walletID string, asOf time.Time) (*entity.BalanceSnapshot, error) {
+	var snapshotSchema schema.BalanceSnapshotSchema
+	if err := conn(ctx, r.db).Table(BalanceSnapshotTable).Where("wallet_id = ? AND as_of <= ?", walletID, utc(asOf)).
+		Order("as_of DESC").Take(&snapshotSchema).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return snapshotSchema.ToBalanceSnapshot(), nil
+}
+
+func (r *BalanceRepo) SaveSnapshot(ctx context.Context, snapshot *entity.BalanceSnapshot) error {
+	snapshotSchema := schema.ToBalanceSnapshotSchema(snapshot)
+	snapshotSchema.AsOf = utc(snapshotSchema.AsOf)
+	return conn(ctx, r.db).Table(BalanceSnapshotTable).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "wallet_id"}, {Name: "as_of"}},
+			DoUpdates: clause.AssignmentColumns([]string{"balance", "updated_at"}),
+		}).
+		Create(snapshotSchema).Error
+}
+
+func (r *BalanceRepo) SumTransactions(ctx context.Context, walletID string, from time.Time, to time.Time) (float64, error) {
+	var balance float64
+	query := conn(ctx, r.db).Table(TransactionsTable).
+		Select(balanceQuery, entity.TransactionIn).
+		Where("wallet_id = ? AND status = ?", walletID, entity.TransactionStatusSuccessful)
+	if !from.IsZero() {
+		query = query.Where("settled_at >= ?", utc(from))
+	}
+	if !to.IsZero() {
+		query = query.Where("settled_at < ?", utc(to))
+	}
+	if err := query.Row().Scan(&balance); err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+func (r *BalanceRepo) ListWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	var walletIDs []string
+	if err := conn(ctx, r.db).Table(WalletTable).Where("id > ?", afterID).
+		Order("id").Limit(limit).Pluck("id", &walletIDs).Error; err != nil {
+		return nil, err
+	}
+	return walletIDs, nil
+}
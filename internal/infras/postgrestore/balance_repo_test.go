@@ -0,0 +1,147 @@
+package postgrestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBalanceRepo_Snapshots(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewBalanceRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: save, replace and get latest snapshot", func(t *testing.T) {
+			//Arrange
+			wallet, _ := initWalletForSchedule(t, db)
+			first := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+			second := first.AddDate(0, 0, 1)
+			assert.NoError(t, repo.SaveSnapshot(ctx, entity.NewBalanceSnapshot(wallet.ID, first, 1000)))
+			assert.NoError(t, repo.SaveSnapshot(ctx, entity.NewBalanceSnapshot(wallet.ID, second, 1200)))
+
+			//Act
+			err := repo.SaveSnapshot(ctx, entity.NewBalanceSnapshot(wallet.ID, second, 1500))
+
+			//Assert
+			assert.NoError(t, err)
+			got, err := repo.GetLatestSnapshot(ctx, wallet.ID, second.Add(time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, 1500.0, got.Balance)
+			assert.True(t, second.Equal(got.AsOf))
+
+			got, err = repo.GetLatestSnapshot(ctx, wallet.ID, second.Add(-time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, 1000.0, got.Balance)
+		})
+
+		t.Run("snapshot not found", func(t *testing.T) {
+			//Act
+			got, err := repo.GetLatestSnapshot(ctx, "w_0002", time.Now())
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Nil(t, got)
+		})
+	})
+}
+
+func TestBalanceRepo_SumTransactions(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewBalanceRepo(db)
+		ctx := context.Background()
+
+		closeAt := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+		wallet, account := initWalletForSchedule(t, db)
+		initTransactionForStatement(t, db, wallet.ID, account.ID, 1000, entity.TransactionIn,
+			entity.TransactionStatusSuccessful, closeAt.Add(-time.Hour))
+		initTransactionForStatement(t, db, wallet.ID, account.ID, 5000, entity.TransactionIn,
+			entity.TransactionStatusFailed, closeAt.Add(time.Hour))
+		initTransactionForStatement(t, db, wallet.ID, account.ID, 300, entity.TransactionOut,
+			entity.TransactionStatusSuccessful, closeAt.Add(2*time.Hour))
+
+		tests := []struct {
+			name string
+			from time.Time
+			to   time.Time
+			want float64
+		}{
+			{name: "every transaction", want: 700},
+			{name: "before the close", to: closeAt, want: 1000},
+			{name: "tail after the close", from: closeAt, want: -300},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				//Act
+				got, err := repo.SumTransactions(ctx, wallet.ID, tt.from, tt.to)
+
+				//Assert
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			})
+		}
+	})
+}
+
+func TestBalanceRepo_SumTransactions_SettledAfterTheSnapshot(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		//Arrange
+		repo := NewBalanceRepo(db)
+		transRepo := NewTransactionRepo(db)
+		ctx := context.Background()
+		closeAt := entity.DayClose(time.Now())
+		wallet, account := initWalletForSchedule(t, db)
+		initTransactionForStatement(t, db, wallet.ID, account.ID, 1000, entity.TransactionIn,
+			entity.TransactionStatusSuccessful, closeAt.Add(-2*time.Hour))
+		trans := initTransactionForStatement(t, db, wallet.ID, account.ID, 300, entity.TransactionOut,
+			entity.TransactionStatusNew, closeAt.Add(-time.Hour))
+		snapshot, err := repo.SumTransactions(ctx, wallet.ID, time.Time{}, closeAt)
+		assert.NoError(t, err)
+
+		//Act
+		err = transRepo.UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful, trans.Version)
+
+		//Assert
+		assert.NoError(t, err)
+		tail, err := repo.SumTransactions(ctx, wallet.ID, closeAt, time.Time{})
+		assert.NoError(t, err)
+		balance, err := transRepo.GetBalanceByWalletID(ctx, wallet.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1000.0, snapshot)
+		assert.Equal(t, -300.0, tail)
+		assert.Equal(t, balance, snapshot+tail)
+	})
+}
+
+func TestBalanceRepo_ListWalletIDs(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewBalanceRepo(db)
+		ctx := context.Background()
+
+		t.Run("page through wallet ids", func(t *testing.T) {
+			//Arrange
+			first, _ := initWalletForSchedule(t, db)
+			second, _ := initWalletForSchedule(t, db)
+			want := []string{first.ID, second.ID}
+			if want[0] > want[1] {
+				want[0], want[1] = want[1], want[0]
+			}
+
+			//Act
+			page1, err1 := repo.ListWalletIDs(ctx, "", 1)
+			page2, err2 := repo.ListWalletIDs(ctx, page1[0], 1)
+			page3, err3 := repo.ListWalletIDs(ctx, page2[0], 1)
+
+			//Assert
+			assert.NoError(t, err1)
+			assert.NoError(t, err2)
+			assert.NoError(t, err3)
+			assert.Equal(t, want, append(page1, page2...))
+			assert.Empty(t, page3)
+		})
+	})
+}
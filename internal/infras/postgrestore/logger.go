@@ -0,0 +1,109 @@
+package postgrestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// LogOptions bridges the GORM logs to zap
+type LogOptions struct {
+	// Logger is the zap logger, the GORM logs are dropped when nil
+	Logger *zap.SugaredLogger
+	// Level is silent, error, warn or info. Errors are failed statements, warnings slow ones and
+	// info every statement.
+	Level         string
+	SlowThreshold time.Duration
+	// Params logs the values of the statements, off by default as they hold personal data
+	Params bool
+}
+
+var logLevels = map[string]gormlogger.LogLevel{
+	"silent": gormlogger.Silent,
+	"error":  gormlogger.Error,
+	"warn":   gormlogger.Warn,
+	"info":   gormlogger.Info,
+}
+
+// zapLogger writes the GORM logs to zap: failed statements as errors, the ones slower than slowThreshold
+// as warnings and, at the info level, every statement
+type zapLogger struct {
+	log           *zap.SugaredLogger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+	params        bool
+}
+
+// NewGormLogger is the GORM logger of opt, sqlitestore logs through it as well
+func NewGormLogger(opt LogOptions) (gormlogger.Interface, error) {
+	level := gormlogger.Warn
+	if opt.Level != "" {
+		var ok bool
+		if level, ok = logLevels[opt.Level]; !ok {
+			return nil, fmt.Errorf("unknown log level %q, must be silent, error, warn or info", opt.Level)
+		}
+	}
+	if opt.Logger == nil {
+		level = gormlogger.Silent
+	}
+
+	return &zapLogger{log: opt.Logger, level: level, slowThreshold: opt.SlowThreshold, params: opt.Params}, nil
+}
+
+func (l *zapLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *zapLogger) Info(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log.Infof(msg, args...)
+	}
+}
+
+func (l *zapLogger) Warn(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log.Warnf(msg, args...)
+	}
+}
+
+func (l *zapLogger) Error(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log.Errorf(msg, args...)
+	}
+}
+
+func (l *zapLogger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	fields := func() []interface{} {
+		sql, rows := fc()
+		return []interface{}{zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed)}
+	}
+	switch {
+	// the repositories return nil - nil for a missing record, it is not a failure
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		l.log.Errorw("sql failed", append(fields(), zap.Error(err))...)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		l.log.Warnw("slow sql", append(fields(), zap.Duration("threshold", l.slowThreshold))...)
+	case l.level >= gormlogger.Info:
+		l.log.Infow("sql", fields()...)
+	}
+}
+
+// ParamsFilter keeps the placeholders in the logged statements unless the values are asked for
+func (l *zapLogger) ParamsFilter(_ context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.params {
+		return sql, params
+	}
+	return sql, nil
+}
@@ -0,0 +1,128 @@
+package postgrestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+)
+
+func newObservedLogger(t *testing.T, opt LogOptions) (*zapLogger, *observer.ObservedLogs) {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	opt.Logger = zap.New(core).Sugar()
+	l, err := NewGormLogger(opt)
+	require.NoError(t, err)
+	return l.(*zapLogger), logs
+}
+
+func TestZapLogger_Trace(t *testing.T) {
+	statement := func() (string, int64) {
+		return "SELECT * FROM wallets WHERE id = 'w_001'", 1
+	}
+
+	t.Run("failed statement is an error", func(t *testing.T) {
+		// Arrange
+		l, logs := newObservedLogger(t, LogOptions{Level: "error"})
+
+		// Act
+		l.Trace(context.Background(), time.Now(), statement, errors.New("connection reset"))
+
+		// Assert
+		require.Equal(t, 1, logs.Len())
+		entry := logs.All()[0]
+		assert.Equal(t, zapcore.ErrorLevel, entry.Level)
+		assert.Equal(t, "connection reset", entry.ContextMap()["error"])
+	})
+
+	t.Run("missing record is not an error", func(t *testing.T) {
+		// Arrange
+		l, logs := newObservedLogger(t, LogOptions{Level: "error"})
+
+		// Act
+		l.Trace(context.Background(), time.Now(), statement, gorm.ErrRecordNotFound)
+
+		// Assert
+		assert.Equal(t, 0, logs.Len())
+	})
+
+	t.Run("slow statement is a warning", func(t *testing.T) {
+		// Arrange
+		l, logs := newObservedLogger(t, LogOptions{Level: "warn", SlowThreshold: 100 * time.Millisecond})
+
+		// Act
+		l.Trace(context.Background(), time.Now().Add(-time.Second), statement, nil)
+		l.Trace(context.Background(), time.Now(), statement, nil)
+
+		// Assert
+		require.Equal(t, 1, logs.Len())
+		entry := logs.All()[0]
+		assert.Equal(t, zapcore.WarnLevel, entry.Level)
+		assert.Equal(t, "slow sql", entry.Message)
+		assert.Equal(t, "SELECT * FROM wallets WHERE id = 'w_001'", entry.ContextMap()["sql"])
+	})
+
+	t.Run("info logs every statement", func(t *testing.T) {
+		// Arrange
+		l, logs := newObservedLogger(t, LogOptions{Level: "info"})
+
+		// Act
+		l.Trace(context.Background(), time.Now(), statement, nil)
+
+		// Assert
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, zapcore.InfoLevel, logs.All()[0].Level)
+	})
+
+	t.Run("no zap logger is silent", func(t *testing.T) {
+		// Arrange
+		l, err := NewGormLogger(LogOptions{Level: "info"})
+		require.NoError(t, err)
+
+		// Act & Assert
+		assert.NotPanics(t, func() {
+			l.Trace(context.Background(), time.Now(), statement, errors.New("connection reset"))
+		})
+	})
+}
+
+func TestZapLogger_ParamsFilter(t *testing.T) {
+	t.Run("values are hidden by default", func(t *testing.T) {
+		// Arrange
+		l, _ := newObservedLogger(t, LogOptions{})
+
+		// Act
+		sql, params := l.ParamsFilter(context.Background(), "SELECT * FROM users WHERE email = $1", "a@b.c")
+
+		// Assert
+		assert.Equal(t, "SELECT * FROM users WHERE email = $1", sql)
+		assert.Nil(t, params)
+	})
+
+	t.Run("values are logged when asked for", func(t *testing.T) {
+		// Arrange
+		l, _ := newObservedLogger(t, LogOptions{Params: true})
+
+		// Act
+		_, params := l.ParamsFilter(context.Background(), "SELECT * FROM users WHERE email = $1", "a@b.c")
+
+		// Assert
+		assert.Equal(t, []interface{}{"a@b.c"}, params)
+	})
+}
+
+func TestNewGormLogger_UnknownLevel(t *testing.T) {
+	// Act
+	_, err := NewGormLogger(LogOptions{Level: "debug"})
+
+	// Assert
+	assert.Error(t, err)
+}
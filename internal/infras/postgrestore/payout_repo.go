@@ -0,0 +1,100 @@
+package postgrestore
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/postgrestore/schema"
+
+	"gorm.io/gorm"
+)
+
+const (
+	PayoutBatchTable = "payout_batches"
+	PayoutItemTable  = "payout_items"
+)
+
+// payoutItemsInsertBatchSize keeps the bind parameters of one insert below the SQLite limit, lower than the postgres one
+const payoutItemsInsertBatchSize = 200
+
+type PayoutRepo struct {
+	db *gorm.DB
+}
+
+func NewPayoutRepo(db *gorm.DB) *PayoutRepo {
+	return &PayoutRepo{db: db}
+}
+
+func (r *PayoutRepo) SavePayoutBatch(ctx context.Context, batch *entity.PayoutBatch) error {
+	items := make([]*schema.PayoutItemSchema, 0, len(batch.Items))
+	for _, item := range batch.Items {
+		items = append(items, schema.ToPayoutItemSchema(item))
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(PayoutBatchTable).Create(schema.ToPayoutBatchSchema(batch)).Error; err != nil {
+			return err
+		}
+		return tx.Table(PayoutItemTable).CreateInBatches(items, payoutItemsInsertBatchSize).Error
+	})
+}
+
+func (r *PayoutRepo) GetPayoutBatchByID(ctx context.Context, batchID string) (*entity.PayoutBatch, error) {
+	var batchSchema schema.PayoutBatchSchema
+	if err := conn(ctx, r.db).Table(PayoutBatchTable).Where("id = ?", batchID).Take(&batchSchema).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var itemSchemas []schema.PayoutItemSchema
+	if err := conn(ctx, r.db).Table(PayoutItemTable).Where("batch_id = ?", batchID).
+		Order("line").Find(&itemSchemas).Error; err != nil {
+		return nil, err
+	}
+	return batchSchema.ToPayoutBatch(itemSchemas), nil
+}
+
+func (r *PayoutRepo) ClaimPayoutBatch(ctx context.Context, batchID string, now time.Time, until time.Time) (bool, error) {
+	res := conn(ctx, r.db).Table(PayoutBatchTable).
+		Where("id = ? AND status <> ?", batchID, string(entity.PayoutBatchStatusCompleted)).
+		Where("claimed_until IS NULL OR claimed_until <= ?", utc(now)).
+		Updates(map[string]interface{}{
+			"status":        string(entity.PayoutBatchStatusProcessing),
+			"claimed_until": utc(until),
+			"updated_at":    r.db.NowFunc(),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *PayoutRepo) ListClaimablePayoutBatchIDs(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	var ids []string
+	err := conn(ctx, r.db).Table(PayoutBatchTable).
+		Where("status <> ?", string(entity.PayoutBatchStatusCompleted)).
+		Where("claimed_until IS NULL OR claimed_until <= ?", utc(now)).
+		Order("created_at").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *PayoutRepo) UpdatePayoutBatchStatus(ctx context.Context, batchID string, status entity.PayoutBatchStatus) error {
+	return conn(ctx, r.db).Table(PayoutBatchTable).Where("id = ?", batchID).
+		Updates(map[string]interface{}{
+			"status":     string(status),
+			"updated_at": r.db.NowFunc(),
+		}).Error
+}
+
+func (r *PayoutRepo) UpdatePayoutItem(ctx context.Context, item *entity.PayoutItem) error {
+	return conn(ctx, r.db).Table(PayoutItemTable).Where("id = ?", item.ID).
+		Updates(map[string]interface{}{
+			"status":         string(item.Status),
+			"transaction_id": item.TransactionID,
+			"reason":         item.Reason,
+			"updated_at":     r.db.NowFunc(),
+		}).Error
+}
@@ -0,0 +1,158 @@
+package postgrestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newPayoutBatchForTest(t testing.TB, batchID string) *entity.PayoutBatch {
+	t.Helper()
+
+	batch, err := entity.NewPayoutBatch(batchID, "u_001", []*entity.PayoutItem{
+		{WalletID: "w_001", AccountID: "a_001", Amount: 1000, Currency: "VND", Note: "salary"},
+		{WalletID: "w_002", AccountID: "a_002", Amount: 2000, Currency: "VND", Note: "salary"},
+	})
+	assert.NoError(t, err)
+	return batch
+}
+
+func TestPayoutRepo_SaveAndGetPayoutBatch(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewPayoutRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: save and get payout batch", func(t *testing.T) {
+			//Arrange
+			want := newPayoutBatchForTest(t, "b_001")
+
+			//Act
+			err := repo.SavePayoutBatch(ctx, want)
+
+			//Assert
+			assert.NoError(t, err)
+			got, err := repo.GetPayoutBatchByID(ctx, want.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+
+		t.Run("record not found", func(t *testing.T) {
+			//Act
+			got, err := repo.GetPayoutBatchByID(ctx, "b_002")
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Nil(t, got)
+		})
+	})
+}
+
+func TestPayoutRepo_UpdatePayoutBatch(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewPayoutRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: update batch status and item results", func(t *testing.T) {
+			//Arrange
+			batch := newPayoutBatchForTest(t, "b_003")
+			assert.NoError(t, repo.SavePayoutBatch(ctx, batch))
+			assert.NoError(t, batch.Items[0].ToSucceeded("t_001"))
+			assert.NoError(t, batch.Items[1].ToFailed("", "insufficient balance"))
+
+			//Act
+			errBatch := repo.UpdatePayoutBatchStatus(ctx, batch.ID, entity.PayoutBatchStatusCompleted)
+			errItem1 := repo.UpdatePayoutItem(ctx, batch.Items[0])
+			errItem2 := repo.UpdatePayoutItem(ctx, batch.Items[1])
+
+			//Assert
+			assert.NoError(t, errBatch)
+			assert.NoError(t, errItem1)
+			assert.NoError(t, errItem2)
+			batch.Status = entity.PayoutBatchStatusCompleted
+			got, err := repo.GetPayoutBatchByID(ctx, batch.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, batch, got)
+		})
+	})
+}
+
+func TestPayoutRepo_ClaimPayoutBatch(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewPayoutRepo(db)
+		ctx := context.Background()
+		now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+		until := now.Add(entity.PayoutBatchLease)
+
+		t.Run("success: claim a new batch then take it over after its lease", func(t *testing.T) {
+			//Arrange
+			batch := newPayoutBatchForTest(t, "b_004")
+			assert.NoError(t, repo.SavePayoutBatch(ctx, batch))
+
+			//Act
+			claimed, err := repo.ClaimPayoutBatch(ctx, batch.ID, now, until)
+			claimedAgain, errAgain := repo.ClaimPayoutBatch(ctx, batch.ID, until.Add(-time.Second), until)
+			takenOver, errTakenOver := repo.ClaimPayoutBatch(ctx, batch.ID, until, until.Add(entity.PayoutBatchLease))
+
+			//Assert
+			assert.NoError(t, err)
+			assert.True(t, claimed)
+			assert.NoError(t, errAgain)
+			assert.False(t, claimedAgain)
+			assert.NoError(t, errTakenOver)
+			assert.True(t, takenOver)
+			got, err := repo.GetPayoutBatchByID(ctx, batch.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, entity.PayoutBatchStatusProcessing, got.Status)
+			assert.True(t, until.Add(entity.PayoutBatchLease).Equal(got.ClaimedUntil))
+		})
+
+		t.Run("completed or missing batch is not claimed", func(t *testing.T) {
+			//Arrange
+			batch := newPayoutBatchForTest(t, "b_005")
+			assert.NoError(t, repo.SavePayoutBatch(ctx, batch))
+			assert.NoError(t, repo.UpdatePayoutBatchStatus(ctx, batch.ID, entity.PayoutBatchStatusCompleted))
+
+			//Act
+			claimedCompleted, errCompleted := repo.ClaimPayoutBatch(ctx, batch.ID, now, until)
+			claimedMissing, errMissing := repo.ClaimPayoutBatch(ctx, "b_006", now, until)
+
+			//Assert
+			assert.NoError(t, errCompleted)
+			assert.False(t, claimedCompleted)
+			assert.NoError(t, errMissing)
+			assert.False(t, claimedMissing)
+		})
+	})
+}
+
+func TestPayoutRepo_ListClaimablePayoutBatchIDs(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		//Arrange
+		repo := NewPayoutRepo(db)
+		ctx := context.Background()
+		now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+		for _, id := range []string{"b_007", "b_008", "b_009", "b_010"} {
+			assert.NoError(t, repo.SavePayoutBatch(ctx, newPayoutBatchForTest(t, id)))
+		}
+		_, err := repo.ClaimPayoutBatch(ctx, "b_008", now.Add(-time.Hour), now.Add(-time.Minute))
+		assert.NoError(t, err)
+		_, err = repo.ClaimPayoutBatch(ctx, "b_009", now, now.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.NoError(t, repo.UpdatePayoutBatchStatus(ctx, "b_010", entity.PayoutBatchStatusCompleted))
+
+		//Act
+		got, err := repo.ListClaimablePayoutBatchIDs(ctx, now, 10)
+		limited, errLimited := repo.ListClaimablePayoutBatchIDs(ctx, now, 1)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"b_007", "b_008"}, got)
+		assert.NoError(t, errLimited)
+		assert.Len(t, limited, 1)
+	})
+}
@@ -2,11 +2,18 @@ package postgrestore
 
 import (
 	"fmt"
-	"go-clean-template/pkg/config"
+	"math"
 	"strconv"
+	"strings"
+	"time"
+
+	"go-clean-template/pkg/config"
 
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
 type Options struct {
@@ -15,34 +22,209 @@ type Options struct {
 	Password string
 	Host     string
 	Port     string
-	SSLMode  bool
+	// SSLMode is a libpq sslmode: disable, allow, prefer, require, verify-ca or verify-full
+	SSLMode     string
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+	// ConnectTimeout bounds the dial and StatementTimeout makes the server cancel longer statements, zero is no limit
+	ConnectTimeout   time.Duration
+	StatementTimeout time.Duration
+	Pool             PoolOptions
+	// ReplicaDSNs are the read replicas, queried through ReadOnly
+	ReplicaDSNs []string
+	Log         LogOptions
+	// Tracing records a span per statement, without the query parameters. Nil traces nothing.
+	Tracing trace.TracerProvider
+}
+
+// PoolOptions limits the connections of the primary and of every replica, zero keeps the database/sql default
+type PoolOptions struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 }
 
 func ParseFromConfig(c *config.Config) Options {
+	sslMode := c.DB.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+		if c.DB.EnableSSL {
+			sslMode = "require"
+		}
+	}
+
 	return Options{
-		DBName:   c.DB.Name,
-		DBUser:   c.DB.User,
-		Password: c.DB.Pass,
-		Host:     c.DB.Host,
-		Port:     strconv.Itoa(c.DB.Port),
-		SSLMode:  c.DB.EnableSSL,
+		DBName:           c.DB.Name,
+		DBUser:           c.DB.User,
+		Password:         c.DB.Pass,
+		Host:             c.DB.Host,
+		Port:             strconv.Itoa(c.DB.Port),
+		SSLMode:          sslMode,
+		SSLRootCert:      c.DB.SSLRootCert,
+		SSLCert:          c.DB.SSLCert,
+		SSLKey:           c.DB.SSLKey,
+		ConnectTimeout:   c.DB.ConnectTimeout,
+		StatementTimeout: c.DB.StatementTimeout,
+		Pool: PoolOptions{
+			MaxOpenConns:    c.DB.MaxOpenConns,
+			MaxIdleConns:    c.DB.MaxIdleConns,
+			ConnMaxLifetime: c.DB.ConnMaxLifetime,
+			ConnMaxIdleTime: c.DB.ConnMaxIdleTime,
+		},
+		ReplicaDSNs: c.DB.ReplicaDSNs,
+		Log: LogOptions{
+			Level:         c.DB.LogLevel,
+			SlowThreshold: c.DB.SlowThreshold,
+			Params:        c.DB.LogParams,
+		},
 	}
 }
 
+// NewDB connects to the primary. With replicas, the reads of ReadOnly(db) are spread over them and
+// everything else, transactions included, stays on the primary.
 func NewDB(opt Options) (*gorm.DB, error) {
-	dsnStr := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s",
-		opt.Host,
-		opt.DBUser,
-		opt.Password,
-		opt.DBName,
-		opt.Port,
-	)
+	gormLogger, err := NewGormLogger(opt.Log)
+	if err != nil {
+		return nil, err
+	}
 
-	db, err := gorm.Open(postgres.Open(dsnStr), &gorm.Config{TranslateError: true})
+	db, err := gorm.Open(postgres.Open(opt.dsn()), &gorm.Config{TranslateError: true, Logger: gormLogger})
 	if err != nil {
 		return nil, err
 	}
-	db = db.Debug()
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	opt.Pool.apply(sqlDB)
+
+	if opt.Tracing != nil {
+		if err := db.Use(newTracingPlugin(opt)); err != nil {
+			return nil, fmt.Errorf("failed to register tracing: %w", err)
+		}
+	}
+
+	if len(opt.ReplicaDSNs) > 0 {
+		replicas := make([]gorm.Dialector, 0, len(opt.ReplicaDSNs))
+		for _, dsn := range opt.ReplicaDSNs {
+			replicas = append(replicas, postgres.Open(dsn))
+		}
+		if db, err = withReplicas(db, replicas, opt.Pool); err != nil {
+			return nil, fmt.Errorf("failed to connect replicas: %w", err)
+		}
+	}
 
 	return db, nil
 }
+
+// newTracingPlugin traces the statements, the metrics are left to the repository decorators
+func newTracingPlugin(opt Options) gorm.Plugin {
+	return tracing.NewPlugin(
+		tracing.WithTracerProvider(opt.Tracing),
+		tracing.WithDBName(opt.DBName),
+		tracing.WithoutQueryVariables(),
+		tracing.WithoutMetrics(),
+	)
+}
+
+// ReadOnly routes the queries of db to a replica when NewDB connected some. Only for the reads that
+// tolerate the replication lag: a read deciding a write, like the balance checked by a withdrawal, must
+// query the primary. Create, Update and Delete still run on the primary but Exec does not.
+func ReadOnly(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Read).Session(&gorm.Session{})
+}
+
+// withReplicas registers the resolver and pins db to the primary, ReadOnly lifts the pin
+func withReplicas(db *gorm.DB, replicas []gorm.Dialector, pool PoolOptions) (*gorm.DB, error) {
+	resolver := dbresolver.Register(dbresolver.Config{Replicas: replicas, Policy: dbresolver.StrictRoundRobinPolicy()})
+	if pool.MaxOpenConns > 0 {
+		resolver.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns > 0 {
+		resolver.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime > 0 {
+		resolver.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+	if pool.ConnMaxIdleTime > 0 {
+		resolver.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	}
+	if err := db.Use(resolver); err != nil {
+		return nil, err
+	}
+	return db.Clauses(dbresolver.Write).Session(&gorm.Session{}), nil
+}
+
+func (o PoolOptions) apply(db interface {
+	SetMaxOpenConns(int)
+	SetMaxIdleConns(int)
+	SetConnMaxLifetime(time.Duration)
+	SetConnMaxIdleTime(time.Duration)
+}) {
+	if o.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(o.MaxOpenConns)
+	}
+	if o.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(o.MaxIdleConns)
+	}
+	if o.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(o.ConnMaxLifetime)
+	}
+	if o.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(o.ConnMaxIdleTime)
+	}
+}
+
+func (o Options) dsn() string {
+	sslMode := o.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	params := []string{
+		"host=" + o.Host,
+		"user=" + o.DBUser,
+		"password=" + o.Password,
+		"dbname=" + o.DBName,
+		"port=" + o.Port,
+		"sslmode=" + sslMode,
+	}
+	optional := func(key string, value string) {
+		if value != "" {
+			params = append(params, key+"="+value)
+		}
+	}
+	optional("sslrootcert", o.SSLRootCert)
+	optional("sslcert", o.SSLCert)
+	optional("sslkey", o.SSLKey)
+	if o.ConnectTimeout > 0 {
+		// libpq counts whole seconds
+		optional("connect_timeout", strconv.Itoa(int(math.Ceil(o.ConnectTimeout.Seconds()))))
+	}
+	if o.StatementTimeout > 0 {
+		optional("statement_timeout", strconv.FormatInt(o.StatementTimeout.Milliseconds(), 10))
+	}
+	return strings.Join(params, " ")
+}
+
+// isSQLite tells the repositories they run on the SQLite database of sqlitestore, which shares their tables
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// utc normalizes the times written and queried by the repositories: SQLite compares them as text,
+// so a time in another location would not sort with the others
+func utc(t time.Time) time.Time {
+	return t.UTC()
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
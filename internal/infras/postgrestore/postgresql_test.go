@@ -3,14 +3,64 @@ package postgrestore
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"go-clean-template/internal/infras/sqlitestore"
 	"go-clean-template/pkg/config"
 	"go-clean-template/pkg/testutil"
 
+	"github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
 )
 
+// testDBs are the databases the repositories run on: SQLite shares the tables of postgres
+var testDBs = []struct {
+	name    string
+	connect func(t *testing.T) *gorm.DB
+}{
+	{name: "sqlite", connect: connectSQLite},
+	{name: "postgres", connect: connectPostgres},
+}
+
+// forEachDB runs test on a migrated database of every kind
+func forEachDB(t *testing.T, test func(t *testing.T, db *gorm.DB)) {
+	for _, testDB := range testDBs {
+		t.Run(testDB.name, func(t *testing.T) {
+			test(t, testDB.connect(t))
+		})
+	}
+}
+
+func connectSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := sqlitestore.NewDB(sqlitestore.Options{Path: filepath.Join(t.TempDir(), "test.db"), BusyTimeout: time.Second})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		assert.NoError(t, err)
+		assert.NoError(t, sqlDB.Close())
+	})
+
+	_, err = sqlitestore.Migrate(db)
+	require.NoError(t, err)
+	return db
+}
+
+func connectPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := testutil.CreateConnection(t, "test1", "test1", "123456")
+	testutil.MigrateTestDatabase(t, db)
+	return db
+}
+
 func TestParseConfig(t *testing.T) {
 	// Arrange
 	testDBName := "test-db"
@@ -18,40 +68,134 @@ func TestParseConfig(t *testing.T) {
 	testDbPort := 5432
 	testDBUsername := "test-user"
 	testDBPassword := "P@ssw0rd"
-	cfg := &config.Config{
-		DB: struct {
-			Name      string `envconfig:"DB_NAME"`
-			Host      string `envconfig:"DB_HOST"`
-			Port      int    `envconfig:"DB_PORT"`
-			User      string `envconfig:"DB_USER"`
-			Pass      string `envconfig:"DB_PASS"`
-			EnableSSL bool   `envconfig:"ENABLE_SSL"`
-		}{
-			Name:      testDBName,
-			Host:      testDBHost,
-			Port:      testDbPort,
-			User:      testDBUsername,
-			Pass:      testDBPassword,
-			EnableSSL: false,
-		},
-	}
+	cfg := &config.Config{}
+	cfg.DB.Name = testDBName
+	cfg.DB.Host = testDBHost
+	cfg.DB.Port = testDbPort
+	cfg.DB.User = testDBUsername
+	cfg.DB.Pass = testDBPassword
+	cfg.DB.ConnectTimeout = 5 * time.Second
+	cfg.DB.MaxOpenConns = 25
+	cfg.DB.LogLevel = "warn"
+	cfg.DB.SlowThreshold = 200 * time.Millisecond
+	cfg.DB.ReplicaDSNs = []string{"host=replica1"}
 
 	// Act
 	actual := ParseFromConfig(cfg)
 
 	// Assert
 	expected := Options{
-		DBName:   testDBName,
-		DBUser:   testDBUsername,
-		Password: testDBPassword,
-		Host:     testDBHost,
-		Port:     fmt.Sprintf("%d", testDbPort),
-		SSLMode:  false,
+		DBName:         testDBName,
+		DBUser:         testDBUsername,
+		Password:       testDBPassword,
+		Host:           testDBHost,
+		Port:           fmt.Sprintf("%d", testDbPort),
+		SSLMode:        "disable",
+		ConnectTimeout: 5 * time.Second,
+		Pool:           PoolOptions{MaxOpenConns: 25},
+		ReplicaDSNs:    []string{"host=replica1"},
+		Log:            LogOptions{Level: "warn", SlowThreshold: 200 * time.Millisecond},
 	}
 
 	assert.Equal(t, expected, actual)
 }
 
+func TestParseConfig_SSLMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		enableSSL bool
+		sslMode   string
+		want      string
+	}{
+		{name: "disabled by default", want: "disable"},
+		{name: "ENABLE_SSL requires it", enableSSL: true, want: "require"},
+		{name: "DB_SSL_MODE wins", enableSSL: true, sslMode: "verify-full", want: "verify-full"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := &config.Config{}
+			cfg.DB.EnableSSL = tt.enableSSL
+			cfg.DB.SSLMode = tt.sslMode
+
+			// Act
+			actual := ParseFromConfig(cfg)
+
+			// Assert
+			assert.Equal(t, tt.want, actual.SSLMode)
+		})
+	}
+}
+
+func TestOptions_DSN(t *testing.T) {
+	t.Run("minimal", func(t *testing.T) {
+		// Arrange
+		opt := Options{DBName: "wallet", DBUser: "app", Password: "secret", Host: "db", Port: "5432"}
+
+		// Act
+		dsn := opt.dsn()
+
+		// Assert
+		assert.Equal(t, "host=db user=app password=secret dbname=wallet port=5432 sslmode=disable", dsn)
+	})
+
+	t.Run("certificates and timeouts", func(t *testing.T) {
+		// Arrange
+		opt := Options{
+			DBName: "wallet", DBUser: "app", Password: "secret", Host: "db", Port: "5432",
+			SSLMode:          "verify-full",
+			SSLRootCert:      "/certs/ca.pem",
+			SSLCert:          "/certs/client.pem",
+			SSLKey:           "/certs/client.key",
+			ConnectTimeout:   1500 * time.Millisecond,
+			StatementTimeout: 30 * time.Second,
+		}
+
+		// Act
+		dsn := opt.dsn()
+
+		// Assert
+		assert.Equal(t, "host=db user=app password=secret dbname=wallet port=5432 sslmode=verify-full "+
+			"sslrootcert=/certs/ca.pem sslcert=/certs/client.pem sslkey=/certs/client.key "+
+			"connect_timeout=2 statement_timeout=30000", dsn)
+	})
+}
+
+func TestWithReplicas(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	openFile := func(name string, value string) gorm.Dialector {
+		dialector := sqlite.Open(filepath.Join(dir, name))
+		db, err := gorm.Open(dialector, &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.Exec("CREATE TABLE origins (name text)").Error)
+		require.NoError(t, db.Exec("INSERT INTO origins (name) VALUES (?)", value).Error)
+		return dialector
+	}
+	primary, err := gorm.Open(openFile("primary.db", "primary"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Act
+	db, err := withReplicas(primary, []gorm.Dialector{openFile("replica.db", "replica")}, PoolOptions{MaxOpenConns: 2})
+
+	// Assert
+	require.NoError(t, err)
+	origins := func(db *gorm.DB) []string {
+		var names []string
+		require.NoError(t, db.Table("origins").Order("name").Pluck("name", &names).Error)
+		return names
+	}
+	assert.Equal(t, []string{"primary"}, origins(db), "reads stay on the primary")
+	assert.Equal(t, []string{"replica"}, origins(ReadOnly(db)), "read only reads go to the replica")
+
+	require.NoError(t, ReadOnly(db).Table("origins").Create(map[string]interface{}{"name": "written"}).Error)
+	assert.Equal(t, []string{"primary", "written"}, origins(db), "writes go to the primary")
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		assert.Equal(t, []string{"primary", "written"}, origins(tx), "transactions stay on the primary")
+		return nil
+	}))
+}
+
 func TestNewDB(t *testing.T) {
 	t.Run("good case", func(t *testing.T) {
 		// Arrange
@@ -96,3 +240,29 @@ func TestNewDB(t *testing.T) {
 		assert.Nil(t, db)
 	})
 }
+
+func TestNewTracingPlugin(t *testing.T) {
+	// Arrange
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "trace.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE TABLE wallets (id text)").Error)
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+
+	// Act
+	require.NoError(t, db.Use(newTracingPlugin(Options{DBName: "wallet", Tracing: provider})))
+	var ids []string
+	err = db.WithContext(ctx).Table("wallets").Where("id = ?", "secret-id").Pluck("id", &ids).Error
+	parent.End()
+
+	// Assert
+	require.NoError(t, err)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	query := spans[0]
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent.SpanID(), "the query is a child of the request")
+	for _, attr := range query.Attributes {
+		assert.NotContains(t, attr.Value.Emit(), "secret-id", "the query variables are left out")
+	}
+}
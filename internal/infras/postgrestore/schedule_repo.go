@@ -0,0 +1,156 @@
+package postgrestore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/postgrestore/schema"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ScheduleTable    = "schedules"
+	ScheduleRunTable = "schedule_runs"
+)
+
+type ScheduleRepo struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepo(db *gorm.DB) *ScheduleRepo {
+	return &ScheduleRepo{db: db}
+}
+
+func (r *ScheduleRepo) SaveSchedule(ctx context.Context, s *entity.Schedule) error {
+	scheduleSchema := schema.ToScheduleSchema(s)
+	scheduleSchema.StartAt = utc(scheduleSchema.StartAt)
+	scheduleSchema.NextRunAt = utc(scheduleSchema.NextRunAt)
+	return conn(ctx, r.db).Table(ScheduleTable).Create(scheduleSchema).Error
+}
+
+func (r *ScheduleRepo) GetScheduleByID(ctx context.Context, scheduleID string) (*entity.Schedule, error) {
+	var scheduleSchema schema.ScheduleSchema
+	if err := conn(ctx, r.db).Table(ScheduleTable).Where("id = ?", scheduleID).Take(&scheduleSchema).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return scheduleSchema.ToSchedule(), nil
+}
+
+func (r *ScheduleRepo) ListSchedulesByWalletID(ctx context.Context, walletID string) ([]*entity.Schedule, error) {
+	// the rowid keeps the schedules created at the same time in creation order, SQLite has no other tie-breaker
+	order := "created_at"
+	if isSQLite(r.db) {
+		order = "created_at, rowid"
+	}
+
+	var scheduleSchemas []schema.ScheduleSchema
+	if err := conn(ctx, r.db).Table(ScheduleTable).Where("wallet_id = ?", walletID).
+		Order(order).Find(&scheduleSchemas).Error; err != nil {
+		return nil, err
+	}
+	return toSchedules(scheduleSchemas), nil
+}
+
+func (r *ScheduleRepo) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*entity.Schedule, error) {
+	var scheduleSchemas []schema.ScheduleSchema
+	if err := conn(ctx, r.db).Table(ScheduleTable).
+		Where("status = ? AND next_run_at <= ?", entity.ScheduleStatusActive, utc(now)).
+		Order("next_run_at").Limit(limit).Find(&scheduleSchemas).Error; err != nil {
+		return nil, err
+	}
+	return toSchedules(scheduleSchemas), nil
+}
+
+func (r *ScheduleRepo) UpdateSchedule(ctx context.Context, s *entity.Schedule) error {
+	result := conn(ctx, r.db).Table(ScheduleTable).Where("id = ? AND version = ?", s.ID, s.Version).
+		Updates(map[string]interface{}{
+			"amount":      s.Amount,
+			"note":        s.Note,
+			"frequency":   string(s.Frequency),
+			"start_at":    utc(s.StartAt),
+			"next_run_at": utc(s.NextRunAt),
+			"status":      string(s.Status),
+			"version":     gorm.Expr("version + 1"),
+			"updated_at":  r.db.NowFunc(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return missedVersion(ctx, r.db, ScheduleTable, "schedule", s.ID, s.Version)
+	}
+	return nil
+}
+
+// DeleteSchedule deletes the runs with the schedule through the cascade of their foreign key
+func (r *ScheduleRepo) DeleteSchedule(ctx context.Context, scheduleID string, version int64) error {
+	result := conn(ctx, r.db).Table(ScheduleTable).Where("id = ? AND version = ?", scheduleID, version).
+		Delete(&schema.ScheduleSchema{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return missedVersion(ctx, r.db, ScheduleTable, "schedule", scheduleID, version)
+	}
+	return nil
+}
+
+func (r *ScheduleRepo) ClaimScheduleRun(ctx context.Context, run *entity.ScheduleRun, now time.Time) (bool, error) {
+	runSchema := schema.ToScheduleRunSchema(run)
+	runSchema.OccurrenceAt = utc(runSchema.OccurrenceAt)
+	runSchema.ClaimedUntil = utcPtr(runSchema.ClaimedUntil)
+	err := conn(ctx, r.db).Table(ScheduleRunTable).Create(runSchema).Error
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		return false, err
+	}
+
+	// the occurrence has a run, take it over if its scheduler stopped before recording the result
+	res := conn(ctx, r.db).Table(ScheduleRunTable).
+		Where("id = ? AND status = ?", run.ID, string(entity.ScheduleRunPending)).
+		Where("claimed_until IS NULL OR claimed_until <= ?", utc(now)).
+		Updates(map[string]interface{}{
+			"claimed_until": runSchema.ClaimedUntil,
+			"updated_at":    r.db.NowFunc(),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 1 {
+		return true, nil
+	}
+
+	var current schema.ScheduleRunSchema
+	if err := conn(ctx, r.db).Table(ScheduleRunTable).Where("id = ?", run.ID).Take(&current).Error; err != nil {
+		return false, err
+	}
+	if current.Status == string(entity.ScheduleRunPending) {
+		return false, entity.ErrScheduleRunClaimed
+	}
+	return false, nil
+}
+
+func (r *ScheduleRepo) UpdateScheduleRun(ctx context.Context, run *entity.ScheduleRun) error {
+	return conn(ctx, r.db).Table(ScheduleRunTable).Where("id = ?", run.ID).
+		Updates(map[string]interface{}{
+			"status":     string(run.Status),
+			"reason":     run.Reason,
+			"updated_at": r.db.NowFunc(),
+		}).Error
+}
+
+func toSchedules(scheduleSchemas []schema.ScheduleSchema) []*entity.Schedule {
+	schedules := make([]*entity.Schedule, 0, len(scheduleSchemas))
+	for i := range scheduleSchemas {
+		schedules = append(schedules, scheduleSchemas[i].ToSchedule())
+	}
+	return schedules
+}
@@ -0,0 +1,239 @@
+package postgrestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/postgrestore/schema"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initWalletForSchedule(t testing.TB, db *gorm.DB) (*schema.WalletSchema, *schema.LinkedAccountSchema) {
+	t.Helper()
+
+	userId := uuid.New().String()
+	query := `INSERT INTO users (id,full_name, email, phone_number,current_address)
+		VALUES (?, 'Phan Ngoc Quang', 'quangpn@tm.teqn.asia', '0123456789', 'HCM')`
+	assert.NoError(t, db.Exec(query, userId).Error)
+
+	wallet := &schema.WalletSchema{
+		ID:         uuid.New().String(),
+		UserID:     userId,
+		WalletName: "My wallet",
+	}
+	assert.NoError(t, db.Table(WalletTable).Create(wallet).Error)
+
+	account := &schema.LinkedAccountSchema{
+		ID:          uuid.New().String(),
+		UserID:      userId,
+		AccountName: "momo",
+	}
+	assert.NoError(t, db.Table(LinkedAccountTable).Create(account).Error)
+
+	return wallet, account
+}
+
+func newScheduleForTest(t testing.TB, walletID string, accountID string, startAt time.Time) *entity.Schedule {
+	t.Helper()
+
+	s, err := entity.NewSchedule(uuid.New().String(), walletID, accountID, 1000, "VND", entity.TransactionOut, "rent",
+		entity.ScheduleWeekly, startAt)
+	assert.NoError(t, err)
+	return s
+}
+
+func TestScheduleRepo_SaveAndGetSchedule(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewScheduleRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: save and get schedule", func(t *testing.T) {
+			//Arrange
+			wallet, account := initWalletForSchedule(t, db)
+			want := newScheduleForTest(t, wallet.ID, account.ID, time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+
+			//Act
+			err := repo.SaveSchedule(ctx, want)
+
+			//Assert
+			assert.NoError(t, err)
+			got, err := repo.GetScheduleByID(ctx, want.ID)
+			assert.NoError(t, err)
+			assertSchedule(t, want, got)
+		})
+
+		t.Run("record not found", func(t *testing.T) {
+			//Act
+			got, err := repo.GetScheduleByID(ctx, "s_0002")
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Nil(t, got)
+		})
+	})
+}
+
+func TestScheduleRepo_ListDueSchedules(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewScheduleRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: list due schedules", func(t *testing.T) {
+			//Arrange
+			now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+			wallet, account := initWalletForSchedule(t, db)
+			due := newScheduleForTest(t, wallet.ID, account.ID, now.Add(-time.Hour))
+			notDue := newScheduleForTest(t, wallet.ID, account.ID, now.Add(time.Hour))
+			completed := newScheduleForTest(t, wallet.ID, account.ID, now.Add(-time.Hour))
+			completed.Status = entity.ScheduleStatusCompleted
+			for _, s := range []*entity.Schedule{due, notDue, completed} {
+				assert.NoError(t, repo.SaveSchedule(ctx, s))
+			}
+
+			//Act
+			got, err := repo.ListDueSchedules(ctx, now, 10)
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assertSchedule(t, due, got[0])
+		})
+	})
+}
+
+func TestScheduleRepo_UpdateAndDeleteSchedule(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewScheduleRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: update then delete schedule", func(t *testing.T) {
+			//Arrange
+			wallet, account := initWalletForSchedule(t, db)
+			s := newScheduleForTest(t, wallet.ID, account.ID, time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+			assert.NoError(t, repo.SaveSchedule(ctx, s))
+			claimed, err := repo.ClaimScheduleRun(ctx, entity.NewScheduleRun(s.ID, s.NextRunAt), s.NextRunAt)
+			assert.NoError(t, err)
+			assert.True(t, claimed)
+			s.Advance()
+
+			//Act
+			assert.NoError(t, repo.UpdateSchedule(ctx, s))
+			got, err := repo.ListSchedulesByWalletID(ctx, wallet.ID)
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assertSchedule(t, s, got[0])
+			assert.Equal(t, s.Version+1, got[0].Version)
+
+			assert.NoError(t, repo.DeleteSchedule(ctx, s.ID, got[0].Version))
+			deleted, err := repo.GetScheduleByID(ctx, s.ID)
+			assert.NoError(t, err)
+			assert.Nil(t, deleted)
+		})
+
+		t.Run("stale version is a conflict and unknown schedule is not found", func(t *testing.T) {
+			//Arrange
+			wallet, account := initWalletForSchedule(t, db)
+			s := newScheduleForTest(t, wallet.ID, account.ID, time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+			assert.NoError(t, repo.SaveSchedule(ctx, s))
+			assert.NoError(t, repo.UpdateSchedule(ctx, s))
+
+			//Act
+			errUpdate := repo.UpdateSchedule(ctx, s)
+			errDelete := repo.DeleteSchedule(ctx, s.ID, s.Version)
+			errUnknown := repo.UpdateSchedule(ctx, &entity.Schedule{ID: uuid.New().String(), Version: entity.InitialVersion})
+
+			//Assert
+			assert.ErrorIs(t, errUpdate, entity.ErrVersionConflict)
+			assert.ErrorIs(t, errDelete, entity.ErrVersionConflict)
+			assert.ErrorIs(t, errUnknown, entity.ErrNotFound)
+			got, err := repo.GetScheduleByID(ctx, s.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, s.Version+1, got.Version)
+		})
+	})
+}
+
+func TestScheduleRepo_ClaimScheduleRun(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewScheduleRepo(db)
+		ctx := context.Background()
+
+		t.Run("occurrence can only be claimed once", func(t *testing.T) {
+			//Arrange
+			wallet, account := initWalletForSchedule(t, db)
+			s := newScheduleForTest(t, wallet.ID, account.ID, time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+			assert.NoError(t, repo.SaveSchedule(ctx, s))
+			now := s.NextRunAt
+			run := newClaimedRun(s, now)
+
+			//Act
+			first, err1 := repo.ClaimScheduleRun(ctx, run, now)
+			second, err2 := repo.ClaimScheduleRun(ctx, newClaimedRun(s, now), now)
+
+			//Assert
+			assert.NoError(t, err1)
+			assert.True(t, first)
+			assert.ErrorIs(t, err2, entity.ErrScheduleRunClaimed)
+			assert.False(t, second)
+
+			run.ToMaterialized()
+			assert.NoError(t, repo.UpdateScheduleRun(ctx, run))
+			var got schema.ScheduleRunSchema
+			assert.NoError(t, db.Table(ScheduleRunTable).Where("id = ?", run.ID).Take(&got).Error)
+			assert.Equal(t, string(entity.ScheduleRunMaterialized), got.Status)
+			later := now.Add(entity.ScheduleRunLease)
+			third, err3 := repo.ClaimScheduleRun(ctx, newClaimedRun(s, later), later)
+			assert.NoError(t, err3)
+			assert.False(t, third)
+		})
+
+		t.Run("a pending run is claimed again once its lease ended", func(t *testing.T) {
+			//Arrange
+			wallet, account := initWalletForSchedule(t, db)
+			s := newScheduleForTest(t, wallet.ID, account.ID, time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC))
+			assert.NoError(t, repo.SaveSchedule(ctx, s))
+			now := s.NextRunAt
+			claimed, err := repo.ClaimScheduleRun(ctx, newClaimedRun(s, now), now)
+			assert.NoError(t, err)
+			assert.True(t, claimed)
+			later := now.Add(entity.ScheduleRunLease)
+
+			//Act
+			got, err := repo.ClaimScheduleRun(ctx, newClaimedRun(s, later), later)
+
+			//Assert
+			assert.NoError(t, err)
+			assert.True(t, got)
+			var run schema.ScheduleRunSchema
+			assert.NoError(t, db.Table(ScheduleRunTable).Where("id = ?", newClaimedRun(s, later).ID).Take(&run).Error)
+			assert.True(t, later.Add(entity.ScheduleRunLease).Equal(*run.ClaimedUntil))
+		})
+	})
+}
+
+// newClaimedRun is the run of the next occurrence of s, claimed by a scheduler at now
+func newClaimedRun(s *entity.Schedule, now time.Time) *entity.ScheduleRun {
+	run := entity.NewScheduleRun(s.ID, s.NextRunAt)
+	run.ClaimedUntil = now.Add(entity.ScheduleRunLease)
+	return run
+}
+
+func assertSchedule(t testing.TB, want *entity.Schedule, got *entity.Schedule) {
+	t.Helper()
+
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.WalletID, got.WalletID)
+	assert.Equal(t, want.AccountID, got.AccountID)
+	assert.Equal(t, want.Amount, got.Amount)
+	assert.Equal(t, want.TransactionKind, got.TransactionKind)
+	assert.Equal(t, want.Frequency, got.Frequency)
+	assert.Equal(t, want.Status, got.Status)
+	assert.True(t, want.NextRunAt.Equal(got.NextRunAt))
+}
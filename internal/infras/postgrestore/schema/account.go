@@ -18,6 +18,14 @@ func (*LinkedAccountSchema) TableName() string {
 	return "linked_accounts"
 }
 
+func ToLinkedAccountSchema(a *entity.LinkedAccount) *LinkedAccountSchema {
+	return &LinkedAccountSchema{
+		ID:          a.ID,
+		UserID:      a.UserID,
+		AccountName: a.AccountName,
+	}
+}
+
 func (a *LinkedAccountSchema) ToLinkedAccount() *entity.LinkedAccount {
 	return &entity.LinkedAccount{
 		ID:          a.ID,
@@ -53,3 +53,11 @@ func TestAccountSchema_ToLinkedAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestToLinkedAccountSchema(t *testing.T) {
+	account := &entity.LinkedAccount{ID: "1", UserID: "1", AccountName: "momo"}
+
+	if got := ToLinkedAccountSchema(account).ToLinkedAccount(); !reflect.DeepEqual(got, account) {
+		t.Errorf("ToLinkedAccountSchema() = %v, want %v", got, account)
+	}
+}
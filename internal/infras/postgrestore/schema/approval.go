@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type ApprovalSchema struct {
+	ID            string     `gorm:"column:id;primaryKey"`
+	TransactionID string     `gorm:"column:transaction_id;not null"`
+	RequestedBy   string     `gorm:"column:requested_by;not null"`
+	DecidedBy     string     `gorm:"column:decided_by"`
+	Status        string     `gorm:"column:status;not null"`
+	Reason        string     `gorm:"column:reason"`
+	ExpiresAt     time.Time  `gorm:"column:expires_at;not null"`
+	DecidedAt     *time.Time `gorm:"column:decided_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at;<-:create"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at"`
+}
+
+func (*ApprovalSchema) TableName() string {
+	return "transaction_approvals"
+}
+
+func ToApprovalSchema(approval *entity.Approval) *ApprovalSchema {
+	return &ApprovalSchema{
+		ID:            approval.ID,
+		TransactionID: approval.TransactionID,
+		RequestedBy:   approval.RequestedBy,
+		DecidedBy:     approval.DecidedBy,
+		Status:        string(approval.Status),
+		Reason:        approval.Reason,
+		ExpiresAt:     approval.ExpiresAt,
+		DecidedAt:     approval.DecidedAt,
+	}
+}
+
+func (a *ApprovalSchema) ToApproval() *entity.Approval {
+	return &entity.Approval{
+		ID:            a.ID,
+		TransactionID: a.TransactionID,
+		RequestedBy:   a.RequestedBy,
+		DecidedBy:     a.DecidedBy,
+		Status:        entity.ApprovalStatus(a.Status),
+		Reason:        a.Reason,
+		ExpiresAt:     a.ExpiresAt,
+		DecidedAt:     a.DecidedAt,
+	}
+}
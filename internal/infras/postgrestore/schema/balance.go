@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type BalanceSnapshotSchema struct {
+	WalletID  string    `gorm:"column:wallet_id;primaryKey"`
+	AsOf      time.Time `gorm:"column:as_of;primaryKey"`
+	Balance   float64   `gorm:"column:balance;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;<-:create"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (*BalanceSnapshotSchema) TableName() string {
+	return "balance_snapshots"
+}
+
+func ToBalanceSnapshotSchema(s *entity.BalanceSnapshot) *BalanceSnapshotSchema {
+	return &BalanceSnapshotSchema{
+		WalletID: s.WalletID,
+		AsOf:     s.AsOf,
+		Balance:  s.Balance,
+	}
+}
+
+func (s *BalanceSnapshotSchema) ToBalanceSnapshot() *entity.BalanceSnapshot {
+	return &entity.BalanceSnapshot{
+		WalletID: s.WalletID,
+		AsOf:     s.AsOf,
+		Balance:  s.Balance,
+	}
+}
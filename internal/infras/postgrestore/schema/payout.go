@@ -0,0 +1,102 @@
+package schema
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type PayoutBatchSchema struct {
+	ID           string     `gorm:"column:id;primaryKey"`
+	CreatedBy    string     `gorm:"column:created_by"`
+	Status       string     `gorm:"column:status;not null"`
+	ClaimedUntil *time.Time `gorm:"column:claimed_until"`
+	CreatedAt    time.Time  `gorm:"column:created_at;<-:create"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+}
+
+func (*PayoutBatchSchema) TableName() string {
+	return "payout_batches"
+}
+
+func ToPayoutBatchSchema(b *entity.PayoutBatch) *PayoutBatchSchema {
+	s := &PayoutBatchSchema{
+		ID:        b.ID,
+		CreatedBy: b.CreatedBy,
+		Status:    string(b.Status),
+	}
+	if !b.ClaimedUntil.IsZero() {
+		claimedUntil := b.ClaimedUntil
+		s.ClaimedUntil = &claimedUntil
+	}
+	return s
+}
+
+// ToPayoutBatch converts the batch row and its item rows to an entity
+func (b *PayoutBatchSchema) ToPayoutBatch(items []PayoutItemSchema) *entity.PayoutBatch {
+	batch := &entity.PayoutBatch{
+		ID:        b.ID,
+		CreatedBy: b.CreatedBy,
+		Status:    entity.PayoutBatchStatus(b.Status),
+		Items:     make([]*entity.PayoutItem, 0, len(items)),
+	}
+	if b.ClaimedUntil != nil {
+		batch.ClaimedUntil = *b.ClaimedUntil
+	}
+	for i := range items {
+		batch.Items = append(batch.Items, items[i].ToPayoutItem())
+	}
+	return batch
+}
+
+type PayoutItemSchema struct {
+	ID            string    `gorm:"column:id;primaryKey"`
+	BatchID       string    `gorm:"column:batch_id;not null"`
+	Line          int       `gorm:"column:line;not null"`
+	WalletID      string    `gorm:"column:wallet_id;not null"`
+	AccountID     string    `gorm:"column:account_id;not null"`
+	Amount        float64   `gorm:"column:amount;not null"`
+	Currency      string    `gorm:"column:currency;not null"`
+	Note          string    `gorm:"column:note"`
+	Status        string    `gorm:"column:status;not null"`
+	TransactionID string    `gorm:"column:transaction_id"`
+	Reason        string    `gorm:"column:reason"`
+	CreatedAt     time.Time `gorm:"column:created_at;<-:create"`
+	UpdatedAt     time.Time `gorm:"column:updated_at"`
+}
+
+func (*PayoutItemSchema) TableName() string {
+	return "payout_items"
+}
+
+func ToPayoutItemSchema(i *entity.PayoutItem) *PayoutItemSchema {
+	return &PayoutItemSchema{
+		ID:            i.ID,
+		BatchID:       i.BatchID,
+		Line:          i.Line,
+		WalletID:      i.WalletID,
+		AccountID:     i.AccountID,
+		Amount:        i.Amount,
+		Currency:      i.Currency,
+		Note:          i.Note,
+		Status:        string(i.Status),
+		TransactionID: i.TransactionID,
+		Reason:        i.Reason,
+	}
+}
+
+func (i *PayoutItemSchema) ToPayoutItem() *entity.PayoutItem {
+	return &entity.PayoutItem{
+		ID:            i.ID,
+		BatchID:       i.BatchID,
+		Line:          i.Line,
+		WalletID:      i.WalletID,
+		AccountID:     i.AccountID,
+		Amount:        i.Amount,
+		Currency:      i.Currency,
+		Note:          i.Note,
+		Status:        entity.PayoutItemStatus(i.Status),
+		TransactionID: i.TransactionID,
+		Reason:        i.Reason,
+	}
+}
@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"go-clean-template/internal/entity"
+)
+
+func TestPayoutBatchSchema_RoundTrip(t *testing.T) {
+	batch, err := entity.NewPayoutBatch("b_001", "u_001", []*entity.PayoutItem{
+		{WalletID: "w_001", AccountID: "a_001", Amount: 100, Currency: "USD", Note: "salary"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = batch.Items[0].ToSucceeded("t_001")
+	wantBatch := &PayoutBatchSchema{ID: "b_001", CreatedBy: "u_001", Status: "PENDING"}
+	wantItem := &PayoutItemSchema{
+		ID:            "b_001-1",
+		BatchID:       "b_001",
+		Line:          1,
+		WalletID:      "w_001",
+		AccountID:     "a_001",
+		Amount:        100,
+		Currency:      "USD",
+		Note:          "salary",
+		Status:        "SUCCEEDED",
+		TransactionID: "t_001",
+	}
+
+	gotBatch := ToPayoutBatchSchema(batch)
+	if !reflect.DeepEqual(gotBatch, wantBatch) {
+		t.Errorf("ToPayoutBatchSchema() = %v, want %v", gotBatch, wantBatch)
+	}
+	gotItem := ToPayoutItemSchema(batch.Items[0])
+	if !reflect.DeepEqual(gotItem, wantItem) {
+		t.Errorf("ToPayoutItemSchema() = %v, want %v", gotItem, wantItem)
+	}
+	if back := gotBatch.ToPayoutBatch([]PayoutItemSchema{*gotItem}); !reflect.DeepEqual(back, batch) {
+		t.Errorf("ToPayoutBatch() = %v, want %v", back, batch)
+	}
+}
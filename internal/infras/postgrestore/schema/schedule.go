@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type ScheduleSchema struct {
+	ID              string    `gorm:"column:id;primaryKey"`
+	WalletID        string    `gorm:"column:wallet_id;not null"`
+	AccountID       string    `gorm:"column:account_id;not null"`
+	Amount          float64   `gorm:"column:amount;not null"`
+	Currency        string    `gorm:"column:currency;not null"`
+	TransactionKind string    `gorm:"column:transaction_kind;not null"`
+	Note            string    `gorm:"column:note"`
+	Frequency       string    `gorm:"column:frequency;not null"`
+	StartAt         time.Time `gorm:"column:start_at;not null"`
+	NextRunAt       time.Time `gorm:"column:next_run_at;not null"`
+	Status          string    `gorm:"column:status;not null"`
+	CreatedAt       time.Time `gorm:"column:created_at;<-:create"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+	Version         int64     `gorm:"column:version"`
+}
+
+func (*ScheduleSchema) TableName() string {
+	return "schedules"
+}
+
+func ToScheduleSchema(s *entity.Schedule) *ScheduleSchema {
+	return &ScheduleSchema{
+		ID:              s.ID,
+		WalletID:        s.WalletID,
+		AccountID:       s.AccountID,
+		Amount:          s.Amount,
+		Currency:        s.Currency,
+		TransactionKind: string(s.TransactionKind),
+		Note:            s.Note,
+		Frequency:       string(s.Frequency),
+		StartAt:         s.StartAt,
+		NextRunAt:       s.NextRunAt,
+		Status:          string(s.Status),
+		Version:         s.Version,
+	}
+}
+
+func (s *ScheduleSchema) ToSchedule() *entity.Schedule {
+	return &entity.Schedule{
+		ID:              s.ID,
+		WalletID:        s.WalletID,
+		AccountID:       s.AccountID,
+		Amount:          s.Amount,
+		Currency:        s.Currency,
+		TransactionKind: entity.TransactionKind(s.TransactionKind),
+		Note:            s.Note,
+		Frequency:       entity.ScheduleFrequency(s.Frequency),
+		StartAt:         s.StartAt,
+		NextRunAt:       s.NextRunAt,
+		Status:          entity.ScheduleStatus(s.Status),
+		Version:         s.Version,
+	}
+}
+
+type ScheduleRunSchema struct {
+	ID           string     `gorm:"column:id;primaryKey"`
+	ScheduleID   string     `gorm:"column:schedule_id;not null"`
+	OccurrenceAt time.Time  `gorm:"column:occurrence_at;not null"`
+	Status       string     `gorm:"column:status;not null"`
+	Reason       string     `gorm:"column:reason"`
+	ClaimedUntil *time.Time `gorm:"column:claimed_until"`
+	CreatedAt    time.Time  `gorm:"column:created_at;<-:create"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+}
+
+func (*ScheduleRunSchema) TableName() string {
+	return "schedule_runs"
+}
+
+func ToScheduleRunSchema(r *entity.ScheduleRun) *ScheduleRunSchema {
+	s := &ScheduleRunSchema{
+		ID:           r.ID,
+		ScheduleID:   r.ScheduleID,
+		OccurrenceAt: r.OccurrenceAt,
+		Status:       string(r.Status),
+		Reason:       r.Reason,
+	}
+	if !r.ClaimedUntil.IsZero() {
+		claimedUntil := r.ClaimedUntil
+		s.ClaimedUntil = &claimedUntil
+	}
+	return s
+}
+
+func (r *ScheduleRunSchema) ToScheduleRun() *entity.ScheduleRun {
+	run := &entity.ScheduleRun{
+		ID:           r.ID,
+		ScheduleID:   r.ScheduleID,
+		OccurrenceAt: r.OccurrenceAt,
+		Status:       entity.ScheduleRunStatus(r.Status),
+		Reason:       r.Reason,
+	}
+	if r.ClaimedUntil != nil {
+		run.ClaimedUntil = *r.ClaimedUntil
+	}
+	return run
+}
@@ -7,16 +7,18 @@ import (
 )
 
 type TransactionSchema struct {
-	ID              string    `gorm:"column:id;primaryKey"`
-	WalletID        string    `gorm:"column:wallet_id;not null"`
-	AccountID       string    `gorm:"column:account_id;not null"`
-	Amount          float64   `gorm:"column:amount;not null"`
-	Currency        string    `gorm:"column:currency;not null"`
-	TransactionKind string    `gorm:"column:transaction_kind;not null"`
-	Status          string    `gorm:"column:status;not null"`
-	Note            string    `gorm:"column:note"`
-	CreatedAt       time.Time `gorm:"column:created_at;<-:create"`
-	UpdatedAt       time.Time `gorm:"column:updated_at"`
+	ID              string     `gorm:"column:id;primaryKey"`
+	WalletID        string     `gorm:"column:wallet_id;not null"`
+	AccountID       string     `gorm:"column:account_id;not null"`
+	Amount          float64    `gorm:"column:amount;not null"`
+	Currency        string     `gorm:"column:currency;not null"`
+	TransactionKind string     `gorm:"column:transaction_kind;not null"`
+	Status          string     `gorm:"column:status;not null"`
+	Note            string     `gorm:"column:note"`
+	CreatedAt       time.Time  `gorm:"column:created_at;<-:create"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at"`
+	SettledAt       *time.Time `gorm:"column:settled_at"`
+	Version         int64      `gorm:"column:version;not null"`
 }
 
 func (*TransactionSchema) TableName() string {
@@ -33,6 +35,9 @@ func ToTransactionSchema(trans *entity.Transaction) *TransactionSchema {
 		TransactionKind: string(trans.TransactionKind),
 		Status:          string(trans.Status),
 		Note:            trans.Note,
+		CreatedAt:       trans.CreatedAt,
+		SettledAt:       trans.SettledAt,
+		Version:         trans.Version,
 	}
 }
 
@@ -46,5 +51,8 @@ func (trans *TransactionSchema) ToTransaction() *entity.Transaction {
 		TransactionKind: entity.TransactionKind(trans.TransactionKind),
 		Status:          entity.TransactionStatus(trans.Status),
 		Note:            trans.Note,
+		CreatedAt:       trans.CreatedAt,
+		SettledAt:       trans.SettledAt,
+		Version:         trans.Version,
 	}
 }
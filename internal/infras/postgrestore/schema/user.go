@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"go-clean-template/internal/entity"
+)
+
+type UserSchema struct {
+	ID             string    `gorm:"column:id;primaryKey"`
+	FullName       string    `gorm:"column:full_name;not null"`
+	Email          string    `gorm:"column:email;not null"`
+	PhoneNumber    string    `gorm:"column:phone_number;not null"`
+	CurrentAddress string    `gorm:"column:current_address;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;<-:create"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (*UserSchema) TableName() string {
+	return "users"
+}
+
+func ToUserSchema(u *entity.User) *UserSchema {
+	return &UserSchema{
+		ID:             u.ID,
+		FullName:       u.FullName,
+		Email:          u.Email,
+		PhoneNumber:    u.PhoneNumber,
+		CurrentAddress: u.CurrentAddress,
+	}
+}
+
+func (u *UserSchema) ToUser() *entity.User {
+	return &entity.User{
+		ID:             u.ID,
+		FullName:       u.FullName,
+		Email:          u.Email,
+		PhoneNumber:    u.PhoneNumber,
+		CurrentAddress: u.CurrentAddress,
+	}
+}
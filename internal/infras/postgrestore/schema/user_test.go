@@ -0,0 +1,22 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"go-clean-template/internal/entity"
+)
+
+func TestUserSchema_ToUser(t *testing.T) {
+	user := &entity.User{
+		ID:             "1",
+		FullName:       "Phan Ngoc Quang",
+		Email:          "quangpn@tm.teqn.asia",
+		PhoneNumber:    "0123456789",
+		CurrentAddress: "HCM",
+	}
+
+	if got := ToUserSchema(user).ToUser(); !reflect.DeepEqual(got, user) {
+		t.Errorf("ToUser() = %v, want %v", got, user)
+	}
+}
@@ -12,16 +12,27 @@ type WalletSchema struct {
 	WalletName string    `gorm:"column:wallet_name;not null"`
 	CreatedAt  time.Time `gorm:"column:created_at;<-:create"`
 	UpdatedAt  time.Time `gorm:"column:updated_at"`
+	Version    int64     `gorm:"column:version;not null"`
 }
 
 func (*WalletSchema) TableName() string {
 	return "wallets"
 }
 
+func ToWalletSchema(w *entity.Wallet) *WalletSchema {
+	return &WalletSchema{
+		ID:         w.ID,
+		UserID:     w.UserID,
+		WalletName: w.WalletName,
+		Version:    w.Version,
+	}
+}
+
 func (w *WalletSchema) ToWallet() *entity.Wallet {
 	return &entity.Wallet{
 		ID:         w.ID,
 		UserID:     w.UserID,
 		WalletName: w.WalletName,
+		Version:    w.Version,
 	}
 }
@@ -0,0 +1,68 @@
+package postgrestore
+
+import (
+	"context"
+
+	"go-clean-template/internal/fixture"
+	"go-clean-template/internal/infras/postgrestore/schema"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const UserTable = "users"
+
+// seedBatchSize bounds the rows of one insert statement, below the 32766 parameters SQLite accepts and the 65535 of postgres
+const seedBatchSize = 500
+
+type SeedRepo struct {
+	db *gorm.DB
+}
+
+func NewSeedRepo(db *gorm.DB) *SeedRepo {
+	return &SeedRepo{db: db}
+}
+
+// Seed upserts the set in one transaction, users first so the foreign keys of the other records hold
+func (r *SeedRepo) Seed(ctx context.Context, set *fixture.Set) error {
+	users := make([]*schema.UserSchema, 0, len(set.Users))
+	for _, u := range set.Users {
+		users = append(users, schema.ToUserSchema(u))
+	}
+	wallets := make([]*schema.WalletSchema, 0, len(set.Wallets))
+	for _, w := range set.Wallets {
+		wallets = append(wallets, schema.ToWalletSchema(w))
+	}
+	accounts := make([]*schema.LinkedAccountSchema, 0, len(set.LinkedAccounts))
+	for _, a := range set.LinkedAccounts {
+		accounts = append(accounts, schema.ToLinkedAccountSchema(a))
+	}
+	transactions := make([]*schema.TransactionSchema, 0, len(set.Transactions))
+	for _, trans := range set.Transactions {
+		transactions = append(transactions, newTransactionSchema(r.db, trans))
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := upsert(tx, UserTable, users); err != nil {
+			return err
+		}
+		if err := upsert(tx, WalletTable, wallets); err != nil {
+			return err
+		}
+		if err := upsert(tx, LinkedAccountTable, accounts); err != nil {
+			return err
+		}
+		return upsert(tx, TransactionsTable, transactions)
+	})
+}
+
+// upsert inserts the rows of a table, replacing the ones with the same id
+func upsert[T any](tx *gorm.DB, table string, rows []T) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Table(table).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		UpdateAll: true,
+	}).CreateInBatches(rows, seedBatchSize).Error
+}
@@ -0,0 +1,54 @@
+package postgrestore
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/fixture"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSeedRepo_Seed(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewSeedRepo(db)
+		transRepo := NewTransactionRepo(db)
+		ctx := context.Background()
+
+		t.Run("success: seed twice is a no-op", func(t *testing.T) {
+			//Arrange
+			set, err := fixture.Random(rand.New(rand.NewSource(1)), fixture.RandomOptions{
+				Users: 3, TransactionsPerUser: 20, History: 30 * 24 * time.Hour, Now: time.Now(),
+			})
+			require.NoError(t, err)
+
+			//Act
+			err1 := repo.Seed(ctx, set)
+			err2 := repo.Seed(ctx, set)
+
+			//Assert
+			assert.NoError(t, err1)
+			assert.NoError(t, err2)
+			for _, w := range set.Wallets {
+				got, err := transRepo.GetWalletByID(ctx, w.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, w, got)
+
+				var want float64
+				for _, trans := range set.Transactions {
+					if trans.WalletID == w.ID && trans.Status == entity.TransactionStatusSuccessful {
+						want += trans.SignedAmount()
+					}
+				}
+				balance, err := transRepo.GetBalanceByWalletID(ctx, w.ID)
+				assert.NoError(t, err)
+				assert.InDelta(t, want, balance, 0.001)
+			}
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package postgrestore
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/postgrestore/schema"
+
+	"gorm.io/gorm"
+)
+
+type StatementRepo struct {
+	db *gorm.DB
+}
+
+func NewStatementRepo(db *gorm.DB) *StatementRepo {
+	return &StatementRepo{db: db}
+}
+
+func (r *StatementRepo) GetBalanceBefore(ctx context.Context, walletID string, before time.Time) (float64, error) {
+	var balance float64
+	if err := conn(ctx, r.db).Table(TransactionsTable).
+		Select(balanceQuery, entity.TransactionIn).
+		Where("wallet_id = ? AND status = ? AND settled_at < ?", walletID, entity.TransactionStatusSuccessful, utc(before)).
+		Row().Scan(&balance); err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+// StreamTransactions reads the transactions from a single cursor: the statement is one query, on one connection and
+// one snapshot, even when the reads are spread over replicas. The connection is held until the last line is written.
+func (r *StatementRepo) StreamTransactions(ctx context.Context, walletID string, from time.Time, to time.Time,
+	fn func(trans *entity.Transaction) error) error {
+	rows, err := conn(ctx, r.db).Table(TransactionsTable).
+		Where("wallet_id = ? AND status = ? AND settled_at >= ? AND settled_at < ?",
+			walletID, entity.TransactionStatusSuccessful, utc(from), utc(to)).
+		Order("settled_at, id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transSchema schema.TransactionSchema
+		if err := r.db.ScanRows(rows, &transSchema); err != nil {
+			return err
+		}
+		if err := fn(transSchema.ToTransaction()); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,115 @@
+package postgrestore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func initTransactionForStatement(t testing.TB, db *gorm.DB, walletID string, accountID string, amount float64,
+	kind entity.TransactionKind, status entity.TransactionStatus, createdAt time.Time) *entity.Transaction {
+	t.Helper()
+
+	trans := entity.NewTransaction(uuid.New().String(), walletID, accountID, amount, "VND", kind, "", status)
+	trans.CreatedAt = createdAt
+	assert.NoError(t, db.Table(TransactionsTable).Create(newTransactionSchema(db, trans)).Error)
+	return trans
+}
+
+func TestStatementRepo(t *testing.T) {
+	forEachDB(t, func(t *testing.T, db *gorm.DB) {
+		repo := NewStatementRepo(db)
+		ctx := context.Background()
+
+		from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+		wallet, account := initWalletForSchedule(t, db)
+		initTransactionForStatement(t, db, wallet.ID, account.ID, 1000, entity.TransactionIn,
+			entity.TransactionStatusSuccessful, from.AddDate(0, 0, -3))
+		initTransactionForStatement(t, db, wallet.ID, account.ID, 200, entity.TransactionOut,
+			entity.TransactionStatusSuccessful, from.AddDate(0, 0, -2))
+		initTransactionForStatement(t, db, wallet.ID, account.ID, 5000, entity.TransactionIn,
+			entity.TransactionStatusFailed, from.AddDate(0, 0, -1))
+		second := initTransactionForStatement(t, db, wallet.ID, account.ID, 300, entity.TransactionOut,
+			entity.TransactionStatusSuccessful, from.AddDate(0, 0, 10))
+		first := initTransactionForStatement(t, db, wallet.ID, account.ID, 500, entity.TransactionIn,
+			entity.TransactionStatusSuccessful, from.AddDate(0, 0, 1))
+		initTransactionForStatement(t, db, wallet.ID, account.ID, 700, entity.TransactionIn,
+			entity.TransactionStatusSuccessful, to)
+
+		t.Run("balance before the range", func(t *testing.T) {
+			//Act
+			got, err := repo.GetBalanceBefore(ctx, wallet.ID, from)
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Equal(t, 800.0, got)
+		})
+
+		t.Run("balance of a wallet without transactions", func(t *testing.T) {
+			//Act
+			got, err := repo.GetBalanceBefore(ctx, "w_0002", from)
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Equal(t, 0.0, got)
+		})
+
+		t.Run("stream successful transactions of the range oldest first", func(t *testing.T) {
+			//Arrange
+			var got []string
+
+			//Act
+			err := repo.StreamTransactions(ctx, wallet.ID, from, to, func(trans *entity.Transaction) error {
+				got = append(got, trans.ID)
+				return nil
+			})
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Equal(t, []string{first.ID, second.ID}, got)
+		})
+
+		t.Run("error of fn stops the stream", func(t *testing.T) {
+			//Arrange
+			errStop := fmt.Errorf("stop")
+			calls := 0
+
+			//Act
+			err := repo.StreamTransactions(ctx, wallet.ID, from, to, func(trans *entity.Transaction) error {
+				calls++
+				return errStop
+			})
+
+			//Assert
+			assert.Equal(t, errStop, err)
+			assert.Equal(t, 1, calls)
+		})
+
+		t.Run("transactions settled during the stream are not read", func(t *testing.T) {
+			//Arrange
+			var got []string
+
+			//Act
+			err := repo.StreamTransactions(ctx, wallet.ID, from, to, func(trans *entity.Transaction) error {
+				if len(got) == 0 {
+					initTransactionForStatement(t, db, wallet.ID, account.ID, 900, entity.TransactionIn,
+						entity.TransactionStatusSuccessful, from.AddDate(0, 0, 20))
+				}
+				got = append(got, trans.ID)
+				return nil
+			})
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Equal(t, []string{first.ID, second.ID}, got)
+		})
+	})
+}
@@ -2,11 +2,14 @@ package postgrestore
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"go-clean-template/internal/entity"
 	"go-clean-template/internal/infras/postgrestore/schema"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
@@ -15,6 +18,9 @@ const (
 	LinkedAccountTable = "linked_accounts"
 )
 
+// balanceQuery sums the signed amounts of the selected transactions, it takes the IN kind as argument
+const balanceQuery = `COALESCE(SUM(CASE WHEN transaction_kind = ? THEN amount ELSE -amount END), 0)`
+
 type TransactionRepo struct {
 	db *gorm.DB
 }
@@ -28,7 +34,7 @@ func (r *TransactionRepo) GetWalletByID(ctx context.Context, walletID string) (*
 		wallet       *entity.Wallet
 		walletSchema schema.WalletSchema
 	)
-	if err := r.db.WithContext(ctx).Table(WalletTable).Where("id = ?", walletID).Take(&walletSchema).Error;
+	if err := conn(ctx, r.db).Table(WalletTable).Where("id = ?", walletID).Take(&walletSchema).Error;
 		err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -40,8 +46,21 @@ func (r *TransactionRepo) GetWalletByID(ctx context.Context, walletID string) (*
 }
 
 func (r *TransactionRepo) SaveTransaction(ctx context.Context, trans *entity.Transaction) error {
+	return conn(ctx, r.db).Table(TransactionsTable).Create(newTransactionSchema(r.db, trans)).Error
+}
+
+// newTransactionSchema stores the times in UTC and settles a transaction inserted SUCCESSFUL at its creation
+func newTransactionSchema(db *gorm.DB, trans *entity.Transaction) *schema.TransactionSchema {
 	transSchema := schema.ToTransactionSchema(trans)
-	return r.db.WithContext(ctx).Table(TransactionsTable).Create(transSchema).Error
+	if transSchema.CreatedAt.IsZero() {
+		transSchema.CreatedAt = db.NowFunc()
+	}
+	transSchema.CreatedAt = utc(transSchema.CreatedAt)
+	if transSchema.SettledAt == nil && trans.Status == entity.TransactionStatusSuccessful {
+		transSchema.SettledAt = &transSchema.CreatedAt
+	}
+	transSchema.SettledAt = utcPtr(transSchema.SettledAt)
+	return transSchema
 }
 
 func (r *TransactionRepo) GetLinkedAccountByID(ctx context.Context, accountID string) (*entity.LinkedAccount, error) {
@@ -49,7 +68,7 @@ func (r *TransactionRepo) GetLinkedAccountByID(ctx context.Context, accountID st
 		account       *entity.LinkedAccount
 		accountSchema schema.LinkedAccountSchema
 	)
-	if err := r.db.WithContext(ctx).Table(LinkedAccountTable).Where("id = ?", accountID).Take(&accountSchema).Error; err != nil {
+	if err := conn(ctx, r.db).Table(LinkedAccountTable).Where("id = ?", accountID).Take(&accountSchema).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -61,9 +80,8 @@ func (r *TransactionRepo) GetLinkedAccountByID(ctx context.Context, accountID st
 
 func (r *TransactionRepo) GetBalanceByWalletID(ctx context.Context, walletID string) (float64, error) {
 	var balance float64
-	selectQuery := `SUM(CASE WHEN transaction_kind = ? THEN amount ELSE -amount END)`
-	if err := r.db.WithContext(ctx).Table(TransactionsTable).
-		Select(selectQuery, entity.TransactionIn).
+	if err := conn(ctx, r.db).Table(TransactionsTable).
+		Select(balanceQuery, entity.TransactionIn).
 		Where("wallet_id = ? and status = ?", walletID, entity.TransactionStatusSuccessful).
 		Row().Scan(&balance); err != nil {
 		return 0, err
@@ -73,7 +91,7 @@ func (r *TransactionRepo) GetBalanceByWalletID(ctx context.Context, walletID str
 
 func (r *TransactionRepo) GetTransactionByID(ctx context.Context, transID string) (*entity.Transaction, error) {
 	var transSchema schema.TransactionSchema
-	if err := r.db.WithContext(ctx).Table(TransactionsTable).Where("id = ?", transID).Take(&transSchema).Error; err != nil {
+	if err := conn(ctx, r.db).Table(TransactionsTable).Where("id = ?", transID).Take(&transSchema).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -82,7 +100,82 @@ func (r *TransactionRepo) GetTransactionByID(ctx context.Context, transID string
 	return transSchema.ToTransaction(), nil
 }
 
-func (r *TransactionRepo) UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus) error {
-	return r.db.WithContext(ctx).Table(TransactionsTable).Where("id = ?", transID).
-		Update("status", string(status)).Error
+func (r *TransactionRepo) UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus,
+	version int64) error {
+	now := r.db.NowFunc()
+	updates := map[string]interface{}{
+		"status":     string(status),
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": now,
+	}
+	if status == entity.TransactionStatusSuccessful {
+		updates["settled_at"] = now
+	}
+	result := conn(ctx, r.db).Table(TransactionsTable).Where("id = ? AND version = ?", transID, version).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return missedVersion(ctx, r.db, TransactionsTable, "transaction", transID, version)
+	}
+	return nil
+}
+
+// missedVersion tells why a write expecting a version matched no row: the record does not exist or it is at another version
+func missedVersion(ctx context.Context, db *gorm.DB, table string, kind string, id string, version int64) error {
+	var count int64
+	if err := conn(ctx, db).Table(table).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%s %s: %w", kind, id, entity.ErrNotFound)
+	}
+	return entity.NewVersionConflictError(kind, id, version)
+}
+
+func (r *TransactionRepo) CountTransactionsByStatus(ctx context.Context,
+	statuses []entity.TransactionStatus) (map[entity.TransactionStatus]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	if err := conn(ctx, r.db).Table(TransactionsTable).Select("status, COUNT(*) AS count").
+		Where("status IN ?", statuses).Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[entity.TransactionStatus]int64, len(statuses))
+	for _, status := range statuses {
+		counts[status] = 0
+	}
+	for _, row := range rows {
+		counts[entity.TransactionStatus(row.Status)] = row.Count
+	}
+	return counts, nil
+}
+
+// LockWallet selects the wallet FOR UPDATE. SQLite has no row locks, the dialect drops the clause:
+// its transactions already run one at a time.
+func (r *TransactionRepo) LockWallet(ctx context.Context, walletID string) (*entity.Wallet, error) {
+	var walletSchema schema.WalletSchema
+	if err := conn(ctx, r.db).Table(WalletTable).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", walletID).Take(&walletSchema).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return walletSchema.ToWallet(), nil
+}
+
+func (r *TransactionRepo) GetPendingWithdrawals(ctx context.Context, walletID string) (float64, error) {
+	var pending float64
+	if err := conn(ctx, r.db).Table(TransactionsTable).Select("COALESCE(SUM(amount), 0)").
+		Where("wallet_id = ? AND transaction_kind = ? AND status IN ?", walletID, entity.TransactionOut,
+			entity.PendingStatuses()).
+		Row().Scan(&pending); err != nil {
+		return 0, err
+	}
+	return pending, nil
 }
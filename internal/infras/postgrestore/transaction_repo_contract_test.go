@@ -0,0 +1,55 @@
+package postgrestore
+
+import (
+	"testing"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/infras/postgrestore/schema"
+	"go-clean-template/internal/infras/repotest"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type contractFixture struct {
+	db *gorm.DB
+}
+
+func (f contractFixture) createUser(t testing.TB, userID string) {
+	query := `INSERT INTO users (id, full_name, email, phone_number, current_address)
+		VALUES (?, 'Phan Ngoc Quang', 'quangpn@tm.teqn.asia', '0123456789', 'HCM') ON CONFLICT (id) DO NOTHING`
+	require.NoError(t, f.db.Exec(query, userID).Error)
+}
+
+func (f contractFixture) CreateWallet(t testing.TB, wallet *entity.Wallet) {
+	f.createUser(t, wallet.UserID)
+	require.NoError(t, f.db.Table(WalletTable).Create(&schema.WalletSchema{
+		ID:         wallet.ID,
+		UserID:     wallet.UserID,
+		WalletName: wallet.WalletName,
+	}).Error)
+}
+
+func (f contractFixture) CreateLinkedAccount(t testing.TB, account *entity.LinkedAccount) {
+	f.createUser(t, account.UserID)
+	require.NoError(t, f.db.Table(LinkedAccountTable).Create(&schema.LinkedAccountSchema{
+		ID:          account.ID,
+		UserID:      account.UserID,
+		AccountName: account.AccountName,
+	}).Error)
+}
+
+func TestTransactionRepo_Contract(t *testing.T) {
+	for _, testDB := range testDBs {
+		t.Run(testDB.name, func(t *testing.T) {
+			repotest.RunTransactionRepositoryContract(t, func(t *testing.T) repotest.TransactionBackend {
+				db := testDB.connect(t)
+				return repotest.TransactionBackend{
+					Repo:       NewTransactionRepo(db),
+					Fixture:    contractFixture{db: db},
+					Transactor: NewTransactor(db),
+				}
+			})
+		})
+	}
+}
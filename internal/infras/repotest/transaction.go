@@ -0,0 +1,354 @@
+// Package repotest holds the behavioral contracts every repository backend has to pass.
+// Backends run them from their own tests, so a new backend gets the whole suite for free.
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrentWriters is the number of goroutines writing at the same time in the concurrency contract
+const concurrentWriters = 20
+
+// Fixture creates the records ITransactionRepository reads but cannot write itself
+type Fixture interface {
+	CreateWallet(t testing.TB, wallet *entity.Wallet)
+	CreateLinkedAccount(t testing.TB, account *entity.LinkedAccount)
+}
+
+// TransactionBackend is a transaction repository with the fixture writing to the same storage
+// and the transactor of that storage
+type TransactionBackend struct {
+	Repo       usecase.ITransactionRepository
+	Fixture    Fixture
+	Transactor usecase.ITransactor
+}
+
+// NewTransactionBackend returns an empty backend. It is called once per contract test.
+type NewTransactionBackend func(t *testing.T) TransactionBackend
+
+// RunTransactionRepositoryContract runs the ITransactionRepository contract against a backend
+func RunTransactionRepositoryContract(t *testing.T, newBackend NewTransactionBackend) {
+	t.Run("GetWalletByID", func(t *testing.T) { testGetWalletByID(t, newBackend(t)) })
+	t.Run("GetLinkedAccountByID", func(t *testing.T) { testGetLinkedAccountByID(t, newBackend(t)) })
+	t.Run("SaveAndGetTransaction", func(t *testing.T) { testSaveAndGetTransaction(t, newBackend(t)) })
+	t.Run("GetBalanceByWalletID", func(t *testing.T) { testGetBalanceByWalletID(t, newBackend(t)) })
+	t.Run("GetPendingWithdrawals", func(t *testing.T) { testGetPendingWithdrawals(t, newBackend(t)) })
+	t.Run("UpdateTransactionStatus", func(t *testing.T) { testUpdateTransactionStatus(t, newBackend(t)) })
+	t.Run("CountTransactionsByStatus", func(t *testing.T) { testCountTransactionsByStatus(t, newBackend(t)) })
+	t.Run("Concurrency", func(t *testing.T) { testConcurrency(t, newBackend(t)) })
+	t.Run("WithinTransaction", func(t *testing.T) { testWithinTransaction(t, newBackend(t)) })
+	t.Run("LockWallet", func(t *testing.T) { testLockWallet(t, newBackend(t)) })
+}
+
+func testGetWalletByID(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	want := &entity.Wallet{ID: uuid.New().String(), UserID: uuid.New().String(), WalletName: "My wallet"}
+	b.Fixture.CreateWallet(t, want)
+
+	t.Run("found", func(t *testing.T) {
+		got, err := b.Repo.GetWalletByID(ctx, want.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("not found returns nil - nil", func(t *testing.T) {
+		got, err := b.Repo.GetWalletByID(ctx, uuid.New().String())
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func testGetLinkedAccountByID(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	want := &entity.LinkedAccount{ID: uuid.New().String(), UserID: uuid.New().String(), AccountName: "momo"}
+	b.Fixture.CreateLinkedAccount(t, want)
+
+	t.Run("found", func(t *testing.T) {
+		got, err := b.Repo.GetLinkedAccountByID(ctx, want.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("not found returns nil - nil", func(t *testing.T) {
+		got, err := b.Repo.GetLinkedAccountByID(ctx, uuid.New().String())
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func testSaveAndGetTransaction(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	wallet, account := createWalletAndAccount(t, b)
+
+	t.Run("round trip keeps the id and fields", func(t *testing.T) {
+		want := newTransaction(wallet, account, 1000, entity.TransactionIn, entity.TransactionStatusNew)
+		require.NoError(t, b.Repo.SaveTransaction(ctx, want))
+
+		got, err := b.Repo.GetTransactionByID(ctx, want.ID)
+
+		assert.NoError(t, err)
+		assertTransaction(t, want, got)
+	})
+
+	t.Run("ids do not collide", func(t *testing.T) {
+		first := newTransaction(wallet, account, 100, entity.TransactionIn, entity.TransactionStatusNew)
+		second := newTransaction(wallet, account, 200, entity.TransactionIn, entity.TransactionStatusNew)
+		require.NoError(t, b.Repo.SaveTransaction(ctx, first))
+		require.NoError(t, b.Repo.SaveTransaction(ctx, second))
+
+		gotFirst, err1 := b.Repo.GetTransactionByID(ctx, first.ID)
+		gotSecond, err2 := b.Repo.GetTransactionByID(ctx, second.ID)
+
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assertTransaction(t, first, gotFirst)
+		assertTransaction(t, second, gotSecond)
+	})
+
+	t.Run("saving a duplicated id fails", func(t *testing.T) {
+		trans := newTransaction(wallet, account, 100, entity.TransactionIn, entity.TransactionStatusNew)
+		require.NoError(t, b.Repo.SaveTransaction(ctx, trans))
+
+		err := b.Repo.SaveTransaction(ctx, trans)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not found returns nil - nil", func(t *testing.T) {
+		got, err := b.Repo.GetTransactionByID(ctx, uuid.New().String())
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func testGetBalanceByWalletID(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	wallet, account := createWalletAndAccount(t, b)
+	other, _ := createWalletAndAccount(t, b)
+
+	t.Run("wallet without transactions", func(t *testing.T) {
+		got, err := b.Repo.GetBalanceByWalletID(ctx, wallet.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, got)
+	})
+
+	t.Run("only successful transactions of the wallet count", func(t *testing.T) {
+		for _, trans := range []*entity.Transaction{
+			newTransaction(wallet, account, 1000, entity.TransactionIn, entity.TransactionStatusSuccessful),
+			newTransaction(wallet, account, 250.5, entity.TransactionOut, entity.TransactionStatusSuccessful),
+			newTransaction(wallet, account, 100, entity.TransactionIn, entity.TransactionStatusSuccessful),
+			newTransaction(wallet, account, 5000, entity.TransactionIn, entity.TransactionStatusNew),
+			newTransaction(wallet, account, 300, entity.TransactionOut, entity.TransactionStatusFailed),
+			newTransaction(wallet, account, 400, entity.TransactionOut, entity.TransactionStatusAwaitingApproval),
+			newTransaction(other, account, 700, entity.TransactionIn, entity.TransactionStatusSuccessful),
+		} {
+			require.NoError(t, b.Repo.SaveTransaction(ctx, trans))
+		}
+
+		got, err := b.Repo.GetBalanceByWalletID(ctx, wallet.ID)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 849.5, got, 0.000001)
+	})
+}
+
+func testGetPendingWithdrawals(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	wallet, account := createWalletAndAccount(t, b)
+	other, _ := createWalletAndAccount(t, b)
+
+	t.Run("wallet without transactions", func(t *testing.T) {
+		got, err := b.Repo.GetPendingWithdrawals(ctx, wallet.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, got)
+	})
+
+	t.Run("only the pending withdrawals of the wallet count", func(t *testing.T) {
+		for _, trans := range []*entity.Transaction{
+			newTransaction(wallet, account, 100, entity.TransactionOut, entity.TransactionStatusNew),
+			newTransaction(wallet, account, 200, entity.TransactionOut, entity.TransactionStatusAwaitingApproval),
+			newTransaction(wallet, account, 300.5, entity.TransactionOut, entity.TransactionStatusApproved),
+			newTransaction(wallet, account, 50, entity.TransactionOut, entity.TransactionStatusProcessing),
+			newTransaction(wallet, account, 400, entity.TransactionOut, entity.TransactionStatusSuccessful),
+			newTransaction(wallet, account, 500, entity.TransactionOut, entity.TransactionStatusRejected),
+			newTransaction(wallet, account, 600, entity.TransactionIn, entity.TransactionStatusNew),
+			newTransaction(other, account, 700, entity.TransactionOut, entity.TransactionStatusNew),
+		} {
+			require.NoError(t, b.Repo.SaveTransaction(ctx, trans))
+		}
+
+		got, err := b.Repo.GetPendingWithdrawals(ctx, wallet.ID)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 650.5, got, 0.000001)
+	})
+}
+
+func testCountTransactionsByStatus(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	wallet, account := createWalletAndAccount(t, b)
+	statuses := []entity.TransactionStatus{entity.TransactionStatusNew, entity.TransactionStatusAwaitingApproval}
+
+	t.Run("no transactions counts 0", func(t *testing.T) {
+		got, err := b.Repo.CountTransactionsByStatus(ctx, statuses)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[entity.TransactionStatus]int64{
+			entity.TransactionStatusNew:              0,
+			entity.TransactionStatusAwaitingApproval: 0,
+		}, got)
+	})
+
+	t.Run("only the requested statuses are counted", func(t *testing.T) {
+		for _, trans := range []*entity.Transaction{
+			newTransaction(wallet, account, 100, entity.TransactionIn, entity.TransactionStatusNew),
+			newTransaction(wallet, account, 200, entity.TransactionOut, entity.TransactionStatusNew),
+			newTransaction(wallet, account, 300, entity.TransactionOut, entity.TransactionStatusAwaitingApproval),
+			newTransaction(wallet, account, 400, entity.TransactionIn, entity.TransactionStatusSuccessful),
+		} {
+			require.NoError(t, b.Repo.SaveTransaction(ctx, trans))
+		}
+
+		got, err := b.Repo.CountTransactionsByStatus(ctx, statuses)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[entity.TransactionStatus]int64{
+			entity.TransactionStatusNew:              2,
+			entity.TransactionStatusAwaitingApproval: 1,
+		}, got)
+	})
+}
+
+func testUpdateTransactionStatus(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	wallet, account := createWalletAndAccount(t, b)
+
+	t.Run("expected version updates and increments it", func(t *testing.T) {
+		trans := newTransaction(wallet, account, 1000, entity.TransactionIn, entity.TransactionStatusNew)
+		require.NoError(t, b.Repo.SaveTransaction(ctx, trans))
+
+		err := b.Repo.UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful, trans.Version)
+
+		assert.NoError(t, err)
+		got, err := b.Repo.GetTransactionByID(ctx, trans.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TransactionStatusSuccessful, got.Status)
+		assert.Equal(t, trans.Version+1, got.Version)
+		assert.NotNil(t, got.SettledAt)
+		balance, err := b.Repo.GetBalanceByWalletID(ctx, wallet.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1000.0, balance)
+	})
+
+	t.Run("stale version is a conflict and changes nothing", func(t *testing.T) {
+		trans := newTransaction(wallet, account, 500, entity.TransactionOut, entity.TransactionStatusNew)
+		require.NoError(t, b.Repo.SaveTransaction(ctx, trans))
+		require.NoError(t, b.Repo.UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusAwaitingApproval, trans.Version))
+
+		err := b.Repo.UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful, trans.Version)
+
+		assert.ErrorIs(t, err, entity.ErrVersionConflict)
+		got, err := b.Repo.GetTransactionByID(ctx, trans.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TransactionStatusAwaitingApproval, got.Status)
+		assert.Equal(t, trans.Version+1, got.Version)
+		assert.Nil(t, got.SettledAt)
+	})
+
+	t.Run("unknown transaction is not found", func(t *testing.T) {
+		err := b.Repo.UpdateTransactionStatus(ctx, uuid.New().String(), entity.TransactionStatusSuccessful,
+			entity.InitialVersion)
+
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.NotErrorIs(t, err, entity.ErrVersionConflict)
+	})
+}
+
+func testConcurrency(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	wallet, account := createWalletAndAccount(t, b)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, concurrentWriters)
+	)
+	for i := 0; i < concurrentWriters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			trans := newTransaction(wallet, account, 10, entity.TransactionIn, entity.TransactionStatusNew)
+			if err := b.Repo.SaveTransaction(ctx, trans); err != nil {
+				errs <- fmt.Errorf("save %s: %w", trans.ID, err)
+				return
+			}
+			if err := b.Repo.UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful, trans.Version); err != nil {
+				errs <- fmt.Errorf("update %s: %w", trans.ID, err)
+				return
+			}
+			if _, err := b.Repo.GetBalanceByWalletID(ctx, wallet.ID); err != nil {
+				errs <- fmt.Errorf("balance: %w", err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	balance, err := b.Repo.GetBalanceByWalletID(ctx, wallet.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10*concurrentWriters), balance)
+}
+
+func createWalletAndAccount(t testing.TB, b TransactionBackend) (*entity.Wallet, *entity.LinkedAccount) {
+	t.Helper()
+
+	userID := uuid.New().String()
+	wallet := &entity.Wallet{ID: uuid.New().String(), UserID: userID, WalletName: "My wallet"}
+	account := &entity.LinkedAccount{ID: uuid.New().String(), UserID: userID, AccountName: "momo"}
+	b.Fixture.CreateWallet(t, wallet)
+	b.Fixture.CreateLinkedAccount(t, account)
+	return wallet, account
+}
+
+func newTransaction(wallet *entity.Wallet, account *entity.LinkedAccount, amount float64,
+	kind entity.TransactionKind, status entity.TransactionStatus) *entity.Transaction {
+	return entity.NewTransaction(uuid.New().String(), wallet.ID, account.ID, amount, "VND", kind, "contract", status)
+}
+
+// assertTransaction compares everything but CreatedAt, whose precision depends on the backend
+func assertTransaction(t testing.TB, want *entity.Transaction, got *entity.Transaction) {
+	t.Helper()
+
+	if !assert.NotNil(t, got) {
+		return
+	}
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.WalletID, got.WalletID)
+	assert.Equal(t, want.AccountID, got.AccountID)
+	assert.Equal(t, want.Amount, got.Amount)
+	assert.Equal(t, want.Currency, got.Currency)
+	assert.Equal(t, want.TransactionKind, got.TransactionKind)
+	assert.Equal(t, want.Status, got.Status)
+	assert.Equal(t, want.Note, got.Note)
+	assert.False(t, got.CreatedAt.IsZero())
+}
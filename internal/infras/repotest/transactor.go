@@ -0,0 +1,138 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"go-clean-template/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWithinTransaction(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	wallet, account := createWalletAndAccount(t, b)
+	errFn := errors.New("fn failed")
+
+	t.Run("commits the writes of fn, which reads them", func(t *testing.T) {
+		trans := newTransaction(wallet, account, 100, entity.TransactionOut, entity.TransactionStatusNew)
+
+		err := b.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := b.Repo.SaveTransaction(ctx, trans); err != nil {
+				return err
+			}
+			got, err := b.Repo.GetTransactionByID(ctx, trans.ID)
+			assertTransaction(t, trans, got)
+			return err
+		})
+
+		assert.NoError(t, err)
+		got, err := b.Repo.GetTransactionByID(ctx, trans.ID)
+		assert.NoError(t, err)
+		assertTransaction(t, trans, got)
+	})
+
+	t.Run("rolls back the writes of fn when it fails", func(t *testing.T) {
+		trans := newTransaction(wallet, account, 100, entity.TransactionOut, entity.TransactionStatusNew)
+
+		err := b.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			assert.NoError(t, b.Repo.SaveTransaction(ctx, trans))
+			return errFn
+		})
+
+		assert.ErrorIs(t, err, errFn)
+		got, err := b.Repo.GetTransactionByID(ctx, trans.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("a nested call joins the transaction", func(t *testing.T) {
+		outer := newTransaction(wallet, account, 100, entity.TransactionOut, entity.TransactionStatusNew)
+		inner := newTransaction(wallet, account, 200, entity.TransactionOut, entity.TransactionStatusNew)
+
+		err := b.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			assert.NoError(t, b.Repo.SaveTransaction(ctx, outer))
+			assert.NoError(t, b.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				return b.Repo.SaveTransaction(ctx, inner)
+			}))
+			return errFn
+		})
+
+		assert.ErrorIs(t, err, errFn)
+		for _, trans := range []*entity.Transaction{outer, inner} {
+			got, err := b.Repo.GetTransactionByID(ctx, trans.ID)
+			assert.NoError(t, err)
+			assert.Nil(t, got)
+		}
+	})
+}
+
+func testLockWallet(t *testing.T, b TransactionBackend) {
+	ctx := context.Background()
+	wallet, account := createWalletAndAccount(t, b)
+
+	t.Run("found", func(t *testing.T) {
+		err := b.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			got, err := b.Repo.LockWallet(ctx, wallet.ID)
+			assert.Equal(t, wallet, got)
+			return err
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not found returns nil - nil", func(t *testing.T) {
+		err := b.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			got, err := b.Repo.LockWallet(ctx, uuid.New().String())
+			assert.Nil(t, got)
+			return err
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("the withdrawals checking the balance under the lock do not overdraw", func(t *testing.T) {
+		deposit := newTransaction(wallet, account, 100, entity.TransactionIn, entity.TransactionStatusSuccessful)
+		require.NoError(t, b.Repo.SaveTransaction(ctx, deposit))
+
+		var (
+			wg   sync.WaitGroup
+			errs = make(chan error, concurrentWriters)
+		)
+		for i := 0; i < concurrentWriters; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				errs <- b.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+					if _, err := b.Repo.LockWallet(ctx, wallet.ID); err != nil {
+						return err
+					}
+					balance, err := b.Repo.GetBalanceByWalletID(ctx, wallet.ID)
+					if err != nil {
+						return err
+					}
+					pending, err := b.Repo.GetPendingWithdrawals(ctx, wallet.ID)
+					if err != nil || balance-pending < 30 {
+						return err
+					}
+					return b.Repo.SaveTransaction(ctx,
+						newTransaction(wallet, account, 30, entity.TransactionOut, entity.TransactionStatusNew))
+				})
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+		pending, err := b.Repo.GetPendingWithdrawals(ctx, wallet.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 90.0, pending)
+	})
+}
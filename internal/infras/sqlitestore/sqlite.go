@@ -0,0 +1,85 @@
+// Package sqlitestore opens the SQLite database of single box deployments.
+//
+// The tables are the ones of postgrestore, created by the SQLite migrations embedded from migrations/sqlite,
+// and the postgrestore repositories run on them. NewDB sets the clock of gorm to UTC as SQLite compares
+// timestamps as text.
+package sqlitestore
+
+import (
+	"fmt"
+	"time"
+
+	"go-clean-template/migrations"
+	"go-clean-template/pkg/config"
+
+	"github.com/glebarez/sqlite"
+	migrate "github.com/rubenv/sql-migrate"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Dialect is the sql-migrate dialect of SQLite
+const Dialect = "sqlite3"
+
+type Options struct {
+	Path         string
+	BusyTimeout  time.Duration
+	MaxOpenConns int
+	// Log receives the GORM logs, they are dropped when nil
+	Log gormlogger.Interface
+}
+
+func ParseFromConfig(c *config.Config) Options {
+	return Options{
+		Path:         c.SQLite.Path,
+		BusyTimeout:  c.SQLite.BusyTimeout,
+		MaxOpenConns: c.SQLite.MaxOpenConns,
+	}
+}
+
+// NewDB opens the database file in WAL mode with foreign keys enforced.
+// WAL lets the connections of the pool read while one of them writes. The transactions begin with BEGIN IMMEDIATE:
+// they take the write lock first and wait up to BusyTimeout for it, so a transaction reading a balance before
+// inserting a withdrawal is never overtaken by another writer, nor fails to upgrade its read lock with SQLITE_BUSY.
+func NewDB(opt Options) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("file:%s?_txlock=immediate&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"+
+		"&_pragma=busy_timeout(%d)", opt.Path, opt.BusyTimeout.Milliseconds())
+
+	log := opt.Log
+	if log == nil {
+		log = gormlogger.Discard
+	}
+
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		TranslateError: true,
+		Logger:         log,
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if opt.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(opt.MaxOpenConns)
+	}
+	if err := sqlDB.Ping(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Migrate applies the SQLite migrations that have not been applied yet
+func Migrate(db *gorm.DB) (int, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return 0, err
+	}
+	return migrate.Exec(sqlDB, Dialect, migrations.SQLite, migrate.Up)
+}
@@ -0,0 +1,141 @@
+package sqlitestore
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go-clean-template/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func createTestDB(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	db, err := NewDB(Options{Path: filepath.Join(t.TempDir(), "test.db"), BusyTimeout: 5 * time.Second, MaxOpenConns: 4})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		assert.NoError(t, err)
+		assert.NoError(t, sqlDB.Close())
+	})
+
+	_, err = Migrate(db)
+	require.NoError(t, err)
+	return db
+}
+
+func TestParseConfig(t *testing.T) {
+	// Arrange
+	cfg := &config.Config{}
+	cfg.SQLite.Path = "/var/lib/wallet/wallet.db"
+	cfg.SQLite.BusyTimeout = 3 * time.Second
+	cfg.SQLite.MaxOpenConns = 4
+
+	// Act
+	actual := ParseFromConfig(cfg)
+
+	// Assert
+	assert.Equal(t, Options{Path: "/var/lib/wallet/wallet.db", BusyTimeout: 3 * time.Second, MaxOpenConns: 4}, actual)
+}
+
+func TestNewDB(t *testing.T) {
+	t.Run("good case: WAL mode with foreign keys", func(t *testing.T) {
+		// Act
+		db := createTestDB(t)
+
+		// Assert
+		var journalMode string
+		assert.NoError(t, db.Raw("PRAGMA journal_mode").Scan(&journalMode).Error)
+		assert.Equal(t, "wal", journalMode)
+		var foreignKeys int
+		assert.NoError(t, db.Raw("PRAGMA foreign_keys").Scan(&foreignKeys).Error)
+		assert.Equal(t, 1, foreignKeys)
+	})
+
+	t.Run("bad case: directory does not exist", func(t *testing.T) {
+		// Act
+		db, err := NewDB(Options{Path: filepath.Join(t.TempDir(), "missing", "test.db")})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, db)
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	// Arrange
+	db := createTestDB(t)
+
+	// Act
+	applied, err := Migrate(db)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, applied)
+}
+
+func TestNewDB_Transactions(t *testing.T) {
+	t.Run("good case: transactions reading then writing take turns", func(t *testing.T) {
+		// Arrange
+		db := createTestDB(t)
+		createWallet(t, db)
+
+		// Act
+		var wg sync.WaitGroup
+		errs := make(chan error, 50)
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- db.Transaction(func(tx *gorm.DB) error {
+					var walletName string
+					if err := tx.Raw(`SELECT wallet_name FROM wallets WHERE id = 'w_001'`).Scan(&walletName).Error; err != nil {
+						return err
+					}
+					return tx.Exec(`UPDATE wallets SET wallet_name = ? WHERE id = 'w_001'`, walletName+"x").Error
+				})
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// Assert
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+		var walletName string
+		assert.NoError(t, db.Raw(`SELECT wallet_name FROM wallets WHERE id = 'w_001'`).Scan(&walletName).Error)
+		assert.Len(t, walletName, len("My wallet")+50)
+	})
+
+	t.Run("good case: reads do not wait for an open transaction", func(t *testing.T) {
+		// Arrange
+		db := createTestDB(t)
+		createWallet(t, db)
+		tx := db.Begin()
+		require.NoError(t, tx.Error)
+		defer tx.Rollback()
+		require.NoError(t, tx.Exec(`UPDATE wallets SET wallet_name = 'Renamed' WHERE id = 'w_001'`).Error)
+
+		// Act
+		var walletName string
+		err := db.Raw(`SELECT wallet_name FROM wallets WHERE id = 'w_001'`).Scan(&walletName).Error
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "My wallet", walletName)
+	})
+}
+
+func createWallet(t testing.TB, db *gorm.DB) {
+	t.Helper()
+
+	require.NoError(t, db.Exec(`INSERT INTO users (id, full_name, email, phone_number, current_address)
+		VALUES ('u_001', 'Phan Ngoc Quang', 'quangpn@tm.teqn.asia', '0123456789', 'HCM')`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO wallets (id, user_id, wallet_name) VALUES ('w_001', 'u_001', 'My wallet')`).Error)
+}
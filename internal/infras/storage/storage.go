@@ -0,0 +1,335 @@
+// Package storage builds the repositories of the storage driver selected in the config
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"go-clean-template/internal/fixture"
+	"go-clean-template/internal/infras/memstore"
+	"go-clean-template/internal/infras/mongo"
+	"go-clean-template/internal/infras/postgrestore"
+	"go-clean-template/internal/infras/sqlitestore"
+	"go-clean-template/internal/usecase"
+	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/health"
+
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	DriverPostgres = "postgres"
+	DriverMongo    = "mongo"
+	DriverMemory   = "memory"
+	DriverSQLite   = "sqlite"
+)
+
+type Repositories struct {
+	Transaction usecase.ITransactionRepository
+	Approval    usecase.IApprovalRepository
+	Schedule    usecase.IScheduleRepository
+	Payout      usecase.IPayoutRepository
+	Statement   usecase.IStatementRepository
+	Balance     usecase.IBalanceRepository
+	// The readers serve the reads that tolerate the replication lag, the endpoints showing a transaction,
+	// a schedule, a payout batch or a balance. From a replica when the storage has some, the repository
+	// of the primary otherwise.
+	TransactionReader usecase.ITransactionRepository
+	ScheduleReader    usecase.IScheduleRepository
+	PayoutReader      usecase.IPayoutRepository
+	BalanceReader     usecase.IBalanceRepository
+	// Transactor groups the writes of the repositories in a transaction of the storage
+	Transactor usecase.ITransactor
+	Health     health.Checker
+	// Seeder loads fixtures into the storage, for local and test environments
+	Seeder fixture.Seeder
+
+	closer func(ctx context.Context) error
+}
+
+// Close releases the connections of the storage, the repositories cannot be used afterwards
+func (r *Repositories) Close(ctx context.Context) error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer(ctx)
+}
+
+// Option customizes the storage built by New
+type Option func(o *options)
+
+type options struct {
+	logger  *zap.SugaredLogger
+	tracing trace.TracerProvider
+	metrics mongo.CommandMetrics
+}
+
+// WithLogger logs the events of the driver, like failed and slow statements
+func WithLogger(l *zap.SugaredLogger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// WithTracerProvider records a span per statement or command, on postgres and mongo
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracing = tp
+	}
+}
+
+// WithCommandMetrics records the duration of every mongo command
+func WithCommandMetrics(m mongo.CommandMetrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// New validates the config of the selected driver, connects to the storage and builds its repositories.
+// Every error names the driver, so a misconfigured deployment fails at startup with a clear message.
+func New(cfg *config.Config, opts ...Option) (*Repositories, error) {
+	var o options
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	repos, err := newRepositories(cfg, o)
+	if err != nil {
+		return nil, fmt.Errorf("storage driver %q: %w", cfg.StorageDriver, err)
+	}
+	return repos, nil
+}
+
+func newRepositories(cfg *config.Config, o options) (*Repositories, error) {
+	if err := validate(cfg); err != nil {
+		return nil, err
+	}
+
+	switch cfg.StorageDriver {
+	case DriverPostgres:
+		opt := postgrestore.ParseFromConfig(cfg)
+		opt.Log.Logger = o.logger
+		opt.Tracing = o.tracing
+		db, err := postgrestore.NewDB(opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect: %w", err)
+		}
+		return postgresRepositories(db), nil
+	case DriverMongo:
+		opt := mongo.ParseFromConfig(cfg)
+		opt.Log.Logger = o.logger
+		opt.Tracing = o.tracing
+		opt.Metrics = o.metrics
+		db, err := mongo.NewDB(opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect: %w", err)
+		}
+		return mongoRepositories(db), nil
+	case DriverMemory:
+		db := memstore.NewDB()
+		// a missing snapshot file starts an empty store, it is created by the first save
+		if cfg.Memory.SnapshotFile != "" {
+			if err := db.LoadFile(cfg.Memory.SnapshotFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("failed to restore snapshot: %w", err)
+			}
+		}
+		return memoryRepositories(db, cfg.Memory.SnapshotFile), nil
+	case DriverSQLite:
+		opt := sqlitestore.ParseFromConfig(cfg)
+		// the statements are logged like the ones of postgres, at DB_LOG_LEVEL and without their values by default
+		logOpt := postgrestore.ParseFromConfig(cfg).Log
+		logOpt.Logger = o.logger
+		gormLogger, err := postgrestore.NewGormLogger(logOpt)
+		if err != nil {
+			return nil, err
+		}
+		opt.Log = gormLogger
+		db, err := sqlitestore.NewDB(opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open: %w", err)
+		}
+		// single box deployments have no separate migration step
+		if _, err := sqlitestore.Migrate(db); err != nil {
+			return nil, fmt.Errorf("failed to migrate: %w", err)
+		}
+		return sqliteRepositories(db), nil
+	default:
+		return nil, fmt.Errorf("unknown driver, must be one of %s, %s, %s, %s",
+			DriverPostgres, DriverMongo, DriverMemory, DriverSQLite)
+	}
+}
+
+// validate checks the settings the driver cannot start without, before any connection is attempted
+func validate(cfg *config.Config) error {
+	var missing []string
+	require := func(name string, value string) {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+
+	switch cfg.StorageDriver {
+	case DriverPostgres:
+		require("DB_HOST", cfg.DB.Host)
+		require("DB_NAME", cfg.DB.Name)
+		require("DB_USER", cfg.DB.User)
+		if cfg.DB.Port == 0 {
+			missing = append(missing, "DB_PORT")
+		}
+	case DriverMongo:
+		// DB_MONGO_URI replaces the host and the port
+		withURI := cfg.MongoDB.URI != ""
+		if !withURI {
+			require("DB_MONGO_HOST", cfg.MongoDB.Host)
+		}
+		require("DB_MONGO_NAME", cfg.MongoDB.DBName)
+		if !withURI && cfg.MongoDB.Port == 0 {
+			missing = append(missing, "DB_MONGO_PORT")
+		}
+	case DriverSQLite:
+		require("SQLITE_PATH", cfg.SQLite.Path)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing config %v", missing)
+	}
+	return nil
+}
+
+func postgresRepositories(db *gorm.DB) *Repositories {
+	replica := postgrestore.ReadOnly(db)
+	return &Repositories{
+		Transaction:       postgrestore.NewTransactionRepo(db),
+		Approval:          postgrestore.NewApprovalRepo(db),
+		Schedule:          postgrestore.NewScheduleRepo(db),
+		Payout:            postgrestore.NewPayoutRepo(db),
+		Statement:         postgrestore.NewStatementRepo(replica),
+		Balance:           postgrestore.NewBalanceRepo(db),
+		TransactionReader: postgrestore.NewTransactionRepo(replica),
+		ScheduleReader:    postgrestore.NewScheduleRepo(replica),
+		PayoutReader:      postgrestore.NewPayoutRepo(replica),
+		BalanceReader:     postgrestore.NewBalanceRepo(replica),
+		Transactor:        postgrestore.NewTransactor(db),
+		Health:            sqlHealthCheck(db),
+		Seeder:            postgrestore.NewSeedRepo(db),
+		closer:            sqlCloser(db),
+	}
+}
+
+func mongoRepositories(db *mongodriver.Database) *Repositories {
+	transRepo := mongo.NewTransactionRepo(db)
+	scheduleRepo := mongo.NewScheduleRepo(db)
+	payoutRepo := mongo.NewPayoutRepo(db)
+	balanceRepo := mongo.NewBalanceRepo(db)
+	return &Repositories{
+		Transaction:       transRepo,
+		Approval:          mongo.NewApprovalRepo(db),
+		Schedule:          scheduleRepo,
+		Payout:            payoutRepo,
+		Statement:         mongo.NewStatementRepo(db),
+		Balance:           balanceRepo,
+		TransactionReader: transRepo,
+		ScheduleReader:    scheduleRepo,
+		PayoutReader:      payoutRepo,
+		BalanceReader:     balanceRepo,
+		Transactor:        mongo.NewTransactor(db),
+		Health: health.CheckFunc(func(ctx context.Context) error {
+			return db.Client().Ping(ctx, readpref.Primary())
+		}),
+		Seeder: mongo.NewSeedRepo(db),
+		closer: func(ctx context.Context) error {
+			return db.Client().Disconnect(ctx)
+		},
+	}
+}
+
+// sqliteRepositories runs the postgrestore repositories on the tables of the SQLite migrations
+func sqliteRepositories(db *gorm.DB) *Repositories {
+	transRepo := postgrestore.NewTransactionRepo(db)
+	scheduleRepo := postgrestore.NewScheduleRepo(db)
+	payoutRepo := postgrestore.NewPayoutRepo(db)
+	balanceRepo := postgrestore.NewBalanceRepo(db)
+	return &Repositories{
+		Transaction:       transRepo,
+		Approval:          postgrestore.NewApprovalRepo(db),
+		Schedule:          scheduleRepo,
+		Payout:            payoutRepo,
+		Statement:         postgrestore.NewStatementRepo(db),
+		Balance:           balanceRepo,
+		TransactionReader: transRepo,
+		ScheduleReader:    scheduleRepo,
+		PayoutReader:      payoutRepo,
+		BalanceReader:     balanceRepo,
+		Transactor:        postgrestore.NewTransactor(db),
+		Health:            sqlHealthCheck(db),
+		Seeder:            postgrestore.NewSeedRepo(db),
+		closer:            sqlCloser(db),
+	}
+}
+
+// memoryRepositories builds the in memory repositories. Seeding and closing save the snapshot file when there
+// is one, so the seeded data outlives the seed command and the data of a server outlives its shutdown.
+func memoryRepositories(db *memstore.DB, snapshotFile string) *Repositories {
+	seedRepo := memstore.NewSeedRepo(db)
+	transRepo := memstore.NewTransactionRepo(db)
+	scheduleRepo := memstore.NewScheduleRepo(db)
+	payoutRepo := memstore.NewPayoutRepo(db)
+	balanceRepo := memstore.NewBalanceRepo(db)
+	return &Repositories{
+		Transaction:       transRepo,
+		Approval:          memstore.NewApprovalRepo(db),
+		Schedule:          scheduleRepo,
+		Payout:            payoutRepo,
+		Statement:         memstore.NewStatementRepo(db),
+		Balance:           balanceRepo,
+		TransactionReader: transRepo,
+		ScheduleReader:    scheduleRepo,
+		PayoutReader:      payoutRepo,
+		BalanceReader:     balanceRepo,
+		Transactor:        db,
+		Health: health.CheckFunc(func(context.Context) error {
+			return nil
+		}),
+		Seeder: fixture.SeedFunc(func(ctx context.Context, set *fixture.Set) error {
+			if err := seedRepo.Seed(ctx, set); err != nil {
+				return err
+			}
+			if snapshotFile == "" {
+				return nil
+			}
+			return db.SaveFile(snapshotFile)
+		}),
+		closer: func(context.Context) error {
+			if snapshotFile == "" {
+				return nil
+			}
+			return db.SaveFile(snapshotFile)
+		},
+	}
+}
+
+func sqlHealthCheck(db *gorm.DB) health.Checker {
+	return health.CheckFunc(func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+}
+
+func sqlCloser(db *gorm.DB) func(ctx context.Context) error {
+	return func(context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	}
+}
@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/fixture"
+	"go-clean-template/internal/infras/memstore"
+	"go-clean-template/internal/infras/mongo"
+	"go-clean-template/internal/infras/postgrestore"
+	"go-clean-template/pkg/config"
+	"go-clean-template/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		wantErr string
+	}{
+		{
+			name:    "unknown driver",
+			cfg:     &config.Config{StorageDriver: "mysql"},
+			wantErr: `storage driver "mysql": unknown driver, must be one of postgres, mongo, memory, sqlite`,
+		},
+		{
+			name:    "postgres without host",
+			cfg:     &config.Config{StorageDriver: DriverPostgres},
+			wantErr: `storage driver "postgres": missing config [DB_HOST DB_NAME DB_USER DB_PORT]`,
+		},
+		{
+			name:    "mongo without host",
+			cfg:     &config.Config{StorageDriver: DriverMongo},
+			wantErr: `storage driver "mongo": missing config [DB_MONGO_HOST DB_MONGO_NAME DB_MONGO_PORT]`,
+		},
+		{
+			name: "mongo with a connection string but no database",
+			cfg: func() *config.Config {
+				cfg := &config.Config{StorageDriver: DriverMongo}
+				cfg.MongoDB.URI = "mongodb://mongo1:27017,mongo2:27017/?replicaSet=rs0"
+				return cfg
+			}(),
+			wantErr: `storage driver "mongo": missing config [DB_MONGO_NAME]`,
+		},
+		{
+			name:    "sqlite without path",
+			cfg:     &config.Config{StorageDriver: DriverSQLite},
+			wantErr: `storage driver "sqlite": missing config [SQLITE_PATH]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			repos, err := New(tt.cfg)
+
+			// Assert
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, repos)
+		})
+	}
+}
+
+func TestNew_Memory(t *testing.T) {
+	t.Run("good case: restore snapshot", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		db := memstore.NewDB()
+		db.SeedWallets(&entity.Wallet{ID: "w001"})
+		assert.NoError(t, db.SaveFile(path))
+		cfg := &config.Config{StorageDriver: DriverMemory}
+		cfg.Memory.SnapshotFile = path
+
+		// Act
+		repos, err := New(cfg)
+
+		// Assert
+		assert.NoError(t, err)
+		assertMemoryRepositories(t, repos)
+		wallet, err := repos.Transaction.GetWalletByID(context.Background(), "w001")
+		assert.NoError(t, err)
+		assert.NotNil(t, wallet)
+	})
+
+	t.Run("good case: missing snapshot starts empty", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{StorageDriver: DriverMemory}
+		cfg.Memory.SnapshotFile = filepath.Join(t.TempDir(), "missing.json")
+
+		// Act
+		repos, err := New(cfg)
+
+		// Assert
+		assert.NoError(t, err)
+		assertMemoryRepositories(t, repos)
+	})
+
+	t.Run("good case: seeding saves the snapshot", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{StorageDriver: DriverMemory}
+		cfg.Memory.SnapshotFile = filepath.Join(t.TempDir(), "snapshot.json")
+		repos, err := New(cfg)
+		assert.NoError(t, err)
+
+		// Act
+		err = repos.Seeder.Seed(context.Background(), &fixture.Set{Wallets: []*entity.Wallet{{ID: "w001"}}})
+
+		// Assert
+		assert.NoError(t, err)
+		restored := memstore.NewDB()
+		assert.NoError(t, restored.LoadFile(cfg.Memory.SnapshotFile))
+		wallet, err := memstore.NewTransactionRepo(restored).GetWalletByID(context.Background(), "w001")
+		assert.NoError(t, err)
+		assert.NotNil(t, wallet)
+	})
+
+	t.Run("good case: closing saves the snapshot", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{StorageDriver: DriverMemory}
+		cfg.Memory.SnapshotFile = filepath.Join(t.TempDir(), "snapshot.json")
+		repos, err := New(cfg)
+		assert.NoError(t, err)
+		ctx := context.Background()
+		assert.NoError(t, repos.Transaction.SaveTransaction(ctx, &entity.Transaction{ID: "t001", WalletID: "w001"}))
+
+		// Act
+		err = repos.Close(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		restored := memstore.NewDB()
+		assert.NoError(t, restored.LoadFile(cfg.Memory.SnapshotFile))
+		trans, err := memstore.NewTransactionRepo(restored).GetTransactionByID(ctx, "t001")
+		assert.NoError(t, err)
+		assert.NotNil(t, trans)
+	})
+}
+
+func TestNew_SQLite(t *testing.T) {
+	t.Run("good case: migrate on startup", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{StorageDriver: DriverSQLite}
+		cfg.SQLite.Path = filepath.Join(t.TempDir(), "wallet.db")
+
+		// Act
+		repos, err := New(cfg)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.IsType(t, &postgrestore.TransactionRepo{}, repos.Transaction)
+		assert.IsType(t, &postgrestore.ApprovalRepo{}, repos.Approval)
+		assert.IsType(t, &postgrestore.ScheduleRepo{}, repos.Schedule)
+		assert.IsType(t, &postgrestore.PayoutRepo{}, repos.Payout)
+		assert.IsType(t, &postgrestore.StatementRepo{}, repos.Statement)
+		assert.IsType(t, &postgrestore.BalanceRepo{}, repos.Balance)
+		assert.Same(t, repos.Transaction, repos.TransactionReader)
+		assert.Same(t, repos.Schedule, repos.ScheduleReader)
+		assert.Same(t, repos.Payout, repos.PayoutReader)
+		assert.Same(t, repos.Balance, repos.BalanceReader)
+		assert.IsType(t, &postgrestore.Transactor{}, repos.Transactor)
+		assert.IsType(t, &postgrestore.SeedRepo{}, repos.Seeder)
+		assert.NoError(t, repos.Health.Ping(context.Background()))
+		wallet, err := repos.Transaction.GetWalletByID(context.Background(), "w001")
+		assert.NoError(t, err)
+		assert.Nil(t, wallet)
+	})
+
+	t.Run("good case: statements are logged without their values", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{StorageDriver: DriverSQLite}
+		cfg.SQLite.Path = filepath.Join(t.TempDir(), "wallet.db")
+		cfg.DB.LogLevel = "info"
+		core, logs := observer.New(zapcore.DebugLevel)
+		repos, err := New(cfg, WithLogger(zap.New(core).Sugar()))
+		require.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, repos.Close(context.Background())) })
+
+		// Act
+		_, err = repos.Transaction.GetWalletByID(context.Background(), "w_secret")
+
+		// Assert
+		assert.NoError(t, err)
+		entries := logs.FilterMessage("sql").All()
+		require.NotEmpty(t, entries)
+		sql := entries[len(entries)-1].ContextMap()["sql"]
+		assert.Contains(t, sql, "SELECT")
+		assert.NotContains(t, sql, "w_secret")
+	})
+
+	t.Run("close releases the database", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{StorageDriver: DriverSQLite}
+		cfg.SQLite.Path = filepath.Join(t.TempDir(), "wallet.db")
+		repos, err := New(cfg)
+		require.NoError(t, err)
+
+		// Act
+		err = repos.Close(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Error(t, repos.Health.Ping(context.Background()))
+	})
+}
+
+func TestPostgresRepositories(t *testing.T) {
+	// Arrange
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	// Act
+	repos := postgresRepositories(db)
+
+	// Assert
+	assert.IsType(t, &postgrestore.TransactionRepo{}, repos.Transaction)
+	assert.IsType(t, &postgrestore.ApprovalRepo{}, repos.Approval)
+	assert.IsType(t, &postgrestore.ScheduleRepo{}, repos.Schedule)
+	assert.IsType(t, &postgrestore.PayoutRepo{}, repos.Payout)
+	assert.IsType(t, &postgrestore.StatementRepo{}, repos.Statement)
+	assert.IsType(t, &postgrestore.BalanceRepo{}, repos.Balance)
+	assert.IsType(t, &postgrestore.BalanceRepo{}, repos.BalanceReader)
+	assert.NotSame(t, repos.Balance, repos.BalanceReader)
+	assert.IsType(t, &postgrestore.Transactor{}, repos.Transactor)
+	assert.IsType(t, &postgrestore.SeedRepo{}, repos.Seeder)
+	assert.NotNil(t, repos.Health)
+}
+
+func TestMongoRepositories(t *testing.T) {
+	// Act
+	repos := mongoRepositories(&mongodriver.Database{})
+
+	// Assert
+	assert.IsType(t, &mongo.TransactionRepo{}, repos.Transaction)
+	assert.IsType(t, &mongo.ApprovalRepo{}, repos.Approval)
+	assert.IsType(t, &mongo.ScheduleRepo{}, repos.Schedule)
+	assert.IsType(t, &mongo.PayoutRepo{}, repos.Payout)
+	assert.IsType(t, &mongo.StatementRepo{}, repos.Statement)
+	assert.IsType(t, &mongo.BalanceRepo{}, repos.Balance)
+	assert.Same(t, repos.Balance, repos.BalanceReader)
+	assert.IsType(t, &mongo.Transactor{}, repos.Transactor)
+	assert.IsType(t, &mongo.SeedRepo{}, repos.Seeder)
+	assert.NotNil(t, repos.Health)
+}
+
+func TestNew_Postgres(t *testing.T) {
+	// Arrange
+	dbName, dbUser, dbPass := "test1", "test1", "123456"
+	cont := testutil.SetupPostgresContainer(t, dbName, dbUser, dbPass)
+	host, _ := cont.Host(context.Background())
+	port, _ := cont.MappedPort(context.Background(), "5432")
+	cfg := &config.Config{StorageDriver: DriverPostgres}
+	cfg.DB.Name, cfg.DB.User, cfg.DB.Pass, cfg.DB.Host = dbName, dbUser, dbPass, host
+	cfg.DB.Port, _ = strconv.Atoi(port.Port())
+
+	// Act
+	repos, err := New(cfg)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.IsType(t, &postgrestore.TransactionRepo{}, repos.Transaction)
+	assert.NoError(t, repos.Health.Ping(context.Background()))
+}
+
+func TestNew_Mongo(t *testing.T) {
+	// Arrange
+	uri, err := testutil.SetupMongoContainer(t).ConnectionString(context.Background())
+	require.NoError(t, err)
+	cfg := &config.Config{StorageDriver: DriverMongo}
+	cfg.MongoDB.URI, cfg.MongoDB.DBName = uri, "wallet"
+
+	// Act
+	repos, err := New(cfg)
+
+	// Assert
+	require.NoError(t, err)
+	assert.IsType(t, &mongo.TransactionRepo{}, repos.Transaction)
+	assert.NoError(t, repos.Health.Ping(context.Background()))
+	assert.NoError(t, repos.Close(context.Background()))
+	assert.Error(t, repos.Health.Ping(context.Background()))
+}
+
+func assertMemoryRepositories(t *testing.T, repos *Repositories) {
+	t.Helper()
+
+	assert.IsType(t, &memstore.TransactionRepo{}, repos.Transaction)
+	assert.IsType(t, &memstore.ApprovalRepo{}, repos.Approval)
+	assert.IsType(t, &memstore.ScheduleRepo{}, repos.Schedule)
+	assert.IsType(t, &memstore.PayoutRepo{}, repos.Payout)
+	assert.IsType(t, &memstore.StatementRepo{}, repos.Statement)
+	assert.IsType(t, &memstore.BalanceRepo{}, repos.Balance)
+	assert.IsType(t, &memstore.DB{}, repos.Transactor)
+	assert.Same(t, repos.Balance, repos.BalanceReader)
+	assert.NoError(t, repos.Health.Ping(context.Background()))
+}
@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"context"
+
+	"go-clean-template/internal/usecase"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PaymentServiceProvider starts a client span around every PSP call
+type PaymentServiceProvider struct {
+	next   usecase.IPaymentServiceProvider
+	tracer trace.Tracer
+}
+
+func NewPaymentServiceProvider(next usecase.IPaymentServiceProvider, provider trace.TracerProvider) *PaymentServiceProvider {
+	return &PaymentServiceProvider{next: next, tracer: newTracer(provider)}
+}
+
+func (p *PaymentServiceProvider) Deposit(ctx context.Context, amount float64, currency string, note string) (err error) {
+	ctx, span := p.start(ctx, "deposit", currency)
+	defer func() { end(span, err) }()
+
+	return p.next.Deposit(ctx, amount, currency, note)
+}
+
+func (p *PaymentServiceProvider) Withdraw(ctx context.Context, amount float64, currency string, note string) (err error) {
+	ctx, span := p.start(ctx, "withdraw", currency)
+	defer func() { end(span, err) }()
+
+	return p.next.Withdraw(ctx, amount, currency, note)
+}
+
+func (p *PaymentServiceProvider) start(ctx context.Context, operation string, currency string) (context.Context, trace.Span) {
+	return p.tracer.Start(ctx, "PSP."+operation, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrPSPOperation.String(operation), attrCurrency.String(currency)))
+}
@@ -0,0 +1,32 @@
+// Package tracing decorates the use cases and their dependencies with OpenTelemetry spans, the storage
+// drivers trace their own queries
+package tracing
+
+import (
+	"go-clean-template/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	attrWalletID      = attribute.Key("wallet.id")
+	attrTransactionID = attribute.Key("transaction.id")
+	attrCurrency      = attribute.Key("transaction.currency")
+	attrVersion       = attribute.Key("transaction.version")
+	attrPSPOperation  = attribute.Key("psp.operation")
+)
+
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	return provider.Tracer(tracing.TracerName)
+}
+
+// end records err on the span and ends it
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
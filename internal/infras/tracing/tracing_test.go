@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+func TestTransactionUseCase(t *testing.T) {
+	t.Run("the PSP call is a child of the use case span", func(t *testing.T) {
+		// Arrange
+		provider, exporter := newTestProvider()
+		pspMock := mocks.NewIPaymentServiceProvider(t)
+		pspMock.EXPECT().Deposit(mock.Anything, 100.0, "VND", "note").Return(nil).Once()
+		psp := NewPaymentServiceProvider(pspMock, provider)
+		ucMock := mocks.NewITransactionUseCase(t)
+		ucMock.EXPECT().Deposit(mock.Anything, "w1", "a1", 100.0, "VND", "note").RunAndReturn(
+			func(ctx context.Context, _ string, _ string, amount float64, currency string, note string) error {
+				return psp.Deposit(ctx, amount, currency, note)
+			}).Once()
+		uc := NewTransactionUseCase(ucMock, provider)
+
+		// Act
+		err := uc.Deposit(context.Background(), "w1", "a1", 100, "VND", "note")
+
+		// Assert
+		require.NoError(t, err)
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		pspSpan, ucSpan := spans[0], spans[1]
+		assert.Equal(t, "PSP.deposit", pspSpan.Name)
+		assert.Equal(t, trace.SpanKindClient, pspSpan.SpanKind)
+		assert.Equal(t, ucSpan.SpanContext.SpanID(), pspSpan.Parent.SpanID())
+		assert.Equal(t, "TransactionUseCase.Deposit", ucSpan.Name)
+		assert.Contains(t, ucSpan.Attributes, attribute.String("wallet.id", "w1"))
+		assert.Equal(t, codes.Unset, ucSpan.Status.Code)
+	})
+
+	t.Run("an error is recorded on the span", func(t *testing.T) {
+		// Arrange
+		provider, exporter := newTestProvider()
+		ucMock := mocks.NewITransactionUseCase(t)
+		ucMock.EXPECT().PayTransaction(mock.Anything, "t1", int64(2)).
+			Return(entity.NewVersionConflictError("transaction", "t1", 2)).Once()
+		uc := NewTransactionUseCase(ucMock, provider)
+
+		// Act
+		err := uc.PayTransaction(context.Background(), "t1", 2)
+
+		// Assert
+		assert.ErrorIs(t, err, entity.ErrVersionConflict)
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Contains(t, spans[0].Attributes, attribute.Int64("transaction.version", 2))
+		require.Len(t, spans[0].Events, 1, "the error event")
+	})
+
+	t.Run("the created transaction id is recorded", func(t *testing.T) {
+		// Arrange
+		provider, exporter := newTestProvider()
+		ucMock := mocks.NewITransactionUseCase(t)
+		ucMock.EXPECT().Withdraw(mock.Anything, "u1", "w1", "a1", 50.0, "VND", "note").
+			Return(&entity.Transaction{ID: "t1"}, nil).Once()
+		uc := NewTransactionUseCase(ucMock, provider)
+
+		// Act
+		trans, err := uc.Withdraw(context.Background(), "u1", "w1", "a1", 50, "VND", "note")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "t1", trans.ID)
+		assert.Contains(t, exporter.GetSpans()[0].Attributes, attribute.String("transaction.id", "t1"))
+	})
+}
+
+func TestPaymentServiceProvider(t *testing.T) {
+	// Arrange
+	provider, exporter := newTestProvider()
+	pspMock := mocks.NewIPaymentServiceProvider(t)
+	pspMock.EXPECT().Withdraw(mock.Anything, 50.0, "VND", "note").Return(errors.New("timeout")).Once()
+	psp := NewPaymentServiceProvider(pspMock, provider)
+
+	// Act
+	err := psp.Withdraw(context.Background(), 50, "VND", "note")
+
+	// Assert
+	assert.EqualError(t, err, "timeout")
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "PSP.withdraw", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "timeout", spans[0].Status.Description)
+}
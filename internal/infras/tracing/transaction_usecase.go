@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/internal/usecase"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TransactionUseCase starts a span around every use case method. The amounts, notes and approvers are left
+// out of the spans, the ids are enough to find the records.
+type TransactionUseCase struct {
+	next   usecase.ITransactionUseCase
+	tracer trace.Tracer
+}
+
+func NewTransactionUseCase(next usecase.ITransactionUseCase, provider trace.TracerProvider) *TransactionUseCase {
+	return &TransactionUseCase{next: next, tracer: newTracer(provider)}
+}
+
+func (u *TransactionUseCase) Deposit(ctx context.Context, walletID string, accountID string, amount float64,
+	currency string, note string) (err error) {
+	ctx, span := u.tracer.Start(ctx, "TransactionUseCase.Deposit",
+		trace.WithAttributes(attrWalletID.String(walletID), attrCurrency.String(currency)))
+	defer func() { end(span, err) }()
+
+	return u.next.Deposit(ctx, walletID, accountID, amount, currency, note)
+}
+
+func (u *TransactionUseCase) Withdraw(ctx context.Context, requestedBy string, walletID string, accountID string,
+	amount float64, currency string, note string) (trans *entity.Transaction, err error) {
+	ctx, span := u.tracer.Start(ctx, "TransactionUseCase.Withdraw",
+		trace.WithAttributes(attrWalletID.String(walletID), attrCurrency.String(currency)))
+	defer func() {
+		if trans != nil {
+			span.SetAttributes(attrTransactionID.String(trans.ID))
+		}
+		end(span, err)
+	}()
+
+	return u.next.Withdraw(ctx, requestedBy, walletID, accountID, amount, currency, note)
+}
+
+func (u *TransactionUseCase) GetTransaction(ctx context.Context, transID string) (trans *entity.Transaction, err error) {
+	ctx, span := u.tracer.Start(ctx, "TransactionUseCase.GetTransaction",
+		trace.WithAttributes(attrTransactionID.String(transID)))
+	defer func() { end(span, err) }()
+
+	return u.next.GetTransaction(ctx, transID)
+}
+
+func (u *TransactionUseCase) PayTransaction(ctx context.Context, transID string, version int64) (err error) {
+	ctx, span := u.tracer.Start(ctx, "TransactionUseCase.PayTransaction",
+		trace.WithAttributes(attrTransactionID.String(transID), attrVersion.Int64(version)))
+	defer func() { end(span, err) }()
+
+	return u.next.PayTransaction(ctx, transID, version)
+}
+
+func (u *TransactionUseCase) ApproveTransaction(ctx context.Context, transID string, approverID string,
+	version int64) (err error) {
+	ctx, span := u.tracer.Start(ctx, "TransactionUseCase.ApproveTransaction",
+		trace.WithAttributes(attrTransactionID.String(transID), attrVersion.Int64(version)))
+	defer func() { end(span, err) }()
+
+	return u.next.ApproveTransaction(ctx, transID, approverID, version)
+}
+
+func (u *TransactionUseCase) RejectTransaction(ctx context.Context, transID string, approverID string, reason string,
+	version int64) (err error) {
+	ctx, span := u.tracer.Start(ctx, "TransactionUseCase.RejectTransaction",
+		trace.WithAttributes(attrTransactionID.String(transID), attrVersion.Int64(version)))
+	defer func() { end(span, err) }()
+
+	return u.next.RejectTransaction(ctx, transID, approverID, reason, version)
+}
+
+func (u *TransactionUseCase) ExpireApprovals(ctx context.Context, now time.Time) (expired int, err error) {
+	ctx, span := u.tracer.Start(ctx, "TransactionUseCase.ExpireApprovals")
+	defer func() { end(span, err) }()
+
+	return u.next.ExpireApprovals(ctx, now)
+}
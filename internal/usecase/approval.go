@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/pkg/apperror"
+)
+
+// expiredApprovalsBatchSize bounds the approvals expired per sweep
+const expiredApprovalsBatchSize = 100
+
+// ApprovalPolicy decides which withdrawals need a second person to approve them.
+// A zero Threshold disables the maker-checker workflow.
+type ApprovalPolicy struct {
+	Threshold float64
+	TTL       time.Duration
+}
+
+func (uc *TransactionUseCase) SetApproval(repo IApprovalRepository, policy ApprovalPolicy) {
+	uc.approvalRepo = repo
+	uc.approvalPolicy = policy
+}
+
+func (uc *TransactionUseCase) requiresApproval(amount float64) bool {
+	return uc.approvalRepo != nil && uc.approvalPolicy.Threshold > 0 && amount > uc.approvalPolicy.Threshold
+}
+
+func (uc *TransactionUseCase) requestApproval(ctx context.Context, trans *entity.Transaction,
+	requestedBy string) (*entity.Approval, error) {
+	approval := entity.NewApproval(uc.newID(), trans.ID, requestedBy, time.Now().Add(uc.approvalPolicy.TTL))
+	if err := uc.approvalRepo.SaveApproval(ctx, approval); err != nil {
+		return nil, apperror.ErrCreate(err, "failed to create approval request")
+	}
+	return approval, nil
+}
+
+// notifyApprovalRequest is sent once the approval request is committed, never for a rolled back one
+func (uc *TransactionUseCase) notifyApprovalRequest(ctx context.Context, trans *entity.Transaction, approval *entity.Approval) {
+	uc.notify(ctx, fmt.Sprintf("Withdrawal %s of %.2f %s is awaiting approval until %s",
+		trans.ID, trans.Amount, trans.Currency, approval.ExpiresAt.Format(time.RFC3339)))
+}
+
+func (uc *TransactionUseCase) ApproveTransaction(ctx context.Context, transID string, approverID string, version int64) error {
+	trans, approval, err := uc.getPendingApproval(ctx, transID, version)
+	if err != nil {
+		return err
+	}
+
+	if err := approval.Approve(approverID, time.Now()); err != nil {
+		return decisionError(err)
+	}
+	if err := trans.ToApproved(); err != nil {
+		return apperror.ErrInvalidParams(err)
+	}
+	return uc.decide(ctx, trans, approval)
+}
+
+func (uc *TransactionUseCase) RejectTransaction(ctx context.Context, transID string, approverID string, reason string,
+	version int64) error {
+	trans, approval, err := uc.getPendingApproval(ctx, transID, version)
+	if err != nil {
+		return err
+	}
+
+	if err := approval.Reject(approverID, reason, time.Now()); err != nil {
+		return decisionError(err)
+	}
+	if err := trans.ToRejected(); err != nil {
+		return apperror.ErrInvalidParams(err)
+	}
+	return uc.decide(ctx, trans, approval)
+}
+
+// decide stores a decision and the status of its transaction together, or neither of them
+func (uc *TransactionUseCase) decide(ctx context.Context, trans *entity.Transaction, approval *entity.Approval) error {
+	return uc.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.approvalRepo.UpdateApproval(ctx, approval); err != nil {
+			return updateApprovalError(err)
+		}
+		if err := uc.repo.UpdateTransactionStatus(ctx, trans.ID, trans.Status, trans.Version); err != nil {
+			return updateStatusError(err)
+		}
+		return nil
+	})
+}
+
+// getPendingApproval loads a transaction awaiting approval and its approval request.
+// An approval found past its deadline is expired and its transaction rejected.
+func (uc *TransactionUseCase) getPendingApproval(ctx context.Context, transID string, version int64) (*entity.Transaction, *entity.Approval, error) {
+	if uc.approvalRepo == nil {
+		return nil, nil, apperror.New(apperror.APPROVAL_DISABLED)
+	}
+
+	trans, err := uc.repo.GetTransactionByID(ctx, transID)
+	if err != nil {
+		return nil, nil, apperror.ErrGet(err, "failed to get transaction by id")
+	}
+	if trans == nil {
+		return nil, nil, apperror.New(apperror.TXN_NOT_FOUND)
+	}
+	if trans.Status != entity.TransactionStatusAwaitingApproval {
+		return nil, nil, apperror.New(apperror.TXN_INVALID_STATE)
+	}
+	if err := checkVersion(trans, version); err != nil {
+		return nil, nil, err
+	}
+
+	approval, err := uc.approvalRepo.GetApprovalByTransactionID(ctx, transID)
+	if err != nil {
+		return nil, nil, apperror.ErrGet(err, "failed to get approval by transaction id")
+	}
+	if approval == nil {
+		return nil, nil, apperror.New(apperror.APPROVAL_NOT_FOUND)
+	}
+
+	now := time.Now()
+	if approval.IsExpired(now) {
+		if err := uc.expire(ctx, trans, approval, now); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, apperror.New(apperror.APPROVAL_EXPIRED)
+	}
+
+	return trans, approval, nil
+}
+
+// ExpireApprovals expires the approvals past their deadline and rejects their withdrawals, which releases the
+// amounts they reserved. It returns how many were expired; the ones decided meanwhile are left alone.
+func (uc *TransactionUseCase) ExpireApprovals(ctx context.Context, now time.Time) (int, error) {
+	if uc.approvalRepo == nil {
+		return 0, nil
+	}
+
+	approvals, err := uc.approvalRepo.ListExpiredApprovals(ctx, now, expiredApprovalsBatchSize)
+	if err != nil {
+		return 0, apperror.ErrGet(err, "failed to list expired approvals")
+	}
+
+	var (
+		expired int
+		errs    []error
+	)
+	for _, approval := range approvals {
+		trans, err := uc.repo.GetTransactionByID(ctx, approval.TransactionID)
+		if err != nil {
+			errs = append(errs, apperror.ErrGet(err, "failed to get transaction by id"))
+			continue
+		}
+		if trans == nil || trans.Status != entity.TransactionStatusAwaitingApproval {
+			continue
+		}
+
+		err = uc.expire(ctx, trans, approval, now)
+		if errors.Is(err, entity.ErrApprovalDecided) || errors.Is(err, entity.ErrVersionConflict) {
+			// decided or expired by someone else since it was listed
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		expired++
+	}
+	return expired, errors.Join(errs...)
+}
+
+// expire expires an approval and rejects its transaction together
+func (uc *TransactionUseCase) expire(ctx context.Context, trans *entity.Transaction, approval *entity.Approval,
+	now time.Time) error {
+	if err := approval.Expire(now); err != nil {
+		return apperror.ErrOtherInternalServerError(err, "failed to expire approval")
+	}
+	if err := trans.ToRejected(); err != nil {
+		return apperror.ErrInvalidParams(err)
+	}
+	return uc.decide(ctx, trans, approval)
+}
+
+func decisionError(err error) error {
+	if errors.Is(err, entity.ErrSelfApproval) {
+		return apperror.New(apperror.SELF_APPROVAL)
+	}
+	return apperror.ErrNoPermission().WithInfo(err.Error())
+}
+
+func updateApprovalError(err error) error {
+	if errors.Is(err, entity.ErrApprovalDecided) {
+		return apperror.ErrConflict(err, "approval was decided concurrently, get the transaction again")
+	}
+	return apperror.ErrUpdate(err, "failed to update approval")
+}
+
+func (uc *TransactionUseCase) notify(ctx context.Context, message string) {
+	for _, n := range uc.notifiers {
+		n.SendNotification(ctx, message)
+	}
+}
@@ -0,0 +1,514 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	mocks2 "go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransactionUseCase_SetApproval(t *testing.T) {
+	approvalRepo := mocks2.NewIApprovalRepository(t)
+	policy := ApprovalPolicy{Threshold: 50000000, TTL: time.Hour}
+	uc := &TransactionUseCase{}
+
+	uc.SetApproval(approvalRepo, policy)
+
+	assert.Equal(t, &TransactionUseCase{approvalRepo: approvalRepo, approvalPolicy: policy}, uc)
+}
+
+func TestTransactionUseCase_Withdraw_RequiresApproval(t *testing.T) {
+	transRepo := mocks2.NewITransactionRepository(t)
+	approvalRepo := mocks2.NewIApprovalRepository(t)
+	notifier := mocks2.NewINotifier(t)
+	uc := TransactionUseCase{
+		repo:           transRepo,
+		notifiers:      []INotifier{notifier},
+		approvalRepo:   approvalRepo,
+		approvalPolicy: ApprovalPolicy{Threshold: 50000000, TTL: time.Hour},
+	}
+
+	t.Run("success: amount above threshold awaits approval", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		walletID := "w_00001"
+		accountID := "a_00001"
+		userID := "u_00001"
+		requesterID := "u_00009"
+		amount := 60000000.0
+		currency := "VND"
+		note := "Withdraw 60,000,000 VND"
+
+		accountMock := &entity.LinkedAccount{ID: accountID, UserID: userID, AccountName: "momo"}
+		walletMock := &entity.Wallet{ID: walletID, UserID: userID, WalletName: "quangpn's wallet"}
+		newTransMock := &entity.Transaction{
+			WalletID:        walletID,
+			AccountID:       accountID,
+			Amount:          amount,
+			Currency:        currency,
+			TransactionKind: entity.TransactionOut,
+			Note:            note,
+			Status:          entity.TransactionStatusAwaitingApproval,
+		}
+
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(walletMock, nil).Once()
+		transRepo.EXPECT().GetBalanceByWalletID(ctx, walletID).Return(100000000.0, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, walletID).Return(0.0, nil).Once()
+		transRepo.EXPECT().SaveTransaction(ctx, IsMatchByTransaction(newTransMock)).Return(nil).Once()
+		approvalRepo.EXPECT().SaveApproval(ctx, mock.MatchedBy(func(a *entity.Approval) bool {
+			return a.RequestedBy == requesterID && a.Status == entity.ApprovalStatusPending && a.ExpiresAt.After(time.Now())
+		})).Return(nil).Once()
+		notifier.EXPECT().SendNotification(ctx, mock.AnythingOfType("string")).Return().Once()
+
+		//Act
+		got, err := uc.Withdraw(ctx, requesterID, walletID, accountID, amount, currency, note)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TransactionStatusAwaitingApproval, got.Status)
+	})
+
+	t.Run("success: amount below threshold is ready to pay", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		walletID := "w_00001"
+		accountID := "a_00001"
+		amount := 1000000.0
+
+		accountMock := &entity.LinkedAccount{ID: accountID, UserID: "u_00001", AccountName: "momo"}
+		walletMock := &entity.Wallet{ID: walletID, UserID: "u_00001", WalletName: "quangpn's wallet"}
+		newTransMock := &entity.Transaction{
+			WalletID:        walletID,
+			AccountID:       accountID,
+			Amount:          amount,
+			Currency:        "VND",
+			TransactionKind: entity.TransactionOut,
+			Status:          entity.TransactionStatusNew,
+		}
+
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(walletMock, nil).Once()
+		transRepo.EXPECT().GetBalanceByWalletID(ctx, walletID).Return(100000000.0, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, walletID).Return(0.0, nil).Once()
+		transRepo.EXPECT().SaveTransaction(ctx, IsMatchByTransaction(newTransMock)).Return(nil).Once()
+
+		//Act
+		_, err := uc.Withdraw(ctx, "u_00009", walletID, accountID, amount, "VND", "")
+
+		//Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("success: the owner of the wallet requests the approval without caller", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		walletID := "w_00001"
+		accountID := "a_00001"
+
+		accountMock := &entity.LinkedAccount{ID: accountID, UserID: "u_00001", AccountName: "momo"}
+		walletMock := &entity.Wallet{ID: walletID, UserID: "u_00001", WalletName: "quangpn's wallet"}
+
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(walletMock, nil).Once()
+		transRepo.EXPECT().GetBalanceByWalletID(ctx, walletID).Return(100000000.0, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, walletID).Return(0.0, nil).Once()
+		transRepo.EXPECT().SaveTransaction(ctx, mock.Anything).Return(nil).Once()
+		approvalRepo.EXPECT().SaveApproval(ctx, mock.MatchedBy(func(a *entity.Approval) bool {
+			return a.RequestedBy == "u_00001"
+		})).Return(nil).Once()
+		notifier.EXPECT().SendNotification(ctx, mock.AnythingOfType("string")).Return().Once()
+
+		//Act
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, 60000000.0, "VND", "")
+
+		//Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed to create approval request rolls back the transaction", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		walletID := "w_00001"
+		accountID := "a_00001"
+		errDB := fmt.Errorf("unexpected error")
+		transactor := mocks2.NewITransactor(t)
+		uc := uc
+		uc.SetTransactor(transactor)
+
+		accountMock := &entity.LinkedAccount{ID: accountID, UserID: "u_00001", AccountName: "momo"}
+		walletMock := &entity.Wallet{ID: walletID, UserID: "u_00001", WalletName: "quangpn's wallet"}
+
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(walletMock, nil).Once()
+		transRepo.EXPECT().GetBalanceByWalletID(ctx, walletID).Return(100000000.0, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, walletID).Return(0.0, nil).Once()
+		transactor.EXPECT().WithinTransaction(ctx, mock.Anything).
+			RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).Once()
+		transRepo.EXPECT().SaveTransaction(ctx, mock.Anything).Return(nil).Once()
+		approvalRepo.EXPECT().SaveApproval(ctx, mock.Anything).Return(errDB).Once()
+
+		//Act
+		_, err := uc.Withdraw(ctx, "u_00009", walletID, accountID, 60000000.0, "VND", "")
+
+		//Assert
+		assert.Equal(t, apperror.ErrCreate(errDB, "failed to create approval request"), err)
+	})
+}
+
+func TestTransactionUseCase_ApproveTransaction(t *testing.T) {
+	transRepo := mocks2.NewITransactionRepository(t)
+	approvalRepo := mocks2.NewIApprovalRepository(t)
+	uc := TransactionUseCase{
+		repo:           transRepo,
+		approvalRepo:   approvalRepo,
+		approvalPolicy: ApprovalPolicy{Threshold: 50000000, TTL: time.Hour},
+	}
+	newTrans := func(status entity.TransactionStatus) *entity.Transaction {
+		return entity.NewTransaction("t_00001", "w_00001", "a_00001", 60000000.0, "VND",
+			entity.TransactionOut, "", status)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := newTrans(entity.TransactionStatusAwaitingApproval)
+		approval := entity.NewApproval("ap_00001", trans.ID, "u_00001", time.Now().Add(time.Hour))
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		approvalRepo.EXPECT().GetApprovalByTransactionID(ctx, trans.ID).Return(approval, nil).Once()
+		approvalRepo.EXPECT().UpdateApproval(ctx, mock.MatchedBy(func(a *entity.Approval) bool {
+			return a.Status == entity.ApprovalStatusApproved && a.DecidedBy == "u_00002"
+		})).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusApproved, trans.Version).Return(nil).Once()
+
+		//Act
+		err := uc.ApproveTransaction(ctx, trans.ID, "u_00002", 0)
+
+		//Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("approver is the requester", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := newTrans(entity.TransactionStatusAwaitingApproval)
+		approval := entity.NewApproval("ap_00001", trans.ID, "u_00001", time.Now().Add(time.Hour))
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		approvalRepo.EXPECT().GetApprovalByTransactionID(ctx, trans.ID).Return(approval, nil).Once()
+
+		//Act
+		err := uc.ApproveTransaction(ctx, trans.ID, "u_00001", 0)
+
+		//Assert
+		expectedErr := apperror.New(apperror.SELF_APPROVAL)
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("approval decided concurrently", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := newTrans(entity.TransactionStatusAwaitingApproval)
+		approval := entity.NewApproval("ap_00001", trans.ID, "u_00001", time.Now().Add(time.Hour))
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		approvalRepo.EXPECT().GetApprovalByTransactionID(ctx, trans.ID).Return(approval, nil).Once()
+		approvalRepo.EXPECT().UpdateApproval(ctx, mock.Anything).Return(entity.ErrApprovalDecided).Once()
+
+		//Act
+		err := uc.ApproveTransaction(ctx, trans.ID, "u_00002", 0)
+
+		//Assert
+		expectedErr := apperror.ErrConflict(entity.ErrApprovalDecided, "approval was decided concurrently, get the transaction again")
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("transaction modified concurrently rolls back the decision", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := newTrans(entity.TransactionStatusAwaitingApproval)
+		approval := entity.NewApproval("ap_00001", trans.ID, "u_00001", time.Now().Add(time.Hour))
+		errConflict := entity.NewVersionConflictError("transaction", trans.ID, trans.Version)
+		transactor := mocks2.NewITransactor(t)
+		uc := uc
+		uc.SetTransactor(transactor)
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		approvalRepo.EXPECT().GetApprovalByTransactionID(ctx, trans.ID).Return(approval, nil).Once()
+		transactor.EXPECT().WithinTransaction(ctx, mock.Anything).
+			RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).Once()
+		approvalRepo.EXPECT().UpdateApproval(ctx, mock.Anything).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusApproved, trans.Version).
+			Return(errConflict).Once()
+
+		//Act
+		err := uc.ApproveTransaction(ctx, trans.ID, "u_00002", 0)
+
+		//Assert
+		assert.Equal(t, apperror.New(apperror.VERSION_CONFLICT).WithRaw(errConflict), err)
+	})
+
+	t.Run("transaction is not awaiting approval", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := newTrans(entity.TransactionStatusNew)
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+
+		//Act
+		err := uc.ApproveTransaction(ctx, trans.ID, "u_00002", 0)
+
+		//Assert
+		expectedErr := apperror.New(apperror.TXN_INVALID_STATE)
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("approval has expired", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := newTrans(entity.TransactionStatusAwaitingApproval)
+		approval := entity.NewApproval("ap_00001", trans.ID, "u_00001", time.Now().Add(-time.Minute))
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		approvalRepo.EXPECT().GetApprovalByTransactionID(ctx, trans.ID).Return(approval, nil).Once()
+		approvalRepo.EXPECT().UpdateApproval(ctx, mock.MatchedBy(func(a *entity.Approval) bool {
+			return a.Status == entity.ApprovalStatusExpired
+		})).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusRejected, trans.Version).Return(nil).Once()
+
+		//Act
+		err := uc.ApproveTransaction(ctx, trans.ID, "u_00002", 0)
+
+		//Assert
+		expectedErr := apperror.New(apperror.APPROVAL_EXPIRED)
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("failed to get approval by transaction id", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := newTrans(entity.TransactionStatusAwaitingApproval)
+		errDB := fmt.Errorf("unexpected error")
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		approvalRepo.EXPECT().GetApprovalByTransactionID(ctx, trans.ID).Return(nil, errDB).Once()
+
+		//Act
+		err := uc.ApproveTransaction(ctx, trans.ID, "u_00002", 0)
+
+		//Assert
+		assert.Equal(t, apperror.ErrGet(errDB, "failed to get approval by transaction id"), err)
+	})
+}
+
+func TestTransactionUseCase_RejectTransaction(t *testing.T) {
+	transRepo := mocks2.NewITransactionRepository(t)
+	approvalRepo := mocks2.NewIApprovalRepository(t)
+	uc := TransactionUseCase{
+		repo:           transRepo,
+		approvalRepo:   approvalRepo,
+		approvalPolicy: ApprovalPolicy{Threshold: 50000000, TTL: time.Hour},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := entity.NewTransaction("t_00001", "w_00001", "a_00001", 60000000.0, "VND",
+			entity.TransactionOut, "", entity.TransactionStatusAwaitingApproval)
+		approval := entity.NewApproval("ap_00001", trans.ID, "u_00001", time.Now().Add(time.Hour))
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		approvalRepo.EXPECT().GetApprovalByTransactionID(ctx, trans.ID).Return(approval, nil).Once()
+		approvalRepo.EXPECT().UpdateApproval(ctx, mock.MatchedBy(func(a *entity.Approval) bool {
+			return a.Status == entity.ApprovalStatusRejected && a.Reason == "suspicious"
+		})).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusRejected, trans.Version).Return(nil).Once()
+
+		//Act
+		err := uc.RejectTransaction(ctx, trans.ID, "u_00002", "suspicious", 0)
+
+		//Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("approval workflow is disabled", func(t *testing.T) {
+		//Arrange
+		uc := TransactionUseCase{repo: transRepo}
+
+		//Act
+		err := uc.RejectTransaction(context.Background(), "t_00001", "u_00002", "suspicious", 0)
+
+		//Assert
+		expectedErr := apperror.New(apperror.APPROVAL_DISABLED)
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestTransactionUseCase_ExpireApprovals(t *testing.T) {
+	transRepo := mocks2.NewITransactionRepository(t)
+	approvalRepo := mocks2.NewIApprovalRepository(t)
+	uc := TransactionUseCase{
+		repo:           transRepo,
+		approvalRepo:   approvalRepo,
+		approvalPolicy: ApprovalPolicy{Threshold: 50000000, TTL: time.Hour},
+	}
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	newTrans := func(transID string, status entity.TransactionStatus) *entity.Transaction {
+		return entity.NewTransaction(transID, "w_00001", "a_00001", 60000000.0, "VND",
+			entity.TransactionOut, "", status)
+	}
+
+	t.Run("success: expire approvals and reject their transactions", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := newTrans("t_00001", entity.TransactionStatusAwaitingApproval)
+		approval := entity.NewApproval("ap_00001", trans.ID, "u_00001", now.Add(-time.Minute))
+
+		approvalRepo.EXPECT().ListExpiredApprovals(ctx, now, expiredApprovalsBatchSize).
+			Return([]*entity.Approval{approval}, nil).Once()
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		approvalRepo.EXPECT().UpdateApproval(ctx, mock.MatchedBy(func(a *entity.Approval) bool {
+			return a.ID == approval.ID && a.Status == entity.ApprovalStatusExpired && a.DecidedAt.Equal(now)
+		})).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusRejected, trans.Version).Return(nil).Once()
+
+		//Act
+		expired, err := uc.ExpireApprovals(ctx, now)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, expired)
+	})
+
+	t.Run("approvals decided concurrently are skipped", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		decided := newTrans("t_00001", entity.TransactionStatusAwaitingApproval)
+		modified := newTrans("t_00002", entity.TransactionStatusAwaitingApproval)
+		approved := newTrans("t_00003", entity.TransactionStatusApproved)
+		approvals := []*entity.Approval{
+			entity.NewApproval("ap_00001", decided.ID, "u_00001", now.Add(-time.Minute)),
+			entity.NewApproval("ap_00002", modified.ID, "u_00001", now.Add(-time.Minute)),
+			entity.NewApproval("ap_00003", approved.ID, "u_00001", now.Add(-time.Minute)),
+		}
+		errConflict := entity.NewVersionConflictError("transaction", modified.ID, modified.Version)
+
+		approvalRepo.EXPECT().ListExpiredApprovals(ctx, now, expiredApprovalsBatchSize).Return(approvals, nil).Once()
+		transRepo.EXPECT().GetTransactionByID(ctx, decided.ID).Return(decided, nil).Once()
+		approvalRepo.EXPECT().UpdateApproval(ctx, approvals[0]).Return(entity.ErrApprovalDecided).Once()
+		transRepo.EXPECT().GetTransactionByID(ctx, modified.ID).Return(modified, nil).Once()
+		approvalRepo.EXPECT().UpdateApproval(ctx, approvals[1]).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, modified.ID, entity.TransactionStatusRejected, modified.Version).
+			Return(errConflict).Once()
+		transRepo.EXPECT().GetTransactionByID(ctx, approved.ID).Return(approved, nil).Once()
+
+		//Act
+		expired, err := uc.ExpireApprovals(ctx, now)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, expired)
+	})
+
+	t.Run("failed transaction does not stop the others", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := newTrans("t_00002", entity.TransactionStatusAwaitingApproval)
+		approvals := []*entity.Approval{
+			entity.NewApproval("ap_00001", "t_00001", "u_00001", now.Add(-time.Minute)),
+			entity.NewApproval("ap_00002", trans.ID, "u_00001", now.Add(-time.Minute)),
+		}
+		errDB := fmt.Errorf("unexpected error")
+
+		approvalRepo.EXPECT().ListExpiredApprovals(ctx, now, expiredApprovalsBatchSize).Return(approvals, nil).Once()
+		transRepo.EXPECT().GetTransactionByID(ctx, "t_00001").Return(nil, errDB).Once()
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		approvalRepo.EXPECT().UpdateApproval(ctx, approvals[1]).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusRejected, trans.Version).Return(nil).Once()
+
+		//Act
+		expired, err := uc.ExpireApprovals(ctx, now)
+
+		//Assert
+		assert.ErrorIs(t, err, errDB)
+		assert.Equal(t, 1, expired)
+	})
+
+	t.Run("failed to list expired approvals", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errDB := fmt.Errorf("unexpected error")
+
+		approvalRepo.EXPECT().ListExpiredApprovals(ctx, now, expiredApprovalsBatchSize).Return(nil, errDB).Once()
+
+		//Act
+		expired, err := uc.ExpireApprovals(ctx, now)
+
+		//Assert
+		assert.Equal(t, apperror.ErrGet(errDB, "failed to list expired approvals"), err)
+		assert.Equal(t, 0, expired)
+	})
+
+	t.Run("approval workflow is disabled", func(t *testing.T) {
+		//Arrange
+		uc := TransactionUseCase{repo: transRepo}
+
+		//Act
+		expired, err := uc.ExpireApprovals(context.Background(), now)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, expired)
+	})
+}
+
+func TestTransactionUseCase_PayTransaction_AwaitingApproval(t *testing.T) {
+	transRepo := mocks2.NewITransactionRepository(t)
+	paymentSvc := mocks2.NewIPaymentServiceProvider(t)
+	uc := TransactionUseCase{
+		repo:       transRepo,
+		paymentSvc: paymentSvc,
+	}
+
+	t.Run("transaction is awaiting approval", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := entity.NewTransaction("t_00001", "w_00001", "a_00001", 60000000.0, "VND",
+			entity.TransactionOut, "", entity.TransactionStatusAwaitingApproval)
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+
+		//Act
+		err := uc.PayTransaction(ctx, trans.ID, 0)
+
+		//Assert
+		expectedErr := apperror.New(apperror.TXN_AWAITING_APPROVAL)
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("success: approved transaction", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := entity.NewTransaction("t_00001", "w_00001", "a_00001", 60000000.0, "VND",
+			entity.TransactionOut, "", entity.TransactionStatusApproved)
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		paymentSvc.EXPECT().Withdraw(ctx, trans.Amount, trans.Currency, trans.Note).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusProcessing, trans.Version).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful, trans.Version+1).Return(nil).Once()
+
+		//Act
+		err := uc.PayTransaction(ctx, trans.ID, 0)
+
+		//Assert
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/pkg/apperror"
+)
+
+// snapshotWalletsBatchSize limits how many wallet ids are loaded per page while building snapshots
+const snapshotWalletsBatchSize = 500
+
+type BalanceUseCase struct {
+	repo      IBalanceRepository
+	transRepo ITransactionRepository
+}
+
+func NewBalanceUseCase(repo IBalanceRepository, transRepo ITransactionRepository) *BalanceUseCase {
+	return &BalanceUseCase{
+		repo:      repo,
+		transRepo: transRepo,
+	}
+}
+
+func (uc *BalanceUseCase) GetBalance(ctx context.Context, walletID string, asOf time.Time) (float64, error) {
+	if err := uc.checkWallet(ctx, walletID); err != nil {
+		return 0, err
+	}
+
+	balance, _, err := balanceFromSnapshot(ctx, uc.repo, walletID, asOf)
+	if err != nil {
+		return 0, apperror.ErrGet(err, "failed to get balance by wallet id")
+	}
+	return balance, nil
+}
+
+// BuildSnapshots rolls the latest snapshot of every wallet forward to the daily close of asOf.
+// It is idempotent, so a job can run it as often as it likes. A failing wallet does not stop the others;
+// their errors are joined.
+func (uc *BalanceUseCase) BuildSnapshots(ctx context.Context, asOf time.Time) (int, error) {
+	var (
+		closeAt = entity.DayClose(asOf)
+		afterID string
+		built   int
+		errs    []error
+	)
+	for {
+		walletIDs, err := uc.repo.ListWalletIDs(ctx, afterID, snapshotWalletsBatchSize)
+		if err != nil {
+			errs = append(errs, apperror.ErrGet(err, "failed to list wallet ids"))
+			break
+		}
+
+		for _, walletID := range walletIDs {
+			if err := uc.buildSnapshot(ctx, walletID, closeAt); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			built++
+		}
+
+		if len(walletIDs) < snapshotWalletsBatchSize {
+			break
+		}
+		afterID = walletIDs[len(walletIDs)-1]
+	}
+
+	return built, errors.Join(errs...)
+}
+
+func (uc *BalanceUseCase) buildSnapshot(ctx context.Context, walletID string, closeAt time.Time) error {
+	balance, _, err := balanceFromSnapshot(ctx, uc.repo, walletID, closeAt)
+	if err != nil {
+		return apperror.ErrGet(err, fmt.Sprintf("failed to get balance of wallet %s", walletID))
+	}
+
+	if err := uc.repo.SaveSnapshot(ctx, entity.NewBalanceSnapshot(walletID, closeAt, balance)); err != nil {
+		return apperror.ErrCreate(err, fmt.Sprintf("failed to save balance snapshot of wallet %s", walletID))
+	}
+	return nil
+}
+
+// CheckConsistency recomputes the balance from every transaction of the wallet. A transaction settled after
+// the snapshot of its day was built is the typical cause of an inconsistent check.
+func (uc *BalanceUseCase) CheckConsistency(ctx context.Context, walletID string, asOf time.Time) (*entity.BalanceCheck, error) {
+	if err := uc.checkWallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	balance, snapshot, err := balanceFromSnapshot(ctx, uc.repo, walletID, asOf)
+	if err != nil {
+		return nil, apperror.ErrGet(err, "failed to get balance by wallet id")
+	}
+
+	recomputed, err := uc.repo.SumTransactions(ctx, walletID, time.Time{}, asOf)
+	if err != nil {
+		return nil, apperror.ErrGet(err, "failed to recompute balance")
+	}
+
+	check := &entity.BalanceCheck{
+		WalletID:   walletID,
+		AsOf:       asOf,
+		Balance:    balance,
+		Recomputed: recomputed,
+	}
+	if snapshot != nil {
+		check.SnapshotAsOf = &snapshot.AsOf
+	}
+	return check, nil
+}
+
+func (uc *BalanceUseCase) checkWallet(ctx context.Context, walletID string) error {
+	wallet, err := uc.transRepo.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return apperror.ErrGet(err, "failed to get wallet by id")
+	}
+	if wallet == nil {
+		return apperror.New(apperror.WALLET_NOT_FOUND)
+	}
+	return nil
+}
+
+// balanceFromSnapshot adds the transactions created after the latest snapshot to its balance,
+// so only the tail since the last daily close is scanned. A zero asOf means the current balance.
+func balanceFromSnapshot(ctx context.Context, repo IBalanceRepository, walletID string,
+	asOf time.Time) (float64, *entity.BalanceSnapshot, error) {
+	snapshotAt := asOf
+	if snapshotAt.IsZero() {
+		snapshotAt = time.Now()
+	}
+
+	snapshot, err := repo.GetLatestSnapshot(ctx, walletID, snapshotAt)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var (
+		from    time.Time
+		balance float64
+	)
+	if snapshot != nil {
+		from = snapshot.AsOf
+		balance = snapshot.Balance
+	}
+
+	tail, err := repo.SumTransactions(ctx, walletID, from, asOf)
+	if err != nil {
+		return 0, nil, err
+	}
+	return balance + tail, snapshot, nil
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	mocks2 "go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewBalanceUseCase(t *testing.T) {
+	repo := mocks2.NewIBalanceRepository(t)
+	transRepo := mocks2.NewITransactionRepository(t)
+	want := &BalanceUseCase{
+		repo:      repo,
+		transRepo: transRepo,
+	}
+
+	if got := NewBalanceUseCase(repo, transRepo); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBalanceUseCase() = %v, want %v", got, want)
+	}
+}
+
+func TestBalanceUseCase_GetBalance(t *testing.T) {
+	repo := mocks2.NewIBalanceRepository(t)
+	transRepo := mocks2.NewITransactionRepository(t)
+	uc := BalanceUseCase{
+		repo:      repo,
+		transRepo: transRepo,
+	}
+	walletID := "w_00001"
+	asOf := time.Date(2024, 7, 10, 15, 0, 0, 0, time.UTC)
+	snapshotAt := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success: snapshot plus tail", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		repo.EXPECT().GetLatestSnapshot(ctx, walletID, asOf).
+			Return(entity.NewBalanceSnapshot(walletID, snapshotAt, 1000), nil).Once()
+		repo.EXPECT().SumTransactions(ctx, walletID, snapshotAt, asOf).Return(-200, nil).Once()
+
+		//Act
+		got, err := uc.GetBalance(ctx, walletID, asOf)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 800.0, got)
+	})
+
+	t.Run("success: current balance without snapshot", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		repo.EXPECT().GetLatestSnapshot(ctx, walletID, mock.Anything).Return(nil, nil).Once()
+		repo.EXPECT().SumTransactions(ctx, walletID, time.Time{}, time.Time{}).Return(1500, nil).Once()
+
+		//Act
+		got, err := uc.GetBalance(ctx, walletID, time.Time{})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1500.0, got)
+	})
+
+	t.Run("wallet not found", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(nil, nil).Once()
+
+		//Act
+		_, err := uc.GetBalance(ctx, walletID, asOf)
+
+		//Assert
+		assert.Equal(t, apperror.New(apperror.WALLET_NOT_FOUND), err)
+	})
+
+	t.Run("failed to get latest snapshot", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errDB := fmt.Errorf("unexpected error")
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		repo.EXPECT().GetLatestSnapshot(ctx, walletID, asOf).Return(nil, errDB).Once()
+
+		//Act
+		_, err := uc.GetBalance(ctx, walletID, asOf)
+
+		//Assert
+		assert.Equal(t, apperror.ErrGet(errDB, "failed to get balance by wallet id"), err)
+	})
+}
+
+func TestBalanceUseCase_BuildSnapshots(t *testing.T) {
+	repo := mocks2.NewIBalanceRepository(t)
+	uc := BalanceUseCase{repo: repo}
+	asOf := time.Date(2024, 7, 11, 0, 30, 0, 0, time.UTC)
+	closeAt := time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC)
+	previous := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success: roll snapshots forward", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo.EXPECT().ListWalletIDs(ctx, "", snapshotWalletsBatchSize).Return([]string{"w_00001", "w_00002"}, nil).Once()
+		repo.EXPECT().GetLatestSnapshot(ctx, "w_00001", closeAt).
+			Return(entity.NewBalanceSnapshot("w_00001", previous, 1000), nil).Once()
+		repo.EXPECT().SumTransactions(ctx, "w_00001", previous, closeAt).Return(500, nil).Once()
+		repo.EXPECT().SaveSnapshot(ctx, entity.NewBalanceSnapshot("w_00001", closeAt, 1500)).Return(nil).Once()
+		repo.EXPECT().GetLatestSnapshot(ctx, "w_00002", closeAt).Return(nil, nil).Once()
+		repo.EXPECT().SumTransactions(ctx, "w_00002", time.Time{}, closeAt).Return(300, nil).Once()
+		repo.EXPECT().SaveSnapshot(ctx, entity.NewBalanceSnapshot("w_00002", closeAt, 300)).Return(nil).Once()
+
+		//Act
+		got, err := uc.BuildSnapshots(ctx, asOf)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, got)
+	})
+
+	t.Run("failing wallet does not stop the others", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errDB := fmt.Errorf("unexpected error")
+		repo.EXPECT().ListWalletIDs(ctx, "", snapshotWalletsBatchSize).Return([]string{"w_00001", "w_00002"}, nil).Once()
+		repo.EXPECT().GetLatestSnapshot(ctx, "w_00001", closeAt).Return(nil, errDB).Once()
+		repo.EXPECT().GetLatestSnapshot(ctx, "w_00002", closeAt).Return(nil, nil).Once()
+		repo.EXPECT().SumTransactions(ctx, "w_00002", time.Time{}, closeAt).Return(300, nil).Once()
+		repo.EXPECT().SaveSnapshot(ctx, entity.NewBalanceSnapshot("w_00002", closeAt, 300)).Return(nil).Once()
+
+		//Act
+		got, err := uc.BuildSnapshots(ctx, asOf)
+
+		//Assert
+		assert.Equal(t, 1, got)
+		assert.Equal(t, errors.Join(apperror.ErrGet(errDB, "failed to get balance of wallet w_00001")), err)
+	})
+
+	t.Run("failed to list wallet ids", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errDB := fmt.Errorf("unexpected error")
+		repo.EXPECT().ListWalletIDs(ctx, "", snapshotWalletsBatchSize).Return(nil, errDB).Once()
+
+		//Act
+		got, err := uc.BuildSnapshots(ctx, asOf)
+
+		//Assert
+		assert.Equal(t, 0, got)
+		assert.Equal(t, errors.Join(apperror.ErrGet(errDB, "failed to list wallet ids")), err)
+	})
+}
+
+func TestBalanceUseCase_CheckConsistency(t *testing.T) {
+	repo := mocks2.NewIBalanceRepository(t)
+	transRepo := mocks2.NewITransactionRepository(t)
+	uc := BalanceUseCase{
+		repo:      repo,
+		transRepo: transRepo,
+	}
+	walletID := "w_00001"
+	asOf := time.Date(2024, 7, 10, 15, 0, 0, 0, time.UTC)
+	snapshotAt := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("snapshot drifted from the transactions", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		repo.EXPECT().GetLatestSnapshot(ctx, walletID, asOf).
+			Return(entity.NewBalanceSnapshot(walletID, snapshotAt, 1000), nil).Once()
+		repo.EXPECT().SumTransactions(ctx, walletID, snapshotAt, asOf).Return(500, nil).Once()
+		repo.EXPECT().SumTransactions(ctx, walletID, time.Time{}, asOf).Return(1200, nil).Once()
+
+		//Act
+		got, err := uc.CheckConsistency(ctx, walletID, asOf)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.BalanceCheck{
+			WalletID:     walletID,
+			AsOf:         asOf,
+			SnapshotAsOf: &snapshotAt,
+			Balance:      1500,
+			Recomputed:   1200,
+		}, got)
+		assert.False(t, got.IsConsistent())
+	})
+
+	t.Run("failed to recompute balance", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errDB := fmt.Errorf("unexpected error")
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		repo.EXPECT().GetLatestSnapshot(ctx, walletID, asOf).Return(nil, nil).Once()
+		repo.EXPECT().SumTransactions(ctx, walletID, time.Time{}, asOf).Return(1200, nil).Once()
+		repo.EXPECT().SumTransactions(ctx, walletID, time.Time{}, asOf).Return(0, errDB).Once()
+
+		//Act
+		got, err := uc.CheckConsistency(ctx, walletID, asOf)
+
+		//Assert
+		assert.Nil(t, got)
+		assert.Equal(t, apperror.ErrGet(errDB, "failed to recompute balance"), err)
+	})
+}
+
+func TestTransactionUseCase_SetBalanceSnapshots(t *testing.T) {
+	transRepo := mocks2.NewITransactionRepository(t)
+	balanceRepo := mocks2.NewIBalanceRepository(t)
+	uc := TransactionUseCase{repo: transRepo}
+	uc.SetBalanceSnapshots(balanceRepo)
+
+	t.Run("withdraw reads balance from snapshot", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		walletID := "w_00001"
+		accountID := "a_00001"
+		snapshotAt := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(&entity.LinkedAccount{ID: accountID}, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		balanceRepo.EXPECT().GetLatestSnapshot(ctx, walletID, mock.Anything).
+			Return(entity.NewBalanceSnapshot(walletID, snapshotAt, 1000), nil).Once()
+		balanceRepo.EXPECT().SumTransactions(ctx, walletID, snapshotAt, time.Time{}).Return(-500, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, walletID).Return(0.0, nil).Once()
+
+		//Act
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, 1000, "VND", "")
+
+		//Assert
+		assert.Equal(t, apperror.New(apperror.INSUFFICIENT_FUNDS), err)
+	})
+}
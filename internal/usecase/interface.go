@@ -2,14 +2,62 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"go-clean-template/internal/entity"
 )
 
 type ITransactionUseCase interface {
 	Deposit(ctx context.Context, walletID string, accountID string, amount float64, currency string, note string) error
-	Withdraw(ctx context.Context, walletID string, accountID string, amount float64, currency string, note string) error
-	PayTransaction(ctx context.Context, transID string) error
+	// Withdraw creates a withdraw transaction and returns it. Large withdrawals are created awaiting approval of
+	// someone else than requestedBy, the owner of the wallet when empty.
+	Withdraw(ctx context.Context, requestedBy string, walletID string, accountID string, amount float64, currency string,
+		note string) (*entity.Transaction, error)
+	GetTransaction(ctx context.Context, transID string) (*entity.Transaction, error)
+	// PayTransaction, ApproveTransaction and RejectTransaction fail with a conflict when the transaction is not at
+	// version anymore. A zero version skips the check, concurrent updates are still detected.
+	PayTransaction(ctx context.Context, transID string, version int64) error
+	ApproveTransaction(ctx context.Context, transID string, approverID string, version int64) error
+	RejectTransaction(ctx context.Context, transID string, approverID string, reason string, version int64) error
+	// ExpireApprovals rejects the withdrawals whose approval is past its deadline at now and returns their number
+	ExpireApprovals(ctx context.Context, now time.Time) (int, error)
+}
+
+type IScheduleUseCase interface {
+	CreateSchedule(ctx context.Context, walletID string, accountID string, amount float64, currency string,
+		transKind entity.TransactionKind, note string, frequency entity.ScheduleFrequency, startAt time.Time) (*entity.Schedule, error)
+	GetSchedule(ctx context.Context, scheduleID string) (*entity.Schedule, error)
+	ListSchedules(ctx context.Context, walletID string) ([]*entity.Schedule, error)
+	UpdateSchedule(ctx context.Context, scheduleID string, amount float64, note string,
+		frequency entity.ScheduleFrequency, startAt time.Time, version int64) (*entity.Schedule, error)
+	DeleteSchedule(ctx context.Context, scheduleID string, version int64) error
+	RunDueSchedules(ctx context.Context, now time.Time) (int, error)
+}
+
+type IPayoutUseCase interface {
+	CreatePayoutBatch(ctx context.Context, createdBy string, items []*entity.PayoutItem) (*entity.PayoutBatch, error)
+	GetPayoutBatch(ctx context.Context, batchID string) (*entity.PayoutBatch, error)
+	// ProcessPayoutBatch claims a batch then withdraws and pays its pending items. It blocks until the batch is
+	// completed or its lease is about to end, and does nothing when another processor holds the batch.
+	ProcessPayoutBatch(ctx context.Context, batchID string) error
+	// ResumePayoutBatches processes the batches left unfinished by a stopped processor and returns their number
+	ResumePayoutBatches(ctx context.Context, now time.Time) (int, error)
+}
+
+type IStatementUseCase interface {
+	// OpenStatement checks the wallet and computes the opening balance of [from, to)
+	OpenStatement(ctx context.Context, walletID string, from time.Time, to time.Time) (*entity.Statement, error)
+	// StreamStatement calls fn with every line of an opened statement and leaves the closing balance in it
+	StreamStatement(ctx context.Context, statement *entity.Statement, fn func(line *entity.StatementLine) error) error
+}
+
+type IBalanceUseCase interface {
+	// GetBalance get the balance of a wallet as of the given time. A zero asOf means the current balance.
+	GetBalance(ctx context.Context, walletID string, asOf time.Time) (float64, error)
+	// BuildSnapshots snapshots the balance of every wallet at the daily close asOf and returns how many were built
+	BuildSnapshots(ctx context.Context, asOf time.Time) (int, error)
+	// CheckConsistency compares the snapshot based balance of a wallet with a full recomputation
+	CheckConsistency(ctx context.Context, walletID string, asOf time.Time) (*entity.BalanceCheck, error)
 }
 
 type IPaymentServiceProvider interface {
@@ -17,10 +65,20 @@ type IPaymentServiceProvider interface {
 	Withdraw(ctx context.Context, amount float64, currency string, note string) error
 }
 
+// ITransactor runs fn in a transaction of the storage: the repositories called with the ctx of fn write in it,
+// and all of their writes are rolled back when fn returns an error. A call within fn joins the transaction.
+type ITransactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type ITransactionRepository interface {
 	// GetWalletByID get a wallet by id. If wallet not found, return nil - nil
 	GetWalletByID(ctx context.Context, walletID string) (*entity.Wallet, error)
 
+	// LockWallet get a wallet like GetWalletByID and lock it until the transaction of ctx ends,
+	// the transactions locking the same wallet run one after the other
+	LockWallet(ctx context.Context, walletID string) (*entity.Wallet, error)
+
 	//SaveTransaction insert a transaction
 	SaveTransaction(ctx context.Context, trans *entity.Transaction) error
 
@@ -30,11 +88,114 @@ type ITransactionRepository interface {
 	// GetBalanceByWalletID get balance by wallet id
 	GetBalanceByWalletID(ctx context.Context, walletID string) (float64, error)
 
+	// GetPendingWithdrawals sum the amounts of the withdrawals of a wallet in one of the entity.PendingStatuses
+	GetPendingWithdrawals(ctx context.Context, walletID string) (float64, error)
+
 	// GetTransactionByID get transaction by id. If Transaction not found, return nil - nil
 	GetTransactionByID(ctx context.Context, transID string) (*entity.Transaction, error)
 
-	// UpdateTransactionStatus update transaction status
-	UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus) error
+	// UpdateTransactionStatus update transaction status if the transaction is still at version and increment the version.
+	// Otherwise return an entity.VersionConflictError, or entity.ErrNotFound if the transaction does not exist
+	UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus, version int64) error
+
+	// CountTransactionsByStatus count the transactions of each status, the statuses without transaction count 0
+	CountTransactionsByStatus(ctx context.Context, statuses []entity.TransactionStatus) (map[entity.TransactionStatus]int64, error)
+}
+
+type IApprovalRepository interface {
+	// SaveApproval insert an approval request
+	SaveApproval(ctx context.Context, approval *entity.Approval) error
+
+	// GetApprovalByTransactionID get approval of a transaction. If approval not found, return nil - nil
+	GetApprovalByTransactionID(ctx context.Context, transID string) (*entity.Approval, error)
+
+	// UpdateApproval update the decision of a pending approval.
+	// Return entity.ErrApprovalDecided if the approval is no longer pending or does not exist
+	UpdateApproval(ctx context.Context, approval *entity.Approval) error
+
+	// ListExpiredApprovals list pending approvals whose deadline is at or before now, oldest deadline first
+	ListExpiredApprovals(ctx context.Context, now time.Time, limit int) ([]*entity.Approval, error)
+}
+
+type IScheduleRepository interface {
+	// SaveSchedule insert a schedule
+	SaveSchedule(ctx context.Context, schedule *entity.Schedule) error
+
+	// GetScheduleByID get a schedule by id. If schedule not found, return nil - nil
+	GetScheduleByID(ctx context.Context, scheduleID string) (*entity.Schedule, error)
+
+	// ListSchedulesByWalletID list all schedules of a wallet
+	ListSchedulesByWalletID(ctx context.Context, walletID string) ([]*entity.Schedule, error)
+
+	// ListDueSchedules list active schedules whose next run is at or before now, oldest first
+	ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*entity.Schedule, error)
+
+	// UpdateSchedule update a schedule if it is still at schedule.Version and increment the stored version.
+	// Otherwise return an entity.VersionConflictError, or entity.ErrNotFound if the schedule does not exist
+	UpdateSchedule(ctx context.Context, schedule *entity.Schedule) error
+
+	// DeleteSchedule delete a schedule and its runs if it is still at version.
+	// Otherwise return an entity.VersionConflictError, or entity.ErrNotFound if the schedule does not exist
+	DeleteSchedule(ctx context.Context, scheduleID string, version int64) error
+
+	// ClaimScheduleRun insert a run for an occurrence, claimed until run.ClaimedUntil, or claim again the run of the
+	// occurrence if it is entity.ScheduleRun.Claimable at now. If the run is finished, return false - nil.
+	// If another scheduler holds its lease, return false - entity.ErrScheduleRunClaimed
+	ClaimScheduleRun(ctx context.Context, run *entity.ScheduleRun, now time.Time) (bool, error)
+
+	// UpdateScheduleRun update the result of a run
+	UpdateScheduleRun(ctx context.Context, run *entity.ScheduleRun) error
+}
+
+type IPayoutRepository interface {
+	// SavePayoutBatch insert a payout batch with its items
+	SavePayoutBatch(ctx context.Context, batch *entity.PayoutBatch) error
+
+	// GetPayoutBatchByID get a payout batch with its items ordered by line. If batch not found, return nil - nil
+	GetPayoutBatchByID(ctx context.Context, batchID string) (*entity.PayoutBatch, error)
+
+	// ClaimPayoutBatch set a batch that is entity.PayoutBatch.Claimable at now to PROCESSING, claimed until until.
+	// If the batch is not claimable or not found, return false - nil
+	ClaimPayoutBatch(ctx context.Context, batchID string, now time.Time, until time.Time) (bool, error)
+
+	// ListClaimablePayoutBatchIDs list the ids of at most limit batches that are claimable at now
+	ListClaimablePayoutBatchIDs(ctx context.Context, now time.Time, limit int) ([]string, error)
+
+	// UpdatePayoutBatchStatus update payout batch status
+	UpdatePayoutBatchStatus(ctx context.Context, batchID string, status entity.PayoutBatchStatus) error
+
+	// UpdatePayoutItem update the result of a payout item
+	UpdatePayoutItem(ctx context.Context, item *entity.PayoutItem) error
+}
+
+type IStatementRepository interface {
+	// GetBalanceBefore get the balance of a wallet from the successful transactions settled before the given time
+	GetBalanceBefore(ctx context.Context, walletID string, before time.Time) (float64, error)
+
+	// StreamTransactions call fn for every successful transaction of a wallet settled in [from, to), oldest first.
+	// Transactions are read from a cursor and never loaded all at once. An error of fn stops the iteration.
+	StreamTransactions(ctx context.Context, walletID string, from time.Time, to time.Time,
+		fn func(trans *entity.Transaction) error) error
+}
+
+type IBalanceRepository interface {
+	// GetLatestSnapshot get the latest snapshot of a wallet taken at or before asOf. If there is none, return nil - nil
+	GetLatestSnapshot(ctx context.Context, walletID string, asOf time.Time) (*entity.BalanceSnapshot, error)
+
+	// SaveSnapshot insert a snapshot or replace the one of the same wallet and time
+	SaveSnapshot(ctx context.Context, snapshot *entity.BalanceSnapshot) error
+
+	// SumTransactions sum the successful transactions of a wallet settled in [from, to).
+	// A zero from means since the first transaction and a zero to means up to now.
+	SumTransactions(ctx context.Context, walletID string, from time.Time, to time.Time) (float64, error)
+
+	// ListWalletIDs list up to limit wallet ids greater than afterID, in ascending order
+	ListWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error)
+}
+
+type IIDGenerator interface {
+	// NewID returns a new unique id
+	NewID() string
 }
 
 type INotifier interface {
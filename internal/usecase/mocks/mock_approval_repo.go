@@ -0,0 +1,253 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "go-clean-template/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IApprovalRepository is an autogenerated mock type for the IApprovalRepository type
+type IApprovalRepository struct {
+	mock.Mock
+}
+
+type IApprovalRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IApprovalRepository) EXPECT() *IApprovalRepository_Expecter {
+	return &IApprovalRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetApprovalByTransactionID provides a mock function with given fields: ctx, transID
+func (_m *IApprovalRepository) GetApprovalByTransactionID(ctx context.Context, transID string) (*entity.Approval, error) {
+	ret := _m.Called(ctx, transID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApprovalByTransactionID")
+	}
+
+	var r0 *entity.Approval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Approval, error)); ok {
+		return rf(ctx, transID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Approval); ok {
+		r0 = rf(ctx, transID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Approval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, transID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IApprovalRepository_GetApprovalByTransactionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApprovalByTransactionID'
+type IApprovalRepository_GetApprovalByTransactionID_Call struct {
+	*mock.Call
+}
+
+// GetApprovalByTransactionID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transID string
+func (_e *IApprovalRepository_Expecter) GetApprovalByTransactionID(ctx interface{}, transID interface{}) *IApprovalRepository_GetApprovalByTransactionID_Call {
+	return &IApprovalRepository_GetApprovalByTransactionID_Call{Call: _e.mock.On("GetApprovalByTransactionID", ctx, transID)}
+}
+
+func (_c *IApprovalRepository_GetApprovalByTransactionID_Call) Run(run func(ctx context.Context, transID string)) *IApprovalRepository_GetApprovalByTransactionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IApprovalRepository_GetApprovalByTransactionID_Call) Return(_a0 *entity.Approval, _a1 error) *IApprovalRepository_GetApprovalByTransactionID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IApprovalRepository_GetApprovalByTransactionID_Call) RunAndReturn(run func(context.Context, string) (*entity.Approval, error)) *IApprovalRepository_GetApprovalByTransactionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExpiredApprovals provides a mock function with given fields: ctx, now, limit
+func (_m *IApprovalRepository) ListExpiredApprovals(ctx context.Context, now time.Time, limit int) ([]*entity.Approval, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpiredApprovals")
+	}
+
+	var r0 []*entity.Approval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*entity.Approval, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*entity.Approval); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Approval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IApprovalRepository_ListExpiredApprovals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiredApprovals'
+type IApprovalRepository_ListExpiredApprovals_Call struct {
+	*mock.Call
+}
+
+// ListExpiredApprovals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *IApprovalRepository_Expecter) ListExpiredApprovals(ctx interface{}, now interface{}, limit interface{}) *IApprovalRepository_ListExpiredApprovals_Call {
+	return &IApprovalRepository_ListExpiredApprovals_Call{Call: _e.mock.On("ListExpiredApprovals", ctx, now, limit)}
+}
+
+func (_c *IApprovalRepository_ListExpiredApprovals_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *IApprovalRepository_ListExpiredApprovals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *IApprovalRepository_ListExpiredApprovals_Call) Return(_a0 []*entity.Approval, _a1 error) *IApprovalRepository_ListExpiredApprovals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IApprovalRepository_ListExpiredApprovals_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*entity.Approval, error)) *IApprovalRepository_ListExpiredApprovals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveApproval provides a mock function with given fields: ctx, approval
+func (_m *IApprovalRepository) SaveApproval(ctx context.Context, approval *entity.Approval) error {
+	ret := _m.Called(ctx, approval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveApproval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Approval) error); ok {
+		r0 = rf(ctx, approval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IApprovalRepository_SaveApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveApproval'
+type IApprovalRepository_SaveApproval_Call struct {
+	*mock.Call
+}
+
+// SaveApproval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - approval *entity.Approval
+func (_e *IApprovalRepository_Expecter) SaveApproval(ctx interface{}, approval interface{}) *IApprovalRepository_SaveApproval_Call {
+	return &IApprovalRepository_SaveApproval_Call{Call: _e.mock.On("SaveApproval", ctx, approval)}
+}
+
+func (_c *IApprovalRepository_SaveApproval_Call) Run(run func(ctx context.Context, approval *entity.Approval)) *IApprovalRepository_SaveApproval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Approval))
+	})
+	return _c
+}
+
+func (_c *IApprovalRepository_SaveApproval_Call) Return(_a0 error) *IApprovalRepository_SaveApproval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IApprovalRepository_SaveApproval_Call) RunAndReturn(run func(context.Context, *entity.Approval) error) *IApprovalRepository_SaveApproval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateApproval provides a mock function with given fields: ctx, approval
+func (_m *IApprovalRepository) UpdateApproval(ctx context.Context, approval *entity.Approval) error {
+	ret := _m.Called(ctx, approval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateApproval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Approval) error); ok {
+		r0 = rf(ctx, approval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IApprovalRepository_UpdateApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateApproval'
+type IApprovalRepository_UpdateApproval_Call struct {
+	*mock.Call
+}
+
+// UpdateApproval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - approval *entity.Approval
+func (_e *IApprovalRepository_Expecter) UpdateApproval(ctx interface{}, approval interface{}) *IApprovalRepository_UpdateApproval_Call {
+	return &IApprovalRepository_UpdateApproval_Call{Call: _e.mock.On("UpdateApproval", ctx, approval)}
+}
+
+func (_c *IApprovalRepository_UpdateApproval_Call) Run(run func(ctx context.Context, approval *entity.Approval)) *IApprovalRepository_UpdateApproval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Approval))
+	})
+	return _c
+}
+
+func (_c *IApprovalRepository_UpdateApproval_Call) Return(_a0 error) *IApprovalRepository_UpdateApproval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IApprovalRepository_UpdateApproval_Call) RunAndReturn(run func(context.Context, *entity.Approval) error) *IApprovalRepository_UpdateApproval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIApprovalRepository creates a new instance of IApprovalRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIApprovalRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IApprovalRepository {
+	mock := &IApprovalRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
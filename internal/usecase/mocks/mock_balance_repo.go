@@ -0,0 +1,266 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "go-clean-template/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IBalanceRepository is an autogenerated mock type for the IBalanceRepository type
+type IBalanceRepository struct {
+	mock.Mock
+}
+
+type IBalanceRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IBalanceRepository) EXPECT() *IBalanceRepository_Expecter {
+	return &IBalanceRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetLatestSnapshot provides a mock function with given fields: ctx, walletID, asOf
+func (_m *IBalanceRepository) GetLatestSnapshot(ctx context.Context, walletID string, asOf time.Time) (*entity.BalanceSnapshot, error) {
+	ret := _m.Called(ctx, walletID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestSnapshot")
+	}
+
+	var r0 *entity.BalanceSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*entity.BalanceSnapshot, error)); ok {
+		return rf(ctx, walletID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *entity.BalanceSnapshot); ok {
+		r0 = rf(ctx, walletID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BalanceSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, walletID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IBalanceRepository_GetLatestSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestSnapshot'
+type IBalanceRepository_GetLatestSnapshot_Call struct {
+	*mock.Call
+}
+
+// GetLatestSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+//   - asOf time.Time
+func (_e *IBalanceRepository_Expecter) GetLatestSnapshot(ctx interface{}, walletID interface{}, asOf interface{}) *IBalanceRepository_GetLatestSnapshot_Call {
+	return &IBalanceRepository_GetLatestSnapshot_Call{Call: _e.mock.On("GetLatestSnapshot", ctx, walletID, asOf)}
+}
+
+func (_c *IBalanceRepository_GetLatestSnapshot_Call) Run(run func(ctx context.Context, walletID string, asOf time.Time)) *IBalanceRepository_GetLatestSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IBalanceRepository_GetLatestSnapshot_Call) Return(_a0 *entity.BalanceSnapshot, _a1 error) *IBalanceRepository_GetLatestSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IBalanceRepository_GetLatestSnapshot_Call) RunAndReturn(run func(context.Context, string, time.Time) (*entity.BalanceSnapshot, error)) *IBalanceRepository_GetLatestSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWalletIDs provides a mock function with given fields: ctx, afterID, limit
+func (_m *IBalanceRepository) ListWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWalletIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]string, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []string); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IBalanceRepository_ListWalletIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWalletIDs'
+type IBalanceRepository_ListWalletIDs_Call struct {
+	*mock.Call
+}
+
+// ListWalletIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID string
+//   - limit int
+func (_e *IBalanceRepository_Expecter) ListWalletIDs(ctx interface{}, afterID interface{}, limit interface{}) *IBalanceRepository_ListWalletIDs_Call {
+	return &IBalanceRepository_ListWalletIDs_Call{Call: _e.mock.On("ListWalletIDs", ctx, afterID, limit)}
+}
+
+func (_c *IBalanceRepository_ListWalletIDs_Call) Run(run func(ctx context.Context, afterID string, limit int)) *IBalanceRepository_ListWalletIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *IBalanceRepository_ListWalletIDs_Call) Return(_a0 []string, _a1 error) *IBalanceRepository_ListWalletIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IBalanceRepository_ListWalletIDs_Call) RunAndReturn(run func(context.Context, string, int) ([]string, error)) *IBalanceRepository_ListWalletIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveSnapshot provides a mock function with given fields: ctx, snapshot
+func (_m *IBalanceRepository) SaveSnapshot(ctx context.Context, snapshot *entity.BalanceSnapshot) error {
+	ret := _m.Called(ctx, snapshot)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BalanceSnapshot) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IBalanceRepository_SaveSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSnapshot'
+type IBalanceRepository_SaveSnapshot_Call struct {
+	*mock.Call
+}
+
+// SaveSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshot *entity.BalanceSnapshot
+func (_e *IBalanceRepository_Expecter) SaveSnapshot(ctx interface{}, snapshot interface{}) *IBalanceRepository_SaveSnapshot_Call {
+	return &IBalanceRepository_SaveSnapshot_Call{Call: _e.mock.On("SaveSnapshot", ctx, snapshot)}
+}
+
+func (_c *IBalanceRepository_SaveSnapshot_Call) Run(run func(ctx context.Context, snapshot *entity.BalanceSnapshot)) *IBalanceRepository_SaveSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.BalanceSnapshot))
+	})
+	return _c
+}
+
+func (_c *IBalanceRepository_SaveSnapshot_Call) Return(_a0 error) *IBalanceRepository_SaveSnapshot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IBalanceRepository_SaveSnapshot_Call) RunAndReturn(run func(context.Context, *entity.BalanceSnapshot) error) *IBalanceRepository_SaveSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumTransactions provides a mock function with given fields: ctx, walletID, from, to
+func (_m *IBalanceRepository) SumTransactions(ctx context.Context, walletID string, from time.Time, to time.Time) (float64, error) {
+	ret := _m.Called(ctx, walletID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumTransactions")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (float64, error)); ok {
+		return rf(ctx, walletID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) float64); ok {
+		r0 = rf(ctx, walletID, from, to)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, walletID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IBalanceRepository_SumTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumTransactions'
+type IBalanceRepository_SumTransactions_Call struct {
+	*mock.Call
+}
+
+// SumTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+//   - from time.Time
+//   - to time.Time
+func (_e *IBalanceRepository_Expecter) SumTransactions(ctx interface{}, walletID interface{}, from interface{}, to interface{}) *IBalanceRepository_SumTransactions_Call {
+	return &IBalanceRepository_SumTransactions_Call{Call: _e.mock.On("SumTransactions", ctx, walletID, from, to)}
+}
+
+func (_c *IBalanceRepository_SumTransactions_Call) Run(run func(ctx context.Context, walletID string, from time.Time, to time.Time)) *IBalanceRepository_SumTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IBalanceRepository_SumTransactions_Call) Return(_a0 float64, _a1 error) *IBalanceRepository_SumTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IBalanceRepository_SumTransactions_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (float64, error)) *IBalanceRepository_SumTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIBalanceRepository creates a new instance of IBalanceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIBalanceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IBalanceRepository {
+	mock := &IBalanceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
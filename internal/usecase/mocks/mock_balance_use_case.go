@@ -0,0 +1,215 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "go-clean-template/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IBalanceUseCase is an autogenerated mock type for the IBalanceUseCase type
+type IBalanceUseCase struct {
+	mock.Mock
+}
+
+type IBalanceUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IBalanceUseCase) EXPECT() *IBalanceUseCase_Expecter {
+	return &IBalanceUseCase_Expecter{mock: &_m.Mock}
+}
+
+// BuildSnapshots provides a mock function with given fields: ctx, asOf
+func (_m *IBalanceUseCase) BuildSnapshots(ctx context.Context, asOf time.Time) (int, error) {
+	ret := _m.Called(ctx, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildSnapshots")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IBalanceUseCase_BuildSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildSnapshots'
+type IBalanceUseCase_BuildSnapshots_Call struct {
+	*mock.Call
+}
+
+// BuildSnapshots is a helper method to define mock.On call
+//   - ctx context.Context
+//   - asOf time.Time
+func (_e *IBalanceUseCase_Expecter) BuildSnapshots(ctx interface{}, asOf interface{}) *IBalanceUseCase_BuildSnapshots_Call {
+	return &IBalanceUseCase_BuildSnapshots_Call{Call: _e.mock.On("BuildSnapshots", ctx, asOf)}
+}
+
+func (_c *IBalanceUseCase_BuildSnapshots_Call) Run(run func(ctx context.Context, asOf time.Time)) *IBalanceUseCase_BuildSnapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IBalanceUseCase_BuildSnapshots_Call) Return(_a0 int, _a1 error) *IBalanceUseCase_BuildSnapshots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IBalanceUseCase_BuildSnapshots_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *IBalanceUseCase_BuildSnapshots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckConsistency provides a mock function with given fields: ctx, walletID, asOf
+func (_m *IBalanceUseCase) CheckConsistency(ctx context.Context, walletID string, asOf time.Time) (*entity.BalanceCheck, error) {
+	ret := _m.Called(ctx, walletID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckConsistency")
+	}
+
+	var r0 *entity.BalanceCheck
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*entity.BalanceCheck, error)); ok {
+		return rf(ctx, walletID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *entity.BalanceCheck); ok {
+		r0 = rf(ctx, walletID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BalanceCheck)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, walletID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IBalanceUseCase_CheckConsistency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckConsistency'
+type IBalanceUseCase_CheckConsistency_Call struct {
+	*mock.Call
+}
+
+// CheckConsistency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+//   - asOf time.Time
+func (_e *IBalanceUseCase_Expecter) CheckConsistency(ctx interface{}, walletID interface{}, asOf interface{}) *IBalanceUseCase_CheckConsistency_Call {
+	return &IBalanceUseCase_CheckConsistency_Call{Call: _e.mock.On("CheckConsistency", ctx, walletID, asOf)}
+}
+
+func (_c *IBalanceUseCase_CheckConsistency_Call) Run(run func(ctx context.Context, walletID string, asOf time.Time)) *IBalanceUseCase_CheckConsistency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IBalanceUseCase_CheckConsistency_Call) Return(_a0 *entity.BalanceCheck, _a1 error) *IBalanceUseCase_CheckConsistency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IBalanceUseCase_CheckConsistency_Call) RunAndReturn(run func(context.Context, string, time.Time) (*entity.BalanceCheck, error)) *IBalanceUseCase_CheckConsistency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalance provides a mock function with given fields: ctx, walletID, asOf
+func (_m *IBalanceUseCase) GetBalance(ctx context.Context, walletID string, asOf time.Time) (float64, error) {
+	ret := _m.Called(ctx, walletID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (float64, error)); ok {
+		return rf(ctx, walletID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) float64); ok {
+		r0 = rf(ctx, walletID, asOf)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, walletID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IBalanceUseCase_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
+type IBalanceUseCase_GetBalance_Call struct {
+	*mock.Call
+}
+
+// GetBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+//   - asOf time.Time
+func (_e *IBalanceUseCase_Expecter) GetBalance(ctx interface{}, walletID interface{}, asOf interface{}) *IBalanceUseCase_GetBalance_Call {
+	return &IBalanceUseCase_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, walletID, asOf)}
+}
+
+func (_c *IBalanceUseCase_GetBalance_Call) Run(run func(ctx context.Context, walletID string, asOf time.Time)) *IBalanceUseCase_GetBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IBalanceUseCase_GetBalance_Call) Return(_a0 float64, _a1 error) *IBalanceUseCase_GetBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IBalanceUseCase_GetBalance_Call) RunAndReturn(run func(context.Context, string, time.Time) (float64, error)) *IBalanceUseCase_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIBalanceUseCase creates a new instance of IBalanceUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIBalanceUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IBalanceUseCase {
+	mock := &IBalanceUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
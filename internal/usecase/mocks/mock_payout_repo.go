@@ -0,0 +1,360 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "go-clean-template/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IPayoutRepository is an autogenerated mock type for the IPayoutRepository type
+type IPayoutRepository struct {
+	mock.Mock
+}
+
+type IPayoutRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IPayoutRepository) EXPECT() *IPayoutRepository_Expecter {
+	return &IPayoutRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimPayoutBatch provides a mock function with given fields: ctx, batchID, now, until
+func (_m *IPayoutRepository) ClaimPayoutBatch(ctx context.Context, batchID string, now time.Time, until time.Time) (bool, error) {
+	ret := _m.Called(ctx, batchID, now, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimPayoutBatch")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, batchID, now, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, batchID, now, until)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, batchID, now, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPayoutRepository_ClaimPayoutBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimPayoutBatch'
+type IPayoutRepository_ClaimPayoutBatch_Call struct {
+	*mock.Call
+}
+
+// ClaimPayoutBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID string
+//   - now time.Time
+//   - until time.Time
+func (_e *IPayoutRepository_Expecter) ClaimPayoutBatch(ctx interface{}, batchID interface{}, now interface{}, until interface{}) *IPayoutRepository_ClaimPayoutBatch_Call {
+	return &IPayoutRepository_ClaimPayoutBatch_Call{Call: _e.mock.On("ClaimPayoutBatch", ctx, batchID, now, until)}
+}
+
+func (_c *IPayoutRepository_ClaimPayoutBatch_Call) Run(run func(ctx context.Context, batchID string, now time.Time, until time.Time)) *IPayoutRepository_ClaimPayoutBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IPayoutRepository_ClaimPayoutBatch_Call) Return(_a0 bool, _a1 error) *IPayoutRepository_ClaimPayoutBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IPayoutRepository_ClaimPayoutBatch_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (bool, error)) *IPayoutRepository_ClaimPayoutBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPayoutBatchByID provides a mock function with given fields: ctx, batchID
+func (_m *IPayoutRepository) GetPayoutBatchByID(ctx context.Context, batchID string) (*entity.PayoutBatch, error) {
+	ret := _m.Called(ctx, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayoutBatchByID")
+	}
+
+	var r0 *entity.PayoutBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PayoutBatch, error)); ok {
+		return rf(ctx, batchID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PayoutBatch); ok {
+		r0 = rf(ctx, batchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PayoutBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, batchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPayoutRepository_GetPayoutBatchByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPayoutBatchByID'
+type IPayoutRepository_GetPayoutBatchByID_Call struct {
+	*mock.Call
+}
+
+// GetPayoutBatchByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID string
+func (_e *IPayoutRepository_Expecter) GetPayoutBatchByID(ctx interface{}, batchID interface{}) *IPayoutRepository_GetPayoutBatchByID_Call {
+	return &IPayoutRepository_GetPayoutBatchByID_Call{Call: _e.mock.On("GetPayoutBatchByID", ctx, batchID)}
+}
+
+func (_c *IPayoutRepository_GetPayoutBatchByID_Call) Run(run func(ctx context.Context, batchID string)) *IPayoutRepository_GetPayoutBatchByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IPayoutRepository_GetPayoutBatchByID_Call) Return(_a0 *entity.PayoutBatch, _a1 error) *IPayoutRepository_GetPayoutBatchByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IPayoutRepository_GetPayoutBatchByID_Call) RunAndReturn(run func(context.Context, string) (*entity.PayoutBatch, error)) *IPayoutRepository_GetPayoutBatchByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListClaimablePayoutBatchIDs provides a mock function with given fields: ctx, now, limit
+func (_m *IPayoutRepository) ListClaimablePayoutBatchIDs(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListClaimablePayoutBatchIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]string, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []string); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPayoutRepository_ListClaimablePayoutBatchIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListClaimablePayoutBatchIDs'
+type IPayoutRepository_ListClaimablePayoutBatchIDs_Call struct {
+	*mock.Call
+}
+
+// ListClaimablePayoutBatchIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *IPayoutRepository_Expecter) ListClaimablePayoutBatchIDs(ctx interface{}, now interface{}, limit interface{}) *IPayoutRepository_ListClaimablePayoutBatchIDs_Call {
+	return &IPayoutRepository_ListClaimablePayoutBatchIDs_Call{Call: _e.mock.On("ListClaimablePayoutBatchIDs", ctx, now, limit)}
+}
+
+func (_c *IPayoutRepository_ListClaimablePayoutBatchIDs_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *IPayoutRepository_ListClaimablePayoutBatchIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *IPayoutRepository_ListClaimablePayoutBatchIDs_Call) Return(_a0 []string, _a1 error) *IPayoutRepository_ListClaimablePayoutBatchIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IPayoutRepository_ListClaimablePayoutBatchIDs_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]string, error)) *IPayoutRepository_ListClaimablePayoutBatchIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePayoutBatch provides a mock function with given fields: ctx, batch
+func (_m *IPayoutRepository) SavePayoutBatch(ctx context.Context, batch *entity.PayoutBatch) error {
+	ret := _m.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePayoutBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PayoutBatch) error); ok {
+		r0 = rf(ctx, batch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPayoutRepository_SavePayoutBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePayoutBatch'
+type IPayoutRepository_SavePayoutBatch_Call struct {
+	*mock.Call
+}
+
+// SavePayoutBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batch *entity.PayoutBatch
+func (_e *IPayoutRepository_Expecter) SavePayoutBatch(ctx interface{}, batch interface{}) *IPayoutRepository_SavePayoutBatch_Call {
+	return &IPayoutRepository_SavePayoutBatch_Call{Call: _e.mock.On("SavePayoutBatch", ctx, batch)}
+}
+
+func (_c *IPayoutRepository_SavePayoutBatch_Call) Run(run func(ctx context.Context, batch *entity.PayoutBatch)) *IPayoutRepository_SavePayoutBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.PayoutBatch))
+	})
+	return _c
+}
+
+func (_c *IPayoutRepository_SavePayoutBatch_Call) Return(_a0 error) *IPayoutRepository_SavePayoutBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPayoutRepository_SavePayoutBatch_Call) RunAndReturn(run func(context.Context, *entity.PayoutBatch) error) *IPayoutRepository_SavePayoutBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePayoutBatchStatus provides a mock function with given fields: ctx, batchID, status
+func (_m *IPayoutRepository) UpdatePayoutBatchStatus(ctx context.Context, batchID string, status entity.PayoutBatchStatus) error {
+	ret := _m.Called(ctx, batchID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePayoutBatchStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.PayoutBatchStatus) error); ok {
+		r0 = rf(ctx, batchID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPayoutRepository_UpdatePayoutBatchStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePayoutBatchStatus'
+type IPayoutRepository_UpdatePayoutBatchStatus_Call struct {
+	*mock.Call
+}
+
+// UpdatePayoutBatchStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID string
+//   - status entity.PayoutBatchStatus
+func (_e *IPayoutRepository_Expecter) UpdatePayoutBatchStatus(ctx interface{}, batchID interface{}, status interface{}) *IPayoutRepository_UpdatePayoutBatchStatus_Call {
+	return &IPayoutRepository_UpdatePayoutBatchStatus_Call{Call: _e.mock.On("UpdatePayoutBatchStatus", ctx, batchID, status)}
+}
+
+func (_c *IPayoutRepository_UpdatePayoutBatchStatus_Call) Run(run func(ctx context.Context, batchID string, status entity.PayoutBatchStatus)) *IPayoutRepository_UpdatePayoutBatchStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.PayoutBatchStatus))
+	})
+	return _c
+}
+
+func (_c *IPayoutRepository_UpdatePayoutBatchStatus_Call) Return(_a0 error) *IPayoutRepository_UpdatePayoutBatchStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPayoutRepository_UpdatePayoutBatchStatus_Call) RunAndReturn(run func(context.Context, string, entity.PayoutBatchStatus) error) *IPayoutRepository_UpdatePayoutBatchStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePayoutItem provides a mock function with given fields: ctx, item
+func (_m *IPayoutRepository) UpdatePayoutItem(ctx context.Context, item *entity.PayoutItem) error {
+	ret := _m.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePayoutItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PayoutItem) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPayoutRepository_UpdatePayoutItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePayoutItem'
+type IPayoutRepository_UpdatePayoutItem_Call struct {
+	*mock.Call
+}
+
+// UpdatePayoutItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *entity.PayoutItem
+func (_e *IPayoutRepository_Expecter) UpdatePayoutItem(ctx interface{}, item interface{}) *IPayoutRepository_UpdatePayoutItem_Call {
+	return &IPayoutRepository_UpdatePayoutItem_Call{Call: _e.mock.On("UpdatePayoutItem", ctx, item)}
+}
+
+func (_c *IPayoutRepository_UpdatePayoutItem_Call) Run(run func(ctx context.Context, item *entity.PayoutItem)) *IPayoutRepository_UpdatePayoutItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.PayoutItem))
+	})
+	return _c
+}
+
+func (_c *IPayoutRepository_UpdatePayoutItem_Call) Return(_a0 error) *IPayoutRepository_UpdatePayoutItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPayoutRepository_UpdatePayoutItem_Call) RunAndReturn(run func(context.Context, *entity.PayoutItem) error) *IPayoutRepository_UpdatePayoutItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIPayoutRepository creates a new instance of IPayoutRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPayoutRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPayoutRepository {
+	mock := &IPayoutRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,263 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "go-clean-template/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IPayoutUseCase is an autogenerated mock type for the IPayoutUseCase type
+type IPayoutUseCase struct {
+	mock.Mock
+}
+
+type IPayoutUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IPayoutUseCase) EXPECT() *IPayoutUseCase_Expecter {
+	return &IPayoutUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CreatePayoutBatch provides a mock function with given fields: ctx, createdBy, items
+func (_m *IPayoutUseCase) CreatePayoutBatch(ctx context.Context, createdBy string, items []*entity.PayoutItem) (*entity.PayoutBatch, error) {
+	ret := _m.Called(ctx, createdBy, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePayoutBatch")
+	}
+
+	var r0 *entity.PayoutBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*entity.PayoutItem) (*entity.PayoutBatch, error)); ok {
+		return rf(ctx, createdBy, items)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*entity.PayoutItem) *entity.PayoutBatch); ok {
+		r0 = rf(ctx, createdBy, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PayoutBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []*entity.PayoutItem) error); ok {
+		r1 = rf(ctx, createdBy, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPayoutUseCase_CreatePayoutBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePayoutBatch'
+type IPayoutUseCase_CreatePayoutBatch_Call struct {
+	*mock.Call
+}
+
+// CreatePayoutBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - createdBy string
+//   - items []*entity.PayoutItem
+func (_e *IPayoutUseCase_Expecter) CreatePayoutBatch(ctx interface{}, createdBy interface{}, items interface{}) *IPayoutUseCase_CreatePayoutBatch_Call {
+	return &IPayoutUseCase_CreatePayoutBatch_Call{Call: _e.mock.On("CreatePayoutBatch", ctx, createdBy, items)}
+}
+
+func (_c *IPayoutUseCase_CreatePayoutBatch_Call) Run(run func(ctx context.Context, createdBy string, items []*entity.PayoutItem)) *IPayoutUseCase_CreatePayoutBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]*entity.PayoutItem))
+	})
+	return _c
+}
+
+func (_c *IPayoutUseCase_CreatePayoutBatch_Call) Return(_a0 *entity.PayoutBatch, _a1 error) *IPayoutUseCase_CreatePayoutBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IPayoutUseCase_CreatePayoutBatch_Call) RunAndReturn(run func(context.Context, string, []*entity.PayoutItem) (*entity.PayoutBatch, error)) *IPayoutUseCase_CreatePayoutBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPayoutBatch provides a mock function with given fields: ctx, batchID
+func (_m *IPayoutUseCase) GetPayoutBatch(ctx context.Context, batchID string) (*entity.PayoutBatch, error) {
+	ret := _m.Called(ctx, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayoutBatch")
+	}
+
+	var r0 *entity.PayoutBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PayoutBatch, error)); ok {
+		return rf(ctx, batchID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PayoutBatch); ok {
+		r0 = rf(ctx, batchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PayoutBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, batchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPayoutUseCase_GetPayoutBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPayoutBatch'
+type IPayoutUseCase_GetPayoutBatch_Call struct {
+	*mock.Call
+}
+
+// GetPayoutBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID string
+func (_e *IPayoutUseCase_Expecter) GetPayoutBatch(ctx interface{}, batchID interface{}) *IPayoutUseCase_GetPayoutBatch_Call {
+	return &IPayoutUseCase_GetPayoutBatch_Call{Call: _e.mock.On("GetPayoutBatch", ctx, batchID)}
+}
+
+func (_c *IPayoutUseCase_GetPayoutBatch_Call) Run(run func(ctx context.Context, batchID string)) *IPayoutUseCase_GetPayoutBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IPayoutUseCase_GetPayoutBatch_Call) Return(_a0 *entity.PayoutBatch, _a1 error) *IPayoutUseCase_GetPayoutBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IPayoutUseCase_GetPayoutBatch_Call) RunAndReturn(run func(context.Context, string) (*entity.PayoutBatch, error)) *IPayoutUseCase_GetPayoutBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessPayoutBatch provides a mock function with given fields: ctx, batchID
+func (_m *IPayoutUseCase) ProcessPayoutBatch(ctx context.Context, batchID string) error {
+	ret := _m.Called(ctx, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessPayoutBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, batchID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPayoutUseCase_ProcessPayoutBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessPayoutBatch'
+type IPayoutUseCase_ProcessPayoutBatch_Call struct {
+	*mock.Call
+}
+
+// ProcessPayoutBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID string
+func (_e *IPayoutUseCase_Expecter) ProcessPayoutBatch(ctx interface{}, batchID interface{}) *IPayoutUseCase_ProcessPayoutBatch_Call {
+	return &IPayoutUseCase_ProcessPayoutBatch_Call{Call: _e.mock.On("ProcessPayoutBatch", ctx, batchID)}
+}
+
+func (_c *IPayoutUseCase_ProcessPayoutBatch_Call) Run(run func(ctx context.Context, batchID string)) *IPayoutUseCase_ProcessPayoutBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IPayoutUseCase_ProcessPayoutBatch_Call) Return(_a0 error) *IPayoutUseCase_ProcessPayoutBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPayoutUseCase_ProcessPayoutBatch_Call) RunAndReturn(run func(context.Context, string) error) *IPayoutUseCase_ProcessPayoutBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumePayoutBatches provides a mock function with given fields: ctx, now
+func (_m *IPayoutUseCase) ResumePayoutBatches(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumePayoutBatches")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPayoutUseCase_ResumePayoutBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumePayoutBatches'
+type IPayoutUseCase_ResumePayoutBatches_Call struct {
+	*mock.Call
+}
+
+// ResumePayoutBatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *IPayoutUseCase_Expecter) ResumePayoutBatches(ctx interface{}, now interface{}) *IPayoutUseCase_ResumePayoutBatches_Call {
+	return &IPayoutUseCase_ResumePayoutBatches_Call{Call: _e.mock.On("ResumePayoutBatches", ctx, now)}
+}
+
+func (_c *IPayoutUseCase_ResumePayoutBatches_Call) Run(run func(ctx context.Context, now time.Time)) *IPayoutUseCase_ResumePayoutBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IPayoutUseCase_ResumePayoutBatches_Call) Return(_a0 int, _a1 error) *IPayoutUseCase_ResumePayoutBatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IPayoutUseCase_ResumePayoutBatches_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *IPayoutUseCase_ResumePayoutBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIPayoutUseCase creates a new instance of IPayoutUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPayoutUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPayoutUseCase {
+	mock := &IPayoutUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,465 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "go-clean-template/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IScheduleRepository is an autogenerated mock type for the IScheduleRepository type
+type IScheduleRepository struct {
+	mock.Mock
+}
+
+type IScheduleRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IScheduleRepository) EXPECT() *IScheduleRepository_Expecter {
+	return &IScheduleRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimScheduleRun provides a mock function with given fields: ctx, run, now
+func (_m *IScheduleRepository) ClaimScheduleRun(ctx context.Context, run *entity.ScheduleRun, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, run, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimScheduleRun")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ScheduleRun, time.Time) (bool, error)); ok {
+		return rf(ctx, run, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ScheduleRun, time.Time) bool); ok {
+		r0 = rf(ctx, run, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.ScheduleRun, time.Time) error); ok {
+		r1 = rf(ctx, run, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduleRepository_ClaimScheduleRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimScheduleRun'
+type IScheduleRepository_ClaimScheduleRun_Call struct {
+	*mock.Call
+}
+
+// ClaimScheduleRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - run *entity.ScheduleRun
+//   - now time.Time
+func (_e *IScheduleRepository_Expecter) ClaimScheduleRun(ctx interface{}, run interface{}, now interface{}) *IScheduleRepository_ClaimScheduleRun_Call {
+	return &IScheduleRepository_ClaimScheduleRun_Call{Call: _e.mock.On("ClaimScheduleRun", ctx, run, now)}
+}
+
+func (_c *IScheduleRepository_ClaimScheduleRun_Call) Run(run func(ctx context.Context, run *entity.ScheduleRun, now time.Time)) *IScheduleRepository_ClaimScheduleRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.ScheduleRun), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IScheduleRepository_ClaimScheduleRun_Call) Return(_a0 bool, _a1 error) *IScheduleRepository_ClaimScheduleRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduleRepository_ClaimScheduleRun_Call) RunAndReturn(run func(context.Context, *entity.ScheduleRun, time.Time) (bool, error)) *IScheduleRepository_ClaimScheduleRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSchedule provides a mock function with given fields: ctx, scheduleID, version
+func (_m *IScheduleRepository) DeleteSchedule(ctx context.Context, scheduleID string, version int64) error {
+	ret := _m.Called(ctx, scheduleID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, scheduleID, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IScheduleRepository_DeleteSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSchedule'
+type IScheduleRepository_DeleteSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scheduleID string
+//   - version int64
+func (_e *IScheduleRepository_Expecter) DeleteSchedule(ctx interface{}, scheduleID interface{}, version interface{}) *IScheduleRepository_DeleteSchedule_Call {
+	return &IScheduleRepository_DeleteSchedule_Call{Call: _e.mock.On("DeleteSchedule", ctx, scheduleID, version)}
+}
+
+func (_c *IScheduleRepository_DeleteSchedule_Call) Run(run func(ctx context.Context, scheduleID string, version int64)) *IScheduleRepository_DeleteSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IScheduleRepository_DeleteSchedule_Call) Return(_a0 error) *IScheduleRepository_DeleteSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IScheduleRepository_DeleteSchedule_Call) RunAndReturn(run func(context.Context, string, int64) error) *IScheduleRepository_DeleteSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetScheduleByID provides a mock function with given fields: ctx, scheduleID
+func (_m *IScheduleRepository) GetScheduleByID(ctx context.Context, scheduleID string) (*entity.Schedule, error) {
+	ret := _m.Called(ctx, scheduleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScheduleByID")
+	}
+
+	var r0 *entity.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Schedule, error)); ok {
+		return rf(ctx, scheduleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Schedule); ok {
+		r0 = rf(ctx, scheduleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, scheduleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduleRepository_GetScheduleByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetScheduleByID'
+type IScheduleRepository_GetScheduleByID_Call struct {
+	*mock.Call
+}
+
+// GetScheduleByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scheduleID string
+func (_e *IScheduleRepository_Expecter) GetScheduleByID(ctx interface{}, scheduleID interface{}) *IScheduleRepository_GetScheduleByID_Call {
+	return &IScheduleRepository_GetScheduleByID_Call{Call: _e.mock.On("GetScheduleByID", ctx, scheduleID)}
+}
+
+func (_c *IScheduleRepository_GetScheduleByID_Call) Run(run func(ctx context.Context, scheduleID string)) *IScheduleRepository_GetScheduleByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IScheduleRepository_GetScheduleByID_Call) Return(_a0 *entity.Schedule, _a1 error) *IScheduleRepository_GetScheduleByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduleRepository_GetScheduleByID_Call) RunAndReturn(run func(context.Context, string) (*entity.Schedule, error)) *IScheduleRepository_GetScheduleByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDueSchedules provides a mock function with given fields: ctx, now, limit
+func (_m *IScheduleRepository) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*entity.Schedule, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDueSchedules")
+	}
+
+	var r0 []*entity.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*entity.Schedule, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*entity.Schedule); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduleRepository_ListDueSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDueSchedules'
+type IScheduleRepository_ListDueSchedules_Call struct {
+	*mock.Call
+}
+
+// ListDueSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *IScheduleRepository_Expecter) ListDueSchedules(ctx interface{}, now interface{}, limit interface{}) *IScheduleRepository_ListDueSchedules_Call {
+	return &IScheduleRepository_ListDueSchedules_Call{Call: _e.mock.On("ListDueSchedules", ctx, now, limit)}
+}
+
+func (_c *IScheduleRepository_ListDueSchedules_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *IScheduleRepository_ListDueSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *IScheduleRepository_ListDueSchedules_Call) Return(_a0 []*entity.Schedule, _a1 error) *IScheduleRepository_ListDueSchedules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduleRepository_ListDueSchedules_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*entity.Schedule, error)) *IScheduleRepository_ListDueSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSchedulesByWalletID provides a mock function with given fields: ctx, walletID
+func (_m *IScheduleRepository) ListSchedulesByWalletID(ctx context.Context, walletID string) ([]*entity.Schedule, error) {
+	ret := _m.Called(ctx, walletID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSchedulesByWalletID")
+	}
+
+	var r0 []*entity.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Schedule, error)); ok {
+		return rf(ctx, walletID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Schedule); ok {
+		r0 = rf(ctx, walletID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduleRepository_ListSchedulesByWalletID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSchedulesByWalletID'
+type IScheduleRepository_ListSchedulesByWalletID_Call struct {
+	*mock.Call
+}
+
+// ListSchedulesByWalletID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+func (_e *IScheduleRepository_Expecter) ListSchedulesByWalletID(ctx interface{}, walletID interface{}) *IScheduleRepository_ListSchedulesByWalletID_Call {
+	return &IScheduleRepository_ListSchedulesByWalletID_Call{Call: _e.mock.On("ListSchedulesByWalletID", ctx, walletID)}
+}
+
+func (_c *IScheduleRepository_ListSchedulesByWalletID_Call) Run(run func(ctx context.Context, walletID string)) *IScheduleRepository_ListSchedulesByWalletID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IScheduleRepository_ListSchedulesByWalletID_Call) Return(_a0 []*entity.Schedule, _a1 error) *IScheduleRepository_ListSchedulesByWalletID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduleRepository_ListSchedulesByWalletID_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Schedule, error)) *IScheduleRepository_ListSchedulesByWalletID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveSchedule provides a mock function with given fields: ctx, schedule
+func (_m *IScheduleRepository) SaveSchedule(ctx context.Context, schedule *entity.Schedule) error {
+	ret := _m.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Schedule) error); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IScheduleRepository_SaveSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSchedule'
+type IScheduleRepository_SaveSchedule_Call struct {
+	*mock.Call
+}
+
+// SaveSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - schedule *entity.Schedule
+func (_e *IScheduleRepository_Expecter) SaveSchedule(ctx interface{}, schedule interface{}) *IScheduleRepository_SaveSchedule_Call {
+	return &IScheduleRepository_SaveSchedule_Call{Call: _e.mock.On("SaveSchedule", ctx, schedule)}
+}
+
+func (_c *IScheduleRepository_SaveSchedule_Call) Run(run func(ctx context.Context, schedule *entity.Schedule)) *IScheduleRepository_SaveSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Schedule))
+	})
+	return _c
+}
+
+func (_c *IScheduleRepository_SaveSchedule_Call) Return(_a0 error) *IScheduleRepository_SaveSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IScheduleRepository_SaveSchedule_Call) RunAndReturn(run func(context.Context, *entity.Schedule) error) *IScheduleRepository_SaveSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSchedule provides a mock function with given fields: ctx, schedule
+func (_m *IScheduleRepository) UpdateSchedule(ctx context.Context, schedule *entity.Schedule) error {
+	ret := _m.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Schedule) error); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IScheduleRepository_UpdateSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSchedule'
+type IScheduleRepository_UpdateSchedule_Call struct {
+	*mock.Call
+}
+
+// UpdateSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - schedule *entity.Schedule
+func (_e *IScheduleRepository_Expecter) UpdateSchedule(ctx interface{}, schedule interface{}) *IScheduleRepository_UpdateSchedule_Call {
+	return &IScheduleRepository_UpdateSchedule_Call{Call: _e.mock.On("UpdateSchedule", ctx, schedule)}
+}
+
+func (_c *IScheduleRepository_UpdateSchedule_Call) Run(run func(ctx context.Context, schedule *entity.Schedule)) *IScheduleRepository_UpdateSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Schedule))
+	})
+	return _c
+}
+
+func (_c *IScheduleRepository_UpdateSchedule_Call) Return(_a0 error) *IScheduleRepository_UpdateSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IScheduleRepository_UpdateSchedule_Call) RunAndReturn(run func(context.Context, *entity.Schedule) error) *IScheduleRepository_UpdateSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateScheduleRun provides a mock function with given fields: ctx, run
+func (_m *IScheduleRepository) UpdateScheduleRun(ctx context.Context, run *entity.ScheduleRun) error {
+	ret := _m.Called(ctx, run)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateScheduleRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ScheduleRun) error); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IScheduleRepository_UpdateScheduleRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateScheduleRun'
+type IScheduleRepository_UpdateScheduleRun_Call struct {
+	*mock.Call
+}
+
+// UpdateScheduleRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - run *entity.ScheduleRun
+func (_e *IScheduleRepository_Expecter) UpdateScheduleRun(ctx interface{}, run interface{}) *IScheduleRepository_UpdateScheduleRun_Call {
+	return &IScheduleRepository_UpdateScheduleRun_Call{Call: _e.mock.On("UpdateScheduleRun", ctx, run)}
+}
+
+func (_c *IScheduleRepository_UpdateScheduleRun_Call) Run(run func(ctx context.Context, run *entity.ScheduleRun)) *IScheduleRepository_UpdateScheduleRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.ScheduleRun))
+	})
+	return _c
+}
+
+func (_c *IScheduleRepository_UpdateScheduleRun_Call) Return(_a0 error) *IScheduleRepository_UpdateScheduleRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IScheduleRepository_UpdateScheduleRun_Call) RunAndReturn(run func(context.Context, *entity.ScheduleRun) error) *IScheduleRepository_UpdateScheduleRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIScheduleRepository creates a new instance of IScheduleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIScheduleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IScheduleRepository {
+	mock := &IScheduleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,393 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "go-clean-template/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IScheduleUseCase is an autogenerated mock type for the IScheduleUseCase type
+type IScheduleUseCase struct {
+	mock.Mock
+}
+
+type IScheduleUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IScheduleUseCase) EXPECT() *IScheduleUseCase_Expecter {
+	return &IScheduleUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CreateSchedule provides a mock function with given fields: ctx, walletID, accountID, amount, currency, transKind, note, frequency, startAt
+func (_m *IScheduleUseCase) CreateSchedule(ctx context.Context, walletID string, accountID string, amount float64, currency string, transKind entity.TransactionKind, note string, frequency entity.ScheduleFrequency, startAt time.Time) (*entity.Schedule, error) {
+	ret := _m.Called(ctx, walletID, accountID, amount, currency, transKind, note, frequency, startAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSchedule")
+	}
+
+	var r0 *entity.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64, string, entity.TransactionKind, string, entity.ScheduleFrequency, time.Time) (*entity.Schedule, error)); ok {
+		return rf(ctx, walletID, accountID, amount, currency, transKind, note, frequency, startAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64, string, entity.TransactionKind, string, entity.ScheduleFrequency, time.Time) *entity.Schedule); ok {
+		r0 = rf(ctx, walletID, accountID, amount, currency, transKind, note, frequency, startAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, float64, string, entity.TransactionKind, string, entity.ScheduleFrequency, time.Time) error); ok {
+		r1 = rf(ctx, walletID, accountID, amount, currency, transKind, note, frequency, startAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduleUseCase_CreateSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSchedule'
+type IScheduleUseCase_CreateSchedule_Call struct {
+	*mock.Call
+}
+
+// CreateSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+//   - accountID string
+//   - amount float64
+//   - currency string
+//   - transKind entity.TransactionKind
+//   - note string
+//   - frequency entity.ScheduleFrequency
+//   - startAt time.Time
+func (_e *IScheduleUseCase_Expecter) CreateSchedule(ctx interface{}, walletID interface{}, accountID interface{}, amount interface{}, currency interface{}, transKind interface{}, note interface{}, frequency interface{}, startAt interface{}) *IScheduleUseCase_CreateSchedule_Call {
+	return &IScheduleUseCase_CreateSchedule_Call{Call: _e.mock.On("CreateSchedule", ctx, walletID, accountID, amount, currency, transKind, note, frequency, startAt)}
+}
+
+func (_c *IScheduleUseCase_CreateSchedule_Call) Run(run func(ctx context.Context, walletID string, accountID string, amount float64, currency string, transKind entity.TransactionKind, note string, frequency entity.ScheduleFrequency, startAt time.Time)) *IScheduleUseCase_CreateSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(float64), args[4].(string), args[5].(entity.TransactionKind), args[6].(string), args[7].(entity.ScheduleFrequency), args[8].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IScheduleUseCase_CreateSchedule_Call) Return(_a0 *entity.Schedule, _a1 error) *IScheduleUseCase_CreateSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduleUseCase_CreateSchedule_Call) RunAndReturn(run func(context.Context, string, string, float64, string, entity.TransactionKind, string, entity.ScheduleFrequency, time.Time) (*entity.Schedule, error)) *IScheduleUseCase_CreateSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSchedule provides a mock function with given fields: ctx, scheduleID, version
+func (_m *IScheduleUseCase) DeleteSchedule(ctx context.Context, scheduleID string, version int64) error {
+	ret := _m.Called(ctx, scheduleID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, scheduleID, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IScheduleUseCase_DeleteSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSchedule'
+type IScheduleUseCase_DeleteSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scheduleID string
+//   - version int64
+func (_e *IScheduleUseCase_Expecter) DeleteSchedule(ctx interface{}, scheduleID interface{}, version interface{}) *IScheduleUseCase_DeleteSchedule_Call {
+	return &IScheduleUseCase_DeleteSchedule_Call{Call: _e.mock.On("DeleteSchedule", ctx, scheduleID, version)}
+}
+
+func (_c *IScheduleUseCase_DeleteSchedule_Call) Run(run func(ctx context.Context, scheduleID string, version int64)) *IScheduleUseCase_DeleteSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IScheduleUseCase_DeleteSchedule_Call) Return(_a0 error) *IScheduleUseCase_DeleteSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IScheduleUseCase_DeleteSchedule_Call) RunAndReturn(run func(context.Context, string, int64) error) *IScheduleUseCase_DeleteSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSchedule provides a mock function with given fields: ctx, scheduleID
+func (_m *IScheduleUseCase) GetSchedule(ctx context.Context, scheduleID string) (*entity.Schedule, error) {
+	ret := _m.Called(ctx, scheduleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchedule")
+	}
+
+	var r0 *entity.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Schedule, error)); ok {
+		return rf(ctx, scheduleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Schedule); ok {
+		r0 = rf(ctx, scheduleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, scheduleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduleUseCase_GetSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSchedule'
+type IScheduleUseCase_GetSchedule_Call struct {
+	*mock.Call
+}
+
+// GetSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scheduleID string
+func (_e *IScheduleUseCase_Expecter) GetSchedule(ctx interface{}, scheduleID interface{}) *IScheduleUseCase_GetSchedule_Call {
+	return &IScheduleUseCase_GetSchedule_Call{Call: _e.mock.On("GetSchedule", ctx, scheduleID)}
+}
+
+func (_c *IScheduleUseCase_GetSchedule_Call) Run(run func(ctx context.Context, scheduleID string)) *IScheduleUseCase_GetSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IScheduleUseCase_GetSchedule_Call) Return(_a0 *entity.Schedule, _a1 error) *IScheduleUseCase_GetSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduleUseCase_GetSchedule_Call) RunAndReturn(run func(context.Context, string) (*entity.Schedule, error)) *IScheduleUseCase_GetSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSchedules provides a mock function with given fields: ctx, walletID
+func (_m *IScheduleUseCase) ListSchedules(ctx context.Context, walletID string) ([]*entity.Schedule, error) {
+	ret := _m.Called(ctx, walletID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSchedules")
+	}
+
+	var r0 []*entity.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Schedule, error)); ok {
+		return rf(ctx, walletID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Schedule); ok {
+		r0 = rf(ctx, walletID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduleUseCase_ListSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSchedules'
+type IScheduleUseCase_ListSchedules_Call struct {
+	*mock.Call
+}
+
+// ListSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+func (_e *IScheduleUseCase_Expecter) ListSchedules(ctx interface{}, walletID interface{}) *IScheduleUseCase_ListSchedules_Call {
+	return &IScheduleUseCase_ListSchedules_Call{Call: _e.mock.On("ListSchedules", ctx, walletID)}
+}
+
+func (_c *IScheduleUseCase_ListSchedules_Call) Run(run func(ctx context.Context, walletID string)) *IScheduleUseCase_ListSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IScheduleUseCase_ListSchedules_Call) Return(_a0 []*entity.Schedule, _a1 error) *IScheduleUseCase_ListSchedules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduleUseCase_ListSchedules_Call) RunAndReturn(run func(context.Context, string) ([]*entity.Schedule, error)) *IScheduleUseCase_ListSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunDueSchedules provides a mock function with given fields: ctx, now
+func (_m *IScheduleUseCase) RunDueSchedules(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunDueSchedules")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduleUseCase_RunDueSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunDueSchedules'
+type IScheduleUseCase_RunDueSchedules_Call struct {
+	*mock.Call
+}
+
+// RunDueSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *IScheduleUseCase_Expecter) RunDueSchedules(ctx interface{}, now interface{}) *IScheduleUseCase_RunDueSchedules_Call {
+	return &IScheduleUseCase_RunDueSchedules_Call{Call: _e.mock.On("RunDueSchedules", ctx, now)}
+}
+
+func (_c *IScheduleUseCase_RunDueSchedules_Call) Run(run func(ctx context.Context, now time.Time)) *IScheduleUseCase_RunDueSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IScheduleUseCase_RunDueSchedules_Call) Return(_a0 int, _a1 error) *IScheduleUseCase_RunDueSchedules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduleUseCase_RunDueSchedules_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *IScheduleUseCase_RunDueSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSchedule provides a mock function with given fields: ctx, scheduleID, amount, note, frequency, startAt, version
+func (_m *IScheduleUseCase) UpdateSchedule(ctx context.Context, scheduleID string, amount float64, note string, frequency entity.ScheduleFrequency, startAt time.Time, version int64) (*entity.Schedule, error) {
+	ret := _m.Called(ctx, scheduleID, amount, note, frequency, startAt, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSchedule")
+	}
+
+	var r0 *entity.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, string, entity.ScheduleFrequency, time.Time, int64) (*entity.Schedule, error)); ok {
+		return rf(ctx, scheduleID, amount, note, frequency, startAt, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, string, entity.ScheduleFrequency, time.Time, int64) *entity.Schedule); ok {
+		r0 = rf(ctx, scheduleID, amount, note, frequency, startAt, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, string, entity.ScheduleFrequency, time.Time, int64) error); ok {
+		r1 = rf(ctx, scheduleID, amount, note, frequency, startAt, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduleUseCase_UpdateSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSchedule'
+type IScheduleUseCase_UpdateSchedule_Call struct {
+	*mock.Call
+}
+
+// UpdateSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scheduleID string
+//   - amount float64
+//   - note string
+//   - frequency entity.ScheduleFrequency
+//   - startAt time.Time
+//   - version int64
+func (_e *IScheduleUseCase_Expecter) UpdateSchedule(ctx interface{}, scheduleID interface{}, amount interface{}, note interface{}, frequency interface{}, startAt interface{}, version interface{}) *IScheduleUseCase_UpdateSchedule_Call {
+	return &IScheduleUseCase_UpdateSchedule_Call{Call: _e.mock.On("UpdateSchedule", ctx, scheduleID, amount, note, frequency, startAt, version)}
+}
+
+func (_c *IScheduleUseCase_UpdateSchedule_Call) Run(run func(ctx context.Context, scheduleID string, amount float64, note string, frequency entity.ScheduleFrequency, startAt time.Time, version int64)) *IScheduleUseCase_UpdateSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64), args[3].(string), args[4].(entity.ScheduleFrequency), args[5].(time.Time), args[6].(int64))
+	})
+	return _c
+}
+
+func (_c *IScheduleUseCase_UpdateSchedule_Call) Return(_a0 *entity.Schedule, _a1 error) *IScheduleUseCase_UpdateSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduleUseCase_UpdateSchedule_Call) RunAndReturn(run func(context.Context, string, float64, string, entity.ScheduleFrequency, time.Time, int64) (*entity.Schedule, error)) *IScheduleUseCase_UpdateSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIScheduleUseCase creates a new instance of IScheduleUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIScheduleUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IScheduleUseCase {
+	mock := &IScheduleUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
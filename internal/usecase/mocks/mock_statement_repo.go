@@ -0,0 +1,148 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "go-clean-template/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IStatementRepository is an autogenerated mock type for the IStatementRepository type
+type IStatementRepository struct {
+	mock.Mock
+}
+
+type IStatementRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IStatementRepository) EXPECT() *IStatementRepository_Expecter {
+	return &IStatementRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetBalanceBefore provides a mock function with given fields: ctx, walletID, before
+func (_m *IStatementRepository) GetBalanceBefore(ctx context.Context, walletID string, before time.Time) (float64, error) {
+	ret := _m.Called(ctx, walletID, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceBefore")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (float64, error)); ok {
+		return rf(ctx, walletID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) float64); ok {
+		r0 = rf(ctx, walletID, before)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, walletID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IStatementRepository_GetBalanceBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceBefore'
+type IStatementRepository_GetBalanceBefore_Call struct {
+	*mock.Call
+}
+
+// GetBalanceBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+//   - before time.Time
+func (_e *IStatementRepository_Expecter) GetBalanceBefore(ctx interface{}, walletID interface{}, before interface{}) *IStatementRepository_GetBalanceBefore_Call {
+	return &IStatementRepository_GetBalanceBefore_Call{Call: _e.mock.On("GetBalanceBefore", ctx, walletID, before)}
+}
+
+func (_c *IStatementRepository_GetBalanceBefore_Call) Run(run func(ctx context.Context, walletID string, before time.Time)) *IStatementRepository_GetBalanceBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IStatementRepository_GetBalanceBefore_Call) Return(_a0 float64, _a1 error) *IStatementRepository_GetBalanceBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IStatementRepository_GetBalanceBefore_Call) RunAndReturn(run func(context.Context, string, time.Time) (float64, error)) *IStatementRepository_GetBalanceBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamTransactions provides a mock function with given fields: ctx, walletID, from, to, fn
+func (_m *IStatementRepository) StreamTransactions(ctx context.Context, walletID string, from time.Time, to time.Time, fn func(*entity.Transaction) error) error {
+	ret := _m.Called(ctx, walletID, from, to, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamTransactions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, func(*entity.Transaction) error) error); ok {
+		r0 = rf(ctx, walletID, from, to, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IStatementRepository_StreamTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamTransactions'
+type IStatementRepository_StreamTransactions_Call struct {
+	*mock.Call
+}
+
+// StreamTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+//   - from time.Time
+//   - to time.Time
+//   - fn func(*entity.Transaction) error
+func (_e *IStatementRepository_Expecter) StreamTransactions(ctx interface{}, walletID interface{}, from interface{}, to interface{}, fn interface{}) *IStatementRepository_StreamTransactions_Call {
+	return &IStatementRepository_StreamTransactions_Call{Call: _e.mock.On("StreamTransactions", ctx, walletID, from, to, fn)}
+}
+
+func (_c *IStatementRepository_StreamTransactions_Call) Run(run func(ctx context.Context, walletID string, from time.Time, to time.Time, fn func(*entity.Transaction) error)) *IStatementRepository_StreamTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time), args[4].(func(*entity.Transaction) error))
+	})
+	return _c
+}
+
+func (_c *IStatementRepository_StreamTransactions_Call) Return(_a0 error) *IStatementRepository_StreamTransactions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IStatementRepository_StreamTransactions_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time, func(*entity.Transaction) error) error) *IStatementRepository_StreamTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIStatementRepository creates a new instance of IStatementRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIStatementRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IStatementRepository {
+	mock := &IStatementRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
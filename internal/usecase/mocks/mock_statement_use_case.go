@@ -0,0 +1,149 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "go-clean-template/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IStatementUseCase is an autogenerated mock type for the IStatementUseCase type
+type IStatementUseCase struct {
+	mock.Mock
+}
+
+type IStatementUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IStatementUseCase) EXPECT() *IStatementUseCase_Expecter {
+	return &IStatementUseCase_Expecter{mock: &_m.Mock}
+}
+
+// OpenStatement provides a mock function with given fields: ctx, walletID, from, to
+func (_m *IStatementUseCase) OpenStatement(ctx context.Context, walletID string, from time.Time, to time.Time) (*entity.Statement, error) {
+	ret := _m.Called(ctx, walletID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenStatement")
+	}
+
+	var r0 *entity.Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (*entity.Statement, error)); ok {
+		return rf(ctx, walletID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) *entity.Statement); ok {
+		r0 = rf(ctx, walletID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Statement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, walletID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IStatementUseCase_OpenStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenStatement'
+type IStatementUseCase_OpenStatement_Call struct {
+	*mock.Call
+}
+
+// OpenStatement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+//   - from time.Time
+//   - to time.Time
+func (_e *IStatementUseCase_Expecter) OpenStatement(ctx interface{}, walletID interface{}, from interface{}, to interface{}) *IStatementUseCase_OpenStatement_Call {
+	return &IStatementUseCase_OpenStatement_Call{Call: _e.mock.On("OpenStatement", ctx, walletID, from, to)}
+}
+
+func (_c *IStatementUseCase_OpenStatement_Call) Run(run func(ctx context.Context, walletID string, from time.Time, to time.Time)) *IStatementUseCase_OpenStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IStatementUseCase_OpenStatement_Call) Return(_a0 *entity.Statement, _a1 error) *IStatementUseCase_OpenStatement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IStatementUseCase_OpenStatement_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (*entity.Statement, error)) *IStatementUseCase_OpenStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamStatement provides a mock function with given fields: ctx, statement, fn
+func (_m *IStatementUseCase) StreamStatement(ctx context.Context, statement *entity.Statement, fn func(*entity.StatementLine) error) error {
+	ret := _m.Called(ctx, statement, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamStatement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Statement, func(*entity.StatementLine) error) error); ok {
+		r0 = rf(ctx, statement, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IStatementUseCase_StreamStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamStatement'
+type IStatementUseCase_StreamStatement_Call struct {
+	*mock.Call
+}
+
+// StreamStatement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statement *entity.Statement
+//   - fn func(*entity.StatementLine) error
+func (_e *IStatementUseCase_Expecter) StreamStatement(ctx interface{}, statement interface{}, fn interface{}) *IStatementUseCase_StreamStatement_Call {
+	return &IStatementUseCase_StreamStatement_Call{Call: _e.mock.On("StreamStatement", ctx, statement, fn)}
+}
+
+func (_c *IStatementUseCase_StreamStatement_Call) Run(run func(ctx context.Context, statement *entity.Statement, fn func(*entity.StatementLine) error)) *IStatementUseCase_StreamStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Statement), args[2].(func(*entity.StatementLine) error))
+	})
+	return _c
+}
+
+func (_c *IStatementUseCase_StreamStatement_Call) Return(_a0 error) *IStatementUseCase_StreamStatement_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IStatementUseCase_StreamStatement_Call) RunAndReturn(run func(context.Context, *entity.Statement, func(*entity.StatementLine) error) error) *IStatementUseCase_StreamStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIStatementUseCase creates a new instance of IStatementUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIStatementUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IStatementUseCase {
+	mock := &IStatementUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
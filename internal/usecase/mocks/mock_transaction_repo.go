@@ -23,6 +23,65 @@ func (_m *ITransactionRepository) EXPECT() *ITransactionRepository_Expecter {
 	return &ITransactionRepository_Expecter{mock: &_m.Mock}
 }
 
+// CountTransactionsByStatus provides a mock function with given fields: ctx, statuses
+func (_m *ITransactionRepository) CountTransactionsByStatus(ctx context.Context, statuses []entity.TransactionStatus) (map[entity.TransactionStatus]int64, error) {
+	ret := _m.Called(ctx, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTransactionsByStatus")
+	}
+
+	var r0 map[entity.TransactionStatus]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.TransactionStatus) (map[entity.TransactionStatus]int64, error)); ok {
+		return rf(ctx, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.TransactionStatus) map[entity.TransactionStatus]int64); ok {
+		r0 = rf(ctx, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[entity.TransactionStatus]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.TransactionStatus) error); ok {
+		r1 = rf(ctx, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionRepository_CountTransactionsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountTransactionsByStatus'
+type ITransactionRepository_CountTransactionsByStatus_Call struct {
+	*mock.Call
+}
+
+// CountTransactionsByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statuses []entity.TransactionStatus
+func (_e *ITransactionRepository_Expecter) CountTransactionsByStatus(ctx interface{}, statuses interface{}) *ITransactionRepository_CountTransactionsByStatus_Call {
+	return &ITransactionRepository_CountTransactionsByStatus_Call{Call: _e.mock.On("CountTransactionsByStatus", ctx, statuses)}
+}
+
+func (_c *ITransactionRepository_CountTransactionsByStatus_Call) Run(run func(ctx context.Context, statuses []entity.TransactionStatus)) *ITransactionRepository_CountTransactionsByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.TransactionStatus))
+	})
+	return _c
+}
+
+func (_c *ITransactionRepository_CountTransactionsByStatus_Call) Return(_a0 map[entity.TransactionStatus]int64, _a1 error) *ITransactionRepository_CountTransactionsByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionRepository_CountTransactionsByStatus_Call) RunAndReturn(run func(context.Context, []entity.TransactionStatus) (map[entity.TransactionStatus]int64, error)) *ITransactionRepository_CountTransactionsByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBalanceByWalletID provides a mock function with given fields: ctx, walletID
 func (_m *ITransactionRepository) GetBalanceByWalletID(ctx context.Context, walletID string) (float64, error) {
 	ret := _m.Called(ctx, walletID)
@@ -139,6 +198,63 @@ func (_c *ITransactionRepository_GetLinkedAccountByID_Call) RunAndReturn(run fun
 	return _c
 }
 
+// GetPendingWithdrawals provides a mock function with given fields: ctx, walletID
+func (_m *ITransactionRepository) GetPendingWithdrawals(ctx context.Context, walletID string) (float64, error) {
+	ret := _m.Called(ctx, walletID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingWithdrawals")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (float64, error)); ok {
+		return rf(ctx, walletID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) float64); ok {
+		r0 = rf(ctx, walletID)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionRepository_GetPendingWithdrawals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingWithdrawals'
+type ITransactionRepository_GetPendingWithdrawals_Call struct {
+	*mock.Call
+}
+
+// GetPendingWithdrawals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+func (_e *ITransactionRepository_Expecter) GetPendingWithdrawals(ctx interface{}, walletID interface{}) *ITransactionRepository_GetPendingWithdrawals_Call {
+	return &ITransactionRepository_GetPendingWithdrawals_Call{Call: _e.mock.On("GetPendingWithdrawals", ctx, walletID)}
+}
+
+func (_c *ITransactionRepository_GetPendingWithdrawals_Call) Run(run func(ctx context.Context, walletID string)) *ITransactionRepository_GetPendingWithdrawals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITransactionRepository_GetPendingWithdrawals_Call) Return(_a0 float64, _a1 error) *ITransactionRepository_GetPendingWithdrawals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionRepository_GetPendingWithdrawals_Call) RunAndReturn(run func(context.Context, string) (float64, error)) *ITransactionRepository_GetPendingWithdrawals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTransactionByID provides a mock function with given fields: ctx, transID
 func (_m *ITransactionRepository) GetTransactionByID(ctx context.Context, transID string) (*entity.Transaction, error) {
 	ret := _m.Called(ctx, transID)
@@ -257,6 +373,65 @@ func (_c *ITransactionRepository_GetWalletByID_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// LockWallet provides a mock function with given fields: ctx, walletID
+func (_m *ITransactionRepository) LockWallet(ctx context.Context, walletID string) (*entity.Wallet, error) {
+	ret := _m.Called(ctx, walletID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockWallet")
+	}
+
+	var r0 *entity.Wallet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Wallet, error)); ok {
+		return rf(ctx, walletID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Wallet); ok {
+		r0 = rf(ctx, walletID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Wallet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionRepository_LockWallet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockWallet'
+type ITransactionRepository_LockWallet_Call struct {
+	*mock.Call
+}
+
+// LockWallet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID string
+func (_e *ITransactionRepository_Expecter) LockWallet(ctx interface{}, walletID interface{}) *ITransactionRepository_LockWallet_Call {
+	return &ITransactionRepository_LockWallet_Call{Call: _e.mock.On("LockWallet", ctx, walletID)}
+}
+
+func (_c *ITransactionRepository_LockWallet_Call) Run(run func(ctx context.Context, walletID string)) *ITransactionRepository_LockWallet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITransactionRepository_LockWallet_Call) Return(_a0 *entity.Wallet, _a1 error) *ITransactionRepository_LockWallet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionRepository_LockWallet_Call) RunAndReturn(run func(context.Context, string) (*entity.Wallet, error)) *ITransactionRepository_LockWallet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveTransaction provides a mock function with given fields: ctx, trans
 func (_m *ITransactionRepository) SaveTransaction(ctx context.Context, trans *entity.Transaction) error {
 	ret := _m.Called(ctx, trans)
@@ -304,17 +479,17 @@ func (_c *ITransactionRepository_SaveTransaction_Call) RunAndReturn(run func(con
 	return _c
 }
 
-// UpdateTransactionStatus provides a mock function with given fields: ctx, transID, status
-func (_m *ITransactionRepository) UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus) error {
-	ret := _m.Called(ctx, transID, status)
+// UpdateTransactionStatus provides a mock function with given fields: ctx, transID, status, version
+func (_m *ITransactionRepository) UpdateTransactionStatus(ctx context.Context, transID string, status entity.TransactionStatus, version int64) error {
+	ret := _m.Called(ctx, transID, status, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTransactionStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, entity.TransactionStatus) error); ok {
-		r0 = rf(ctx, transID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.TransactionStatus, int64) error); ok {
+		r0 = rf(ctx, transID, status, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -331,13 +506,14 @@ type ITransactionRepository_UpdateTransactionStatus_Call struct {
 //   - ctx context.Context
 //   - transID string
 //   - status entity.TransactionStatus
-func (_e *ITransactionRepository_Expecter) UpdateTransactionStatus(ctx interface{}, transID interface{}, status interface{}) *ITransactionRepository_UpdateTransactionStatus_Call {
-	return &ITransactionRepository_UpdateTransactionStatus_Call{Call: _e.mock.On("UpdateTransactionStatus", ctx, transID, status)}
+//   - version int64
+func (_e *ITransactionRepository_Expecter) UpdateTransactionStatus(ctx interface{}, transID interface{}, status interface{}, version interface{}) *ITransactionRepository_UpdateTransactionStatus_Call {
+	return &ITransactionRepository_UpdateTransactionStatus_Call{Call: _e.mock.On("UpdateTransactionStatus", ctx, transID, status, version)}
 }
 
-func (_c *ITransactionRepository_UpdateTransactionStatus_Call) Run(run func(ctx context.Context, transID string, status entity.TransactionStatus)) *ITransactionRepository_UpdateTransactionStatus_Call {
+func (_c *ITransactionRepository_UpdateTransactionStatus_Call) Run(run func(ctx context.Context, transID string, status entity.TransactionStatus, version int64)) *ITransactionRepository_UpdateTransactionStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(entity.TransactionStatus))
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.TransactionStatus), args[3].(int64))
 	})
 	return _c
 }
@@ -347,7 +523,7 @@ func (_c *ITransactionRepository_UpdateTransactionStatus_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *ITransactionRepository_UpdateTransactionStatus_Call) RunAndReturn(run func(context.Context, string, entity.TransactionStatus) error) *ITransactionRepository_UpdateTransactionStatus_Call {
+func (_c *ITransactionRepository_UpdateTransactionStatus_Call) RunAndReturn(run func(context.Context, string, entity.TransactionStatus, int64) error) *ITransactionRepository_UpdateTransactionStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5,7 +5,11 @@ package mocks
 import (
 	context "context"
 
+	entity "go-clean-template/internal/entity"
+
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ITransactionUseCase is an autogenerated mock type for the ITransactionUseCase type
@@ -21,6 +25,55 @@ func (_m *ITransactionUseCase) EXPECT() *ITransactionUseCase_Expecter {
 	return &ITransactionUseCase_Expecter{mock: &_m.Mock}
 }
 
+// ApproveTransaction provides a mock function with given fields: ctx, transID, approverID, version
+func (_m *ITransactionUseCase) ApproveTransaction(ctx context.Context, transID string, approverID string, version int64) error {
+	ret := _m.Called(ctx, transID, approverID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) error); ok {
+		r0 = rf(ctx, transID, approverID, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITransactionUseCase_ApproveTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveTransaction'
+type ITransactionUseCase_ApproveTransaction_Call struct {
+	*mock.Call
+}
+
+// ApproveTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transID string
+//   - approverID string
+//   - version int64
+func (_e *ITransactionUseCase_Expecter) ApproveTransaction(ctx interface{}, transID interface{}, approverID interface{}, version interface{}) *ITransactionUseCase_ApproveTransaction_Call {
+	return &ITransactionUseCase_ApproveTransaction_Call{Call: _e.mock.On("ApproveTransaction", ctx, transID, approverID, version)}
+}
+
+func (_c *ITransactionUseCase_ApproveTransaction_Call) Run(run func(ctx context.Context, transID string, approverID string, version int64)) *ITransactionUseCase_ApproveTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *ITransactionUseCase_ApproveTransaction_Call) Return(_a0 error) *ITransactionUseCase_ApproveTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITransactionUseCase_ApproveTransaction_Call) RunAndReturn(run func(context.Context, string, string, int64) error) *ITransactionUseCase_ApproveTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Deposit provides a mock function with given fields: ctx, walletID, accountID, amount, currency, note
 func (_m *ITransactionUseCase) Deposit(ctx context.Context, walletID string, accountID string, amount float64, currency string, note string) error {
 	ret := _m.Called(ctx, walletID, accountID, amount, currency, note)
@@ -72,17 +125,133 @@ func (_c *ITransactionUseCase_Deposit_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
-// PayTransaction provides a mock function with given fields: ctx, transID
-func (_m *ITransactionUseCase) PayTransaction(ctx context.Context, transID string) error {
+// ExpireApprovals provides a mock function with given fields: ctx, now
+func (_m *ITransactionUseCase) ExpireApprovals(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireApprovals")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionUseCase_ExpireApprovals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireApprovals'
+type ITransactionUseCase_ExpireApprovals_Call struct {
+	*mock.Call
+}
+
+// ExpireApprovals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *ITransactionUseCase_Expecter) ExpireApprovals(ctx interface{}, now interface{}) *ITransactionUseCase_ExpireApprovals_Call {
+	return &ITransactionUseCase_ExpireApprovals_Call{Call: _e.mock.On("ExpireApprovals", ctx, now)}
+}
+
+func (_c *ITransactionUseCase_ExpireApprovals_Call) Run(run func(ctx context.Context, now time.Time)) *ITransactionUseCase_ExpireApprovals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ITransactionUseCase_ExpireApprovals_Call) Return(_a0 int, _a1 error) *ITransactionUseCase_ExpireApprovals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionUseCase_ExpireApprovals_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *ITransactionUseCase_ExpireApprovals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransaction provides a mock function with given fields: ctx, transID
+func (_m *ITransactionUseCase) GetTransaction(ctx context.Context, transID string) (*entity.Transaction, error) {
 	ret := _m.Called(ctx, transID)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransaction")
+	}
+
+	var r0 *entity.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Transaction, error)); ok {
+		return rf(ctx, transID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Transaction); ok {
+		r0 = rf(ctx, transID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, transID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionUseCase_GetTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransaction'
+type ITransactionUseCase_GetTransaction_Call struct {
+	*mock.Call
+}
+
+// GetTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transID string
+func (_e *ITransactionUseCase_Expecter) GetTransaction(ctx interface{}, transID interface{}) *ITransactionUseCase_GetTransaction_Call {
+	return &ITransactionUseCase_GetTransaction_Call{Call: _e.mock.On("GetTransaction", ctx, transID)}
+}
+
+func (_c *ITransactionUseCase_GetTransaction_Call) Run(run func(ctx context.Context, transID string)) *ITransactionUseCase_GetTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITransactionUseCase_GetTransaction_Call) Return(_a0 *entity.Transaction, _a1 error) *ITransactionUseCase_GetTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionUseCase_GetTransaction_Call) RunAndReturn(run func(context.Context, string) (*entity.Transaction, error)) *ITransactionUseCase_GetTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PayTransaction provides a mock function with given fields: ctx, transID, version
+func (_m *ITransactionUseCase) PayTransaction(ctx context.Context, transID string, version int64) error {
+	ret := _m.Called(ctx, transID, version)
+
 	if len(ret) == 0 {
 		panic("no return value specified for PayTransaction")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, transID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, transID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -98,13 +267,14 @@ type ITransactionUseCase_PayTransaction_Call struct {
 // PayTransaction is a helper method to define mock.On call
 //   - ctx context.Context
 //   - transID string
-func (_e *ITransactionUseCase_Expecter) PayTransaction(ctx interface{}, transID interface{}) *ITransactionUseCase_PayTransaction_Call {
-	return &ITransactionUseCase_PayTransaction_Call{Call: _e.mock.On("PayTransaction", ctx, transID)}
+//   - version int64
+func (_e *ITransactionUseCase_Expecter) PayTransaction(ctx interface{}, transID interface{}, version interface{}) *ITransactionUseCase_PayTransaction_Call {
+	return &ITransactionUseCase_PayTransaction_Call{Call: _e.mock.On("PayTransaction", ctx, transID, version)}
 }
 
-func (_c *ITransactionUseCase_PayTransaction_Call) Run(run func(ctx context.Context, transID string)) *ITransactionUseCase_PayTransaction_Call {
+func (_c *ITransactionUseCase_PayTransaction_Call) Run(run func(ctx context.Context, transID string, version int64)) *ITransactionUseCase_PayTransaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
 	})
 	return _c
 }
@@ -114,22 +284,22 @@ func (_c *ITransactionUseCase_PayTransaction_Call) Return(_a0 error) *ITransacti
 	return _c
 }
 
-func (_c *ITransactionUseCase_PayTransaction_Call) RunAndReturn(run func(context.Context, string) error) *ITransactionUseCase_PayTransaction_Call {
+func (_c *ITransactionUseCase_PayTransaction_Call) RunAndReturn(run func(context.Context, string, int64) error) *ITransactionUseCase_PayTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Withdraw provides a mock function with given fields: ctx, walletID, accountID, amount, currency, note
-func (_m *ITransactionUseCase) Withdraw(ctx context.Context, walletID string, accountID string, amount float64, currency string, note string) error {
-	ret := _m.Called(ctx, walletID, accountID, amount, currency, note)
+// RejectTransaction provides a mock function with given fields: ctx, transID, approverID, reason, version
+func (_m *ITransactionUseCase) RejectTransaction(ctx context.Context, transID string, approverID string, reason string, version int64) error {
+	ret := _m.Called(ctx, transID, approverID, reason, version)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Withdraw")
+		panic("no return value specified for RejectTransaction")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64, string, string) error); ok {
-		r0 = rf(ctx, walletID, accountID, amount, currency, note)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int64) error); ok {
+		r0 = rf(ctx, transID, approverID, reason, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -137,6 +307,68 @@ func (_m *ITransactionUseCase) Withdraw(ctx context.Context, walletID string, ac
 	return r0
 }
 
+// ITransactionUseCase_RejectTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectTransaction'
+type ITransactionUseCase_RejectTransaction_Call struct {
+	*mock.Call
+}
+
+// RejectTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transID string
+//   - approverID string
+//   - reason string
+//   - version int64
+func (_e *ITransactionUseCase_Expecter) RejectTransaction(ctx interface{}, transID interface{}, approverID interface{}, reason interface{}, version interface{}) *ITransactionUseCase_RejectTransaction_Call {
+	return &ITransactionUseCase_RejectTransaction_Call{Call: _e.mock.On("RejectTransaction", ctx, transID, approverID, reason, version)}
+}
+
+func (_c *ITransactionUseCase_RejectTransaction_Call) Run(run func(ctx context.Context, transID string, approverID string, reason string, version int64)) *ITransactionUseCase_RejectTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *ITransactionUseCase_RejectTransaction_Call) Return(_a0 error) *ITransactionUseCase_RejectTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITransactionUseCase_RejectTransaction_Call) RunAndReturn(run func(context.Context, string, string, string, int64) error) *ITransactionUseCase_RejectTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Withdraw provides a mock function with given fields: ctx, requestedBy, walletID, accountID, amount, currency, note
+func (_m *ITransactionUseCase) Withdraw(ctx context.Context, requestedBy string, walletID string, accountID string, amount float64, currency string, note string) (*entity.Transaction, error) {
+	ret := _m.Called(ctx, requestedBy, walletID, accountID, amount, currency, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Withdraw")
+	}
+
+	var r0 *entity.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, float64, string, string) (*entity.Transaction, error)); ok {
+		return rf(ctx, requestedBy, walletID, accountID, amount, currency, note)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, float64, string, string) *entity.Transaction); ok {
+		r0 = rf(ctx, requestedBy, walletID, accountID, amount, currency, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, float64, string, string) error); ok {
+		r1 = rf(ctx, requestedBy, walletID, accountID, amount, currency, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ITransactionUseCase_Withdraw_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Withdraw'
 type ITransactionUseCase_Withdraw_Call struct {
 	*mock.Call
@@ -144,28 +376,29 @@ type ITransactionUseCase_Withdraw_Call struct {
 
 // Withdraw is a helper method to define mock.On call
 //   - ctx context.Context
+//   - requestedBy string
 //   - walletID string
 //   - accountID string
 //   - amount float64
 //   - currency string
 //   - note string
-func (_e *ITransactionUseCase_Expecter) Withdraw(ctx interface{}, walletID interface{}, accountID interface{}, amount interface{}, currency interface{}, note interface{}) *ITransactionUseCase_Withdraw_Call {
-	return &ITransactionUseCase_Withdraw_Call{Call: _e.mock.On("Withdraw", ctx, walletID, accountID, amount, currency, note)}
+func (_e *ITransactionUseCase_Expecter) Withdraw(ctx interface{}, requestedBy interface{}, walletID interface{}, accountID interface{}, amount interface{}, currency interface{}, note interface{}) *ITransactionUseCase_Withdraw_Call {
+	return &ITransactionUseCase_Withdraw_Call{Call: _e.mock.On("Withdraw", ctx, requestedBy, walletID, accountID, amount, currency, note)}
 }
 
-func (_c *ITransactionUseCase_Withdraw_Call) Run(run func(ctx context.Context, walletID string, accountID string, amount float64, currency string, note string)) *ITransactionUseCase_Withdraw_Call {
+func (_c *ITransactionUseCase_Withdraw_Call) Run(run func(ctx context.Context, requestedBy string, walletID string, accountID string, amount float64, currency string, note string)) *ITransactionUseCase_Withdraw_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(float64), args[4].(string), args[5].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(float64), args[5].(string), args[6].(string))
 	})
 	return _c
 }
 
-func (_c *ITransactionUseCase_Withdraw_Call) Return(_a0 error) *ITransactionUseCase_Withdraw_Call {
-	_c.Call.Return(_a0)
+func (_c *ITransactionUseCase_Withdraw_Call) Return(_a0 *entity.Transaction, _a1 error) *ITransactionUseCase_Withdraw_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ITransactionUseCase_Withdraw_Call) RunAndReturn(run func(context.Context, string, string, float64, string, string) error) *ITransactionUseCase_Withdraw_Call {
+func (_c *ITransactionUseCase_Withdraw_Call) RunAndReturn(run func(context.Context, string, string, string, float64, string, string) (*entity.Transaction, error)) *ITransactionUseCase_Withdraw_Call {
 	_c.Call.Return(run)
 	return _c
 }
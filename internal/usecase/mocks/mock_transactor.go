@@ -0,0 +1,83 @@
+// Code generated by mockery v2.39.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITransactor is an autogenerated mock type for the ITransactor type
+type ITransactor struct {
+	mock.Mock
+}
+
+type ITransactor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ITransactor) EXPECT() *ITransactor_Expecter {
+	return &ITransactor_Expecter{mock: &_m.Mock}
+}
+
+// WithinTransaction provides a mock function with given fields: ctx, fn
+func (_m *ITransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithinTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITransactor_WithinTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithinTransaction'
+type ITransactor_WithinTransaction_Call struct {
+	*mock.Call
+}
+
+// WithinTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(context.Context) error
+func (_e *ITransactor_Expecter) WithinTransaction(ctx interface{}, fn interface{}) *ITransactor_WithinTransaction_Call {
+	return &ITransactor_WithinTransaction_Call{Call: _e.mock.On("WithinTransaction", ctx, fn)}
+}
+
+func (_c *ITransactor_WithinTransaction_Call) Run(run func(ctx context.Context, fn func(context.Context) error)) *ITransactor_WithinTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *ITransactor_WithinTransaction_Call) Return(_a0 error) *ITransactor_WithinTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITransactor_WithinTransaction_Call) RunAndReturn(run func(context.Context, func(context.Context) error) error) *ITransactor_WithinTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewITransactor creates a new instance of ITransactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITransactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITransactor {
+	mock := &ITransactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/pkg/apperror"
+)
+
+// resumedPayoutBatchesLimit bounds the batches resumed at once
+const resumedPayoutBatchesLimit = 100
+
+// pspRejectedReason is the reason of an item whose payment was refused
+const pspRejectedReason = "payment service provider rejected the withdrawal"
+
+type PayoutUseCase struct {
+	repo         IPayoutRepository
+	reader       IPayoutRepository
+	transRepo    ITransactionRepository
+	transUseCase ITransactionUseCase
+	notifiers    []INotifier
+	idGenerator  IIDGenerator
+	concurrency  int
+	lease        time.Duration
+	now          func() time.Time
+}
+
+// NewPayoutUseCase creates a payout use case processing at most concurrency items of a batch at the same time
+func NewPayoutUseCase(repo IPayoutRepository, transRepo ITransactionRepository, transUseCase ITransactionUseCase,
+	concurrency int) *PayoutUseCase {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &PayoutUseCase{
+		repo:         repo,
+		transRepo:    transRepo,
+		transUseCase: transUseCase,
+		notifiers:    []INotifier{},
+		concurrency:  concurrency,
+		lease:        entity.PayoutBatchLease,
+		now:          time.Now,
+	}
+}
+
+// SetReader serves GetPayoutBatch from repo, like a replica: the progress polled by the clients tolerates the
+// replication lag. The processing keeps reading the batches from the primary.
+func (uc *PayoutUseCase) SetReader(repo IPayoutRepository) {
+	uc.reader = repo
+}
+
+func (uc *PayoutUseCase) readRepo() IPayoutRepository {
+	if uc.reader != nil {
+		return uc.reader
+	}
+	return uc.repo
+}
+
+func (uc *PayoutUseCase) SetNotifiers(notifiers ...INotifier) {
+	uc.notifiers = append(uc.notifiers, notifiers...)
+}
+
+// SetIDGenerator replaces the random UUIDs used as ids of new payout batches, their items are numbered after them
+func (uc *PayoutUseCase) SetIDGenerator(idGenerator IIDGenerator) {
+	uc.idGenerator = idGenerator
+}
+
+func (uc *PayoutUseCase) CreatePayoutBatch(ctx context.Context, createdBy string, items []*entity.PayoutItem) (*entity.PayoutBatch, error) {
+	batch, err := entity.NewPayoutBatch(newID(uc.idGenerator), createdBy, items)
+	if err != nil {
+		var itemsErr entity.PayoutItemsError
+		if errors.As(err, &itemsErr) {
+			return nil, apperror.ErrInvalidParams(err).WithInfo([]string(itemsErr))
+		}
+		return nil, apperror.ErrInvalidParams(err)
+	}
+
+	if err := uc.repo.SavePayoutBatch(ctx, batch); err != nil {
+		return nil, apperror.ErrCreate(err, "failed to create payout batch")
+	}
+	return batch, nil
+}
+
+func (uc *PayoutUseCase) GetPayoutBatch(ctx context.Context, batchID string) (*entity.PayoutBatch, error) {
+	return getPayoutBatch(ctx, uc.readRepo(), batchID)
+}
+
+func getPayoutBatch(ctx context.Context, repo IPayoutRepository, batchID string) (*entity.PayoutBatch, error) {
+	batch, err := repo.GetPayoutBatchByID(ctx, batchID)
+	if err != nil {
+		return nil, apperror.ErrGet(err, "failed to get payout batch by id")
+	}
+	if batch == nil {
+		return nil, apperror.New(apperror.PAYOUT_BATCH_NOT_FOUND)
+	}
+	return batch, nil
+}
+
+// ProcessPayoutBatch claims a batch and runs its pending items through Withdraw and PayTransaction. A failed item
+// is recorded with its reason and does not stop the others. Only errors persisting the results are returned.
+func (uc *PayoutUseCase) ProcessPayoutBatch(ctx context.Context, batchID string) error {
+	_, err := uc.processPayoutBatch(ctx, batchID)
+	return err
+}
+
+// ResumePayoutBatches processes the batches that are not completed and whose lease has ended, one after the other:
+// the ones never started because their processor stopped before, and the ones it left unfinished.
+func (uc *PayoutUseCase) ResumePayoutBatches(ctx context.Context, now time.Time) (int, error) {
+	batchIDs, err := uc.repo.ListClaimablePayoutBatchIDs(ctx, now, resumedPayoutBatchesLimit)
+	if err != nil {
+		return 0, apperror.ErrGet(err, "failed to list claimable payout batches")
+	}
+
+	var (
+		resumed int
+		errs    []error
+	)
+	for _, batchID := range batchIDs {
+		claimed, err := uc.processPayoutBatch(ctx, batchID)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if claimed {
+			resumed++
+		}
+	}
+	return resumed, errors.Join(errs...)
+}
+
+// processPayoutBatch returns false when the batch could not be claimed: it is completed or another processor
+// holds its lease. No item starts in the second half of the lease and an item has a quarter of the lease to
+// finish, so no item is still running when another processor takes the batch over; the batch is left
+// PROCESSING and resumed after the lease.
+func (uc *PayoutUseCase) processPayoutBatch(ctx context.Context, batchID string) (bool, error) {
+	batch, err := getPayoutBatch(ctx, uc.repo, batchID)
+	if err != nil {
+		return false, err
+	}
+	now := uc.now()
+	if !batch.Claimable(now) {
+		return false, nil
+	}
+
+	claimed, err := uc.repo.ClaimPayoutBatch(ctx, batch.ID, now, now.Add(uc.lease))
+	if err != nil {
+		return false, apperror.ErrUpdate(err, "failed to claim payout batch")
+	}
+	if !claimed {
+		return false, nil
+	}
+	batch.Status = entity.PayoutBatchStatusProcessing
+	deadline := now.Add(uc.lease / 2)
+
+	// the items of a wallet run one after the other, each worker takes all the items of a wallet
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		errs       []error
+		unfinished bool
+		queue      = make(chan []*entity.PayoutItem)
+	)
+	for i := 0; i < uc.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for items := range queue {
+				for _, item := range items {
+					if uc.now().After(deadline) {
+						mu.Lock()
+						unfinished = true
+						mu.Unlock()
+						break
+					}
+					if err := uc.processItem(ctx, batch.CreatedBy, item, uc.lease/4); err != nil {
+						mu.Lock()
+						errs = append(errs, err)
+						mu.Unlock()
+					}
+				}
+			}
+		}()
+	}
+	for _, items := range pendingItemsByWallet(batch) {
+		queue <- items
+	}
+	close(queue)
+	wg.Wait()
+
+	if unfinished {
+		return true, errors.Join(errs...)
+	}
+
+	if err := uc.repo.UpdatePayoutBatchStatus(ctx, batch.ID, entity.PayoutBatchStatusCompleted); err != nil {
+		errs = append(errs, apperror.ErrUpdate(err, "failed to update payout batch status"))
+	} else {
+		batch.Status = entity.PayoutBatchStatusCompleted
+	}
+
+	progress := batch.Progress()
+	uc.notify(ctx, fmt.Sprintf("Payout batch %s completed: %d succeeded, %d failed, %d awaiting approval",
+		batch.ID, progress.Succeeded, progress.Failed, progress.AwaitingApproval))
+
+	return true, errors.Join(errs...)
+}
+
+// pendingItemsByWallet groups the pending items by wallet, in the order of their first item
+func pendingItemsByWallet(batch *entity.PayoutBatch) [][]*entity.PayoutItem {
+	var (
+		groups [][]*entity.PayoutItem
+		index  = map[string]int{}
+	)
+	for _, item := range batch.Items {
+		if item.Status != entity.PayoutItemStatusPending {
+			continue
+		}
+		i, ok := index[item.WalletID]
+		if !ok {
+			i = len(groups)
+			index[item.WalletID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], item)
+	}
+	return groups
+}
+
+// processItem withdraws and pays one item on behalf of the creator of its batch within timeout, and stores its
+// result. The withdrawal has the id of the item: an item resumed after its processor stopped finds the
+// transaction created before and only pays it if it is not paid yet.
+func (uc *PayoutUseCase) processItem(ctx context.Context, createdBy string, item *entity.PayoutItem,
+	timeout time.Duration) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			_ = item.ToFailed(item.TransactionID, fmt.Sprintf("unexpected error: %v", r))
+			err = uc.updateItem(ctx, item)
+		}
+	}()
+
+	itemCtx, cancel := context.WithTimeout(WithTransactionID(ctx, item.ID), timeout)
+	defer cancel()
+
+	trans, err := uc.transUseCase.Withdraw(itemCtx, createdBy, item.WalletID, item.AccountID, item.Amount,
+		item.Currency, item.Note)
+	switch {
+	case err != nil:
+		_ = item.ToFailed("", err.Error())
+	default:
+		uc.settle(itemCtx, item, trans)
+	}
+
+	// the result is stored even when the item ran out of time
+	return uc.updateItem(ctx, item)
+}
+
+// settle moves the item to the status of its transaction, and pays the transaction first when it is new. A
+// withdrawal approved since it was created is still left to the approval workflow.
+func (uc *PayoutUseCase) settle(ctx context.Context, item *entity.PayoutItem, trans *entity.Transaction) {
+	switch trans.Status {
+	case entity.TransactionStatusNew:
+		uc.pay(ctx, item, trans.ID, trans.Version)
+	case entity.TransactionStatusAwaitingApproval, entity.TransactionStatusApproved:
+		_ = item.ToAwaitingApproval(trans.ID)
+	case entity.TransactionStatusSuccessful:
+		_ = item.ToSucceeded(trans.ID)
+	case entity.TransactionStatusFailed:
+		_ = item.ToFailed(trans.ID, pspRejectedReason)
+	default:
+		_ = item.ToFailed(trans.ID, fmt.Sprintf("withdrawal is %s", trans.Status))
+	}
+}
+
+func (uc *PayoutUseCase) pay(ctx context.Context, item *entity.PayoutItem, transID string, version int64) {
+	if err := uc.transUseCase.PayTransaction(ctx, transID, version); err != nil {
+		_ = item.ToFailed(transID, err.Error())
+		return
+	}
+
+	// PayTransaction records a rejected payment on the transaction instead of returning an error
+	trans, err := uc.transRepo.GetTransactionByID(ctx, transID)
+	if err != nil {
+		_ = item.ToFailed(transID, apperror.ErrGet(err, "failed to get transaction by id").Error())
+		return
+	}
+	if trans == nil || trans.Status != entity.TransactionStatusSuccessful {
+		_ = item.ToFailed(transID, pspRejectedReason)
+		return
+	}
+	_ = item.ToSucceeded(transID)
+}
+
+func (uc *PayoutUseCase) updateItem(ctx context.Context, item *entity.PayoutItem) error {
+	if err := uc.repo.UpdatePayoutItem(ctx, item); err != nil {
+		return apperror.ErrUpdate(err, fmt.Sprintf("failed to update payout item %s", item.ID))
+	}
+	return nil
+}
+
+func (uc *PayoutUseCase) notify(ctx context.Context, message string) {
+	for _, n := range uc.notifiers {
+		n.SendNotification(ctx, message)
+	}
+}
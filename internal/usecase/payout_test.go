@@ -0,0 +1,430 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	mocks2 "go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewPayoutUseCase(t *testing.T) {
+	repo := mocks2.NewIPayoutRepository(t)
+	transRepo := mocks2.NewITransactionRepository(t)
+	transUseCase := mocks2.NewITransactionUseCase(t)
+	want := &PayoutUseCase{
+		repo:         repo,
+		transRepo:    transRepo,
+		transUseCase: transUseCase,
+		notifiers:    []INotifier{},
+		concurrency:  1,
+		lease:        entity.PayoutBatchLease,
+	}
+
+	got := NewPayoutUseCase(repo, transRepo, transUseCase, 0)
+	assert.NotNil(t, got.now)
+	got.now = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPayoutUseCase() = %v, want %v", got, want)
+	}
+}
+
+func TestPayoutUseCase_SetIDGenerator(t *testing.T) {
+	repo := mocks2.NewIPayoutRepository(t)
+	idGenerator := mocks2.NewIIDGenerator(t)
+	uc := PayoutUseCase{repo: repo}
+	uc.SetIDGenerator(idGenerator)
+
+	t.Run("create uses the generated id", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		batchID := "0190a4e5-7c1b-7d4e-9f3a-2b6c8d0e1f2a"
+		idGenerator.EXPECT().NewID().Return(batchID).Once()
+		repo.EXPECT().SavePayoutBatch(ctx, mock.MatchedBy(func(b *entity.PayoutBatch) bool {
+			return b.ID == batchID
+		})).Return(nil).Once()
+
+		//Act
+		got, err := uc.CreatePayoutBatch(ctx, "u_00001", []*entity.PayoutItem{
+			{WalletID: "w_00001", AccountID: "a_00001", Amount: 1000, Currency: "VND"},
+		})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, batchID+"-1", got.Items[0].ID)
+	})
+}
+
+func TestPayoutUseCase_CreatePayoutBatch(t *testing.T) {
+	repo := mocks2.NewIPayoutRepository(t)
+	uc := PayoutUseCase{repo: repo}
+
+	t.Run("success", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		items := []*entity.PayoutItem{
+			{WalletID: "w_00001", AccountID: "a_00001", Amount: 1000, Currency: "VND"},
+			{WalletID: "w_00002", AccountID: "a_00002", Amount: 2000, Currency: "VND"},
+		}
+		repo.EXPECT().SavePayoutBatch(ctx, mock.MatchedBy(func(b *entity.PayoutBatch) bool {
+			return b.CreatedBy == "u_00001" && len(b.Items) == 2 && b.Status == entity.PayoutBatchStatusPending
+		})).Return(nil).Once()
+
+		//Act
+		got, err := uc.CreatePayoutBatch(ctx, "u_00001", items)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.NotEmpty(t, got.ID)
+		assert.Equal(t, got.ID, got.Items[0].BatchID)
+	})
+
+	t.Run("invalid items are reported in info", func(t *testing.T) {
+		//Arrange
+		items := []*entity.PayoutItem{
+			{WalletID: "w_00001", AccountID: "a_00001", Amount: 0},
+		}
+
+		//Act
+		got, err := uc.CreatePayoutBatch(context.Background(), "u_00001", items)
+
+		//Assert
+		assert.Nil(t, got)
+		itemsErr := entity.PayoutItemsError{"line 1: amount must be greater than 0"}
+		assert.Equal(t, apperror.ErrInvalidParams(itemsErr).WithInfo([]string(itemsErr)), err)
+	})
+
+	t.Run("failed to create payout batch", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errDB := fmt.Errorf("unexpected error")
+		repo.EXPECT().SavePayoutBatch(ctx, mock.Anything).Return(errDB).Once()
+
+		//Act
+		_, err := uc.CreatePayoutBatch(ctx, "u_00001", []*entity.PayoutItem{
+			{WalletID: "w_00001", AccountID: "a_00001", Amount: 1000},
+		})
+
+		//Assert
+		assert.Equal(t, apperror.ErrCreate(errDB, "failed to create payout batch"), err)
+	})
+}
+
+func TestPayoutUseCase_GetPayoutBatch(t *testing.T) {
+	repo := mocks2.NewIPayoutRepository(t)
+	uc := PayoutUseCase{repo: repo}
+
+	t.Run("payout batch not found", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo.EXPECT().GetPayoutBatchByID(ctx, "b_00001").Return(nil, nil).Once()
+
+		//Act
+		got, err := uc.GetPayoutBatch(ctx, "b_00001")
+
+		//Assert
+		assert.Nil(t, got)
+		assert.Equal(t, apperror.New(apperror.PAYOUT_BATCH_NOT_FOUND), err)
+	})
+}
+
+func newPayoutBatchForTest(t *testing.T, amounts ...float64) *entity.PayoutBatch {
+	items := make([]*entity.PayoutItem, 0, len(amounts))
+	for i, amount := range amounts {
+		items = append(items, &entity.PayoutItem{
+			WalletID:  fmt.Sprintf("w_%05d", i+1),
+			AccountID: "a_00001",
+			Amount:    amount,
+			Currency:  "VND",
+		})
+	}
+	batch, err := entity.NewPayoutBatch("b_00001", "u_00001", items)
+	assert.NoError(t, err)
+	return batch
+}
+
+// itemCtx matches the context of a payout item, which gives the id of the item to its withdrawal
+func itemCtx(itemID string) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		transID, _ := ctx.Value(transactionIDKey{}).(string)
+		_, hasDeadline := ctx.Deadline()
+		return transID == itemID && hasDeadline
+	})
+}
+
+func TestPayoutUseCase_ProcessPayoutBatch(t *testing.T) {
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	newBatch := newPayoutBatchForTest
+
+	t.Run("failed items do not stop the batch", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo := mocks2.NewIPayoutRepository(t)
+		transRepo := mocks2.NewITransactionRepository(t)
+		transUseCase := mocks2.NewITransactionUseCase(t)
+		notifier := mocks2.NewINotifier(t)
+		uc := NewPayoutUseCase(repo, transRepo, transUseCase, 2)
+		uc.now = func() time.Time { return now }
+		uc.SetNotifiers(notifier)
+		batch := newBatch(t, 1000, 2000, 3000, 60000000)
+
+		repo.EXPECT().GetPayoutBatchByID(ctx, batch.ID).Return(batch, nil).Once()
+		repo.EXPECT().ClaimPayoutBatch(ctx, batch.ID, now, now.Add(entity.PayoutBatchLease)).Return(true, nil).Once()
+
+		// item 1 succeeds
+		transUseCase.EXPECT().Withdraw(itemCtx("b_00001-1"), "u_00001", "w_00001", "a_00001", 1000.0, "VND", "").
+			Return(&entity.Transaction{ID: "t_00001", Status: entity.TransactionStatusNew, Version: entity.InitialVersion}, nil).Once()
+		transUseCase.EXPECT().PayTransaction(itemCtx("b_00001-1"), "t_00001", entity.InitialVersion).Return(nil).Once()
+		transRepo.EXPECT().GetTransactionByID(itemCtx("b_00001-1"), "t_00001").
+			Return(&entity.Transaction{ID: "t_00001", Status: entity.TransactionStatusSuccessful}, nil).Once()
+		// item 2 cannot be withdrawn
+		transUseCase.EXPECT().Withdraw(itemCtx("b_00001-2"), "u_00001", "w_00002", "a_00001", 2000.0, "VND", "").
+			Return(nil, apperror.New(apperror.INSUFFICIENT_FUNDS)).Once()
+		// item 3 is rejected by the payment service provider
+		transUseCase.EXPECT().Withdraw(itemCtx("b_00001-3"), "u_00001", "w_00003", "a_00001", 3000.0, "VND", "").
+			Return(&entity.Transaction{ID: "t_00003", Status: entity.TransactionStatusNew, Version: entity.InitialVersion}, nil).Once()
+		transUseCase.EXPECT().PayTransaction(itemCtx("b_00001-3"), "t_00003", entity.InitialVersion).Return(nil).Once()
+		transRepo.EXPECT().GetTransactionByID(itemCtx("b_00001-3"), "t_00003").
+			Return(&entity.Transaction{ID: "t_00003", Status: entity.TransactionStatusFailed}, nil).Once()
+		// item 4 needs approval
+		transUseCase.EXPECT().Withdraw(itemCtx("b_00001-4"), "u_00001", "w_00004", "a_00001", 60000000.0, "VND", "").
+			Return(&entity.Transaction{ID: "t_00004", Status: entity.TransactionStatusAwaitingApproval}, nil).Once()
+
+		repo.EXPECT().UpdatePayoutItem(ctx, mock.Anything).Return(nil).Times(4)
+		repo.EXPECT().UpdatePayoutBatchStatus(ctx, batch.ID, entity.PayoutBatchStatusCompleted).Return(nil).Once()
+		notifier.EXPECT().SendNotification(ctx, mock.AnythingOfType("string")).Return().Once()
+
+		//Act
+		err := uc.ProcessPayoutBatch(ctx, batch.ID)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PayoutBatchStatusCompleted, batch.Status)
+		assert.Equal(t, entity.PayoutProgress{Total: 4, Succeeded: 1, Failed: 2, AwaitingApproval: 1}, batch.Progress())
+		assert.Equal(t, entity.PayoutItemStatusSucceeded, batch.Items[0].Status)
+		assert.Equal(t, "INSUFFICIENT_FUNDS: insufficient balance", batch.Items[1].Reason)
+		assert.Equal(t, "t_00003", batch.Items[2].TransactionID)
+		assert.Equal(t, entity.PayoutItemStatusAwaitingApproval, batch.Items[3].Status)
+	})
+
+	t.Run("resumed items are not paid again", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo := mocks2.NewIPayoutRepository(t)
+		transUseCase := mocks2.NewITransactionUseCase(t)
+		uc := NewPayoutUseCase(repo, nil, transUseCase, 1)
+		uc.now = func() time.Time { return now }
+		batch := newBatch(t, 1000, 2000)
+		batch.Status = entity.PayoutBatchStatusProcessing
+
+		repo.EXPECT().GetPayoutBatchByID(ctx, batch.ID).Return(batch, nil).Once()
+		repo.EXPECT().ClaimPayoutBatch(ctx, batch.ID, now, now.Add(entity.PayoutBatchLease)).Return(true, nil).Once()
+		// the withdrawals of the items were created and paid before the processor stopped
+		transUseCase.EXPECT().Withdraw(itemCtx("b_00001-1"), "u_00001", "w_00001", "a_00001", 1000.0, "VND", "").
+			Return(&entity.Transaction{ID: "b_00001-1", Status: entity.TransactionStatusSuccessful}, nil).Once()
+		transUseCase.EXPECT().Withdraw(itemCtx("b_00001-2"), "u_00001", "w_00002", "a_00001", 2000.0, "VND", "").
+			Return(&entity.Transaction{ID: "b_00001-2", Status: entity.TransactionStatusFailed}, nil).Once()
+		repo.EXPECT().UpdatePayoutItem(ctx, mock.Anything).Return(nil).Times(2)
+		repo.EXPECT().UpdatePayoutBatchStatus(ctx, batch.ID, entity.PayoutBatchStatusCompleted).Return(nil).Once()
+
+		//Act
+		err := uc.ProcessPayoutBatch(ctx, batch.ID)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PayoutItemStatusSucceeded, batch.Items[0].Status)
+		assert.Equal(t, entity.PayoutItemStatusFailed, batch.Items[1].Status)
+		assert.Equal(t, "payment service provider rejected the withdrawal", batch.Items[1].Reason)
+	})
+
+	t.Run("completed batch is not processed again", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo := mocks2.NewIPayoutRepository(t)
+		uc := NewPayoutUseCase(repo, nil, nil, 2)
+		batch := newBatch(t, 1000)
+		batch.Status = entity.PayoutBatchStatusCompleted
+		repo.EXPECT().GetPayoutBatchByID(ctx, batch.ID).Return(batch, nil).Once()
+
+		//Act
+		err := uc.ProcessPayoutBatch(ctx, batch.ID)
+
+		//Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed to update payout item", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo := mocks2.NewIPayoutRepository(t)
+		transUseCase := mocks2.NewITransactionUseCase(t)
+		uc := NewPayoutUseCase(repo, nil, transUseCase, 2)
+		uc.now = func() time.Time { return now }
+		batch := newBatch(t, 1000)
+		errDB := fmt.Errorf("unexpected error")
+
+		repo.EXPECT().GetPayoutBatchByID(ctx, batch.ID).Return(batch, nil).Once()
+		repo.EXPECT().ClaimPayoutBatch(ctx, batch.ID, now, now.Add(entity.PayoutBatchLease)).Return(true, nil).Once()
+		repo.EXPECT().UpdatePayoutBatchStatus(ctx, batch.ID, entity.PayoutBatchStatusCompleted).Return(nil).Once()
+		transUseCase.EXPECT().Withdraw(itemCtx("b_00001-1"), "u_00001", "w_00001", "a_00001", 1000.0, "VND", "").
+			Return(nil, apperror.New(apperror.WALLET_NOT_FOUND)).Once()
+		repo.EXPECT().UpdatePayoutItem(ctx, batch.Items[0]).Return(errDB).Once()
+
+		//Act
+		err := uc.ProcessPayoutBatch(ctx, batch.ID)
+
+		//Assert
+		assert.EqualError(t, err, apperror.ErrUpdate(errDB, "failed to update payout item b_00001-1").Error())
+	})
+
+	t.Run("batch claimed by another processor is skipped", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo := mocks2.NewIPayoutRepository(t)
+		uc := NewPayoutUseCase(repo, nil, nil, 2)
+		uc.now = func() time.Time { return now }
+		batch := newBatch(t, 1000)
+		repo.EXPECT().GetPayoutBatchByID(ctx, batch.ID).Return(batch, nil).Once()
+		repo.EXPECT().ClaimPayoutBatch(ctx, batch.ID, now, now.Add(entity.PayoutBatchLease)).Return(false, nil).Once()
+
+		//Act
+		err := uc.ProcessPayoutBatch(ctx, batch.ID)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PayoutBatchStatusPending, batch.Status)
+	})
+
+	t.Run("items of a wallet run one after the other", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo := mocks2.NewIPayoutRepository(t)
+		transUseCase := mocks2.NewITransactionUseCase(t)
+		uc := NewPayoutUseCase(repo, nil, transUseCase, 4)
+		uc.now = func() time.Time { return now }
+		batch := newBatch(t, 1000, 2000, 3000, 4000)
+		for _, item := range batch.Items {
+			item.WalletID = "w_00001"
+		}
+		var (
+			mu      sync.Mutex
+			running int
+			maxRun  int
+		)
+
+		repo.EXPECT().GetPayoutBatchByID(ctx, batch.ID).Return(batch, nil).Once()
+		repo.EXPECT().ClaimPayoutBatch(ctx, batch.ID, now, now.Add(entity.PayoutBatchLease)).Return(true, nil).Once()
+		transUseCase.EXPECT().Withdraw(mock.Anything, "u_00001", "w_00001", "a_00001", mock.Anything, "VND", "").
+			RunAndReturn(func(context.Context, string, string, string, float64, string, string) (*entity.Transaction, error) {
+				mu.Lock()
+				running++
+				maxRun = max(maxRun, running)
+				mu.Unlock()
+				time.Sleep(time.Millisecond)
+				mu.Lock()
+				running--
+				mu.Unlock()
+				return nil, apperror.New(apperror.INSUFFICIENT_FUNDS)
+			}).Times(4)
+		repo.EXPECT().UpdatePayoutItem(ctx, mock.Anything).Return(nil).Times(4)
+		repo.EXPECT().UpdatePayoutBatchStatus(ctx, batch.ID, entity.PayoutBatchStatusCompleted).Return(nil).Once()
+
+		//Act
+		err := uc.ProcessPayoutBatch(ctx, batch.ID)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, maxRun)
+	})
+
+	t.Run("no item starts in the second half of the lease", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo := mocks2.NewIPayoutRepository(t)
+		transUseCase := mocks2.NewITransactionUseCase(t)
+		uc := NewPayoutUseCase(repo, nil, transUseCase, 1)
+		clock := now
+		uc.now = func() time.Time { return clock }
+		batch := newBatch(t, 1000, 2000)
+		batch.Items[1].WalletID = "w_00001"
+
+		repo.EXPECT().GetPayoutBatchByID(ctx, batch.ID).Return(batch, nil).Once()
+		repo.EXPECT().ClaimPayoutBatch(ctx, batch.ID, now, now.Add(entity.PayoutBatchLease)).Return(true, nil).Once()
+		transUseCase.EXPECT().Withdraw(itemCtx("b_00001-1"), "u_00001", "w_00001", "a_00001", 1000.0, "VND", "").
+			RunAndReturn(func(context.Context, string, string, string, float64, string, string) (*entity.Transaction, error) {
+				clock = now.Add(entity.PayoutBatchLease/2 + time.Second)
+				return nil, apperror.New(apperror.INSUFFICIENT_FUNDS)
+			}).Once()
+		repo.EXPECT().UpdatePayoutItem(ctx, batch.Items[0]).Return(nil).Once()
+
+		//Act
+		err := uc.ProcessPayoutBatch(ctx, batch.ID)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PayoutBatchStatusProcessing, batch.Status)
+		assert.Equal(t, entity.PayoutItemStatusPending, batch.Items[1].Status)
+	})
+}
+
+func TestPayoutUseCase_ResumePayoutBatches(t *testing.T) {
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("processes the claimable batches it claims", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo := mocks2.NewIPayoutRepository(t)
+		transUseCase := mocks2.NewITransactionUseCase(t)
+		uc := NewPayoutUseCase(repo, nil, transUseCase, 1)
+		uc.now = func() time.Time { return now }
+		resumed := newPayoutBatchForTest(t, 1000)
+		resumed.Status = entity.PayoutBatchStatusProcessing
+		resumed.ClaimedUntil = now.Add(-time.Minute)
+		taken := newPayoutBatchForTest(t, 2000)
+		taken.ID = "b_00002"
+
+		repo.EXPECT().ListClaimablePayoutBatchIDs(ctx, now, resumedPayoutBatchesLimit).
+			Return([]string{resumed.ID, taken.ID}, nil).Once()
+		repo.EXPECT().GetPayoutBatchByID(ctx, resumed.ID).Return(resumed, nil).Once()
+		repo.EXPECT().ClaimPayoutBatch(ctx, resumed.ID, now, now.Add(entity.PayoutBatchLease)).Return(true, nil).Once()
+		transUseCase.EXPECT().Withdraw(itemCtx("b_00001-1"), "u_00001", "w_00001", "a_00001", 1000.0, "VND", "").
+			Return(nil, apperror.New(apperror.INSUFFICIENT_FUNDS)).Once()
+		repo.EXPECT().UpdatePayoutItem(ctx, resumed.Items[0]).Return(nil).Once()
+		repo.EXPECT().UpdatePayoutBatchStatus(ctx, resumed.ID, entity.PayoutBatchStatusCompleted).Return(nil).Once()
+		// another processor claimed it in between
+		repo.EXPECT().GetPayoutBatchByID(ctx, taken.ID).Return(taken, nil).Once()
+		repo.EXPECT().ClaimPayoutBatch(ctx, taken.ID, now, now.Add(entity.PayoutBatchLease)).Return(false, nil).Once()
+
+		//Act
+		got, err := uc.ResumePayoutBatches(ctx, now)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got)
+		assert.Equal(t, entity.PayoutBatchStatusCompleted, resumed.Status)
+	})
+
+	t.Run("failed to list claimable payout batches", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo := mocks2.NewIPayoutRepository(t)
+		uc := NewPayoutUseCase(repo, nil, nil, 1)
+		errDB := fmt.Errorf("unexpected error")
+		repo.EXPECT().ListClaimablePayoutBatchIDs(ctx, now, resumedPayoutBatchesLimit).Return(nil, errDB).Once()
+
+		//Act
+		got, err := uc.ResumePayoutBatches(ctx, now)
+
+		//Assert
+		assert.Equal(t, 0, got)
+		assert.Equal(t, apperror.ErrGet(errDB, "failed to list claimable payout batches"), err)
+	})
+}
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/pkg/apperror"
+)
+
+// dueSchedulesBatchSize limits how many schedules are loaded per scheduler tick
+const dueSchedulesBatchSize = 100
+
+type ScheduleUseCase struct {
+	repo         IScheduleRepository
+	reader       IScheduleRepository
+	transRepo    ITransactionRepository
+	transUseCase ITransactionUseCase
+	notifiers    []INotifier
+	idGenerator  IIDGenerator
+	now          func() time.Time
+}
+
+func NewScheduleUseCase(repo IScheduleRepository, transRepo ITransactionRepository, transUseCase ITransactionUseCase) *ScheduleUseCase {
+	return &ScheduleUseCase{
+		repo:         repo,
+		transRepo:    transRepo,
+		transUseCase: transUseCase,
+		notifiers:    []INotifier{},
+		now:          time.Now,
+	}
+}
+
+// SetReader serves GetSchedule and ListSchedules from repo, like a replica: the endpoints tolerate the
+// replication lag. The updates and the runs keep reading the schedules from the primary.
+func (uc *ScheduleUseCase) SetReader(repo IScheduleRepository) {
+	uc.reader = repo
+}
+
+func (uc *ScheduleUseCase) readRepo() IScheduleRepository {
+	if uc.reader != nil {
+		return uc.reader
+	}
+	return uc.repo
+}
+
+func (uc *ScheduleUseCase) SetNotifiers(notifiers ...INotifier) {
+	uc.notifiers = append(uc.notifiers, notifiers...)
+}
+
+// SetIDGenerator replaces the random UUIDs used as ids of new schedules
+func (uc *ScheduleUseCase) SetIDGenerator(idGenerator IIDGenerator) {
+	uc.idGenerator = idGenerator
+}
+
+// CreateSchedule starts a schedule at its first occurrence from now, like UpdateSchedule the occurrences before now
+// are not backfilled. A one-off schedule in the past is refused.
+func (uc *ScheduleUseCase) CreateSchedule(ctx context.Context, walletID string, accountID string, amount float64, currency string,
+	transKind entity.TransactionKind, note string, frequency entity.ScheduleFrequency, startAt time.Time) (*entity.Schedule, error) {
+	schedule, err := entity.NewSchedule(newID(uc.idGenerator), walletID, accountID, amount, currency, transKind, note, frequency, startAt)
+	if err != nil {
+		return nil, apperror.ErrInvalidParams(err)
+	}
+	schedule.SkipMissed(uc.now())
+	if schedule.Status != entity.ScheduleStatusActive {
+		return nil, apperror.ErrInvalidParams(fmt.Errorf("start_at of a one-off schedule must not be in the past"))
+	}
+
+	if err := uc.checkWalletAndAccount(ctx, walletID, accountID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.SaveSchedule(ctx, schedule); err != nil {
+		return nil, apperror.ErrCreate(err, "failed to create schedule")
+	}
+	return schedule, nil
+}
+
+func (uc *ScheduleUseCase) GetSchedule(ctx context.Context, scheduleID string) (*entity.Schedule, error) {
+	return getSchedule(ctx, uc.readRepo(), scheduleID)
+}
+
+func getSchedule(ctx context.Context, repo IScheduleRepository, scheduleID string) (*entity.Schedule, error) {
+	schedule, err := repo.GetScheduleByID(ctx, scheduleID)
+	if err != nil {
+		return nil, apperror.ErrGet(err, "failed to get schedule by id")
+	}
+	if schedule == nil {
+		return nil, apperror.New(apperror.SCHEDULE_NOT_FOUND)
+	}
+	return schedule, nil
+}
+
+func (uc *ScheduleUseCase) ListSchedules(ctx context.Context, walletID string) ([]*entity.Schedule, error) {
+	schedules, err := uc.readRepo().ListSchedulesByWalletID(ctx, walletID)
+	if err != nil {
+		return nil, apperror.ErrGet(err, "failed to list schedules by wallet id")
+	}
+	return schedules, nil
+}
+
+// UpdateSchedule replaces the amount, note and recurrence of a schedule and keeps its status. A new recurrence
+// restarts from startAt, the occurrences before now are not backfilled. A version of 0 updates any version.
+func (uc *ScheduleUseCase) UpdateSchedule(ctx context.Context, scheduleID string, amount float64, note string,
+	frequency entity.ScheduleFrequency, startAt time.Time, version int64) (*entity.Schedule, error) {
+	schedule, err := getSchedule(ctx, uc.repo, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScheduleVersion(schedule, version); err != nil {
+		return nil, err
+	}
+
+	if err := schedule.Update(amount, note, frequency, startAt, uc.now()); err != nil {
+		return nil, apperror.ErrInvalidParams(err)
+	}
+
+	if err := uc.updateSchedule(ctx, schedule); err != nil {
+		return nil, scheduleWriteError(err, apperror.ErrUpdate, "failed to update schedule")
+	}
+	return schedule, nil
+}
+
+// DeleteSchedule deletes a schedule and its runs. A version of 0 deletes any version.
+func (uc *ScheduleUseCase) DeleteSchedule(ctx context.Context, scheduleID string, version int64) error {
+	schedule, err := getSchedule(ctx, uc.repo, scheduleID)
+	if err != nil {
+		return err
+	}
+	if err := checkScheduleVersion(schedule, version); err != nil {
+		return err
+	}
+
+	if err := uc.repo.DeleteSchedule(ctx, scheduleID, schedule.Version); err != nil {
+		return scheduleWriteError(err, apperror.ErrDelete, "failed to delete schedule")
+	}
+	return nil
+}
+
+// updateSchedule stores the schedule at its version and moves it to the next version
+func (uc *ScheduleUseCase) updateSchedule(ctx context.Context, schedule *entity.Schedule) error {
+	if err := uc.repo.UpdateSchedule(ctx, schedule); err != nil {
+		return err
+	}
+	schedule.Version++
+	return nil
+}
+
+// checkScheduleVersion fails with a conflict when the schedule is not at the version the caller expects, 0 expects any
+func checkScheduleVersion(schedule *entity.Schedule, version int64) error {
+	if version != 0 && schedule.Version != version {
+		return apperror.New(apperror.VERSION_MISMATCH).WithRaw(entity.NewVersionConflictError("schedule", schedule.ID, version))
+	}
+	return nil
+}
+
+// scheduleWriteError maps the error of an update or a delete of a schedule, fail wraps the unexpected ones
+func scheduleWriteError(err error, fail func(error, string) *apperror.Error, msg string) error {
+	if errors.Is(err, entity.ErrNotFound) {
+		return apperror.New(apperror.SCHEDULE_NOT_FOUND)
+	}
+	if errors.Is(err, entity.ErrVersionConflict) {
+		return apperror.New(apperror.VERSION_CONFLICT).WithRaw(err)
+	}
+	return fail(err, msg)
+}
+
+// RunDueSchedules materializes every occurrence that is due at now, including the ones missed while
+// the scheduler was down. It returns the number of materialized transactions. A failing schedule
+// does not stop the others; their errors are joined.
+func (uc *ScheduleUseCase) RunDueSchedules(ctx context.Context, now time.Time) (int, error) {
+	schedules, err := uc.repo.ListDueSchedules(ctx, now, dueSchedulesBatchSize)
+	if err != nil {
+		return 0, apperror.ErrGet(err, "failed to list due schedules")
+	}
+
+	var (
+		materialized int
+		errs         []error
+	)
+	for _, schedule := range schedules {
+		for schedule.IsDue(now) {
+			ok, err := uc.runOccurrence(ctx, schedule, now)
+			if errors.Is(err, entity.ErrScheduleRunClaimed) {
+				// another scheduler is running the occurrence and advances the schedule after it
+				break
+			}
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			if ok {
+				materialized++
+			}
+
+			schedule.Advance()
+			if err := uc.updateSchedule(ctx, schedule); err != nil {
+				errs = append(errs, apperror.ErrUpdate(err, "failed to advance schedule"))
+				break
+			}
+		}
+	}
+
+	return materialized, errors.Join(errs...)
+}
+
+// runOccurrence claims the current occurrence of a schedule and creates its transaction.
+// It reports whether a transaction was created; an occurrence already run is left alone. A run left pending by a
+// scheduler that stopped is claimed again once its lease ended. The transaction has the id of the run, so a run
+// claimed again finds the transaction created before its result was recorded instead of creating another.
+func (uc *ScheduleUseCase) runOccurrence(ctx context.Context, schedule *entity.Schedule, now time.Time) (bool, error) {
+	run := entity.NewScheduleRun(schedule.ID, schedule.NextRunAt)
+	run.ClaimedUntil = now.Add(entity.ScheduleRunLease)
+	claimed, err := uc.repo.ClaimScheduleRun(ctx, run, now)
+	if errors.Is(err, entity.ErrScheduleRunClaimed) {
+		return false, err
+	}
+	if err != nil {
+		return false, apperror.ErrCreate(err, "failed to claim schedule run")
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	transCtx := WithTransactionID(ctx, run.ID)
+	switch schedule.TransactionKind {
+	case entity.TransactionIn:
+		err = uc.transUseCase.Deposit(transCtx, schedule.WalletID, schedule.AccountID, schedule.Amount, schedule.Currency,
+			schedule.Note)
+	case entity.TransactionOut:
+		// nobody calls for a scheduled withdrawal, the owner of the wallet requests its approval
+		_, err = uc.transUseCase.Withdraw(transCtx, "", schedule.WalletID, schedule.AccountID, schedule.Amount,
+			schedule.Currency, schedule.Note)
+	}
+
+	switch {
+	case err == nil:
+		run.ToMaterialized()
+	case isInsufficientBalance(err):
+		run.ToSkipped(err.Error())
+		uc.notify(ctx, fmt.Sprintf("Scheduled withdrawal %s of %.2f %s on %s was skipped: insufficient balance",
+			schedule.ID, schedule.Amount, schedule.Currency, run.OccurrenceAt.Format(time.RFC3339)))
+	default:
+		run.ToFailed(err.Error())
+	}
+
+	if err := uc.repo.UpdateScheduleRun(ctx, run); err != nil {
+		return false, apperror.ErrUpdate(err, "failed to update schedule run")
+	}
+	return run.Status == entity.ScheduleRunMaterialized, nil
+}
+
+func (uc *ScheduleUseCase) checkWalletAndAccount(ctx context.Context, walletID string, accountID string) error {
+	account, err := uc.transRepo.GetLinkedAccountByID(ctx, accountID)
+	if err != nil {
+		return apperror.ErrGet(err, "failed to get account by id")
+	}
+	if account == nil {
+		return apperror.New(apperror.ACCOUNT_NOT_FOUND)
+	}
+
+	wallet, err := uc.transRepo.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return apperror.ErrGet(err, "failed to get wallet by id")
+	}
+	if wallet == nil {
+		return apperror.New(apperror.WALLET_NOT_FOUND)
+	}
+	return nil
+}
+
+func (uc *ScheduleUseCase) notify(ctx context.Context, message string) {
+	for _, n := range uc.notifiers {
+		n.SendNotification(ctx, message)
+	}
+}
+
+func isInsufficientBalance(err error) bool {
+	return apperror.Is(err, apperror.INSUFFICIENT_FUNDS)
+}
@@ -0,0 +1,499 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	mocks2 "go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewScheduleUseCase(t *testing.T) {
+	repo := mocks2.NewIScheduleRepository(t)
+	transRepo := mocks2.NewITransactionRepository(t)
+	transUseCase := mocks2.NewITransactionUseCase(t)
+	want := &ScheduleUseCase{
+		repo:         repo,
+		transRepo:    transRepo,
+		transUseCase: transUseCase,
+		notifiers:    []INotifier{},
+	}
+
+	got := NewScheduleUseCase(repo, transRepo, transUseCase)
+	assert.NotNil(t, got.now)
+	got.now = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewScheduleUseCase() = %v, want %v", got, want)
+	}
+}
+
+func TestScheduleUseCase_SetIDGenerator(t *testing.T) {
+	repo := mocks2.NewIScheduleRepository(t)
+	transRepo := mocks2.NewITransactionRepository(t)
+	idGenerator := mocks2.NewIIDGenerator(t)
+	startAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	uc := ScheduleUseCase{repo: repo, transRepo: transRepo, now: func() time.Time { return startAt.Add(-time.Hour) }}
+	uc.SetIDGenerator(idGenerator)
+
+	t.Run("create uses the generated id", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		walletID := "w_00001"
+		accountID := "a_00001"
+		scheduleID := "0190a4e5-7c1b-7d4e-9f3a-2b6c8d0e1f2a"
+		idGenerator.EXPECT().NewID().Return(scheduleID).Once()
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(&entity.LinkedAccount{ID: accountID}, nil).Once()
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		repo.EXPECT().SaveSchedule(ctx, mock.MatchedBy(func(s *entity.Schedule) bool {
+			return s.ID == scheduleID
+		})).Return(nil).Once()
+
+		//Act
+		got, err := uc.CreateSchedule(ctx, walletID, accountID, 1000, "VND", entity.TransactionOut, "",
+			entity.ScheduleWeekly, startAt)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, scheduleID, got.ID)
+	})
+}
+
+func TestScheduleUseCase_CreateSchedule(t *testing.T) {
+	repo := mocks2.NewIScheduleRepository(t)
+	transRepo := mocks2.NewITransactionRepository(t)
+	startAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	uc := ScheduleUseCase{
+		repo:      repo,
+		transRepo: transRepo,
+		now:       func() time.Time { return startAt.Add(-time.Hour) },
+	}
+
+	t.Run("success", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		walletID := "w_00001"
+		accountID := "a_00001"
+
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(&entity.LinkedAccount{ID: accountID}, nil).Once()
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		repo.EXPECT().SaveSchedule(ctx, mock.MatchedBy(func(s *entity.Schedule) bool {
+			return s.WalletID == walletID && s.NextRunAt.Equal(startAt) && s.Status == entity.ScheduleStatusActive
+		})).Return(nil).Once()
+
+		//Act
+		got, err := uc.CreateSchedule(ctx, walletID, accountID, 1000000, "VND", entity.TransactionOut, "rent",
+			entity.ScheduleMonthly, startAt)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.NotEmpty(t, got.ID)
+		assert.Equal(t, entity.ScheduleMonthly, got.Frequency)
+	})
+
+	t.Run("invalid frequency", func(t *testing.T) {
+		//Act
+		got, err := uc.CreateSchedule(context.Background(), "w_00001", "a_00001", 1000000, "VND", entity.TransactionOut,
+			"rent", "DAILY", startAt)
+
+		//Assert
+		assert.Nil(t, got)
+		assert.Equal(t, apperror.ErrInvalidParams(fmt.Errorf("invalid schedule frequency DAILY")), err)
+	})
+
+	t.Run("wallet not found", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, "a_00001").Return(&entity.LinkedAccount{ID: "a_00001"}, nil).Once()
+		transRepo.EXPECT().GetWalletByID(ctx, "w_00001").Return(nil, nil).Once()
+
+		//Act
+		got, err := uc.CreateSchedule(ctx, "w_00001", "a_00001", 1000000, "VND", entity.TransactionOut, "rent",
+			entity.ScheduleWeekly, startAt)
+
+		//Assert
+		assert.Nil(t, got)
+		assert.Equal(t, apperror.New(apperror.WALLET_NOT_FOUND), err)
+	})
+
+	t.Run("a start in the past begins at the next occurrence", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		uc := uc
+		uc.now = func() time.Time { return startAt.AddDate(0, 2, 1) }
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, "a_00001").Return(&entity.LinkedAccount{ID: "a_00001"}, nil).Once()
+		transRepo.EXPECT().GetWalletByID(ctx, "w_00001").Return(&entity.Wallet{ID: "w_00001"}, nil).Once()
+		repo.EXPECT().SaveSchedule(ctx, mock.Anything).Return(nil).Once()
+
+		//Act
+		got, err := uc.CreateSchedule(ctx, "w_00001", "a_00001", 1000000, "VND", entity.TransactionOut, "rent",
+			entity.ScheduleMonthly, startAt)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, startAt.AddDate(0, 3, 0), got.NextRunAt)
+		assert.Equal(t, startAt, got.StartAt)
+	})
+
+	t.Run("a one-off schedule in the past", func(t *testing.T) {
+		//Arrange
+		uc := uc
+		uc.now = func() time.Time { return startAt.Add(time.Minute) }
+
+		//Act
+		got, err := uc.CreateSchedule(context.Background(), "w_00001", "a_00001", 1000000, "VND", entity.TransactionOut,
+			"rent", entity.ScheduleOnce, startAt)
+
+		//Assert
+		assert.Nil(t, got)
+		assert.Equal(t, apperror.ErrInvalidParams(fmt.Errorf("start_at of a one-off schedule must not be in the past")), err)
+	})
+}
+
+func TestScheduleUseCase_GetSchedule(t *testing.T) {
+	repo := mocks2.NewIScheduleRepository(t)
+	uc := ScheduleUseCase{repo: repo}
+
+	t.Run("schedule not found", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo.EXPECT().GetScheduleByID(ctx, "s_00001").Return(nil, nil).Once()
+
+		//Act
+		got, err := uc.GetSchedule(ctx, "s_00001")
+
+		//Assert
+		assert.Nil(t, got)
+		assert.Equal(t, apperror.New(apperror.SCHEDULE_NOT_FOUND), err)
+	})
+
+	t.Run("failed to get schedule by id", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errDB := fmt.Errorf("unexpected error")
+		repo.EXPECT().GetScheduleByID(ctx, "s_00001").Return(nil, errDB).Once()
+
+		//Act
+		got, err := uc.GetSchedule(ctx, "s_00001")
+
+		//Assert
+		assert.Nil(t, got)
+		assert.Equal(t, apperror.ErrGet(errDB, "failed to get schedule by id"), err)
+	})
+
+	t.Run("read from the reader when there is one", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		reader := mocks2.NewIScheduleRepository(t)
+		uc := ScheduleUseCase{repo: repo}
+		uc.SetReader(reader)
+		reader.EXPECT().GetScheduleByID(ctx, "s_00001").Return(&entity.Schedule{ID: "s_00001"}, nil).Once()
+
+		//Act
+		got, err := uc.GetSchedule(ctx, "s_00001")
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "s_00001", got.ID)
+	})
+}
+
+func TestScheduleUseCase_UpdateSchedule(t *testing.T) {
+	repo := mocks2.NewIScheduleRepository(t)
+	uc := ScheduleUseCase{repo: repo, now: time.Now}
+
+	t.Run("success", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		startAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+		current, _ := entity.NewSchedule("s_00001", "w_00001", "a_00001", 1000, "VND", entity.TransactionOut, "",
+			entity.ScheduleWeekly, startAt)
+		current.Advance()
+		repo.EXPECT().GetScheduleByID(ctx, current.ID).Return(current, nil).Once()
+		repo.EXPECT().UpdateSchedule(ctx, mock.MatchedBy(func(s *entity.Schedule) bool {
+			return s.ID == current.ID && s.Amount == 2000 && s.Note == "rent"
+		})).Return(nil).Once()
+
+		//Act
+		got, err := uc.UpdateSchedule(ctx, current.ID, 2000, "rent", entity.ScheduleWeekly, startAt, entity.InitialVersion)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, startAt.AddDate(0, 0, 7), got.NextRunAt)
+		assert.Equal(t, entity.InitialVersion+1, got.Version)
+	})
+
+	t.Run("a new recurrence does not backfill the past occurrences", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		startAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+		current, _ := entity.NewSchedule("s_00001", "w_00001", "a_00001", 1000, "VND", entity.TransactionOut, "",
+			entity.ScheduleWeekly, startAt)
+		repo.EXPECT().GetScheduleByID(ctx, current.ID).Return(current, nil).Once()
+		repo.EXPECT().UpdateSchedule(ctx, mock.MatchedBy(func(s *entity.Schedule) bool {
+			return s.ID == current.ID && s.Frequency == entity.ScheduleEndOfMonth
+		})).Return(nil).Once()
+
+		//Act
+		got, err := uc.UpdateSchedule(ctx, current.ID, 1000, "", entity.ScheduleEndOfMonth, startAt, 0)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.True(t, got.NextRunAt.After(time.Now()))
+		assert.Equal(t, entity.ScheduleStatusActive, got.Status)
+	})
+
+	t.Run("amount with more decimals than the currency of the schedule", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		startAt := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+		current, _ := entity.NewSchedule("s_00001", "w_00001", "a_00001", 1000, "VND", entity.TransactionOut, "",
+			entity.ScheduleWeekly, startAt)
+		repo.EXPECT().GetScheduleByID(ctx, current.ID).Return(current, nil).Once()
+
+		//Act
+		_, err := uc.UpdateSchedule(ctx, current.ID, 1000.5, "", entity.ScheduleWeekly, startAt, 0)
+
+		//Assert
+		appErr, ok := apperror.ErrorAs(err)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, appErr.HTTPCode)
+		assert.EqualError(t, appErr.Raw, "amount has more decimals than its currency allows")
+	})
+
+	t.Run("precondition failed: schedule is not at the expected version", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		current := &entity.Schedule{ID: "s_00001", Currency: "VND", Version: 3}
+		repo.EXPECT().GetScheduleByID(ctx, current.ID).Return(current, nil).Once()
+
+		//Act
+		_, err := uc.UpdateSchedule(ctx, current.ID, 1000, "", entity.ScheduleWeekly, time.Now(), 2)
+
+		//Assert
+		appErr, ok := apperror.ErrorAs(err)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusPreconditionFailed, appErr.HTTPCode)
+		assert.ErrorIs(t, appErr.Raw, entity.ErrVersionConflict)
+	})
+
+	t.Run("conflict: schedule updated concurrently", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		current, _ := entity.NewSchedule("s_00001", "w_00001", "a_00001", 1000, "VND", entity.TransactionOut, "",
+			entity.ScheduleWeekly, time.Now())
+		repo.EXPECT().GetScheduleByID(ctx, current.ID).Return(current, nil).Once()
+		repo.EXPECT().UpdateSchedule(ctx, current).
+			Return(entity.NewVersionConflictError("schedule", current.ID, current.Version)).Once()
+
+		//Act
+		_, err := uc.UpdateSchedule(ctx, current.ID, 2000, "", entity.ScheduleWeekly, current.StartAt, 0)
+
+		//Assert
+		appErr, ok := apperror.ErrorAs(err)
+		assert.True(t, ok)
+		assert.Equal(t, apperror.CODE_CONFLICT, appErr.Code)
+		assert.Equal(t, entity.InitialVersion, current.Version)
+	})
+
+	t.Run("not found: schedule deleted concurrently", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		current, _ := entity.NewSchedule("s_00001", "w_00001", "a_00001", 1000, "VND", entity.TransactionOut, "",
+			entity.ScheduleWeekly, time.Now())
+		repo.EXPECT().GetScheduleByID(ctx, current.ID).Return(current, nil).Once()
+		repo.EXPECT().UpdateSchedule(ctx, current).
+			Return(fmt.Errorf("schedule %s: %w", current.ID, entity.ErrNotFound)).Once()
+
+		//Act
+		_, err := uc.UpdateSchedule(ctx, current.ID, 2000, "", entity.ScheduleWeekly, current.StartAt, 0)
+
+		//Assert
+		assert.Equal(t, apperror.New(apperror.SCHEDULE_NOT_FOUND), err)
+	})
+}
+
+func TestScheduleUseCase_DeleteSchedule(t *testing.T) {
+	repo := mocks2.NewIScheduleRepository(t)
+	uc := ScheduleUseCase{repo: repo}
+
+	t.Run("success", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo.EXPECT().GetScheduleByID(ctx, "s_00001").Return(&entity.Schedule{ID: "s_00001", Version: 2}, nil).Once()
+		repo.EXPECT().DeleteSchedule(ctx, "s_00001", int64(2)).Return(nil).Once()
+
+		//Act
+		err := uc.DeleteSchedule(ctx, "s_00001", 0)
+
+		//Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("precondition failed: schedule is not at the expected version", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		repo.EXPECT().GetScheduleByID(ctx, "s_00001").Return(&entity.Schedule{ID: "s_00001", Version: 2}, nil).Once()
+
+		//Act
+		err := uc.DeleteSchedule(ctx, "s_00001", 1)
+
+		//Assert
+		appErr, ok := apperror.ErrorAs(err)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusPreconditionFailed, appErr.HTTPCode)
+	})
+}
+
+func TestScheduleUseCase_RunDueSchedules(t *testing.T) {
+	repo := mocks2.NewIScheduleRepository(t)
+	transUseCase := mocks2.NewITransactionUseCase(t)
+	notifier := mocks2.NewINotifier(t)
+	uc := ScheduleUseCase{
+		repo:         repo,
+		transUseCase: transUseCase,
+		notifiers:    []INotifier{notifier},
+	}
+	newSchedule := func(kind entity.TransactionKind, startAt time.Time) *entity.Schedule {
+		s, _ := entity.NewSchedule("s_00001", "w_00001", "a_00001", 1000, "VND", kind, "weekly", entity.ScheduleWeekly, startAt)
+		return s
+	}
+	// runCtx matches the context of a run, which gives the id of the run to its transaction
+	runCtx := func(occurrenceAt time.Time) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			transID, _ := ctx.Value(transactionIDKey{}).(string)
+			return transID == entity.NewScheduleRun("s_00001", occurrenceAt).ID
+		})
+	}
+	isRun := func(occurrenceAt time.Time, status entity.ScheduleRunStatus) interface{} {
+		return mock.MatchedBy(func(r *entity.ScheduleRun) bool {
+			return r.OccurrenceAt.Equal(occurrenceAt) && r.Status == status
+		})
+	}
+
+	t.Run("success: catch up missed runs", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		now := time.Date(2024, 7, 16, 10, 0, 0, 0, time.UTC)
+		first := time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC)
+		schedule := newSchedule(entity.TransactionIn, first)
+
+		repo.EXPECT().ListDueSchedules(ctx, now, dueSchedulesBatchSize).Return([]*entity.Schedule{schedule}, nil).Once()
+		for _, occurrenceAt := range []time.Time{first, first.AddDate(0, 0, 7), first.AddDate(0, 0, 14)} {
+			repo.EXPECT().ClaimScheduleRun(ctx, isRun(occurrenceAt, entity.ScheduleRunPending), now).Return(true, nil).Once()
+			repo.EXPECT().UpdateScheduleRun(ctx, isRun(occurrenceAt, entity.ScheduleRunMaterialized)).Return(nil).Once()
+		}
+		for _, occurrenceAt := range []time.Time{first, first.AddDate(0, 0, 7), first.AddDate(0, 0, 14)} {
+			transUseCase.EXPECT().Deposit(runCtx(occurrenceAt), "w_00001", "a_00001", 1000.0, "VND", "weekly").Return(nil).Once()
+		}
+		repo.EXPECT().UpdateSchedule(ctx, schedule).Return(nil).Times(3)
+
+		//Act
+		got, err := uc.RunDueSchedules(ctx, now)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, got)
+		assert.Equal(t, first.AddDate(0, 0, 21), schedule.NextRunAt)
+		assert.Equal(t, entity.InitialVersion+3, schedule.Version)
+	})
+
+	t.Run("skip occurrence already run", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		now := time.Date(2024, 7, 2, 10, 0, 0, 0, time.UTC)
+		schedule := newSchedule(entity.TransactionOut, time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC))
+
+		repo.EXPECT().ListDueSchedules(ctx, now, dueSchedulesBatchSize).Return([]*entity.Schedule{schedule}, nil).Once()
+		repo.EXPECT().ClaimScheduleRun(ctx, mock.Anything, now).Return(false, nil).Once()
+		repo.EXPECT().UpdateSchedule(ctx, schedule).Return(nil).Once()
+
+		//Act
+		got, err := uc.RunDueSchedules(ctx, now)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, got)
+	})
+
+	t.Run("leave occurrence claimed by another scheduler", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		now := time.Date(2024, 7, 2, 10, 0, 0, 0, time.UTC)
+		occurrenceAt := time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC)
+		schedule := newSchedule(entity.TransactionIn, occurrenceAt)
+
+		repo.EXPECT().ListDueSchedules(ctx, now, dueSchedulesBatchSize).Return([]*entity.Schedule{schedule}, nil).Once()
+		repo.EXPECT().ClaimScheduleRun(ctx, mock.MatchedBy(func(r *entity.ScheduleRun) bool {
+			return r.ClaimedUntil.Equal(now.Add(entity.ScheduleRunLease))
+		}), now).Return(false, entity.ErrScheduleRunClaimed).Once()
+
+		//Act
+		got, err := uc.RunDueSchedules(ctx, now)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, got)
+		assert.Equal(t, occurrenceAt, schedule.NextRunAt)
+	})
+
+	t.Run("skip and notify when balance is insufficient", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		now := time.Date(2024, 7, 2, 10, 0, 0, 0, time.UTC)
+		occurrenceAt := time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC)
+		schedule := newSchedule(entity.TransactionOut, occurrenceAt)
+
+		repo.EXPECT().ListDueSchedules(ctx, now, dueSchedulesBatchSize).Return([]*entity.Schedule{schedule}, nil).Once()
+		repo.EXPECT().ClaimScheduleRun(ctx, mock.Anything, now).Return(true, nil).Once()
+		transUseCase.EXPECT().Withdraw(runCtx(occurrenceAt), "", "w_00001", "a_00001", 1000.0, "VND", "weekly").
+			Return(nil, apperror.New(apperror.INSUFFICIENT_FUNDS)).Once()
+		notifier.EXPECT().SendNotification(ctx, mock.AnythingOfType("string")).Return().Once()
+		repo.EXPECT().UpdateScheduleRun(ctx, isRun(occurrenceAt, entity.ScheduleRunSkipped)).Return(nil).Once()
+		repo.EXPECT().UpdateSchedule(ctx, schedule).Return(nil).Once()
+
+		//Act
+		got, err := uc.RunDueSchedules(ctx, now)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, got)
+	})
+
+	t.Run("failed schedule does not stop the others", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		now := time.Date(2024, 7, 2, 10, 0, 0, 0, time.UTC)
+		occurrenceAt := time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC)
+		broken := newSchedule(entity.TransactionOut, occurrenceAt)
+		broken.ID = "s_broken"
+		schedule := newSchedule(entity.TransactionIn, occurrenceAt)
+		errDB := fmt.Errorf("unexpected error")
+
+		repo.EXPECT().ListDueSchedules(ctx, now, dueSchedulesBatchSize).Return([]*entity.Schedule{broken, schedule}, nil).Once()
+		repo.EXPECT().ClaimScheduleRun(ctx, mock.MatchedBy(func(r *entity.ScheduleRun) bool {
+			return r.ScheduleID == broken.ID
+		}), now).Return(false, errDB).Once()
+		repo.EXPECT().ClaimScheduleRun(ctx, mock.MatchedBy(func(r *entity.ScheduleRun) bool {
+			return r.ScheduleID == schedule.ID
+		}), now).Return(true, nil).Once()
+		transUseCase.EXPECT().Deposit(runCtx(occurrenceAt), "w_00001", "a_00001", 1000.0, "VND", "weekly").Return(nil).Once()
+		repo.EXPECT().UpdateScheduleRun(ctx, isRun(occurrenceAt, entity.ScheduleRunMaterialized)).Return(nil).Once()
+		repo.EXPECT().UpdateSchedule(ctx, schedule).Return(nil).Once()
+
+		//Act
+		got, err := uc.RunDueSchedules(ctx, now)
+
+		//Assert
+		assert.EqualError(t, err, apperror.ErrCreate(errDB, "failed to claim schedule run").Error())
+		assert.Equal(t, 1, got)
+		assert.Equal(t, occurrenceAt, broken.NextRunAt)
+	})
+}
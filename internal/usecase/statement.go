@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"go-clean-template/internal/entity"
+	"go-clean-template/pkg/apperror"
+)
+
+type StatementUseCase struct {
+	repo      IStatementRepository
+	transRepo ITransactionRepository
+}
+
+func NewStatementUseCase(repo IStatementRepository, transRepo ITransactionRepository) *StatementUseCase {
+	return &StatementUseCase{
+		repo:      repo,
+		transRepo: transRepo,
+	}
+}
+
+func (uc *StatementUseCase) OpenStatement(ctx context.Context, walletID string, from time.Time, to time.Time) (*entity.Statement, error) {
+	if !from.Before(to) {
+		return nil, apperror.New(apperror.INVALID_PERIOD)
+	}
+
+	wallet, err := uc.transRepo.GetWalletByID(ctx, walletID)
+	if err != nil {
+		return nil, apperror.ErrGet(err, "failed to get wallet by id")
+	}
+	if wallet == nil {
+		return nil, apperror.New(apperror.WALLET_NOT_FOUND)
+	}
+
+	openingBalance, err := uc.repo.GetBalanceBefore(ctx, walletID, from)
+	if err != nil {
+		return nil, apperror.ErrGet(err, "failed to get opening balance")
+	}
+	return entity.NewStatement(walletID, from, to, openingBalance), nil
+}
+
+// StreamStatement reads the transactions from a repository cursor, so the statement is never held in memory.
+// An error returned by fn stops the stream and is returned as is.
+func (uc *StatementUseCase) StreamStatement(ctx context.Context, statement *entity.Statement,
+	fn func(line *entity.StatementLine) error) error {
+	var errFn error
+	err := uc.repo.StreamTransactions(ctx, statement.WalletID, statement.From, statement.To, func(trans *entity.Transaction) error {
+		errFn = fn(statement.Apply(trans))
+		return errFn
+	})
+	if errFn != nil {
+		return errFn
+	}
+	if err != nil {
+		return apperror.ErrGet(err, "failed to stream transactions")
+	}
+	return nil
+}
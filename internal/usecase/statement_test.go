@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-clean-template/internal/entity"
+	mocks2 "go-clean-template/internal/usecase/mocks"
+	"go-clean-template/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewStatementUseCase(t *testing.T) {
+	repo := mocks2.NewIStatementRepository(t)
+	transRepo := mocks2.NewITransactionRepository(t)
+	want := &StatementUseCase{
+		repo:      repo,
+		transRepo: transRepo,
+	}
+
+	if got := NewStatementUseCase(repo, transRepo); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewStatementUseCase() = %v, want %v", got, want)
+	}
+}
+
+func TestStatementUseCase_OpenStatement(t *testing.T) {
+	repo := mocks2.NewIStatementRepository(t)
+	transRepo := mocks2.NewITransactionRepository(t)
+	uc := StatementUseCase{
+		repo:      repo,
+		transRepo: transRepo,
+	}
+	walletID := "w_00001"
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		repo.EXPECT().GetBalanceBefore(ctx, walletID, from).Return(1000, nil).Once()
+
+		//Act
+		got, err := uc.OpenStatement(ctx, walletID, from, to)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.NewStatement(walletID, from, to, 1000), got)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		//Act
+		got, err := uc.OpenStatement(context.Background(), walletID, to, from)
+
+		//Assert
+		assert.Nil(t, got)
+		assert.Equal(t, apperror.New(apperror.INVALID_PERIOD), err)
+	})
+
+	t.Run("wallet not found", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(nil, nil).Once()
+
+		//Act
+		got, err := uc.OpenStatement(ctx, walletID, from, to)
+
+		//Assert
+		assert.Nil(t, got)
+		assert.Equal(t, apperror.New(apperror.WALLET_NOT_FOUND), err)
+	})
+
+	t.Run("failed to get opening balance", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errDB := fmt.Errorf("unexpected error")
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		repo.EXPECT().GetBalanceBefore(ctx, walletID, from).Return(0, errDB).Once()
+
+		//Act
+		_, err := uc.OpenStatement(ctx, walletID, from, to)
+
+		//Assert
+		assert.Equal(t, apperror.ErrGet(errDB, "failed to get opening balance"), err)
+	})
+}
+
+func TestStatementUseCase_StreamStatement(t *testing.T) {
+	repo := mocks2.NewIStatementRepository(t)
+	uc := StatementUseCase{repo: repo}
+	walletID := "w_00001"
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	deposit := entity.NewTransaction("t_00001", walletID, "a_00001", 500, "VND", entity.TransactionIn, "",
+		entity.TransactionStatusSuccessful)
+	withdraw := entity.NewTransaction("t_00002", walletID, "a_00001", 300, "VND", entity.TransactionOut, "",
+		entity.TransactionStatusSuccessful)
+	streamBoth := func(_ context.Context, _ string, _ time.Time, _ time.Time, fn func(*entity.Transaction) error) error {
+		if err := fn(deposit); err != nil {
+			return err
+		}
+		return fn(withdraw)
+	}
+
+	t.Run("success: running balance", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		statement := entity.NewStatement(walletID, from, to, 1000)
+		repo.EXPECT().StreamTransactions(ctx, walletID, from, to, mock.Anything).RunAndReturn(streamBoth).Once()
+		var lines []*entity.StatementLine
+
+		//Act
+		err := uc.StreamStatement(ctx, statement, func(line *entity.StatementLine) error {
+			lines = append(lines, line)
+			return nil
+		})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.StatementLine{
+			{Transaction: deposit, Balance: 1500},
+			{Transaction: withdraw, Balance: 1200},
+		}, lines)
+		assert.Equal(t, 1200.0, statement.ClosingBalance)
+	})
+
+	t.Run("error of fn stops the stream", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errWrite := fmt.Errorf("broken pipe")
+		repo.EXPECT().StreamTransactions(ctx, walletID, from, to, mock.Anything).RunAndReturn(streamBoth).Once()
+		calls := 0
+
+		//Act
+		err := uc.StreamStatement(ctx, entity.NewStatement(walletID, from, to, 0), func(*entity.StatementLine) error {
+			calls++
+			return errWrite
+		})
+
+		//Assert
+		assert.Equal(t, errWrite, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("failed to stream transactions", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		errDB := fmt.Errorf("unexpected error")
+		repo.EXPECT().StreamTransactions(ctx, walletID, from, to, mock.Anything).Return(errDB).Once()
+
+		//Act
+		err := uc.StreamStatement(ctx, entity.NewStatement(walletID, from, to, 0), func(*entity.StatementLine) error {
+			return nil
+		})
+
+		//Assert
+		assert.Equal(t, apperror.ErrGet(errDB, "failed to stream transactions"), err)
+	})
+}
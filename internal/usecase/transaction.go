@@ -2,18 +2,36 @@ package usecase
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"time"
 
 	"go-clean-template/internal/entity"
 	"go-clean-template/pkg/apperror"
+	"go-clean-template/pkg/errorreport"
 
 	"github.com/google/uuid"
 )
 
+// transactionIDKey is the context key of the id set by WithTransactionID
+type transactionIDKey struct{}
+
+// WithTransactionID makes Deposit and Withdraw called with the returned context create their transaction with
+// transID. Called again with the same id, they find the transaction created the first time instead of creating
+// another one, so a payout item or a schedule run resumed after a crash never moves the money twice.
+func WithTransactionID(ctx context.Context, transID string) context.Context {
+	return context.WithValue(ctx, transactionIDKey{}, transID)
+}
+
 type TransactionUseCase struct {
-	repo       ITransactionRepository
-	paymentSvc IPaymentServiceProvider
-	notifiers  []INotifier
+	repo           ITransactionRepository
+	reader         ITransactionRepository
+	paymentSvc     IPaymentServiceProvider
+	notifiers      []INotifier
+	approvalRepo   IApprovalRepository
+	approvalPolicy ApprovalPolicy
+	balanceRepo    IBalanceRepository
+	idGenerator    IIDGenerator
+	transactor     ITransactor
 }
 
 func NewTransactionUseCase(repo ITransactionRepository, paymentSvc IPaymentServiceProvider) *TransactionUseCase {
@@ -28,9 +46,37 @@ func (uc *TransactionUseCase) SetNotifiers(notifiers ...INotifier) {
 	uc.notifiers = append(uc.notifiers, notifiers...)
 }
 
+// SetIDGenerator replaces the random UUIDs used as ids of new transactions and approvals
+func (uc *TransactionUseCase) SetIDGenerator(idGenerator IIDGenerator) {
+	uc.idGenerator = idGenerator
+}
+
+// SetTransactor makes a withdrawal and its approval request one transaction of the storage
+func (uc *TransactionUseCase) SetTransactor(transactor ITransactor) {
+	uc.transactor = transactor
+}
+
+// SetReader serves GetTransaction from repo, like a replica: the endpoint tolerates the replication lag. The
+// payments and the approvals keep reading the transactions they change from the primary.
+func (uc *TransactionUseCase) SetReader(repo ITransactionRepository) {
+	uc.reader = repo
+}
+
+func (uc *TransactionUseCase) readRepo() ITransactionRepository {
+	if uc.reader != nil {
+		return uc.reader
+	}
+	return uc.repo
+}
+
+// SetBalanceSnapshots makes withdrawals read the balance from the latest snapshot and the transactions after it
+func (uc *TransactionUseCase) SetBalanceSnapshots(repo IBalanceRepository) {
+	uc.balanceRepo = repo
+}
+
 func (uc *TransactionUseCase) Deposit(ctx context.Context, walletID string, accountID string, amount float64, currency string, note string) error {
 	var (
-		transID = uuid.New().String()
+		transID = uc.newTransactionID(ctx)
 		err     error
 		trans   *entity.Transaction
 	)
@@ -41,7 +87,7 @@ func (uc *TransactionUseCase) Deposit(ctx context.Context, walletID string, acco
 		return apperror.ErrGet(err, "failed to get account by id")
 	}
 	if account == nil {
-		return apperror.ErrInvalidParams(fmt.Errorf("account not found"))
+		return apperror.New(apperror.ACCOUNT_NOT_FOUND)
 	}
 
 	// create new transaction
@@ -54,20 +100,29 @@ func (uc *TransactionUseCase) Deposit(ctx context.Context, walletID string, acco
 	}
 
 	if wallet == nil {
-		return apperror.ErrInvalidParams(fmt.Errorf("wallet not found"))
+		return apperror.New(apperror.WALLET_NOT_FOUND)
+	}
+
+	// a retried deposit is already saved
+	retried, err := uc.getRetriedTransaction(ctx)
+	if err != nil || retried != nil {
+		return err
 	}
 
 	// save transaction
 	if err := uc.repo.SaveTransaction(ctx, trans); err != nil {
 		return apperror.ErrCreate(err, "failed to create deposit transaction")
 	}
+	errorreport.SetTransaction(ctx, transID)
+	errorreport.AddBreadcrumb(ctx, "transaction", "deposit created", nil)
 
 	return nil
 }
 
-func (uc *TransactionUseCase) Withdraw(ctx context.Context, walletID string, accountID string, amount float64, currency string, note string) error {
+func (uc *TransactionUseCase) Withdraw(ctx context.Context, requestedBy string, walletID string, accountID string,
+	amount float64, currency string, note string) (*entity.Transaction, error) {
 	var (
-		transID = uuid.New().String()
+		transID = uc.newTransactionID(ctx)
 		err     error
 		trans   *entity.Transaction
 	)
@@ -75,47 +130,83 @@ func (uc *TransactionUseCase) Withdraw(ctx context.Context, walletID string, acc
 	// check account linking status
 	account, err := uc.repo.GetLinkedAccountByID(ctx, accountID)
 	if err != nil {
-		return apperror.ErrGet(err, "failed to get account by id")
+		return nil, apperror.ErrGet(err, "failed to get account by id")
 	}
 	if account == nil {
-		return apperror.ErrInvalidParams(fmt.Errorf("account not found"))
+		return nil, apperror.New(apperror.ACCOUNT_NOT_FOUND)
 	}
 
-	// get wallet
-	wallet, err := uc.repo.GetWalletByID(ctx, walletID)
-	if err != nil {
-		return apperror.ErrGet(err, "failed to get wallet by id")
+	// create new transaction, large withdrawals have to be approved before paying
+	status := entity.TransactionStatusNew
+	if uc.requiresApproval(amount) {
+		status = entity.TransactionStatusAwaitingApproval
 	}
+	trans = entity.NewTransaction(transID, walletID, accountID, amount, currency, entity.TransactionOut, note, status)
 
-	if wallet == nil {
-		return apperror.ErrInvalidParams(fmt.Errorf("wallet not found"))
-	}
+	// save transaction, with its approval request or not at all
+	var approval *entity.Approval
+	if err := uc.withinTransaction(ctx, func(ctx context.Context) error {
+		// the lock makes the withdrawals of the wallet check the balance one after the other
+		wallet, err := uc.repo.LockWallet(ctx, walletID)
+		if err != nil {
+			return apperror.ErrGet(err, "failed to get wallet by id")
+		}
+		if wallet == nil {
+			return apperror.New(apperror.WALLET_NOT_FOUND)
+		}
 
-	//check balance
-	balance, err := uc.repo.GetBalanceByWalletID(ctx, walletID)
-	if err != nil {
-		return apperror.ErrGet(err, "failed to get balance by wallet id")
-	}
+		// a retried withdrawal is already saved, with its approval request if it needs one
+		retried, err := uc.getRetriedTransaction(ctx)
+		if err != nil || retried != nil {
+			trans = retried
+			return err
+		}
 
-	if balance < amount {
-		return apperror.ErrInvalidParams(fmt.Errorf("insufficient balance"))
-	}
-	// create new transaction
-	trans = entity.NewTransaction(transID, walletID, accountID, amount, currency, entity.TransactionOut, note, entity.TransactionStatusNew)
+		//check balance
+		balance, err := uc.getAvailableBalance(ctx, walletID)
+		if err != nil {
+			return apperror.ErrGet(err, "failed to get balance by wallet id")
+		}
+		if balance < amount {
+			return apperror.New(apperror.INSUFFICIENT_FUNDS)
+		}
 
-	// save transaction
-	if err := uc.repo.SaveTransaction(ctx, trans); err != nil {
-		return apperror.ErrCreate(err, "failed to create withdraw transaction")
+		if requestedBy == "" {
+			requestedBy = wallet.UserID
+		}
+		if err := uc.repo.SaveTransaction(ctx, trans); err != nil {
+			return apperror.ErrCreate(err, "failed to create withdraw transaction")
+		}
+		if status != entity.TransactionStatusAwaitingApproval {
+			return nil
+		}
+		approval, err = uc.requestApproval(ctx, trans, requestedBy)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	errorreport.SetTransaction(ctx, transID)
+	errorreport.AddBreadcrumb(ctx, "transaction", "withdrawal created", map[string]interface{}{"status": status})
+	if approval != nil {
+		uc.notifyApprovalRequest(ctx, trans, approval)
 	}
 
-	return nil
+	return trans, nil
 }
 
-func (uc *TransactionUseCase) PayTransaction(ctx context.Context, transID string) error {
-	var (
-		transStatus entity.TransactionStatus
-		err         error
-	)
+func (uc *TransactionUseCase) GetTransaction(ctx context.Context, transID string) (*entity.Transaction, error) {
+	trans, err := uc.readRepo().GetTransactionByID(ctx, transID)
+	if err != nil {
+		return nil, apperror.ErrGet(err, "failed to get transaction by id")
+	}
+	if trans == nil {
+		return nil, apperror.New(apperror.TXN_NOT_FOUND)
+	}
+	return trans, nil
+}
+
+func (uc *TransactionUseCase) PayTransaction(ctx context.Context, transID string, version int64) error {
+	errorreport.SetTransaction(ctx, transID)
 	// get trans
 	trans, err := uc.repo.GetTransactionByID(ctx, transID)
 
@@ -124,14 +215,27 @@ func (uc *TransactionUseCase) PayTransaction(ctx context.Context, transID string
 	}
 
 	if trans == nil {
-		return apperror.ErrInvalidParams(fmt.Errorf("no transactions found in ready-to-pay status"))
+		return apperror.New(apperror.TXN_NOT_FOUND)
+	}
+	if err := checkVersion(trans, version); err != nil {
+		return err
 	}
 
 	// check transaction status
-	if trans.Status != entity.TransactionStatusNew {
-		return apperror.ErrInvalidParams(fmt.Errorf("transaction status is not new"))
+	if trans.Status == entity.TransactionStatusAwaitingApproval {
+		return apperror.New(apperror.TXN_AWAITING_APPROVAL)
+	}
+	if err := trans.ToProcessing(); err != nil {
+		return apperror.New(apperror.TXN_INVALID_STATE)
 	}
 
+	// claim the transaction before the payment: of two concurrent payments, only the one storing PROCESSING
+	// calls the payment gateway, the other one is a conflict
+	if err := uc.repo.UpdateTransactionStatus(ctx, transID, trans.Status, trans.Version); err != nil {
+		return updateStatusError(err)
+	}
+	trans.Version++
+
 	// send to payment gateway service
 	if trans.TransactionKind == entity.TransactionIn {
 		err = uc.paymentSvc.Deposit(ctx, trans.Amount, trans.Currency, trans.Note)
@@ -140,14 +244,100 @@ func (uc *TransactionUseCase) PayTransaction(ctx context.Context, transID string
 	}
 
 	if err != nil {
-		transStatus = entity.TransactionStatusFailed
+		_ = trans.ToFailed()
 	} else {
-		transStatus = entity.TransactionStatusSuccessful
+		_ = trans.ToSuccessful()
+	}
+	errorreport.AddBreadcrumb(ctx, "psp", "payment sent", map[string]interface{}{
+		"kind":   trans.TransactionKind,
+		"status": trans.Status,
+	})
+
+	// Update transaction status. A transaction left PROCESSING by a failure here has an unknown outcome, it is
+	// reconciled with the payment service provider.
+	if err := uc.repo.UpdateTransactionStatus(ctx, transID, trans.Status, trans.Version); err != nil {
+		return updateStatusError(err)
 	}
+	return nil
+}
 
-	// Update transaction status
-	if err := uc.repo.UpdateTransactionStatus(ctx, transID, transStatus); err != nil {
-		return apperror.ErrUpdate(err, "failed to update transaction status")
+// checkVersion fails with a conflict when the transaction is not at the version the caller expects, 0 expects any
+func checkVersion(trans *entity.Transaction, version int64) error {
+	if version != 0 && trans.Version != version {
+		return apperror.New(apperror.VERSION_MISMATCH).WithRaw(entity.NewVersionConflictError("transaction", trans.ID, version))
 	}
 	return nil
 }
+
+func updateStatusError(err error) error {
+	if errors.Is(err, entity.ErrNotFound) {
+		return apperror.New(apperror.TXN_NOT_FOUND)
+	}
+	if errors.Is(err, entity.ErrVersionConflict) {
+		return apperror.New(apperror.VERSION_CONFLICT).WithRaw(err)
+	}
+	return apperror.ErrUpdate(err, "failed to update transaction status")
+}
+
+// getAvailableBalance is the balance less the withdrawals that are not paid yet, they are already promised
+func (uc *TransactionUseCase) getAvailableBalance(ctx context.Context, walletID string) (float64, error) {
+	balance, err := uc.getBalance(ctx, walletID)
+	if err != nil {
+		return 0, err
+	}
+	pending, err := uc.repo.GetPendingWithdrawals(ctx, walletID)
+	if err != nil {
+		return 0, err
+	}
+	return balance - pending, nil
+}
+
+func (uc *TransactionUseCase) getBalance(ctx context.Context, walletID string) (float64, error) {
+	if uc.balanceRepo == nil {
+		return uc.repo.GetBalanceByWalletID(ctx, walletID)
+	}
+	balance, _, err := balanceFromSnapshot(ctx, uc.balanceRepo, walletID, time.Time{})
+	return balance, err
+}
+
+// withinTransaction runs fn in a transaction of the storage, or as is without SetTransactor
+func (uc *TransactionUseCase) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.transactor == nil {
+		return fn(ctx)
+	}
+	return uc.transactor.WithinTransaction(ctx, fn)
+}
+
+// newTransactionID is the id given by WithTransactionID, or a new one
+func (uc *TransactionUseCase) newTransactionID(ctx context.Context) string {
+	if transID, ok := ctx.Value(transactionIDKey{}).(string); ok {
+		return transID
+	}
+	return uc.newID()
+}
+
+// getRetriedTransaction gets the transaction already created with the id given by WithTransactionID. It returns
+// nil - nil when there is none or no id was given.
+func (uc *TransactionUseCase) getRetriedTransaction(ctx context.Context) (*entity.Transaction, error) {
+	transID, ok := ctx.Value(transactionIDKey{}).(string)
+	if !ok {
+		return nil, nil
+	}
+	trans, err := uc.repo.GetTransactionByID(ctx, transID)
+	if err != nil {
+		return nil, apperror.ErrGet(err, "failed to get transaction by id")
+	}
+	return trans, nil
+}
+
+func (uc *TransactionUseCase) newID() string {
+	return newID(uc.idGenerator)
+}
+
+// newID returns an id of idGenerator, or a random UUID when the use case has no generator
+func newID(idGenerator IIDGenerator) string {
+	if idGenerator == nil {
+		return uuid.New().String()
+	}
+	return idGenerator.NewID()
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 
@@ -71,6 +72,33 @@ func TestTransactionUseCase_SetNotifiers(t *testing.T) {
 	}
 }
 
+func TestTransactionUseCase_SetIDGenerator(t *testing.T) {
+	transRepo := mocks2.NewITransactionRepository(t)
+	idGenerator := mocks2.NewIIDGenerator(t)
+	uc := TransactionUseCase{repo: transRepo}
+	uc.SetIDGenerator(idGenerator)
+
+	t.Run("deposit uses the generated id", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		walletID := "w_00001"
+		accountID := "a_00001"
+		transID := "0190a4e5-7c1b-7d4e-9f3a-2b6c8d0e1f2a"
+		idGenerator.EXPECT().NewID().Return(transID).Once()
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(&entity.LinkedAccount{ID: accountID}, nil).Once()
+		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		transRepo.EXPECT().SaveTransaction(ctx, mock.MatchedBy(func(trans *entity.Transaction) bool {
+			return trans.ID == transID
+		})).Return(nil).Once()
+
+		//Act
+		err := uc.Deposit(ctx, walletID, accountID, 1000, "VND", "")
+
+		//Assert
+		assert.NoError(t, err)
+	})
+}
+
 func TestTransactionUseCase_Deposit(t *testing.T) {
 	transRepo := mocks2.NewITransactionRepository(t)
 	paymentSvc := mocks2.NewIPaymentServiceProvider(t)
@@ -125,6 +153,22 @@ func TestTransactionUseCase_Deposit(t *testing.T) {
 
 	})
 
+	t.Run("success: a retried deposit is not saved again", func(t *testing.T) {
+		//Arrange
+		ctx := WithTransactionID(context.Background(), "s_00001-1720602000")
+
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, "a_00001").Return(&entity.LinkedAccount{ID: "a_00001"}, nil).Once()
+		transRepo.EXPECT().GetWalletByID(ctx, "w_00001").Return(&entity.Wallet{ID: "w_00001"}, nil).Once()
+		transRepo.EXPECT().GetTransactionByID(ctx, "s_00001-1720602000").
+			Return(&entity.Transaction{ID: "s_00001-1720602000"}, nil).Once()
+
+		//Act
+		err := uc.Deposit(ctx, "w_00001", "a_00001", 1000, "VND", "")
+
+		//Assert
+		assert.NoError(t, err)
+	})
+
 	t.Run("failed to get account by id", func(t *testing.T) {
 		//Arrange
 		ctx := context.Background()
@@ -162,7 +206,7 @@ func TestTransactionUseCase_Deposit(t *testing.T) {
 
 		//Assert
 		assert.Error(t, err)
-		expectedErr := apperror.ErrInvalidParams(fmt.Errorf("account not found"))
+		expectedErr := apperror.New(apperror.ACCOUNT_NOT_FOUND)
 		assert.Equal(t, expectedErr, err)
 	})
 
@@ -217,7 +261,7 @@ func TestTransactionUseCase_Deposit(t *testing.T) {
 
 		//Assert
 		assert.Error(t, err)
-		expectedErr := apperror.ErrInvalidParams(fmt.Errorf("wallet not found"))
+		expectedErr := apperror.New(apperror.WALLET_NOT_FOUND)
 		assert.Equal(t, expectedErr, err)
 	})
 
@@ -312,15 +356,56 @@ func TestTransactionUseCase_Withdraw(t *testing.T) {
 		}
 
 		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
-		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(walletMock, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(walletMock, nil).Once()
 		transRepo.EXPECT().GetBalanceByWalletID(ctx, walletID).Return(balance, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, walletID).Return(0.0, nil).Once()
 		transRepo.EXPECT().SaveTransaction(ctx, IsMatchByTransaction(newTransMock)).Return(nil).Once()
 
 		//Act
-		err := uc.Withdraw(ctx, walletID, accountID, amount, currency, note)
+		got, err := uc.Withdraw(ctx, "", walletID, accountID, amount, currency, note)
 
 		//Assert
 		assert.NoError(t, err)
+		assert.Equal(t, newTransMock.Amount, got.Amount)
+		assert.Equal(t, newTransMock.Status, got.Status)
+	})
+
+	t.Run("success: a retried withdrawal returns the transaction it created", func(t *testing.T) {
+		//Arrange
+		ctx := WithTransactionID(context.Background(), "b_00001-1")
+		created := &entity.Transaction{ID: "b_00001-1", WalletID: "w_00001", Status: entity.TransactionStatusSuccessful}
+
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, "a_00001").Return(&entity.LinkedAccount{ID: "a_00001"}, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, "w_00001").Return(&entity.Wallet{ID: "w_00001"}, nil).Once()
+		transRepo.EXPECT().GetTransactionByID(ctx, "b_00001-1").Return(created, nil).Once()
+
+		//Act
+		got, err := uc.Withdraw(ctx, "", "w_00001", "a_00001", 1000, "VND", "")
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, created, got)
+	})
+
+	t.Run("success: a first withdrawal is created with the given id", func(t *testing.T) {
+		//Arrange
+		ctx := WithTransactionID(context.Background(), "b_00001-2")
+
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, "a_00001").Return(&entity.LinkedAccount{ID: "a_00001"}, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, "w_00001").Return(&entity.Wallet{ID: "w_00001"}, nil).Once()
+		transRepo.EXPECT().GetTransactionByID(ctx, "b_00001-2").Return(nil, nil).Once()
+		transRepo.EXPECT().GetBalanceByWalletID(ctx, "w_00001").Return(5000.0, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, "w_00001").Return(0.0, nil).Once()
+		transRepo.EXPECT().SaveTransaction(ctx, mock.MatchedBy(func(trans *entity.Transaction) bool {
+			return trans.ID == "b_00001-2"
+		})).Return(nil).Once()
+
+		//Act
+		got, err := uc.Withdraw(ctx, "", "w_00001", "a_00001", 1000, "VND", "")
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "b_00001-2", got.ID)
 	})
 
 	t.Run("failed to get account by id", func(t *testing.T) {
@@ -336,7 +421,7 @@ func TestTransactionUseCase_Withdraw(t *testing.T) {
 		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(nil, errDB).Once()
 
 		//Act
-		err := uc.Withdraw(ctx, walletID, accountID, amount, currency, note)
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, amount, currency, note)
 
 		//Assert
 		assert.Error(t, err)
@@ -356,11 +441,11 @@ func TestTransactionUseCase_Withdraw(t *testing.T) {
 		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(nil, nil).Once()
 
 		//Act
-		err := uc.Withdraw(ctx, walletID, accountID, amount, currency, note)
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, amount, currency, note)
 
 		//Assert
 		assert.Error(t, err)
-		expectedErr := apperror.ErrInvalidParams(fmt.Errorf("account not found"))
+		expectedErr := apperror.New(apperror.ACCOUNT_NOT_FOUND)
 		assert.Equal(t, expectedErr, err)
 	})
 
@@ -381,10 +466,10 @@ func TestTransactionUseCase_Withdraw(t *testing.T) {
 		}
 
 		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
-		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(nil, errDB).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(nil, errDB).Once()
 
 		//Act
-		err := uc.Withdraw(ctx, walletID, accountID, amount, currency, note)
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, amount, currency, note)
 
 		//Assert
 		assert.Error(t, err)
@@ -408,14 +493,14 @@ func TestTransactionUseCase_Withdraw(t *testing.T) {
 		}
 
 		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
-		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(nil, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(nil, nil).Once()
 
 		//Act
-		err := uc.Withdraw(ctx, walletID, accountID, amount, currency, note)
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, amount, currency, note)
 
 		//Assert
 		assert.Error(t, err)
-		expectedErr := apperror.ErrInvalidParams(fmt.Errorf("wallet not found"))
+		expectedErr := apperror.New(apperror.WALLET_NOT_FOUND)
 		assert.Equal(t, expectedErr, err)
 	})
 
@@ -442,11 +527,11 @@ func TestTransactionUseCase_Withdraw(t *testing.T) {
 		}
 
 		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
-		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(walletMock, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(walletMock, nil).Once()
 		transRepo.EXPECT().GetBalanceByWalletID(ctx, walletID).Return(0, errDB).Once()
 
 		//Act
-		err := uc.Withdraw(ctx, walletID, accountID, amount, currency, note)
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, amount, currency, note)
 
 		//Assert
 		assert.Error(t, err)
@@ -477,18 +562,40 @@ func TestTransactionUseCase_Withdraw(t *testing.T) {
 		}
 
 		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
-		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(walletMock, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(walletMock, nil).Once()
 		transRepo.EXPECT().GetBalanceByWalletID(ctx, walletID).Return(balance, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, walletID).Return(0.0, nil).Once()
 
 		//Act
-		err := uc.Withdraw(ctx, walletID, accountID, amount, currency, note)
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, amount, currency, note)
 
 		//Assert
 		assert.Error(t, err)
-		expectedErr := apperror.ErrInvalidParams(fmt.Errorf("insufficient balance"))
+		expectedErr := apperror.New(apperror.INSUFFICIENT_FUNDS)
 		assert.Equal(t, expectedErr, err)
 	})
 
+	t.Run("insufficient balance after the pending withdrawals", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		walletID := "w_00001"
+		accountID := "a_00001"
+		amount := 1000.0
+		balance := 1500.0
+		pending := 800.0
+
+		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(&entity.LinkedAccount{ID: accountID}, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(&entity.Wallet{ID: walletID}, nil).Once()
+		transRepo.EXPECT().GetBalanceByWalletID(ctx, walletID).Return(balance, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, walletID).Return(pending, nil).Once()
+
+		//Act
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, amount, "VND", "")
+
+		//Assert
+		assert.Equal(t, apperror.New(apperror.INSUFFICIENT_FUNDS), err)
+	})
+
 	t.Run("failed to create withdraw transaction", func(t *testing.T) {
 		//Arrange
 		ctx := context.Background()
@@ -524,12 +631,13 @@ func TestTransactionUseCase_Withdraw(t *testing.T) {
 		}
 
 		transRepo.EXPECT().GetLinkedAccountByID(ctx, accountID).Return(accountMock, nil).Once()
-		transRepo.EXPECT().GetWalletByID(ctx, walletID).Return(walletMock, nil).Once()
+		transRepo.EXPECT().LockWallet(ctx, walletID).Return(walletMock, nil).Once()
 		transRepo.EXPECT().GetBalanceByWalletID(ctx, walletID).Return(balance, nil).Once()
+		transRepo.EXPECT().GetPendingWithdrawals(ctx, walletID).Return(0.0, nil).Once()
 		transRepo.EXPECT().SaveTransaction(ctx, IsMatchByTransaction(newTrans)).Return(errSaveTrans).Once()
 
 		//Act
-		err := uc.Withdraw(ctx, walletID, accountID, amount, currency, note)
+		_, err := uc.Withdraw(ctx, "", walletID, accountID, amount, currency, note)
 
 		//Assert
 		assert.Error(t, err)
@@ -566,10 +674,11 @@ func TestTransactionUseCase_PayTransaction(t *testing.T) {
 
 		paymentSvc.EXPECT().Withdraw(ctx, trans.Amount, trans.Currency, trans.Note).Return(nil).Once()
 
-		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusProcessing, trans.Version).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful, trans.Version+1).Return(nil).Once()
 
 		//Act
-		err := uc.PayTransaction(ctx, trans.ID)
+		err := uc.PayTransaction(ctx, trans.ID, 0)
 
 		//Assert
 		assert.NoError(t, err)
@@ -593,10 +702,11 @@ func TestTransactionUseCase_PayTransaction(t *testing.T) {
 
 		paymentSvc.EXPECT().Deposit(ctx, trans.Amount, trans.Currency, trans.Note).Return(nil).Once()
 
-		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusProcessing, trans.Version).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful, trans.Version+1).Return(nil).Once()
 
 		//Act
-		err := uc.PayTransaction(ctx, trans.ID)
+		err := uc.PayTransaction(ctx, trans.ID, 0)
 
 		//Assert
 		assert.NoError(t, err)
@@ -611,7 +721,7 @@ func TestTransactionUseCase_PayTransaction(t *testing.T) {
 		transRepo.EXPECT().GetTransactionByID(ctx, transID).Return(nil, errDB).Once()
 
 		//Act
-		err := uc.PayTransaction(ctx, transID)
+		err := uc.PayTransaction(ctx, transID, 0)
 
 		//Assert
 		assert.Error(t, err)
@@ -627,11 +737,11 @@ func TestTransactionUseCase_PayTransaction(t *testing.T) {
 		transRepo.EXPECT().GetTransactionByID(ctx, transID).Return(nil, nil).Once()
 
 		//Act
-		err := uc.PayTransaction(ctx, transID)
+		err := uc.PayTransaction(ctx, transID, 0)
 
 		//Assert
 		assert.Error(t, err)
-		expectedErr := apperror.ErrInvalidParams(fmt.Errorf("no transactions found in ready-to-pay status"))
+		expectedErr := apperror.New(apperror.TXN_NOT_FOUND)
 		assert.Equal(t, expectedErr, err)
 	})
 
@@ -654,11 +764,11 @@ func TestTransactionUseCase_PayTransaction(t *testing.T) {
 		transRepo.EXPECT().GetTransactionByID(ctx, transID).Return(trans, nil).Once()
 
 		//Act
-		err := uc.PayTransaction(ctx, transID)
+		err := uc.PayTransaction(ctx, transID, 0)
 
 		//Assert
 		assert.Error(t, err)
-		expectedErr := apperror.ErrInvalidParams(fmt.Errorf("transaction status is not new"))
+		expectedErr := apperror.New(apperror.TXN_INVALID_STATE)
 		assert.Equal(t, expectedErr, err)
 	})
 
@@ -680,10 +790,11 @@ func TestTransactionUseCase_PayTransaction(t *testing.T) {
 		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
 		paymentSvc.EXPECT().Withdraw(ctx, trans.Amount, trans.Currency, trans.Note).Return(errorWithdraw).Once()
 
-		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusFailed).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusProcessing, trans.Version).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusFailed, trans.Version+1).Return(nil).Once()
 
 		//Act
-		err := uc.PayTransaction(ctx, trans.ID)
+		err := uc.PayTransaction(ctx, trans.ID, 0)
 
 		//Assert
 		assert.NoError(t, err)
@@ -707,13 +818,127 @@ func TestTransactionUseCase_PayTransaction(t *testing.T) {
 		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
 		paymentSvc.EXPECT().Deposit(ctx, trans.Amount, trans.Currency, trans.Note).Return(errorWithdraw).Once()
 
-		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusFailed).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusProcessing, trans.Version).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusFailed, trans.Version+1).Return(nil).Once()
+
+		//Act
+		err := uc.PayTransaction(ctx, trans.ID, 0)
+
+		//Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("precondition failed: transaction is not at the expected version", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := entity.NewTransaction("t_00001", "w_00001", "a_00001", 1000000.0, "VND", entity.TransactionIn, "",
+			entity.TransactionStatusNew)
+		trans.Version = 3
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
 
 		//Act
-		err := uc.PayTransaction(ctx, trans.ID)
+		err := uc.PayTransaction(ctx, trans.ID, 2)
+
+		//Assert
+		appErr, ok := apperror.ErrorAs(err)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusPreconditionFailed, appErr.HTTPCode)
+		assert.ErrorIs(t, appErr.Raw, entity.ErrVersionConflict)
+	})
+
+	t.Run("conflict: transaction updated concurrently", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := entity.NewTransaction("t_00001", "w_00001", "a_00001", 1000000.0, "VND", entity.TransactionIn, "",
+			entity.TransactionStatusNew)
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusProcessing, trans.Version).
+			Return(entity.NewVersionConflictError("transaction", trans.ID, trans.Version)).Once()
+
+		//Act
+		err := uc.PayTransaction(ctx, trans.ID, trans.Version)
+
+		//Assert
+		appErr, ok := apperror.ErrorAs(err)
+		assert.True(t, ok)
+		assert.Equal(t, apperror.CODE_CONFLICT, appErr.Code)
+		assert.Equal(t, http.StatusConflict, appErr.HTTPCode)
+	})
+
+	t.Run("not found: transaction deleted concurrently", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := entity.NewTransaction("t_00001", "w_00001", "a_00001", 1000000.0, "VND", entity.TransactionIn, "",
+			entity.TransactionStatusNew)
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusProcessing, trans.Version).
+			Return(fmt.Errorf("transaction %s: %w", trans.ID, entity.ErrNotFound)).Once()
+
+		//Act
+		err := uc.PayTransaction(ctx, trans.ID, trans.Version)
+
+		//Assert
+		assert.Equal(t, apperror.New(apperror.TXN_NOT_FOUND), err)
+	})
+
+	t.Run("failed to store the result leaves the transaction processing", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		trans := entity.NewTransaction("t_00001", "w_00001", "a_00001", 1000000.0, "VND", entity.TransactionIn, "",
+			entity.TransactionStatusNew)
+		errDB := fmt.Errorf("unexpected error")
+
+		transRepo.EXPECT().GetTransactionByID(ctx, trans.ID).Return(trans, nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusProcessing, trans.Version).
+			Return(nil).Once()
+		paymentSvc.EXPECT().Deposit(ctx, trans.Amount, trans.Currency, trans.Note).Return(nil).Once()
+		transRepo.EXPECT().UpdateTransactionStatus(ctx, trans.ID, entity.TransactionStatusSuccessful, trans.Version+1).
+			Return(errDB).Once()
+
+		//Act
+		err := uc.PayTransaction(ctx, trans.ID, trans.Version)
+
+		//Assert
+		assert.Equal(t, apperror.ErrUpdate(errDB, "failed to update transaction status"), err)
+	})
+}
+
+func TestTransactionUseCase_GetTransaction(t *testing.T) {
+	transRepo := mocks2.NewITransactionRepository(t)
+	uc := NewTransactionUseCase(transRepo, nil)
+
+	t.Run("success", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		want := entity.NewTransaction("t_00001", "w_00001", "a_00001", 1000.0, "VND", entity.TransactionIn, "",
+			entity.TransactionStatusNew)
+		transRepo.EXPECT().GetTransactionByID(ctx, want.ID).Return(want, nil).Once()
+
+		//Act
+		got, err := uc.GetTransaction(ctx, want.ID)
 
 		//Assert
 		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		transRepo.EXPECT().GetTransactionByID(ctx, "t_00002").Return(nil, nil).Once()
+
+		//Act
+		got, err := uc.GetTransaction(ctx, "t_00002")
+
+		//Assert
+		assert.Nil(t, got)
+		appErr, ok := apperror.ErrorAs(err)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, appErr.HTTPCode)
+		assert.Equal(t, apperror.TXN_NOT_FOUND, appErr.DomainCode)
 	})
 }
 
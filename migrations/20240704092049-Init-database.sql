@@ -45,3 +45,7 @@ ALTER TABLE transactions ADD CONSTRAINT fk_trans_wallet_id FOREIGN KEY (wallet_i
 ALTER TABLE transactions ADD CONSTRAINT fk_trans_account_id FOREIGN KEY (account_id) REFERENCES linked_accounts(id);
 
 -- +migrate Down
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS linked_accounts;
+DROP TABLE IF EXISTS wallets;
+DROP TABLE IF EXISTS users;
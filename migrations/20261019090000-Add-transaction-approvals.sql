@@ -0,0 +1,20 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS transaction_approvals (
+    id varchar(255) PRIMARY KEY,
+    transaction_id varchar(255) NOT NULL,
+    requested_by varchar(255) NOT NULL,
+    decided_by varchar(255),
+    status varchar(100) NOT NULL,
+    reason text,
+    expires_at timestamp NOT NULL,
+    decided_at timestamp,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE transaction_approvals ADD CONSTRAINT fk_approval_trans_id FOREIGN KEY (transaction_id) REFERENCES transactions(id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_approval_transaction_id ON transaction_approvals (transaction_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS transaction_approvals;
@@ -0,0 +1,32 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS payout_batches (
+    id varchar(255) PRIMARY KEY,
+    created_by varchar(255),
+    status varchar(100) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS payout_items (
+    id varchar(255) PRIMARY KEY,
+    batch_id varchar(255) NOT NULL,
+    line integer NOT NULL,
+    wallet_id varchar(255) NOT NULL,
+    account_id varchar(255) NOT NULL,
+    amount decimal(10, 2) NOT NULL,
+    currency varchar(10) NOT NULL DEFAULT 'VND',
+    note text,
+    status varchar(100) NOT NULL,
+    transaction_id varchar(255),
+    reason text,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE payout_items ADD CONSTRAINT fk_payout_item_batch_id FOREIGN KEY (batch_id) REFERENCES payout_batches(id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payout_item_line ON payout_items (batch_id, line);
+
+-- +migrate Down
+DROP TABLE IF EXISTS payout_items;
+DROP TABLE IF EXISTS payout_batches;
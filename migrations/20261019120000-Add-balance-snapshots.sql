@@ -0,0 +1,17 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+    wallet_id varchar(255) NOT NULL,
+    as_of timestamp NOT NULL,
+    balance decimal(15, 2) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (wallet_id, as_of)
+);
+
+ALTER TABLE balance_snapshots ADD CONSTRAINT fk_balance_snapshot_wallet_id FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_trans_wallet_created_at ON transactions (wallet_id, created_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_trans_wallet_created_at;
+DROP TABLE IF EXISTS balance_snapshots;
@@ -0,0 +1,8 @@
+
+-- +migrate Up
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE transactions DROP COLUMN IF EXISTS version;
+ALTER TABLE wallets DROP COLUMN IF EXISTS version;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE payout_batches ADD COLUMN IF NOT EXISTS claimed_until timestamp;
+CREATE INDEX IF NOT EXISTS idx_payout_batch_claimable ON payout_batches (status, claimed_until);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_payout_batch_claimable;
+ALTER TABLE payout_batches DROP COLUMN IF EXISTS claimed_until;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS settled_at timestamp;
+-- the snapshots taken so far were cut on created_at, the settled transactions keep their side of the close
+UPDATE transactions SET settled_at = created_at WHERE status = 'SUCCESSFUL' AND settled_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_trans_wallet_settled_at ON transactions (wallet_id, settled_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_trans_wallet_settled_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS settled_at;
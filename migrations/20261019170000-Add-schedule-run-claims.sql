@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE schedule_runs ADD COLUMN IF NOT EXISTS claimed_until timestamp;
+
+-- +migrate Down
+ALTER TABLE schedule_runs DROP COLUMN IF EXISTS claimed_until;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE schedules DROP COLUMN IF EXISTS version;
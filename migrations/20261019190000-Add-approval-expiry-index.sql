@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_approval_expired ON transaction_approvals (status, expires_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_approval_expired;
@@ -0,0 +1,20 @@
+// Package migrations embeds the sql migrations, so the binaries applying them do not depend on the working directory
+package migrations
+
+import (
+	"embed"
+
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+//go:embed *.sql
+var postgresFS embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+// Postgres is the source of the postgres migrations
+var Postgres = migrate.EmbedFileSystemMigrationSource{FileSystem: postgresFS, Root: "."}
+
+// SQLite is the source of the SQLite migrations, one for every postgres migration with the same id
+var SQLite = migrate.EmbedFileSystemMigrationSource{FileSystem: sqliteFS, Root: "sqlite"}
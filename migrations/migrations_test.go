@@ -0,0 +1,80 @@
+package migrations_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go-clean-template/internal/infras/sqlitestore"
+	"go-clean-template/migrations"
+	"go-clean-template/pkg/testutil"
+
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSQLiteMirrorsPostgres(t *testing.T) {
+	postgres, err := migrations.Postgres.FindMigrations()
+	require.NoError(t, err)
+	sqlite, err := migrations.SQLite.FindMigrations()
+	require.NoError(t, err)
+
+	assert.Equal(t, migrationIDs(postgres), migrationIDs(sqlite))
+}
+
+func TestRoundTrip_SQLite(t *testing.T) {
+	db, err := sqlitestore.NewDB(sqlitestore.Options{Path: filepath.Join(t.TempDir(), "wallet.db")})
+	require.NoError(t, err)
+
+	assertRoundTrip(t, db, sqlitestore.Dialect, migrations.SQLite)
+}
+
+func TestRoundTrip_Postgres(t *testing.T) {
+	db := testutil.CreateConnection(t, "test1", "test1", "123456")
+
+	assertRoundTrip(t, db, "postgres", migrations.Postgres)
+}
+
+// assertRoundTrip applies every migration, reverts them all then applies them again,
+// so a Down that leaves a table or an index behind fails the second Up
+func assertRoundTrip(t *testing.T, db *gorm.DB, dialect string, source migrate.MigrationSource) {
+	t.Helper()
+
+	// Arrange
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	known, err := source.FindMigrations()
+	require.NoError(t, err)
+	tables := []string{"users", "wallets", "linked_accounts", "transactions", "transaction_approvals",
+		"schedules", "schedule_runs", "payout_batches", "payout_items", "balance_snapshots"}
+
+	// Act
+	up, err := migrate.Exec(sqlDB, dialect, source, migrate.Up)
+	require.NoError(t, err)
+	assert.Equal(t, len(known), up)
+	for _, table := range tables {
+		assert.True(t, db.Migrator().HasTable(table), table)
+	}
+
+	down, err := migrate.Exec(sqlDB, dialect, source, migrate.Down)
+	require.NoError(t, err)
+	assert.Equal(t, len(known), down)
+	for _, table := range tables {
+		assert.False(t, db.Migrator().HasTable(table), table)
+	}
+
+	again, err := migrate.Exec(sqlDB, dialect, source, migrate.Up)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, len(known), again)
+}
+
+func migrationIDs(migrations []*migrate.Migration) []string {
+	ids := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		ids = append(ids, m.Id)
+	}
+	return ids
+}
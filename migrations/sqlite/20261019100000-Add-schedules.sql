@@ -0,0 +1,37 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS schedules (
+    id varchar(255) PRIMARY KEY,
+    wallet_id varchar(255) NOT NULL,
+    account_id varchar(255) NOT NULL,
+    amount decimal(10, 2) NOT NULL,
+    currency varchar(10) NOT NULL DEFAULT 'VND',
+    transaction_kind varchar(100) NOT NULL,
+    note text,
+    frequency varchar(100) NOT NULL,
+    start_at timestamp NOT NULL,
+    next_run_at timestamp NOT NULL,
+    status varchar(100) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_schedule_wallet_id FOREIGN KEY (wallet_id) REFERENCES wallets(id),
+    CONSTRAINT fk_schedule_account_id FOREIGN KEY (account_id) REFERENCES linked_accounts(id)
+);
+
+CREATE TABLE IF NOT EXISTS schedule_runs (
+    id varchar(255) PRIMARY KEY,
+    schedule_id varchar(255) NOT NULL,
+    occurrence_at timestamp NOT NULL,
+    status varchar(100) NOT NULL,
+    reason text,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_schedule_run_schedule_id FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_schedule_run_occurrence ON schedule_runs (schedule_id, occurrence_at);
+CREATE INDEX IF NOT EXISTS idx_schedule_due ON schedules (status, next_run_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS schedule_runs;
+DROP TABLE IF EXISTS schedules;
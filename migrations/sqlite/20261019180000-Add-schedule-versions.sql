@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE schedules ADD COLUMN version bigint NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE schedules DROP COLUMN version;
@@ -0,0 +1,17 @@
+package apiclient
+
+// The typed client of the HTTP server is generated from api/openapi.yaml, run go generate after changing it
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.1 -config oapi-codegen.yaml ../../api/openapi.yaml
+
+import (
+	"context"
+	"net/http"
+)
+
+// WithBearerToken authenticates every request with the access token
+func WithBearerToken(token string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
@@ -0,0 +1,178 @@
+package apperror
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DomainCode is the stable code of a domain error, the clients switch on it instead of matching the message
+type DomainCode string
+
+const (
+	WALLET_NOT_FOUND       DomainCode = "WALLET_NOT_FOUND"
+	ACCOUNT_NOT_FOUND      DomainCode = "ACCOUNT_NOT_FOUND"
+	TXN_NOT_FOUND          DomainCode = "TXN_NOT_FOUND"
+	TXN_INVALID_STATE      DomainCode = "TXN_INVALID_STATE"
+	TXN_AWAITING_APPROVAL  DomainCode = "TXN_AWAITING_APPROVAL"
+	INSUFFICIENT_FUNDS     DomainCode = "INSUFFICIENT_FUNDS"
+	APPROVAL_DISABLED      DomainCode = "APPROVAL_DISABLED"
+	APPROVAL_NOT_FOUND     DomainCode = "APPROVAL_NOT_FOUND"
+	APPROVAL_EXPIRED       DomainCode = "APPROVAL_EXPIRED"
+	SCHEDULE_NOT_FOUND     DomainCode = "SCHEDULE_NOT_FOUND"
+	PAYOUT_BATCH_NOT_FOUND DomainCode = "PAYOUT_BATCH_NOT_FOUND"
+	INVALID_PERIOD         DomainCode = "INVALID_PERIOD"
+	VERSION_CONFLICT       DomainCode = "VERSION_CONFLICT"
+	VERSION_MISMATCH       DomainCode = "VERSION_MISMATCH"
+	SELF_APPROVAL          DomainCode = "SELF_APPROVAL"
+)
+
+// The languages of the catalog messages, English is the fallback
+const (
+	LanguageEnglish    = "en"
+	LanguageVietnamese = "vi"
+)
+
+var languages = map[string]bool{LanguageEnglish: true, LanguageVietnamese: true}
+
+type catalogEntry struct {
+	httpCode int
+	code     Code
+	messages map[string]string
+}
+
+// catalog holds every domain error, a new code needs a message in each language
+var catalog = map[DomainCode]catalogEntry{
+	WALLET_NOT_FOUND: {http.StatusNotFound, CODE_NOT_FOUND, map[string]string{
+		LanguageEnglish:    "wallet not found",
+		LanguageVietnamese: "không tìm thấy ví",
+	}},
+	ACCOUNT_NOT_FOUND: {http.StatusNotFound, CODE_NOT_FOUND, map[string]string{
+		LanguageEnglish:    "account not found",
+		LanguageVietnamese: "không tìm thấy tài khoản liên kết",
+	}},
+	TXN_NOT_FOUND: {http.StatusNotFound, CODE_NOT_FOUND, map[string]string{
+		LanguageEnglish:    "transaction not found",
+		LanguageVietnamese: "không tìm thấy giao dịch",
+	}},
+	TXN_INVALID_STATE: {http.StatusBadRequest, CODE_INVALID_PARAMS, map[string]string{
+		LanguageEnglish:    "transaction status does not allow this operation",
+		LanguageVietnamese: "trạng thái giao dịch không cho phép thao tác này",
+	}},
+	TXN_AWAITING_APPROVAL: {http.StatusBadRequest, CODE_INVALID_PARAMS, map[string]string{
+		LanguageEnglish:    "transaction is awaiting approval",
+		LanguageVietnamese: "giao dịch đang chờ phê duyệt",
+	}},
+	INSUFFICIENT_FUNDS: {http.StatusBadRequest, CODE_INVALID_PARAMS, map[string]string{
+		LanguageEnglish:    "insufficient balance",
+		LanguageVietnamese: "số dư không đủ",
+	}},
+	APPROVAL_DISABLED: {http.StatusBadRequest, CODE_INVALID_PARAMS, map[string]string{
+		LanguageEnglish:    "approval workflow is disabled",
+		LanguageVietnamese: "quy trình phê duyệt đang tắt",
+	}},
+	APPROVAL_NOT_FOUND: {http.StatusNotFound, CODE_NOT_FOUND, map[string]string{
+		LanguageEnglish:    "approval not found",
+		LanguageVietnamese: "không tìm thấy yêu cầu phê duyệt",
+	}},
+	APPROVAL_EXPIRED: {http.StatusBadRequest, CODE_INVALID_PARAMS, map[string]string{
+		LanguageEnglish:    "approval has expired",
+		LanguageVietnamese: "yêu cầu phê duyệt đã hết hạn",
+	}},
+	SCHEDULE_NOT_FOUND: {http.StatusNotFound, CODE_NOT_FOUND, map[string]string{
+		LanguageEnglish:    "schedule not found",
+		LanguageVietnamese: "không tìm thấy lịch giao dịch",
+	}},
+	PAYOUT_BATCH_NOT_FOUND: {http.StatusNotFound, CODE_NOT_FOUND, map[string]string{
+		LanguageEnglish:    "payout batch not found",
+		LanguageVietnamese: "không tìm thấy lô chi trả",
+	}},
+	INVALID_PERIOD: {http.StatusBadRequest, CODE_INVALID_PARAMS, map[string]string{
+		LanguageEnglish:    "from must be before to",
+		LanguageVietnamese: "thời điểm bắt đầu phải trước thời điểm kết thúc",
+	}},
+	VERSION_CONFLICT: {http.StatusConflict, CODE_CONFLICT, map[string]string{
+		LanguageEnglish:    "the resource was modified, get it again and retry",
+		LanguageVietnamese: "dữ liệu đã bị thay đổi, vui lòng tải lại và thử lại",
+	}},
+	VERSION_MISMATCH: {http.StatusPreconditionFailed, CODE_CONFLICT, map[string]string{
+		LanguageEnglish:    "the resource is no longer at the version of If-Match, get it again and retry",
+		LanguageVietnamese: "dữ liệu không còn ở phiên bản của If-Match, vui lòng tải lại và thử lại",
+	}},
+	SELF_APPROVAL: {http.StatusForbidden, CODE_NO_PERMISSION, map[string]string{
+		LanguageEnglish:    "approver must be different from requester",
+		LanguageVietnamese: "người phê duyệt phải khác người yêu cầu",
+	}},
+}
+
+// New creates the domain error of the catalog, its message is in English until it is localized
+func New(dc DomainCode) *Error {
+	entry, ok := catalog[dc]
+	if !ok {
+		return &Error{
+			HTTPCode:   http.StatusInternalServerError,
+			Code:       CODE_UNSPECIFIED,
+			DomainCode: dc,
+			Message:    string(dc),
+		}
+	}
+	return &Error{
+		HTTPCode:   entry.httpCode,
+		Code:       entry.code,
+		DomainCode: dc,
+		Message:    entry.messages[LanguageEnglish],
+	}
+}
+
+// Is reports whether err is the domain error dc
+func Is(err error, dc DomainCode) bool {
+	e, ok := ErrorAs(err)
+	return ok && e.DomainCode == dc
+}
+
+// LocalizedMessage is the message of the domain error in lang, or the message of the error when the catalog
+// has none
+func (e *Error) LocalizedMessage(lang string) string {
+	if entry, ok := catalog[e.DomainCode]; ok {
+		if msg, ok := entry.messages[lang]; ok {
+			return msg
+		}
+	}
+	return e.Message
+}
+
+// MatchLanguage picks the catalog language preferred by an Accept-Language header, English when none matches
+func MatchLanguage(acceptLanguage string) string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+	var prefs []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		// vi-VN is served in vi
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if primary != "" && q > 0 {
+			prefs = append(prefs, weighted{lang: primary, q: q})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool {
+		return prefs[i].q > prefs[j].q
+	})
+
+	for _, p := range prefs {
+		if languages[p.lang] {
+			return p.lang
+		}
+	}
+	return LanguageEnglish
+}
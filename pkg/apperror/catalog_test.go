@@ -0,0 +1,101 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog(t *testing.T) {
+	for dc, entry := range catalog {
+		for lang := range languages {
+			assert.NotEmpty(t, entry.messages[lang], "%s has no %s message", dc, lang)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("a catalog error", func(t *testing.T) {
+		// Act
+		err := New(WALLET_NOT_FOUND)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+		assert.Equal(t, CODE_NOT_FOUND, err.Code)
+		assert.Equal(t, "WALLET_NOT_FOUND: wallet not found", err.Error())
+		assert.Equal(t, "không tìm thấy ví", err.LocalizedMessage(LanguageVietnamese))
+		assert.Equal(t, "wallet not found", err.LocalizedMessage("fr"))
+	})
+
+	t.Run("a code missing from the catalog is an internal error", func(t *testing.T) {
+		// Act
+		err := New("WALLET_FROZEN")
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, err.HTTPCode)
+		assert.Equal(t, "WALLET_FROZEN", err.LocalizedMessage(LanguageEnglish))
+	})
+}
+
+func TestIs(t *testing.T) {
+	assert.True(t, Is(fmt.Errorf("withdraw: %w", New(INSUFFICIENT_FUNDS)), INSUFFICIENT_FUNDS))
+	assert.True(t, Is(ErrInvalidParams(New(INSUFFICIENT_FUNDS)), INSUFFICIENT_FUNDS))
+	assert.False(t, Is(New(WALLET_NOT_FOUND), INSUFFICIENT_FUNDS))
+	assert.False(t, Is(errors.New("insufficient balance"), INSUFFICIENT_FUNDS))
+}
+
+func TestError_Unwrap(t *testing.T) {
+	errDB := errors.New("write conflict")
+	assert.ErrorIs(t, ErrGet(errDB, "failed to get wallet by id"), errDB)
+	assert.Nil(t, New(WALLET_NOT_FOUND).Unwrap())
+	assert.ErrorIs(t, New(VERSION_CONFLICT).WithRaw(errDB), errDB)
+}
+
+func TestErrInvalidParams(t *testing.T) {
+	t.Run("a plain error is a bad request", func(t *testing.T) {
+		// Act
+		err := ErrInvalidParams(errors.New("amount is required"))
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, err.HTTPCode)
+		assert.Equal(t, CODE_INVALID_PARAMS, err.Code)
+	})
+
+	t.Run("a wrapped error keeps its status and code", func(t *testing.T) {
+		// Act
+		err := ErrInvalidParams(fmt.Errorf("schedule: %w", New(WALLET_NOT_FOUND)))
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, err.HTTPCode)
+		assert.Equal(t, WALLET_NOT_FOUND, err.DomainCode)
+	})
+}
+
+func TestMatchLanguage(t *testing.T) {
+	testCases := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{acceptLanguage: "", want: LanguageEnglish},
+		{acceptLanguage: "vi", want: LanguageVietnamese},
+		{acceptLanguage: "vi-VN,vi;q=0.9,en-US;q=0.8", want: LanguageVietnamese},
+		{acceptLanguage: "en-US,vi;q=0.5", want: LanguageEnglish},
+		{acceptLanguage: "fr-FR,vi;q=0.7,en;q=0.3", want: LanguageVietnamese},
+		{acceptLanguage: "en;q=0.2,vi;q=0.8", want: LanguageVietnamese},
+		{acceptLanguage: "vi;q=0,en", want: LanguageEnglish},
+		{acceptLanguage: "*", want: LanguageEnglish},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.acceptLanguage, func(t *testing.T) {
+			// Act
+			got := MatchLanguage(tc.acceptLanguage)
+
+			// Assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
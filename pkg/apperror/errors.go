@@ -26,6 +26,7 @@ const (
 	CODE_DELETE_FAILED             Code = 100008
 	CODE_CALL_THIRD_PARTY_FAILED   Code = 100009
 	CODE_OTHER_INTERNAL_SERVER_ERR Code = 100010
+	CODE_CONFLICT                  Code = 100011
 )
 
 // Error apperror implement apperror built in go.
@@ -35,6 +36,8 @@ type Error struct {
 	HTTPCode int         `json:"http_code"`
 	Raw      error       `json:"raw"`
 	Info     interface{} `json:"info"`
+	// DomainCode is set on the errors of the catalog
+	DomainCode DomainCode `json:"domain_code"`
 }
 
 func (e *Error) Error() string {
@@ -43,6 +46,9 @@ func (e *Error) Error() string {
 	}
 
 	msg := fmt.Sprintf("%d", e.Code)
+	if e.DomainCode != "" {
+		msg = string(e.DomainCode)
+	}
 	if len(e.Message) > 0 {
 		msg += ": " + e.Message
 	}
@@ -54,6 +60,17 @@ func (e *Error) WithInfo(info interface{}) *Error {
 	return e
 }
 
+// WithRaw keeps the error behind a catalog error, the responses only show it on APP_ENV local or dev
+func (e *Error) WithRaw(err error) *Error {
+	e.Raw = err
+	return e
+}
+
+// Unwrap returns the error of the storage or the provider, so the callers can still match it
+func (e *Error) Unwrap() error {
+	return e.Raw
+}
+
 //ErrorAs finds the first apperror in err's chain that matches Error type and returns it.
 func ErrorAs(err error) (*Error, bool) {
 	pe := new(Error)
@@ -81,12 +98,17 @@ func ErrNoPermission() *Error {
 	}
 }
 
+// ErrInvalidParams is a bad request, unless err is already an *Error: its status and code are kept, so a
+// wrapped WALLET_NOT_FOUND is still a not found.
 func ErrInvalidParams(err error) *Error {
 	if e, ok := ErrorAs(err); ok {
 		return &Error{
-			HTTPCode: http.StatusBadRequest,
-			Code:     e.Code,
-			Message:  e.Message,
+			HTTPCode:   e.HTTPCode,
+			Code:       e.Code,
+			DomainCode: e.DomainCode,
+			Message:    e.Message,
+			Info:       e.Info,
+			Raw:        e.Raw,
 		}
 	}
 
@@ -134,6 +156,16 @@ func ErrDelete(err error, msg string) *Error {
 	}
 }
 
+// ErrConflict is an update that lost the race with another one, the client has to read the resource again
+func ErrConflict(err error, msg string) *Error {
+	return &Error{
+		Raw:      err,
+		HTTPCode: http.StatusConflict,
+		Code:     CODE_CONFLICT,
+		Message:  msg,
+	}
+}
+
 func ErrThirdParty(err error, msg string) *Error {
 	return &Error{
 		Raw:      err,
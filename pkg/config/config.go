@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -17,6 +18,18 @@ type Config struct {
 	CognitoIssuer     string `envconfig:"COGNITO_ISSUER"`
 	CognitoURLGetJWKS string `envconfig:"COGNITO_URL_GET_JWKS"`
 	UserPoolID        string `envconfig:"USER_POOL_ID"`
+	// AdminGroup is the Cognito group allowed on the /admin routes
+	AdminGroup string `envconfig:"ADMIN_GROUP" default:"admin"`
+	// PayoutGroup is the Cognito group allowed on the payout batches, besides the admin group
+	PayoutGroup string `envconfig:"PAYOUT_GROUP" default:"operations"`
+	// ApproverGroup is the Cognito group allowed to approve or reject withdrawals, besides the admin group
+	ApproverGroup string `envconfig:"APPROVER_GROUP" default:"approvers"`
+	IDStrategy    string `envconfig:"ID_STRATEGY" default:"uuidv7"`
+	StorageDriver string `envconfig:"STORAGE_DRIVER" default:"mongo"`
+	// LogLevel is the initial level, PUT /admin/log-level changes it at runtime
+	LogLevel string `envconfig:"LOG_LEVEL" default:"info"`
+	// ShutdownTimeout bounds the graceful shutdown, in-flight requests included
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
 
 	DB struct {
 		Name      string `envconfig:"DB_NAME"`
@@ -25,6 +38,27 @@ type Config struct {
 		User      string `envconfig:"DB_USER"`
 		Pass      string `envconfig:"DB_PASS"`
 		EnableSSL bool   `envconfig:"ENABLE_SSL"`
+		// SSLMode overrides ENABLE_SSL with a libpq sslmode, verify-full checks the server against SSLRootCert
+		SSLMode     string `envconfig:"DB_SSL_MODE"`
+		SSLRootCert string `envconfig:"DB_SSL_ROOT_CERT"`
+		SSLCert     string `envconfig:"DB_SSL_CERT"`
+		SSLKey      string `envconfig:"DB_SSL_KEY"`
+
+		ConnectTimeout   time.Duration `envconfig:"DB_CONNECT_TIMEOUT" default:"5s"`
+		StatementTimeout time.Duration `envconfig:"DB_STATEMENT_TIMEOUT"`
+		MaxOpenConns     int           `envconfig:"DB_MAX_OPEN_CONNS" default:"25"`
+		MaxIdleConns     int           `envconfig:"DB_MAX_IDLE_CONNS" default:"5"`
+		ConnMaxLifetime  time.Duration `envconfig:"DB_CONN_MAX_LIFETIME" default:"30m"`
+		ConnMaxIdleTime  time.Duration `envconfig:"DB_CONN_MAX_IDLE_TIME" default:"5m"`
+
+		// LogLevel is silent, error, warn or info, slow statements are warnings
+		LogLevel      string        `envconfig:"DB_LOG_LEVEL" default:"warn"`
+		SlowThreshold time.Duration `envconfig:"DB_SLOW_THRESHOLD" default:"200ms"`
+		LogParams     bool          `envconfig:"DB_LOG_PARAMS"`
+
+		// ReplicaDSNs are comma separated DSNs of read replicas serving the endpoints that only read: the
+		// transaction, schedule, payout batch, balance and statement reads
+		ReplicaDSNs []string `envconfig:"DB_REPLICA_DSNS"`
 	}
 
 	MongoDB struct {
@@ -33,6 +67,86 @@ type Config struct {
 		DBName string `envconfig:"DB_MONGO_NAME"`
 		User   string `envconfig:"DB_MONGO_USER"`
 		Pass   string `envconfig:"DB_MONGO_PASS"`
+		// URI is a full connection string replacing Host and Port, the options below override its own
+		URI        string `envconfig:"DB_MONGO_URI"`
+		AuthSource string `envconfig:"DB_MONGO_AUTH_SOURCE"`
+		ReplicaSet string `envconfig:"DB_MONGO_REPLICA_SET"`
+
+		ReadPreference string `envconfig:"DB_MONGO_READ_PREFERENCE"`
+		WriteConcern   string `envconfig:"DB_MONGO_WRITE_CONCERN"`
+
+		TLS            bool   `envconfig:"DB_MONGO_TLS"`
+		TLSCAFile      string `envconfig:"DB_MONGO_TLS_CA_FILE"`
+		TLSCertKeyFile string `envconfig:"DB_MONGO_TLS_CERT_KEY_FILE"`
+
+		MaxPoolSize            uint64        `envconfig:"DB_MONGO_MAX_POOL_SIZE" default:"100"`
+		MinPoolSize            uint64        `envconfig:"DB_MONGO_MIN_POOL_SIZE"`
+		MaxConnIdleTime        time.Duration `envconfig:"DB_MONGO_MAX_CONN_IDLE_TIME" default:"5m"`
+		ConnectTimeout         time.Duration `envconfig:"DB_MONGO_CONNECT_TIMEOUT" default:"10s"`
+		ServerSelectionTimeout time.Duration `envconfig:"DB_MONGO_SERVER_SELECTION_TIMEOUT" default:"10s"`
+
+		// LogLevel is silent, error, warn or info, slow commands are warnings
+		LogLevel      string        `envconfig:"DB_MONGO_LOG_LEVEL" default:"warn"`
+		SlowThreshold time.Duration `envconfig:"DB_MONGO_SLOW_THRESHOLD" default:"200ms"`
+	}
+
+	SQLite struct {
+		Path        string        `envconfig:"SQLITE_PATH"`
+		BusyTimeout time.Duration `envconfig:"SQLITE_BUSY_TIMEOUT" default:"5s"`
+		// MaxOpenConns bounds the connections reading at the same time, the writes take turns on any of them
+		MaxOpenConns int `envconfig:"SQLITE_MAX_OPEN_CONNS" default:"4"`
+	}
+
+	Memory struct {
+		SnapshotFile string `envconfig:"MEMORY_SNAPSHOT_FILE"`
+	}
+
+	Approval struct {
+		Threshold float64       `envconfig:"APPROVAL_THRESHOLD"`
+		TTL       time.Duration `envconfig:"APPROVAL_TTL" default:"24h"`
+		// SweepInterval is how often the worker expires the approvals past their deadline
+		SweepInterval time.Duration `envconfig:"APPROVAL_SWEEP_INTERVAL" default:"1m"`
+	}
+
+	Scheduler struct {
+		Interval time.Duration `envconfig:"SCHEDULER_INTERVAL" default:"1m"`
+	}
+
+	Payout struct {
+		Concurrency int `envconfig:"PAYOUT_CONCURRENCY" default:"5"`
+		// ResumeInterval is how often the worker looks for the batches left unfinished by a stopped server
+		ResumeInterval time.Duration `envconfig:"PAYOUT_RESUME_INTERVAL" default:"1m"`
+	}
+
+	Readiness struct {
+		CheckTimeout time.Duration `envconfig:"READINESS_CHECK_TIMEOUT" default:"2s"`
+		CacheTTL     time.Duration `envconfig:"READINESS_CACHE_TTL" default:"5s"`
+		// ShutdownDelay keeps serving while /readyz fails, so the load balancer stops routing to the
+		// server before it closes its listener
+		ShutdownDelay time.Duration `envconfig:"READINESS_SHUTDOWN_DELAY"`
+	}
+
+	Metrics struct {
+		Enabled bool `envconfig:"METRICS_ENABLED" default:"true"`
+		// BacklogTimeout bounds the count of the transaction backlog done on every scrape
+		BacklogTimeout time.Duration `envconfig:"METRICS_BACKLOG_TIMEOUT" default:"5s"`
+	}
+
+	ErrorReport struct {
+		// Reporter is sentry, log or none, sentry logs the errors without SENTRY_DSN or on APP_ENV=local
+		Reporter   string  `envconfig:"ERROR_REPORTER" default:"sentry"`
+		SampleRate float64 `envconfig:"ERROR_REPORT_SAMPLE_RATE" default:"1"`
+	}
+
+	Tracing struct {
+		// Exporter is otlp, stdout or none, otlp reads the standard OTEL_EXPORTER_OTLP_* variables
+		Exporter    string  `envconfig:"TRACING_EXPORTER" default:"none"`
+		ServiceName string  `envconfig:"TRACING_SERVICE_NAME" default:"go-clean-template"`
+		SampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+	}
+
+	BalanceSnapshot struct {
+		Interval time.Duration `envconfig:"BALANCE_SNAPSHOT_INTERVAL" default:"1h"`
 	}
 }
 
@@ -48,3 +162,9 @@ func LoadConfig() (*Config, error) {
 
 	return cfg, nil
 }
+
+// IsDevelopment reports whether APP_ENV is local or dev, the only environments where the error responses
+// show their raw errors
+func (c *Config) IsDevelopment() bool {
+	return c.AppEnv == "local" || c.AppEnv == "dev"
+}
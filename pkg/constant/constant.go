@@ -2,4 +2,6 @@ package constant
 
 const (
 	UserIDKey = "USER_NAME_COGNITO"
+	// UserGroupsKey holds the Cognito groups of the user, a []string
+	UserGroupsKey = "USER_GROUPS_COGNITO"
 )
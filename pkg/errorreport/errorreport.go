@@ -0,0 +1,95 @@
+// Package errorreport reports errors with the scope of the request or the job that failed: its user,
+// transaction, tags and breadcrumbs, whatever sends them
+package errorreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-clean-template/pkg/config"
+
+	"go.uber.org/zap"
+)
+
+type Level string
+
+const (
+	LevelDebug   Level = "debug"
+	LevelInfo    Level = "info"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+	LevelFatal   Level = "fatal"
+)
+
+const (
+	ReporterSentry = "sentry"
+	ReporterLog    = "log"
+	ReporterNone   = "none"
+)
+
+// ErrorReporter sends errors and messages with the scope of ctx
+type ErrorReporter interface {
+	CaptureError(ctx context.Context, err error)
+	CaptureMessage(ctx context.Context, level Level, message string)
+	// Flush waits for the events being sent until ctx is done
+	Flush(ctx context.Context) error
+}
+
+// Event is an error or a message with the scope it was reported in
+type Event struct {
+	Level       Level
+	Err         error
+	Message     string
+	UserID      string
+	Tags        map[string]string
+	Breadcrumbs []Breadcrumb
+	TraceID     string
+	Timestamp   time.Time
+}
+
+type Options struct {
+	// Reporter is sentry, log or none. sentry falls back to log without DSN or in the local environment.
+	Reporter    string
+	DSN         string
+	Environment string
+	// SampleRate is the share of the events sent to Sentry, the others are dropped
+	SampleRate float64
+}
+
+func ParseFromConfig(c *config.Config) Options {
+	return Options{
+		Reporter:    c.ErrorReport.Reporter,
+		DSN:         c.SentryDSN,
+		Environment: c.AppEnv,
+		SampleRate:  c.ErrorReport.SampleRate,
+	}
+}
+
+// New creates the reporter of opt, the log reporter writes to logger
+func New(opt Options, logger *zap.SugaredLogger) (ErrorReporter, error) {
+	switch opt.Reporter {
+	case ReporterNone:
+		return Noop{}, nil
+	case ReporterLog, "":
+		return NewLog(logger), nil
+	case ReporterSentry:
+		if opt.DSN == "" || opt.Environment == "local" {
+			return NewLog(logger), nil
+		}
+		return NewSentry(opt)
+	default:
+		return nil, fmt.Errorf("unknown error reporter %q, must be sentry, log or none", opt.Reporter)
+	}
+}
+
+// Noop drops the events
+type Noop struct{}
+
+func (Noop) CaptureError(context.Context, error) {}
+
+func (Noop) CaptureMessage(context.Context, Level, string) {}
+
+func (Noop) Flush(context.Context) error {
+	return nil
+}
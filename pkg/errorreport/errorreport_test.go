@@ -0,0 +1,222 @@
+package errorreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	sentrygo "github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name    string
+		opt     Options
+		want    ErrorReporter
+		wantErr string
+	}{
+		{name: "none", opt: Options{Reporter: ReporterNone}, want: Noop{}},
+		{name: "log", opt: Options{Reporter: ReporterLog, DSN: "https://key@sentry.example.com/1"}, want: &Log{}},
+		{name: "sentry without DSN logs", opt: Options{Reporter: ReporterSentry}, want: &Log{}},
+		{
+			name: "sentry in the local environment logs",
+			opt:  Options{Reporter: ReporterSentry, DSN: "https://key@sentry.example.com/1", Environment: "local"},
+			want: &Log{},
+		},
+		{name: "unknown", opt: Options{Reporter: "rollbar"}, wantErr: `unknown error reporter "rollbar"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			r, err := New(tc.opt, zap.NewNop().Sugar())
+
+			// Assert
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tc.want, r)
+		})
+	}
+}
+
+func TestScope(t *testing.T) {
+	t.Run("the events carry the scope and the trace of the context", func(t *testing.T) {
+		// Arrange
+		r := NewMemory()
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  trace.SpanID{1},
+		}))
+		ctx = NewScope(ctx)
+		SetUser(ctx, "u1")
+		SetTransaction(ctx, "t1")
+		AddBreadcrumb(ctx, "psp", "payment sent", map[string]interface{}{"status": "FAILED"})
+
+		// Act
+		r.CaptureError(ctx, errors.New("connection refused"))
+
+		// Assert
+		events := r.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, LevelError, events[0].Level)
+		assert.Equal(t, "connection refused", events[0].Message)
+		assert.Equal(t, "u1", events[0].UserID)
+		assert.Equal(t, map[string]string{"transaction_id": "t1"}, events[0].Tags)
+		assert.Equal(t, traceID.String(), events[0].TraceID)
+		require.Len(t, events[0].Breadcrumbs, 1)
+		assert.Equal(t, "payment sent", events[0].Breadcrumbs[0].Message)
+	})
+
+	t.Run("without scope the data is dropped", func(t *testing.T) {
+		// Arrange
+		r := NewMemory()
+		ctx := context.Background()
+		SetUser(ctx, "u1")
+		AddBreadcrumb(ctx, "psp", "payment sent", nil)
+
+		// Act
+		r.CaptureMessage(ctx, LevelWarning, "slow PSP")
+
+		// Assert
+		events := r.Events()
+		require.Len(t, events, 1)
+		assert.Empty(t, events[0].UserID)
+		assert.Empty(t, events[0].Breadcrumbs)
+	})
+
+	t.Run("a new scope starts from its parent without changing it", func(t *testing.T) {
+		// Arrange
+		r := NewMemory()
+		parent := NewScope(context.Background())
+		SetTag(parent, "request_id", "r1")
+
+		// Act
+		child := NewScope(parent)
+		SetTag(child, "batch_id", "b1")
+		r.CaptureError(parent, errors.New("parent"))
+		r.CaptureError(child, errors.New("child"))
+
+		// Assert
+		events := r.Events()
+		assert.Equal(t, map[string]string{"request_id": "r1"}, events[0].Tags)
+		assert.Equal(t, map[string]string{"request_id": "r1", "batch_id": "b1"}, events[1].Tags)
+	})
+
+	t.Run("only the last breadcrumbs are kept", func(t *testing.T) {
+		// Arrange
+		ctx := NewScope(context.Background())
+
+		// Act
+		for i := 0; i < MaxBreadcrumbs+5; i++ {
+			AddBreadcrumb(ctx, "step", fmt.Sprint(i), nil)
+		}
+
+		// Assert
+		e := NewEvent(ctx, LevelError, nil, "failed")
+		require.Len(t, e.Breadcrumbs, MaxBreadcrumbs)
+		assert.Equal(t, "5", e.Breadcrumbs[0].Message)
+	})
+}
+
+func TestLog(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zap.DebugLevel)
+	r := NewLog(zap.New(core).Sugar())
+	ctx := NewScope(context.Background())
+	SetUser(ctx, "u1")
+	SetTransaction(ctx, "t1")
+	AddBreadcrumb(ctx, "transaction", "withdrawal created", nil)
+
+	// Act
+	r.CaptureError(ctx, errors.New("connection refused"))
+
+	// Assert
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, zap.ErrorLevel, entry.Level)
+	assert.Equal(t, "connection refused", entry.Message)
+	assert.Equal(t, "u1", entry.ContextMap()["user_id"])
+	assert.Equal(t, []interface{}{"transaction: withdrawal created"}, entry.ContextMap()["breadcrumbs"])
+}
+
+// transport keeps the events instead of sending them
+type transport struct {
+	mu     sync.Mutex
+	events []*sentrygo.Event
+}
+
+func (t *transport) Configure(sentrygo.ClientOptions) {}
+
+func (t *transport) SendEvent(e *sentrygo.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, e)
+}
+
+func (t *transport) Flush(time.Duration) bool {
+	return true
+}
+
+func TestSentry(t *testing.T) {
+	t.Run("sends the error with its scope", func(t *testing.T) {
+		// Arrange
+		tr := &transport{}
+		r, err := newSentry(Options{DSN: "https://key@sentry.example.com/1", SampleRate: 1}, tr)
+		require.NoError(t, err)
+		ctx := NewScope(context.Background())
+		SetUser(ctx, "u1")
+		SetTransaction(ctx, "t1")
+		AddBreadcrumb(ctx, "psp", "payment sent", nil)
+
+		// Act
+		r.CaptureError(ctx, errors.New("connection refused"))
+		r.CaptureError(context.Background(), errors.New("unscoped"))
+
+		// Assert
+		require.NoError(t, r.Flush(context.Background()))
+		require.Len(t, tr.events, 2)
+		e := tr.events[0]
+		assert.Equal(t, sentrygo.LevelError, e.Level)
+		assert.Equal(t, "connection refused", e.Exception[0].Value)
+		assert.Equal(t, "u1", e.User.ID)
+		assert.Equal(t, "t1", e.Tags["transaction_id"])
+		require.Len(t, e.Breadcrumbs, 1)
+		assert.Equal(t, "psp", e.Breadcrumbs[0].Category)
+		assert.Empty(t, tr.events[1].User.ID, "the events do not share their scope")
+		assert.Empty(t, tr.events[1].Breadcrumbs)
+	})
+
+	t.Run("drops the events out of the sample", func(t *testing.T) {
+		// Arrange
+		tr := &transport{}
+		r, err := newSentry(Options{DSN: "https://key@sentry.example.com/1", SampleRate: 0.5}, tr)
+		require.NoError(t, err)
+		draws := []float64{0.7, 0.2}
+		r.random = func() float64 {
+			draw := draws[0]
+			draws = draws[1:]
+			return draw
+		}
+
+		// Act
+		r.CaptureMessage(context.Background(), LevelWarning, "dropped")
+		r.CaptureMessage(context.Background(), LevelWarning, "sent")
+
+		// Assert
+		require.Len(t, tr.events, 1)
+		assert.Equal(t, "sent", tr.events[0].Message)
+		assert.Equal(t, sentrygo.LevelWarning, tr.events[0].Level)
+	})
+}
@@ -0,0 +1,61 @@
+package errorreport
+
+import (
+	"context"
+
+	"go-clean-template/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// Log writes the events to the logger of their context, or to its logger outside of a request. It
+// replaces Sentry in the local environment.
+type Log struct {
+	logger *zap.SugaredLogger
+}
+
+func NewLog(l *zap.SugaredLogger) *Log {
+	return &Log{logger: l}
+}
+
+func (r *Log) CaptureError(ctx context.Context, err error) {
+	r.write(ctx, NewEvent(ctx, LevelError, err, ""))
+}
+
+func (r *Log) CaptureMessage(ctx context.Context, level Level, message string) {
+	r.write(ctx, NewEvent(ctx, level, nil, message))
+}
+
+func (r *Log) Flush(context.Context) error {
+	return nil
+}
+
+func (r *Log) write(ctx context.Context, e Event) {
+	fields := []interface{}{"tags", e.Tags}
+	if len(e.Breadcrumbs) > 0 {
+		steps := make([]string, 0, len(e.Breadcrumbs))
+		for _, b := range e.Breadcrumbs {
+			steps = append(steps, b.Category+": "+b.Message)
+		}
+		fields = append(fields, "breadcrumbs", steps)
+	}
+	// the request logger already carries the user
+	l, ok := logger.Lookup(ctx)
+	if !ok {
+		l = r.logger
+		if e.UserID != "" {
+			fields = append(fields, "user_id", e.UserID)
+		}
+	}
+
+	switch e.Level {
+	case LevelDebug:
+		l.Debugw(e.Message, fields...)
+	case LevelInfo:
+		l.Infow(e.Message, fields...)
+	case LevelWarning:
+		l.Warnw(e.Message, fields...)
+	default:
+		l.Errorw(e.Message, fields...)
+	}
+}
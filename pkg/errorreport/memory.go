@@ -0,0 +1,41 @@
+package errorreport
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory keeps the events, the tests assert on what was reported
+type Memory struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (r *Memory) CaptureError(ctx context.Context, err error) {
+	r.add(NewEvent(ctx, LevelError, err, ""))
+}
+
+func (r *Memory) CaptureMessage(ctx context.Context, level Level, message string) {
+	r.add(NewEvent(ctx, level, nil, message))
+}
+
+func (r *Memory) Flush(context.Context) error {
+	return nil
+}
+
+// Events returns the events reported so far
+func (r *Memory) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+func (r *Memory) add(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
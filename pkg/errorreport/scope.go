@@ -0,0 +1,119 @@
+package errorreport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MaxBreadcrumbs is the number of breadcrumbs kept by a scope, the oldest are dropped
+const MaxBreadcrumbs = 50
+
+// Breadcrumb is a step taken before an error, like a transaction saved before the PSP call failed
+type Breadcrumb struct {
+	Category  string
+	Message   string
+	Data      map[string]interface{}
+	Timestamp time.Time
+}
+
+// scope collects what the events reported during a request or a job carry. It is shared by the copies of
+// the context, so a use case adding a breadcrumb enriches the error the handler reports.
+type scope struct {
+	mu          sync.Mutex
+	userID      string
+	tags        map[string]string
+	breadcrumbs []Breadcrumb
+}
+
+type scopeKey struct{}
+
+// NewScope returns a copy of ctx with a new scope, starting with the data of the scope of ctx if any. The
+// functions adding data to the scope do nothing without one.
+func NewScope(ctx context.Context) context.Context {
+	s := &scope{tags: map[string]string{}}
+	if parent := scopeFrom(ctx); parent != nil {
+		parent.mu.Lock()
+		s.userID = parent.userID
+		for k, v := range parent.tags {
+			s.tags[k] = v
+		}
+		s.breadcrumbs = append(s.breadcrumbs, parent.breadcrumbs...)
+		parent.mu.Unlock()
+	}
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+func scopeFrom(ctx context.Context) *scope {
+	s, _ := ctx.Value(scopeKey{}).(*scope)
+	return s
+}
+
+// SetUser sets the user of the events reported with ctx
+func SetUser(ctx context.Context, userID string) {
+	if s := scopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.userID = userID
+	}
+}
+
+// SetTransaction tags the events reported with ctx with the transaction
+func SetTransaction(ctx context.Context, transID string) {
+	SetTag(ctx, "transaction_id", transID)
+}
+
+// SetTag tags the events reported with ctx
+func SetTag(ctx context.Context, key, value string) {
+	if s := scopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.tags[key] = value
+	}
+}
+
+// AddBreadcrumb records a step of the request or the job, keep the data free of personal information
+func AddBreadcrumb(ctx context.Context, category, message string, data map[string]interface{}) {
+	if s := scopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.breadcrumbs = append(s.breadcrumbs, Breadcrumb{
+			Category:  category,
+			Message:   message,
+			Data:      data,
+			Timestamp: time.Now(),
+		})
+		if over := len(s.breadcrumbs) - MaxBreadcrumbs; over > 0 {
+			s.breadcrumbs = append([]Breadcrumb(nil), s.breadcrumbs[over:]...)
+		}
+	}
+}
+
+// NewEvent creates an event with the scope and the trace of ctx
+func NewEvent(ctx context.Context, level Level, err error, message string) Event {
+	e := Event{
+		Level:     level,
+		Err:       err,
+		Message:   message,
+		Tags:      map[string]string{},
+		Timestamp: time.Now(),
+	}
+	if s := scopeFrom(ctx); s != nil {
+		s.mu.Lock()
+		e.UserID = s.userID
+		for k, v := range s.tags {
+			e.Tags[k] = v
+		}
+		e.Breadcrumbs = append(e.Breadcrumbs, s.breadcrumbs...)
+		s.mu.Unlock()
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		e.TraceID = sc.TraceID().String()
+	}
+	if e.Message == "" && err != nil {
+		e.Message = err.Error()
+	}
+	return e
+}
@@ -0,0 +1,90 @@
+package errorreport
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	sentrygo "github.com/getsentry/sentry-go"
+)
+
+// defaultFlushTimeout bounds Flush when ctx has no deadline
+const defaultFlushTimeout = 5 * time.Second
+
+// Sentry sends the events to Sentry
+type Sentry struct {
+	hub        *sentrygo.Hub
+	sampleRate float64
+	random     func() float64
+}
+
+// NewSentry initializes the Sentry SDK, the panics caught by its echo middleware are sent too
+func NewSentry(opt Options) (*Sentry, error) {
+	return newSentry(opt, nil)
+}
+
+func newSentry(opt Options, transport sentrygo.Transport) (*Sentry, error) {
+	err := sentrygo.Init(sentrygo.ClientOptions{
+		Dsn:              opt.DSN,
+		Environment:      opt.Environment,
+		AttachStacktrace: true,
+		Transport:        transport,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Sentry{hub: sentrygo.CurrentHub(), sampleRate: opt.SampleRate, random: rand.Float64}, nil
+}
+
+func (r *Sentry) CaptureError(ctx context.Context, err error) {
+	r.capture(NewEvent(ctx, LevelError, err, ""))
+}
+
+func (r *Sentry) CaptureMessage(ctx context.Context, level Level, message string) {
+	r.capture(NewEvent(ctx, level, nil, message))
+}
+
+func (r *Sentry) Flush(ctx context.Context) error {
+	timeout := defaultFlushTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	if !r.hub.Flush(timeout) {
+		return errors.New("sentry events still pending")
+	}
+	return nil
+}
+
+func (r *Sentry) capture(e Event) {
+	if r.sampleRate < 1 && r.random() >= r.sampleRate {
+		return
+	}
+
+	// a hub per event, the concurrent requests do not share their scope
+	hub := r.hub.Clone()
+	hub.ConfigureScope(func(scope *sentrygo.Scope) {
+		scope.SetLevel(sentrygo.Level(e.Level))
+		scope.SetTags(e.Tags)
+		if e.TraceID != "" {
+			scope.SetTag("trace_id", e.TraceID)
+		}
+		if e.UserID != "" {
+			scope.SetUser(sentrygo.User{ID: e.UserID})
+		}
+		for _, b := range e.Breadcrumbs {
+			scope.AddBreadcrumb(&sentrygo.Breadcrumb{
+				Category:  b.Category,
+				Message:   b.Message,
+				Data:      b.Data,
+				Timestamp: b.Timestamp,
+			}, MaxBreadcrumbs)
+		}
+	})
+
+	if e.Err != nil {
+		hub.CaptureException(e.Err)
+	} else {
+		hub.CaptureMessage(e.Message)
+	}
+}
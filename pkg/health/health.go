@@ -0,0 +1,144 @@
+// Package health runs the dependency checks behind the readiness probe
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+// Checker reports whether a dependency can serve requests
+type Checker interface {
+	Ping(ctx context.Context) error
+}
+
+// CheckFunc adapts a function to a Checker
+type CheckFunc func(ctx context.Context) error
+
+func (f CheckFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
+
+// CheckResult is the outcome of a check
+type CheckResult struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	LatencyMS float64   `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report is the readiness of the process and the result of every check
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+func (r Report) Ready() bool {
+	return r.Status == StatusReady
+}
+
+type check struct {
+	name    string
+	checker Checker
+
+	mu     sync.Mutex
+	cached CheckResult
+}
+
+// Registry holds the checks of the readiness probe. Every check has timeout to answer and its result is
+// reused for cacheTTL, so frequent probes do not load the dependencies.
+type Registry struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+	checks   []*check
+	ready    atomic.Bool
+	now      func() time.Time
+}
+
+// NewRegistry creates a registry, it is not ready until SetReady(true)
+func NewRegistry(timeout, cacheTTL time.Duration) *Registry {
+	return &Registry{timeout: timeout, cacheTTL: cacheTTL, now: time.Now}
+}
+
+// Register adds a check, it must be called before the registry serves reports
+func (r *Registry) Register(name string, checker Checker) {
+	r.checks = append(r.checks, &check{name: name, checker: checker})
+}
+
+// SetReady marks the process ready once it has started and not ready when it shuts down
+func (r *Registry) SetReady(ready bool) {
+	r.ready.Store(ready)
+}
+
+// Report runs the checks concurrently. The process is ready when it is started and every check is up.
+// The checks are skipped while it is not started.
+func (r *Registry) Report(ctx context.Context) Report {
+	if !r.ready.Load() {
+		return Report{Status: StatusNotReady, Checks: []CheckResult{}}
+	}
+
+	results := make([]CheckResult, len(r.checks))
+	var wg sync.WaitGroup
+	for i, c := range r.checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = r.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusReady, Checks: results}
+	for _, result := range results {
+		if result.Status != StatusUp {
+			report.Status = StatusNotReady
+		}
+	}
+	return report
+}
+
+// run checks c unless its cached result is recent enough. Concurrent reports wait for the same check.
+func (r *Registry) run(ctx context.Context, c *check) CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.cached.CheckedAt.IsZero() && r.now().Sub(c.cached.CheckedAt) < r.cacheTTL {
+		return c.cached
+	}
+
+	checkCtx := ctx
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	start := r.now()
+	err := c.checker.Ping(checkCtx)
+	result := CheckResult{
+		Name:      c.name,
+		Status:    StatusUp,
+		LatencyMS: float64(r.now().Sub(start).Microseconds()) / 1000,
+		CheckedAt: r.now(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	// a probe giving up is not the dependency failing
+	if ctx.Err() == nil {
+		c.cached = result
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCheck counts its calls and returns err
+func countingCheck(calls *atomic.Int32, err error) CheckFunc {
+	return func(context.Context) error {
+		calls.Add(1)
+		return err
+	}
+}
+
+func TestRegistry_Report(t *testing.T) {
+	t.Run("not ready before SetReady, the checks are skipped", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		r := NewRegistry(time.Second, 0)
+		r.Register("postgres", countingCheck(&calls, nil))
+
+		// Act
+		report := r.Report(context.Background())
+
+		// Assert
+		assert.False(t, report.Ready())
+		assert.Empty(t, report.Checks)
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("ready when every check is up", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		r := NewRegistry(time.Second, 0)
+		r.Register("postgres", countingCheck(&calls, nil))
+		r.Register("psp", countingCheck(&calls, nil))
+		r.SetReady(true)
+
+		// Act
+		report := r.Report(context.Background())
+
+		// Assert
+		assert.True(t, report.Ready())
+		require.Len(t, report.Checks, 2)
+		assert.Equal(t, "postgres", report.Checks[0].Name)
+		assert.Equal(t, StatusUp, report.Checks[0].Status)
+		assert.Equal(t, "psp", report.Checks[1].Name)
+		assert.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("a failing check makes the process not ready", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		r := NewRegistry(time.Second, 0)
+		r.Register("postgres", countingCheck(&calls, nil))
+		r.Register("mongo", countingCheck(&calls, errors.New("connection refused")))
+		r.SetReady(true)
+
+		// Act
+		report := r.Report(context.Background())
+
+		// Assert
+		assert.Equal(t, StatusNotReady, report.Status)
+		assert.Equal(t, StatusUp, report.Checks[0].Status)
+		assert.Equal(t, StatusDown, report.Checks[1].Status)
+		assert.Equal(t, "connection refused", report.Checks[1].Error)
+	})
+
+	t.Run("a slow check times out", func(t *testing.T) {
+		// Arrange
+		r := NewRegistry(10*time.Millisecond, 0)
+		r.Register("psp", CheckFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+		r.SetReady(true)
+
+		// Act
+		report := r.Report(context.Background())
+
+		// Assert
+		assert.False(t, report.Ready())
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+		assert.GreaterOrEqual(t, report.Checks[0].LatencyMS, float64(10))
+	})
+
+	t.Run("the results are cached for the TTL", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		r := NewRegistry(time.Second, 5*time.Second)
+		r.now = func() time.Time { return now }
+		r.Register("postgres", countingCheck(&calls, nil))
+		r.SetReady(true)
+
+		// Act
+		r.Report(context.Background())
+		now = now.Add(4 * time.Second)
+		r.Report(context.Background())
+		cachedCalls := calls.Load()
+		now = now.Add(time.Second)
+		r.Report(context.Background())
+
+		// Assert
+		assert.EqualValues(t, 1, cachedCalls)
+		assert.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("not ready again after SetReady(false)", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		r := NewRegistry(time.Second, 0)
+		r.Register("postgres", countingCheck(&calls, nil))
+		r.SetReady(true)
+
+		// Act
+		r.SetReady(false)
+		report := r.Report(context.Background())
+
+		// Assert
+		assert.False(t, report.Ready())
+	})
+}
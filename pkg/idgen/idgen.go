@@ -0,0 +1,56 @@
+package idgen
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	StrategyUUIDv7   = "uuidv7"
+	StrategyULID     = "ulid"
+	StrategyObjectID = "objectid"
+)
+
+// Generator generates string ids that sort by creation time, so they can be stored as they are
+// in a Postgres varchar primary key or a Mongo string _id.
+type Generator interface {
+	NewID() string
+}
+
+// New returns the generator of a strategy
+func New(strategy string) (Generator, error) {
+	switch strategy {
+	case StrategyUUIDv7:
+		return UUIDv7{}, nil
+	case StrategyULID:
+		return ULID{}, nil
+	case StrategyObjectID:
+		return ObjectID{}, nil
+	default:
+		return nil, fmt.Errorf("unknown id strategy %q", strategy)
+	}
+}
+
+// UUIDv7 generates RFC 9562 version 7 UUIDs, e.g. 0190a4e5-7c1b-7d4e-9f3a-2b6c8d0e1f2a
+type UUIDv7 struct{}
+
+func (UUIDv7) NewID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// ULID generates monotonic ULIDs, e.g. 01J2J9ZQ3W8B6Y5X4V3T2S1R0Q
+type ULID struct{}
+
+func (ULID) NewID() string {
+	return ulid.Make().String()
+}
+
+// ObjectID generates Mongo ObjectIDs in their hex form, e.g. 668e4a1f2b3c4d5e6f708192
+type ObjectID struct{}
+
+func (ObjectID) NewID() string {
+	return primitive.NewObjectID().Hex()
+}
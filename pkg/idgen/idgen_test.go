@@ -0,0 +1,68 @@
+package idgen
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		strategy string
+		want     Generator
+		pattern  string
+	}{
+		{
+			strategy: StrategyUUIDv7,
+			want:     UUIDv7{},
+			pattern:  `^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+		},
+		{
+			strategy: StrategyULID,
+			want:     ULID{},
+			pattern:  `^[0-9A-HJKMNP-TV-Z]{26}$`,
+		},
+		{
+			strategy: StrategyObjectID,
+			want:     ObjectID{},
+			pattern:  `^[0-9a-f]{24}$`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			//Act
+			got, err := New(tt.strategy)
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			seen := make(map[string]bool)
+			for i := 0; i < 1000; i++ {
+				id := got.NewID()
+				assert.Regexp(t, regexp.MustCompile(tt.pattern), id)
+				assert.False(t, seen[id], "duplicated id %s", id)
+				seen[id] = true
+			}
+		})
+	}
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		//Act
+		got, err := New("uuidv4")
+
+		//Assert
+		assert.Nil(t, got)
+		assert.EqualError(t, err, `unknown id strategy "uuidv4"`)
+	})
+}
+
+func TestGenerator_SortsByCreation(t *testing.T) {
+	for _, g := range []Generator{UUIDv7{}, ULID{}} {
+		first := g.NewID()
+		second := g.NewID()
+
+		assert.Less(t, first, second)
+	}
+}
@@ -0,0 +1,125 @@
+// Package lifecycle starts the components of a process and stops them in the reverse order on a signal,
+// so a component is stopped before the ones it depends on.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Component is a part of the process managed by the Manager
+type Component struct {
+	Name string
+	// Run blocks until ctx is canceled or the component fails, nil for a component with nothing to run.
+	// A Run returning shuts the whole process down.
+	Run func(ctx context.Context) error
+	// Stop releases the component within the deadline of ctx, nil when canceling Run is enough
+	Stop func(ctx context.Context) error
+}
+
+// Manager runs the components until a signal and shuts them down
+type Manager struct {
+	logger     *zap.SugaredLogger
+	timeout    time.Duration
+	signals    []os.Signal
+	components []Component
+}
+
+// New creates a manager stopping on SIGINT and SIGTERM, all the components have timeout to stop
+func New(logger *zap.SugaredLogger, timeout time.Duration) *Manager {
+	return &Manager{
+		logger:  logger,
+		timeout: timeout,
+		signals: []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+}
+
+// Add registers a component, the components are started in the order they are added
+func (m *Manager) Add(c Component) {
+	m.components = append(m.components, c)
+}
+
+type running struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// Run starts the components and blocks until ctx is canceled, a signal is received or a component returns.
+// Then it stops the components from the last to the first: Run is canceled, Stop is called and the
+// manager waits for Run to return before moving to the previous component.
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, m.signals...)
+	defer stopSignals()
+
+	exited := make(chan string, len(m.components))
+	runs := make([]*running, len(m.components))
+	for i, c := range m.components {
+		if c.Run == nil {
+			continue
+		}
+		runCtx, cancel := context.WithCancel(context.Background())
+		r := &running{cancel: cancel, done: make(chan struct{})}
+		runs[i] = r
+		go func(c Component) {
+			defer close(r.done)
+			r.err = c.Run(runCtx)
+			exited <- c.Name
+		}(c)
+	}
+
+	select {
+	case <-ctx.Done():
+		m.logger.Info("shutting down")
+	case name := <-exited:
+		m.logger.Warnf("shutting down, %s exited", name)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(m.components) - 1; i >= 0; i-- {
+		if err := m.stop(shutdownCtx, m.components[i], runs[i]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.components[i].Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) stop(ctx context.Context, c Component, r *running) error {
+	if r != nil {
+		r.cancel()
+	}
+
+	var errs []error
+	if c.Stop != nil {
+		if err := c.Stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if r != nil {
+		select {
+		case <-r.done:
+			if r.err != nil {
+				errs = append(errs, r.err)
+			}
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("still running: %w", ctx.Err()))
+		}
+	}
+
+	if len(errs) > 0 {
+		m.logger.Errorf("%s stopped with errors: %v", c.Name, errors.Join(errs...))
+	} else {
+		m.logger.Infof("%s stopped", c.Name)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,123 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type events struct {
+	mu   sync.Mutex
+	list []string
+}
+
+func (e *events) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.list = append(e.list, event)
+}
+
+func (e *events) all() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.list...)
+}
+
+// runUntilCanceled is a component running until it is canceled
+func runUntilCanceled(name string, ev *events) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		<-ctx.Done()
+		ev.add(name + " returned")
+		return nil
+	}
+}
+
+func stopRecorded(name string, ev *events) func(ctx context.Context) error {
+	return func(context.Context) error {
+		ev.add(name + " stopped")
+		return nil
+	}
+}
+
+func TestManager_Run(t *testing.T) {
+	t.Run("stops the components in reverse order", func(t *testing.T) {
+		// Arrange
+		ev := &events{}
+		m := New(zap.NewNop().Sugar(), time.Second)
+		m.Add(Component{Name: "storage", Stop: stopRecorded("storage", ev)})
+		m.Add(Component{Name: "worker", Run: runUntilCanceled("worker", ev)})
+		m.Add(Component{Name: "server", Run: runUntilCanceled("server", ev), Stop: stopRecorded("server", ev)})
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		// Act
+		err := m.Run(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"server stopped", "server returned", "worker returned", "storage stopped"}, ev.all())
+	})
+
+	t.Run("a component returning shuts the others down", func(t *testing.T) {
+		// Arrange
+		ev := &events{}
+		m := New(zap.NewNop().Sugar(), time.Second)
+		m.Add(Component{Name: "storage", Stop: stopRecorded("storage", ev)})
+		m.Add(Component{Name: "server", Run: func(context.Context) error {
+			return errors.New("address already in use")
+		}})
+
+		// Act
+		err := m.Run(context.Background())
+
+		// Assert
+		assert.EqualError(t, err, "server: address already in use")
+		assert.Equal(t, []string{"storage stopped"}, ev.all())
+	})
+
+	t.Run("SIGTERM shuts down", func(t *testing.T) {
+		// Arrange
+		ev := &events{}
+		m := New(zap.NewNop().Sugar(), time.Second)
+		m.Add(Component{Name: "worker", Run: runUntilCanceled("worker", ev)})
+		time.AfterFunc(10*time.Millisecond, func() {
+			_ = syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+		})
+
+		// Act
+		err := m.Run(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"worker returned"}, ev.all())
+	})
+
+	t.Run("a component slower than the timeout is reported, the previous ones are still stopped", func(t *testing.T) {
+		// Arrange
+		ev := &events{}
+		release := make(chan struct{})
+		defer close(release)
+		m := New(zap.NewNop().Sugar(), 20*time.Millisecond)
+		m.Add(Component{Name: "storage", Stop: stopRecorded("storage", ev)})
+		m.Add(Component{Name: "worker", Run: func(context.Context) error {
+			<-release
+			return nil
+		}})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		err := m.Run(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "worker: still running")
+		assert.Equal(t, []string{"storage stopped"}, ev.all())
+	})
+}
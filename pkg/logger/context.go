@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// defaultLogger is returned by FromContext for a context without logger
+var defaultLogger = NOOPLogger
+
+// SetDefault sets the logger of the contexts without one, like the jobs of the worker
+func SetDefault(l *zap.SugaredLogger) {
+	defaultLogger = l
+}
+
+// WithContext returns a copy of ctx carrying l
+func WithContext(ctx context.Context, l *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger of ctx, enriched with the request fields, or the default logger
+func FromContext(ctx context.Context) *zap.SugaredLogger {
+	if l, ok := Lookup(ctx); ok {
+		return l
+	}
+	return defaultLogger
+}
+
+// Lookup returns the logger of ctx, ok is false when ctx carries none
+func Lookup(ctx context.Context) (l *zap.SugaredLogger, ok bool) {
+	l, ok = ctx.Value(contextKey{}).(*zap.SugaredLogger)
+	return l, ok
+}
+
+// With returns a copy of ctx whose logger adds the key value pairs to every log
+func With(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	return WithContext(ctx, FromContext(ctx).With(keysAndValues...))
+}
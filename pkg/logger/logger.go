@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"fmt"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -8,14 +10,18 @@ import (
 // NOOPLogger use for unit testing
 var NOOPLogger = zap.NewNop().Sugar()
 
+// level is shared by the app loggers, so it can be changed at runtime
+var level = zap.NewAtomicLevelAt(zap.InfoLevel)
+
 func NewAppLogger() (*zap.SugaredLogger, error) {
 	cfg := zap.NewProductionConfig()
+	cfg.Level = level
 	cfg.EncoderConfig.TimeKey = "timestamp"
 	cfg.EncoderConfig.EncodeTime = zapcore.RFC3339TimeEncoder
 	cfg.EncoderConfig.CallerKey = "func"
 	cfg.EncoderConfig.EncodeCaller = zapcore.FullCallerEncoder
 
-	logger, err := cfg.Build()
+	logger, err := cfg.Build(zap.WrapCore(NewRedactingCore))
 	if err != nil {
 		return nil, err
 	}
@@ -23,6 +29,19 @@ func NewAppLogger() (*zap.SugaredLogger, error) {
 	return logger.Sugar(), nil
 }
 
+// Level is the level of the app loggers. It serves GET and PUT {"level":"debug"} over HTTP.
+func Level() zap.AtomicLevel {
+	return level
+}
+
+// SetLevel changes the level of the app loggers, l is debug, info, warn, error, dpanic, panic or fatal
+func SetLevel(l string) error {
+	if err := level.UnmarshalText([]byte(l)); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", l, err)
+	}
+	return nil
+}
+
 func Sync(l *zap.SugaredLogger) {
 	if err := l.Sync(); err != nil {
 		l.Error("cannot sync logger: ", err)
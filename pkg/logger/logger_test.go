@@ -0,0 +1,136 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactingCore(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zap.DebugLevel)
+	l := zap.New(NewRedactingCore(core)).Sugar().With("user_email", "jane@example.com", "wallet_id", "w1")
+
+	// Act
+	l.Infow("deposit", "accountName", "Jane Doe", "phone-number", "+84901234567", "access_token", "abc",
+		"amount", 1000)
+
+	// Assert
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]interface{}{
+		"user_email":   Redacted,
+		"wallet_id":    "w1",
+		"accountName":  Redacted,
+		"phone-number": Redacted,
+		"access_token": Redacted,
+		"amount":       int64(1000),
+	}, logs.All()[0].ContextMap())
+}
+
+func TestRedactingCore_Values(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zap.DebugLevel)
+	l := zap.New(NewRedactingCore(core)).Sugar()
+
+	// Act
+	l.Infow("psp call failed", "note", "refund to jane.doe@example.com",
+		"upstream", "401 for Bearer abc.def-ghi", "jwt", "eyJhbGciOiJSUzI1NiJ9.eyJzdWIiOiIxIn0.c2ln",
+		"wallet_id", "w1")
+
+	// Assert
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]interface{}{
+		"note":      "refund to " + Redacted,
+		"upstream":  "401 for " + Redacted,
+		"jwt":       Redacted,
+		"wallet_id": "w1",
+	}, logs.All()[0].ContextMap())
+}
+
+func TestRedactingCore_Errors(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zap.DebugLevel)
+	l := zap.New(NewRedactingCore(core)).Sugar()
+	err := fmt.Errorf("failed to notify jane.doe@example.com: %w", errors.New("401"))
+
+	// Act
+	l.Errorw("failed to notify jane.doe@example.com", zap.Error(err), zap.NamedError("cause", errors.New("timeout")))
+
+	// Assert
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "failed to notify "+Redacted, logs.All()[0].Message)
+	assert.Equal(t, map[string]interface{}{
+		"error": "failed to notify " + Redacted + ": 401",
+		"cause": "timeout",
+	}, logs.All()[0].ContextMap())
+}
+
+func TestSetLevel(t *testing.T) {
+	defer func() {
+		require.NoError(t, SetLevel("info"))
+	}()
+
+	t.Run("changes the level of the app loggers", func(t *testing.T) {
+		// Arrange
+		l, err := NewAppLogger()
+		require.NoError(t, err)
+
+		// Act
+		err = SetLevel("debug")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, zapcore.DebugLevel, Level().Level())
+		assert.True(t, l.Desugar().Core().Enabled(zapcore.DebugLevel))
+	})
+
+	t.Run("an unknown level is rejected", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, SetLevel("warn"))
+
+		// Act
+		err := SetLevel("verbose")
+
+		// Assert
+		assert.ErrorContains(t, err, `invalid log level "verbose"`)
+		assert.Equal(t, zapcore.WarnLevel, Level().Level())
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("the logger of the context carries the added fields", func(t *testing.T) {
+		// Arrange
+		core, logs := observer.New(zap.InfoLevel)
+		ctx := WithContext(context.Background(), zap.New(core).Sugar().With("request_id", "r1"))
+
+		// Act
+		ctx = With(ctx, "user_id", "u1")
+		FromContext(ctx).Info("served")
+
+		// Assert
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, map[string]interface{}{"request_id": "r1", "user_id": "u1"}, logs.All()[0].ContextMap())
+	})
+
+	t.Run("the default logger without logger in the context", func(t *testing.T) {
+		// Arrange
+		core, logs := observer.New(zap.InfoLevel)
+		defer SetDefault(defaultLogger)
+		SetDefault(zap.New(core).Sugar())
+
+		// Act
+		FromContext(context.Background()).Info("job done")
+		_, ok := Lookup(context.Background())
+
+		// Assert
+		assert.Equal(t, 1, logs.Len())
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces the values of the sensitive fields
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are matched against the field keys lowercased and stripped of "_" and "-", so user_email
+// and accountName are redacted too
+var sensitiveKeys = []string{
+	"email",
+	"phone",
+	"accountname",
+	"token",
+	"authorization",
+	"password",
+	"secret",
+	"apikey",
+}
+
+// sensitiveValues are redacted from the string values of every field, whatever their key: an email or a token
+// in a note or an upstream error message
+var sensitiveValues = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._~+/-]+=*`),
+	regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+}
+
+// redactingCore replaces the values of the sensitive fields before they are encoded, whether they are
+// logged with the entry or added by With. The keys, the string values, the errors and the messages are
+// checked, the objects are not: keep the personal data out of them.
+type redactingCore struct {
+	zapcore.Core
+}
+
+// NewRedactingCore wraps core to redact the sensitive fields
+func NewRedactingCore(core zapcore.Core) zapcore.Core {
+	return &redactingCore{Core: core}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(redact(fields))}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = redactValue(entry.Message)
+	return c.Core.Write(entry, redact(fields))
+}
+
+func redact(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+	for i, f := range fields {
+		value, ok := redactField(f)
+		if !ok {
+			continue
+		}
+		if redacted == nil {
+			redacted = append([]zapcore.Field(nil), fields...)
+		}
+		redacted[i] = zap.String(f.Key, value)
+	}
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// redactField returns the redacted value of f and whether it has one. An error is redacted as its message.
+func redactField(f zapcore.Field) (string, bool) {
+	if isSensitive(f.Key) {
+		return Redacted, true
+	}
+	var original string
+	switch f.Type {
+	case zapcore.StringType:
+		original = f.String
+	case zapcore.ErrorType:
+		err, ok := f.Interface.(error)
+		if !ok || err == nil {
+			return "", false
+		}
+		original = err.Error()
+	default:
+		return "", false
+	}
+	value := redactValue(original)
+	return value, value != original
+}
+
+func redactValue(value string) string {
+	for _, pattern := range sensitiveValues {
+		value = pattern.ReplaceAllLiteralString(value, Redacted)
+	}
+	return value
+}
+
+func isSensitive(key string) bool {
+	key = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
@@ -21,11 +23,28 @@ func SetupPostgresContainer(t testing.TB, dbname, user, password string) testcon
 				WithOccurrence(2)),
 	)
 
-	assert.NoError(t, err)
-
+	// the container is nil when the run failed, there is nothing to terminate
 	t.Cleanup(func() {
-		assert.NoError(t, postgresql.Terminate(ctx))
+		if postgresql != nil {
+			assert.NoError(t, postgresql.Terminate(ctx))
+		}
 	})
+	require.NoError(t, err)
 
 	return postgresql
 }
+
+func SetupMongoContainer(t testing.TB) *mongodb.MongoDBContainer {
+	ctx := context.Background()
+	// a single node replica set, standalone servers reject transactions
+	mongoContainer, err := mongodb.Run(ctx, "docker.io/mongo:7.0", mongodb.WithReplicaSet("rs0"))
+
+	t.Cleanup(func() {
+		if mongoContainer != nil {
+			assert.NoError(t, mongoContainer.Terminate(ctx))
+		}
+	})
+	require.NoError(t, err)
+
+	return mongoContainer
+}
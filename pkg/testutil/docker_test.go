@@ -16,3 +16,11 @@ func TestSetupPostgresContainer(t *testing.T) {
 	//Assert
 	assert.NotNil(t, cont)
 }
+
+func TestSetupMongoContainer(t *testing.T) {
+	//Act
+	cont := SetupMongoContainer(t)
+
+	//Assert
+	assert.NotNil(t, cont)
+}
@@ -0,0 +1,25 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"go-clean-template/internal/fixture"
+
+	"github.com/stretchr/testify/require"
+)
+
+// LoadFixtures seeds the YAML or JSON fixture files into the storage of seeder and returns what was seeded
+func LoadFixtures(t testing.TB, seeder fixture.Seeder, paths ...string) *fixture.Set {
+	t.Helper()
+
+	set := &fixture.Set{}
+	for _, path := range paths {
+		loaded, err := fixture.LoadFile(path)
+		require.NoError(t, err)
+		set.Append(loaded)
+	}
+
+	require.NoError(t, seeder.Seed(context.Background(), set))
+	return set
+}
@@ -0,0 +1,25 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"go-clean-template/internal/infras/memstore"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	// Arrange
+	db := memstore.NewDB()
+
+	// Act
+	set := LoadFixtures(t, memstore.NewSeedRepo(db), "testdata/fixtures.json")
+
+	// Assert
+	assert.Len(t, set.Users, 1)
+	assert.Len(t, set.Transactions, 2)
+	balance, err := memstore.NewTransactionRepo(db).GetBalanceByWalletID(context.Background(), "w_001")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(700), balance)
+}
@@ -0,0 +1,27 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func CreateMongoDatabase(t testing.TB, dbName string) *mongo.Database {
+	ctx := context.Background()
+	cont := SetupMongoContainer(t)
+	uri, err := cont.ConnectionString(ctx)
+	assert.NoError(t, err)
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	assert.NoError(t, err)
+	assert.NoError(t, client.Ping(ctx, nil))
+
+	t.Cleanup(func() {
+		assert.NoError(t, client.Disconnect(ctx))
+	})
+
+	return client.Database(dbName)
+}
@@ -0,0 +1,19 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCreateMongoDatabase(t *testing.T) {
+	//Act
+	db := CreateMongoDatabase(t, "db-name")
+
+	//Assert
+	assert.NotNil(t, db)
+	_, err := db.Collection("wallets").InsertOne(context.Background(), bson.M{"_id": "w_001"})
+	assert.NoError(t, err)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"testing"
 
+	"go-clean-template/migrations"
+
 	_ "github.com/lib/pq"
 	migrate "github.com/rubenv/sql-migrate"
 	"github.com/stretchr/testify/assert"
@@ -12,17 +14,13 @@ import (
 	"gorm.io/gorm"
 )
 
-func MigrateTestDatabase(t testing.TB, db *gorm.DB, migrationPath string) {
+func MigrateTestDatabase(t testing.TB, db *gorm.DB) {
 	t.Helper()
 
-	migrations := &migrate.FileMigrationSource{
-		Dir: migrationPath,
-	}
-
 	sqlDB, err := db.DB()
 	assert.NoError(t, err)
 
-	_, err = migrate.Exec(sqlDB, "postgres", migrations, migrate.Up)
+	_, err = migrate.Exec(sqlDB, "postgres", migrations.Postgres, migrate.Up)
 	assert.NoError(t, err)
 }
 
@@ -22,10 +22,9 @@ func TestMigrateTestDatabase(t *testing.T) {
 	testUser := "user"
 	testPassword := "123456"
 	db := CreateConnection(t, testDBName, testUser, testPassword)
-	migrationPath := "../../migrations"
 
 	// Act
-	MigrateTestDatabase(t, db, migrationPath)
+	MigrateTestDatabase(t, db)
 
 	// Assert
 	sqlDB, err := db.DB()
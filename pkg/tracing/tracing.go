@@ -0,0 +1,104 @@
+// Package tracing sets up the OpenTelemetry tracer provider and the W3C trace context propagation
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go-clean-template/pkg/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// TracerName names the spans started by this module, the libraries use their own
+const TracerName = "go-clean-template"
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type Options struct {
+	// Exporter is otlp, stdout or none. otlp is configured by the standard OTEL_EXPORTER_OTLP_* variables.
+	Exporter    string
+	ServiceName string
+	Environment string
+	// SampleRatio is the share of the traces started here that are recorded, the incoming sampling
+	// decision is kept
+	SampleRatio float64
+}
+
+func ParseFromConfig(c *config.Config) Options {
+	return Options{
+		Exporter:    c.Tracing.Exporter,
+		ServiceName: c.Tracing.ServiceName,
+		Environment: c.AppEnv,
+		SampleRatio: c.Tracing.SampleRatio,
+	}
+}
+
+// Setup installs the global propagator and, unless the exporter is none, the global tracer provider. The
+// provider is nil for none, its Shutdown flushes the spans otherwise.
+func Setup(ctx context.Context, opt Options) (*sdktrace.TracerProvider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch opt.Exporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, must be otlp, stdout or none", opt.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the %s exporter: %w", opt.Exporter, err)
+	}
+
+	provider := NewProvider(opt, sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(provider)
+	return provider, nil
+}
+
+// NewProvider creates a tracer provider exporting with processor. The tests pass a synchronous processor
+// over an in memory exporter.
+func NewProvider(opt Options, processor sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		processor,
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opt.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(opt.ServiceName),
+			semconv.DeploymentEnvironment(opt.Environment),
+		)),
+	)
+}
+
+// LogFields are the zap fields linking a log to the span of ctx, none without a span
+func LogFields(ctx context.Context) []interface{} {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []interface{}{
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	}
+}
@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.uber.org/zap"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("none has no provider", func(t *testing.T) {
+		// Act
+		provider, err := Setup(context.Background(), Options{Exporter: ExporterNone})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, provider)
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		// Act
+		provider, err := Setup(context.Background(), Options{Exporter: ExporterStdout, SampleRatio: 1})
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, provider)
+		assert.Equal(t, provider, otel.GetTracerProvider())
+		assert.NoError(t, provider.Shutdown(context.Background()))
+	})
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		// Act
+		_, err := Setup(context.Background(), Options{Exporter: "jaeger"})
+
+		// Assert
+		assert.EqualError(t, err, `unknown tracing exporter "jaeger", must be otlp, stdout or none`)
+	})
+}
+
+func TestNewProvider(t *testing.T) {
+	// Arrange
+	exporter := tracetest.NewInMemoryExporter()
+	provider := NewProvider(Options{ServiceName: "wallet", Environment: "test", SampleRatio: 1},
+		sdktrace.WithSyncer(exporter))
+
+	// Act
+	_, span := provider.Tracer(TracerName).Start(context.Background(), "deposit")
+	span.End()
+
+	// Assert
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "deposit", spans[0].Name)
+	assert.Contains(t, spans[0].Resource.Attributes(), semconv.ServiceName("wallet"))
+	assert.Contains(t, spans[0].Resource.Attributes(), semconv.DeploymentEnvironment("test"))
+}
+
+func TestLogFields(t *testing.T) {
+	t.Run("trace and span ids of the span", func(t *testing.T) {
+		// Arrange
+		provider := NewProvider(Options{SampleRatio: 1}, sdktrace.WithSyncer(tracetest.NewInMemoryExporter()))
+		ctx, span := provider.Tracer(TracerName).Start(context.Background(), "deposit")
+		defer span.End()
+
+		// Act
+		fields := LogFields(ctx)
+
+		// Assert
+		assert.Equal(t, []interface{}{
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		}, fields)
+	})
+
+	t.Run("no span", func(t *testing.T) {
+		assert.Empty(t, LogFields(context.Background()))
+	})
+}
@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const (
+	currencyRule = "currency"
+	idRule       = "id"
+	moneyRule    = "money"
+	noteRule     = "note"
+)
+
+var rules = map[string]validator.Func{
+	currencyRule: isCurrency,
+	idRule:       isID,
+	moneyRule:    hasCurrencyPrecision,
+	noteRule:     isNote,
+}
+
+// defaultMinorUnits is the precision of the amounts without currency
+const defaultMinorUnits = 2
+
+// minorUnits are the ISO 4217 currencies the wallets hold and their number of decimals
+var minorUnits = map[string]int{
+	"VND": 0,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"CNY": 2,
+	"SGD": 2,
+	"THB": 2,
+	"AUD": 2,
+	"KWD": 3,
+}
+
+// MinorUnits is the number of decimals of currency, the default precision for an unknown currency
+func MinorUnits(currency string) int {
+	if units, ok := minorUnits[currency]; ok {
+		return units
+	}
+	return defaultMinorUnits
+}
+
+// IsCurrency reports whether currency is one of the currencies the wallets hold
+func IsCurrency(currency string) bool {
+	_, ok := minorUnits[currency]
+	return ok
+}
+
+func isCurrency(fl validator.FieldLevel) bool {
+	return IsCurrency(fl.Field().String())
+}
+
+var (
+	ulidPattern     = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+	objectIDPattern = regexp.MustCompile(`^[0-9a-f]{24}$`)
+)
+
+// isID accepts the ids of every idgen strategy, so the clients keep working when the strategy changes
+func isID(fl validator.FieldLevel) bool {
+	id := fl.Field().String()
+	if len(id) == 36 {
+		_, err := uuid.Parse(id)
+		return err == nil
+	}
+	return ulidPattern.MatchString(id) || objectIDPattern.MatchString(id)
+}
+
+// hasCurrencyPrecision checks that an amount has no more decimals than the currency of the field named by
+// the param, like money=Currency
+func hasCurrencyPrecision(fl validator.FieldLevel) bool {
+	currency := ""
+	if fl.Param() != "" {
+		parent := fl.Parent()
+		if parent.Kind() == reflect.Ptr {
+			parent = parent.Elem()
+		}
+		if f := parent.FieldByName(fl.Param()); f.IsValid() && f.Kind() == reflect.String {
+			currency = f.String()
+		}
+	}
+	return HasCurrencyPrecision(fl.Field().Float(), currency)
+}
+
+// HasCurrencyPrecision reports whether amount has no more decimals than currency allows
+func HasCurrencyPrecision(amount float64, currency string) bool {
+	// the shortest representation of the float, 0.1 is "0.1" and not 0.1000000000000000055511151231257827
+	_, decimals, _ := strings.Cut(strconv.FormatFloat(amount, 'f', -1, 64), ".")
+	return len(decimals) <= MinorUnits(currency)
+}
+
+// IsNote accepts the printable characters of every language, not the control characters nor the markup
+func IsNote(note string) bool {
+	for _, r := range note {
+		if !unicode.IsPrint(r) || r == '<' || r == '>' {
+			return false
+		}
+	}
+	return true
+}
+
+func isNote(fl validator.FieldLevel) bool {
+	return IsNote(fl.Field().String())
+}
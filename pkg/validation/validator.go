@@ -1,8 +1,127 @@
+// Package validation validates the requests with the rules of go-playground/validator and the rules of
+// the domain, and reports every invalid field
 package validation
 
-//
-//var validate = validator.New()
-//
-//func ValidateStruct(s interfaces{}) error {
-//	return validate.Struct(s)
-//}
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go-clean-template/pkg/apperror"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is an invalid field of a request, in the Info of the invalid params error
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Validator is safe for concurrent use, it caches the rules of every struct it validated
+type Validator struct {
+	validate *validator.Validate
+}
+
+// Default is the validator shared by the requests
+var Default = New()
+
+// New creates a validator with the rules of the domain, the fields are named after their json, query or
+// param tag
+func New() *Validator {
+	v := validator.New()
+	v.RegisterTagNameFunc(fieldName)
+	for tag, fn := range rules {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(fmt.Sprintf("cannot register the %s rule: %v", tag, err))
+		}
+	}
+	return &Validator{validate: v}
+}
+
+// Struct validates s with the default validator
+func Struct(s interface{}) error {
+	return Default.Validate(s)
+}
+
+// Validate checks the validate tags of i. It returns an invalid params error listing the invalid fields in its
+// Info.
+func (v *Validator) Validate(i interface{}) error {
+	err := v.validate.Struct(i)
+	if err == nil {
+		return nil
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return apperror.ErrInvalidParams(err)
+	}
+	fields := make([]FieldError, 0, len(errs))
+	for _, fe := range errs {
+		fields = append(fields, FieldError{
+			Field:   namespace(fe),
+			Rule:    fe.Tag(),
+			Message: message(fe),
+		})
+	}
+	return apperror.ErrInvalidParams(err).WithInfo(fields)
+}
+
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "query", "param", "form"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// namespace is the path of the field without the struct name, like items[0].amount
+func namespace(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "min":
+		if isNumber(fe.Kind()) {
+			return fmt.Sprintf("must be at least %s", fe.Param())
+		}
+		return fmt.Sprintf("must have at least %s elements or characters", fe.Param())
+	case "max":
+		if isNumber(fe.Kind()) {
+			return fmt.Sprintf("must be at most %s", fe.Param())
+		}
+		return fmt.Sprintf("must have at most %s elements or characters", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", fe.Param())
+	case currencyRule:
+		return "must be a supported ISO 4217 currency code"
+	case idRule:
+		return "must be a UUID, a ULID or an ObjectID"
+	case moneyRule:
+		return "has more decimals than its currency allows"
+	case noteRule:
+		return "must only contain printable characters and no markup"
+	default:
+		return fmt.Sprintf("failed on the %s rule", fe.Tag())
+	}
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
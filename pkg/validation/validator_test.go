@@ -0,0 +1,143 @@
+package validation
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-template/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type paymentRequest struct {
+	WalletID string  `json:"wallet_id" validate:"required,id"`
+	Amount   float64 `json:"amount" validate:"required,gt=0,money=Currency"`
+	Currency string  `json:"currency" validate:"omitempty,currency"`
+	Note     string  `json:"note" validate:"max=255,note"`
+	Page     int     `query:"page" validate:"omitempty,min=1"`
+}
+
+func validPayment() paymentRequest {
+	return paymentRequest{
+		WalletID: "0190a6f4-5e8b-7c3d-9f21-6b4e8a2c1d05",
+		Amount:   10.5,
+		Currency: "USD",
+		Note:     "Tiền nhà tháng 7",
+	}
+}
+
+func TestValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *paymentRequest)
+		want   []FieldError
+	}{
+		{
+			name:   "valid",
+			modify: func(r *paymentRequest) {},
+		},
+		{
+			name:   "ULID id",
+			modify: func(r *paymentRequest) { r.WalletID = "01J2KX3V8Q9ZJ5N7M4R6T8W0YB" },
+		},
+		{
+			name:   "ObjectID id",
+			modify: func(r *paymentRequest) { r.WalletID = "668e1f2a9c4b3d2e1f0a9b8c" },
+		},
+		{
+			name:   "missing id",
+			modify: func(r *paymentRequest) { r.WalletID = "" },
+			want:   []FieldError{{Field: "wallet_id", Rule: "required", Message: "is required"}},
+		},
+		{
+			name:   "malformed id",
+			modify: func(r *paymentRequest) { r.WalletID = "wallet1" },
+			want:   []FieldError{{Field: "wallet_id", Rule: "id", Message: "must be a UUID, a ULID or an ObjectID"}},
+		},
+		{
+			name:   "unknown currency",
+			modify: func(r *paymentRequest) { r.Currency = "usd" },
+			want: []FieldError{
+				{Field: "currency", Rule: "currency", Message: "must be a supported ISO 4217 currency code"},
+			},
+		},
+		{
+			name: "decimals on a currency without minor units",
+			modify: func(r *paymentRequest) {
+				r.Amount = 1000.5
+				r.Currency = "VND"
+			},
+			want: []FieldError{
+				{Field: "amount", Rule: "money", Message: "has more decimals than its currency allows"},
+			},
+		},
+		{
+			name: "three decimals on a currency with three minor units",
+			modify: func(r *paymentRequest) {
+				r.Amount = 1.125
+				r.Currency = "KWD"
+			},
+		},
+		{
+			name: "two decimals without currency",
+			modify: func(r *paymentRequest) {
+				r.Amount = 0.1
+				r.Currency = ""
+			},
+		},
+		{
+			name:   "markup in the note",
+			modify: func(r *paymentRequest) { r.Note = "<script>" },
+			want: []FieldError{
+				{Field: "note", Rule: "note", Message: "must only contain printable characters and no markup"},
+			},
+		},
+		{
+			name:   "control character in the note",
+			modify: func(r *paymentRequest) { r.Note = "line\nbreak" },
+			want: []FieldError{
+				{Field: "note", Rule: "note", Message: "must only contain printable characters and no markup"},
+			},
+		},
+		{
+			name: "every invalid field is reported, named after its query tag",
+			modify: func(r *paymentRequest) {
+				r.Amount = -1
+				r.Page = -1
+			},
+			want: []FieldError{
+				{Field: "amount", Rule: "gt", Message: "must be greater than 0"},
+				{Field: "page", Rule: "min", Message: "must be at least 1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := validPayment()
+			tt.modify(&req)
+
+			// Act
+			err := New().Validate(req)
+
+			// Assert
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			appErr, ok := apperror.ErrorAs(err)
+			require.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, appErr.HTTPCode)
+			assert.Equal(t, apperror.CODE_INVALID_PARAMS, appErr.Code)
+			assert.Equal(t, tt.want, appErr.Info)
+		})
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	assert.Equal(t, 0, MinorUnits("VND"))
+	assert.Equal(t, 2, MinorUnits("USD"))
+	assert.Equal(t, 3, MinorUnits("KWD"))
+	assert.Equal(t, 2, MinorUnits(""))
+}